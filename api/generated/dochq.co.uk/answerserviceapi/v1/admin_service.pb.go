@@ -0,0 +1,1249 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.18.1
+// source: admin_service.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+//*
+// Represents the way imported records are written.
+type ImportMode int32
+
+const (
+	ImportMode_IMPORT_MODE_UNKNOWN ImportMode = 0
+	// The answers are written through the answer service, which regenerates their events.
+	ImportMode_IMPORT_MODE_REPLAY ImportMode = 1
+	// The answers and their events are stored as they are, no events are generated.
+	ImportMode_IMPORT_MODE_PRESERVE ImportMode = 2
+)
+
+// Enum value maps for ImportMode.
+var (
+	ImportMode_name = map[int32]string{
+		0: "IMPORT_MODE_UNKNOWN",
+		1: "IMPORT_MODE_REPLAY",
+		2: "IMPORT_MODE_PRESERVE",
+	}
+	ImportMode_value = map[string]int32{
+		"IMPORT_MODE_UNKNOWN":  0,
+		"IMPORT_MODE_REPLAY":   1,
+		"IMPORT_MODE_PRESERVE": 2,
+	}
+)
+
+func (x ImportMode) Enum() *ImportMode {
+	p := new(ImportMode)
+	*p = x
+	return p
+}
+
+func (x ImportMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_admin_service_proto_enumTypes[0].Descriptor()
+}
+
+func (ImportMode) Type() protoreflect.EnumType {
+	return &file_admin_service_proto_enumTypes[0]
+}
+
+func (x ImportMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportMode.Descriptor instead.
+func (ImportMode) EnumDescriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{0}
+}
+
+type ExportAnswersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Checkpoint string `protobuf:"bytes,1,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+}
+
+func (x *ExportAnswersRequest) Reset() {
+	*x = ExportAnswersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_service_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAnswersRequest) ProtoMessage() {}
+
+func (x *ExportAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAnswersRequest.ProtoReflect.Descriptor instead.
+func (*ExportAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExportAnswersRequest) GetCheckpoint() string {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return ""
+}
+
+type ExportAnswersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record     *AnswerRecord `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	Checkpoint string        `protobuf:"bytes,2,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+}
+
+func (x *ExportAnswersResponse) Reset() {
+	*x = ExportAnswersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_service_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportAnswersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAnswersResponse) ProtoMessage() {}
+
+func (x *ExportAnswersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAnswersResponse.ProtoReflect.Descriptor instead.
+func (*ExportAnswersResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportAnswersResponse) GetRecord() *AnswerRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *ExportAnswersResponse) GetCheckpoint() string {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return ""
+}
+
+type ImportAnswersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode   ImportMode    `protobuf:"varint,1,opt,name=mode,proto3,enum=dochq.co.uk.answerservice.generated.service.v1.ImportMode" json:"mode,omitempty"`
+	Record *AnswerRecord `protobuf:"bytes,2,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (x *ImportAnswersRequest) Reset() {
+	*x = ImportAnswersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAnswersRequest) ProtoMessage() {}
+
+func (x *ImportAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAnswersRequest.ProtoReflect.Descriptor instead.
+func (*ImportAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ImportAnswersRequest) GetMode() ImportMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ImportMode_IMPORT_MODE_UNKNOWN
+}
+
+func (x *ImportAnswersRequest) GetRecord() *AnswerRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type ImportFailure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key     string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ImportFailure) Reset() {
+	*x = ImportFailure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFailure) ProtoMessage() {}
+
+func (x *ImportFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFailure.ProtoReflect.Descriptor instead.
+func (*ImportFailure) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ImportFailure) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ImportFailure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportAnswersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Imported int32            `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Failures []*ImportFailure `protobuf:"bytes,2,rep,name=failures,proto3" json:"failures,omitempty"`
+}
+
+func (x *ImportAnswersResponse) Reset() {
+	*x = ImportAnswersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportAnswersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportAnswersResponse) ProtoMessage() {}
+
+func (x *ImportAnswersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportAnswersResponse.ProtoReflect.Descriptor instead.
+func (*ImportAnswersResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ImportAnswersResponse) GetImported() int32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportAnswersResponse) GetFailures() []*ImportFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+type ListDeletedAnswersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageSize  int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListDeletedAnswersRequest) Reset() {
+	*x = ListDeletedAnswersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeletedAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedAnswersRequest) ProtoMessage() {}
+
+func (x *ListDeletedAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedAnswersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListDeletedAnswersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeletedAnswersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDeletedAnswersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeletedAnswers []*DeletedAnswer `protobuf:"bytes,1,rep,name=deleted_answers,json=deletedAnswers,proto3" json:"deleted_answers,omitempty"`
+	NextPageToken  string           `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListDeletedAnswersResponse) Reset() {
+	*x = ListDeletedAnswersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeletedAnswersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedAnswersResponse) ProtoMessage() {}
+
+func (x *ListDeletedAnswersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedAnswersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedAnswersResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListDeletedAnswersResponse) GetDeletedAnswers() []*DeletedAnswer {
+	if x != nil {
+		return x.DeletedAnswers
+	}
+	return nil
+}
+
+func (x *ListDeletedAnswersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type EraseAnswersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keys []string `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *EraseAnswersRequest) Reset() {
+	*x = EraseAnswersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EraseAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseAnswersRequest) ProtoMessage() {}
+
+func (x *EraseAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseAnswersRequest.ProtoReflect.Descriptor instead.
+func (*EraseAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EraseAnswersRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+//*
+// Describes the erasure of an answer key, it holds neither the key nor the answer values.
+// The key is identified by the hex-encoded HMAC-SHA256 keyed by the hash salt, the receipts are in the order of the erased keys.
+type ErasureReceipt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyHash      string                 `protobuf:"bytes,1,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
+	ErasedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+	AnswerErased bool                   `protobuf:"varint,3,opt,name=answer_erased,json=answerErased,proto3" json:"answer_erased,omitempty"`
+	EventsErased int32                  `protobuf:"varint,4,opt,name=events_erased,json=eventsErased,proto3" json:"events_erased,omitempty"`
+	// The verified subject of the client which asked for the erasure.
+	RequestedBy string `protobuf:"bytes,5,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+}
+
+func (x *ErasureReceipt) Reset() {
+	*x = ErasureReceipt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErasureReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErasureReceipt) ProtoMessage() {}
+
+func (x *ErasureReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErasureReceipt.ProtoReflect.Descriptor instead.
+func (*ErasureReceipt) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ErasureReceipt) GetKeyHash() string {
+	if x != nil {
+		return x.KeyHash
+	}
+	return ""
+}
+
+func (x *ErasureReceipt) GetErasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return nil
+}
+
+func (x *ErasureReceipt) GetAnswerErased() bool {
+	if x != nil {
+		return x.AnswerErased
+	}
+	return false
+}
+
+func (x *ErasureReceipt) GetEventsErased() int32 {
+	if x != nil {
+		return x.EventsErased
+	}
+	return 0
+}
+
+func (x *ErasureReceipt) GetRequestedBy() string {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return ""
+}
+
+type EraseAnswersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Receipts []*ErasureReceipt `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"`
+}
+
+func (x *EraseAnswersResponse) Reset() {
+	*x = EraseAnswersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_admin_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EraseAnswersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseAnswersResponse) ProtoMessage() {}
+
+func (x *EraseAnswersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_admin_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseAnswersResponse.ProtoReflect.Descriptor instead.
+func (*EraseAnswersResponse) Descriptor() ([]byte, []int) {
+	return file_admin_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *EraseAnswersResponse) GetReceipts() []*ErasureReceipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+var File_admin_service_proto protoreflect.FileDescriptor
+
+var file_admin_service_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e,
+	0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x36, 0x0a, 0x14, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x22, 0x8b, 0x01, 0x0a, 0x15, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x06,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x64,
+	0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x22, 0xba, 0x01, 0x0a, 0x14, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x04, 0x6d, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3a, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e,
+	0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4d,
+	0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x52, 0x0a, 0x06, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x64, 0x6f, 0x63, 0x68,
+	0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x3b, 0x0a,
+	0x0d, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x8e, 0x01, 0x0a, 0x15, 0x49,
+	0x6d, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x12, 0x59, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b,
+	0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22, 0x57, 0x0a, 0x19, 0x4c,
+	0x69, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xaa, 0x01, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x0f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x64,
+	0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x0e, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x29, 0x0a, 0x13, 0x45, 0x72, 0x61, 0x73, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x22, 0xd1, 0x01, 0x0a,
+	0x0e, 0x45, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x48, 0x61, 0x73, 0x68, 0x12, 0x37, 0x0a, 0x09, 0x65, 0x72,
+	0x61, 0x73, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65, 0x72, 0x61, 0x73, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x5f, 0x65, 0x72,
+	0x61, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x45, 0x72, 0x61, 0x73, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x5f, 0x65, 0x72, 0x61, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x45, 0x72, 0x61, 0x73, 0x65, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x79,
+	0x22, 0x72, 0x0a, 0x14, 0x45, 0x72, 0x61, 0x73, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x64, 0x6f, 0x63,
+	0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x61, 0x73,
+	0x75, 0x72, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x73, 0x2a, 0x57, 0x0a, 0x0a, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x17, 0x0a, 0x13, 0x49, 0x4d, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x4d, 0x4f, 0x44,
+	0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x49,
+	0x4d, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4c, 0x41,
+	0x59, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x49, 0x4d, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x5f, 0x50, 0x52, 0x45, 0x53, 0x45, 0x52, 0x56, 0x45, 0x10, 0x02, 0x32, 0xa2, 0x05,
+	0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xa0,
+	0x01, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x12, 0x44, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x45, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63,
+	0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30,
+	0x01, 0x12, 0xa0, 0x01, 0x0a, 0x0d, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x73, 0x12, 0x44, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75,
+	0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x45, 0x2e, 0x64, 0x6f, 0x63, 0x68,
+	0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x28, 0x01, 0x12, 0xad, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0x49, 0x2e, 0x64, 0x6f,
+	0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x4a, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63,
+	0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x9b, 0x01, 0x0a, 0x0c, 0x45, 0x72, 0x61, 0x73, 0x65, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0x43, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f,
+	0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x61, 0x73, 0x65, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x44, 0x2e, 0x64, 0x6f, 0x63,
+	0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x61, 0x73,
+	0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x42, 0x21, 0x5a, 0x1f, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75,
+	0x6b, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x61,
+	0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_admin_service_proto_rawDescOnce sync.Once
+	file_admin_service_proto_rawDescData = file_admin_service_proto_rawDesc
+)
+
+func file_admin_service_proto_rawDescGZIP() []byte {
+	file_admin_service_proto_rawDescOnce.Do(func() {
+		file_admin_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_admin_service_proto_rawDescData)
+	})
+	return file_admin_service_proto_rawDescData
+}
+
+var file_admin_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_admin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_admin_service_proto_goTypes = []interface{}{
+	(ImportMode)(0),                    // 0: dochq.co.uk.answerservice.generated.service.v1.ImportMode
+	(*ExportAnswersRequest)(nil),       // 1: dochq.co.uk.answerservice.generated.service.v1.ExportAnswersRequest
+	(*ExportAnswersResponse)(nil),      // 2: dochq.co.uk.answerservice.generated.service.v1.ExportAnswersResponse
+	(*ImportAnswersRequest)(nil),       // 3: dochq.co.uk.answerservice.generated.service.v1.ImportAnswersRequest
+	(*ImportFailure)(nil),              // 4: dochq.co.uk.answerservice.generated.service.v1.ImportFailure
+	(*ImportAnswersResponse)(nil),      // 5: dochq.co.uk.answerservice.generated.service.v1.ImportAnswersResponse
+	(*ListDeletedAnswersRequest)(nil),  // 6: dochq.co.uk.answerservice.generated.service.v1.ListDeletedAnswersRequest
+	(*ListDeletedAnswersResponse)(nil), // 7: dochq.co.uk.answerservice.generated.service.v1.ListDeletedAnswersResponse
+	(*EraseAnswersRequest)(nil),        // 8: dochq.co.uk.answerservice.generated.service.v1.EraseAnswersRequest
+	(*ErasureReceipt)(nil),             // 9: dochq.co.uk.answerservice.generated.service.v1.ErasureReceipt
+	(*EraseAnswersResponse)(nil),       // 10: dochq.co.uk.answerservice.generated.service.v1.EraseAnswersResponse
+	(*AnswerRecord)(nil),               // 11: dochq.co.uk.answerservice.generated.model.v1.AnswerRecord
+	(*DeletedAnswer)(nil),              // 12: dochq.co.uk.answerservice.generated.model.v1.DeletedAnswer
+	(*timestamppb.Timestamp)(nil),      // 13: google.protobuf.Timestamp
+}
+var file_admin_service_proto_depIdxs = []int32{
+	11, // 0: dochq.co.uk.answerservice.generated.service.v1.ExportAnswersResponse.record:type_name -> dochq.co.uk.answerservice.generated.model.v1.AnswerRecord
+	0,  // 1: dochq.co.uk.answerservice.generated.service.v1.ImportAnswersRequest.mode:type_name -> dochq.co.uk.answerservice.generated.service.v1.ImportMode
+	11, // 2: dochq.co.uk.answerservice.generated.service.v1.ImportAnswersRequest.record:type_name -> dochq.co.uk.answerservice.generated.model.v1.AnswerRecord
+	4,  // 3: dochq.co.uk.answerservice.generated.service.v1.ImportAnswersResponse.failures:type_name -> dochq.co.uk.answerservice.generated.service.v1.ImportFailure
+	12, // 4: dochq.co.uk.answerservice.generated.service.v1.ListDeletedAnswersResponse.deleted_answers:type_name -> dochq.co.uk.answerservice.generated.model.v1.DeletedAnswer
+	13, // 5: dochq.co.uk.answerservice.generated.service.v1.ErasureReceipt.erased_at:type_name -> google.protobuf.Timestamp
+	9,  // 6: dochq.co.uk.answerservice.generated.service.v1.EraseAnswersResponse.receipts:type_name -> dochq.co.uk.answerservice.generated.service.v1.ErasureReceipt
+	1,  // 7: dochq.co.uk.answerservice.generated.service.v1.AdminService.ExportAnswers:input_type -> dochq.co.uk.answerservice.generated.service.v1.ExportAnswersRequest
+	3,  // 8: dochq.co.uk.answerservice.generated.service.v1.AdminService.ImportAnswers:input_type -> dochq.co.uk.answerservice.generated.service.v1.ImportAnswersRequest
+	6,  // 9: dochq.co.uk.answerservice.generated.service.v1.AdminService.ListDeletedAnswers:input_type -> dochq.co.uk.answerservice.generated.service.v1.ListDeletedAnswersRequest
+	8,  // 10: dochq.co.uk.answerservice.generated.service.v1.AdminService.EraseAnswers:input_type -> dochq.co.uk.answerservice.generated.service.v1.EraseAnswersRequest
+	2,  // 11: dochq.co.uk.answerservice.generated.service.v1.AdminService.ExportAnswers:output_type -> dochq.co.uk.answerservice.generated.service.v1.ExportAnswersResponse
+	5,  // 12: dochq.co.uk.answerservice.generated.service.v1.AdminService.ImportAnswers:output_type -> dochq.co.uk.answerservice.generated.service.v1.ImportAnswersResponse
+	7,  // 13: dochq.co.uk.answerservice.generated.service.v1.AdminService.ListDeletedAnswers:output_type -> dochq.co.uk.answerservice.generated.service.v1.ListDeletedAnswersResponse
+	10, // 14: dochq.co.uk.answerservice.generated.service.v1.AdminService.EraseAnswers:output_type -> dochq.co.uk.answerservice.generated.service.v1.EraseAnswersResponse
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_admin_service_proto_init() }
+func file_admin_service_proto_init() {
+	if File_admin_service_proto != nil {
+		return
+	}
+	file_answer_model_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_admin_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportAnswersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportAnswersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportAnswersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportFailure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportAnswersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeletedAnswersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeletedAnswersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EraseAnswersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErasureReceipt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_admin_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EraseAnswersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_admin_service_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_admin_service_proto_goTypes,
+		DependencyIndexes: file_admin_service_proto_depIdxs,
+		EnumInfos:         file_admin_service_proto_enumTypes,
+		MessageInfos:      file_admin_service_proto_msgTypes,
+	}.Build()
+	File_admin_service_proto = out.File
+	file_admin_service_proto_rawDesc = nil
+	file_admin_service_proto_goTypes = nil
+	file_admin_service_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	//*
+	// Streams every answer together with its history, then the history of every deleted, purged or erased key
+	// without a live answer in a record without the answer, and last the deleted answers without a history.
+	// Each record carries a checkpoint, an export requested with it resumes after that record.
+	ExportAnswers(ctx context.Context, in *ExportAnswersRequest, opts ...grpc.CallOption) (AdminService_ExportAnswersClient, error)
+	//*
+	// Imports the streamed records and returns a summary once the stream is closed.
+	// Importing a record twice is harmless, so an interrupted import may be repeated.
+	ImportAnswers(ctx context.Context, opts ...grpc.CallOption) (AdminService_ImportAnswersClient, error)
+	//*
+	// Lists the deleted answers which are retained until their purge time.
+	ListDeletedAnswers(ctx context.Context, in *ListDeletedAnswersRequest, opts ...grpc.CallOption) (*ListDeletedAnswersResponse, error)
+	//*
+	// Erases the answers of a data subject together with their history and returns a receipt per key.
+	// An erase event, which holds no answer value, is sent to the consumers, it is not recorded in the history.
+	EraseAnswers(ctx context.Context, in *EraseAnswersRequest, opts ...grpc.CallOption) (*EraseAnswersResponse, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ExportAnswers(ctx context.Context, in *ExportAnswersRequest, opts ...grpc.CallOption) (AdminService_ExportAnswersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[0], "/dochq.co.uk.answerservice.generated.service.v1.AdminService/ExportAnswers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceExportAnswersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_ExportAnswersClient interface {
+	Recv() (*ExportAnswersResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceExportAnswersClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceExportAnswersClient) Recv() (*ExportAnswersResponse, error) {
+	m := new(ExportAnswersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) ImportAnswers(ctx context.Context, opts ...grpc.CallOption) (AdminService_ImportAnswersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[1], "/dochq.co.uk.answerservice.generated.service.v1.AdminService/ImportAnswers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceImportAnswersClient{stream}
+	return x, nil
+}
+
+type AdminService_ImportAnswersClient interface {
+	Send(*ImportAnswersRequest) error
+	CloseAndRecv() (*ImportAnswersResponse, error)
+	grpc.ClientStream
+}
+
+type adminServiceImportAnswersClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceImportAnswersClient) Send(m *ImportAnswersRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *adminServiceImportAnswersClient) CloseAndRecv() (*ImportAnswersResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportAnswersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) ListDeletedAnswers(ctx context.Context, in *ListDeletedAnswersRequest, opts ...grpc.CallOption) (*ListDeletedAnswersResponse, error) {
+	out := new(ListDeletedAnswersResponse)
+	err := c.cc.Invoke(ctx, "/dochq.co.uk.answerservice.generated.service.v1.AdminService/ListDeletedAnswers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) EraseAnswers(ctx context.Context, in *EraseAnswersRequest, opts ...grpc.CallOption) (*EraseAnswersResponse, error) {
+	out := new(EraseAnswersResponse)
+	err := c.cc.Invoke(ctx, "/dochq.co.uk.answerservice.generated.service.v1.AdminService/EraseAnswers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	//*
+	// Streams every answer together with its history, then the history of every deleted, purged or erased key
+	// without a live answer in a record without the answer, and last the deleted answers without a history.
+	// Each record carries a checkpoint, an export requested with it resumes after that record.
+	ExportAnswers(*ExportAnswersRequest, AdminService_ExportAnswersServer) error
+	//*
+	// Imports the streamed records and returns a summary once the stream is closed.
+	// Importing a record twice is harmless, so an interrupted import may be repeated.
+	ImportAnswers(AdminService_ImportAnswersServer) error
+	//*
+	// Lists the deleted answers which are retained until their purge time.
+	ListDeletedAnswers(context.Context, *ListDeletedAnswersRequest) (*ListDeletedAnswersResponse, error)
+	//*
+	// Erases the answers of a data subject together with their history and returns a receipt per key.
+	// An erase event, which holds no answer value, is sent to the consumers, it is not recorded in the history.
+	EraseAnswers(context.Context, *EraseAnswersRequest) (*EraseAnswersResponse, error)
+}
+
+// UnimplementedAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (*UnimplementedAdminServiceServer) ExportAnswers(*ExportAnswersRequest, AdminService_ExportAnswersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAnswers not implemented")
+}
+func (*UnimplementedAdminServiceServer) ImportAnswers(AdminService_ImportAnswersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportAnswers not implemented")
+}
+func (*UnimplementedAdminServiceServer) ListDeletedAnswers(context.Context, *ListDeletedAnswersRequest) (*ListDeletedAnswersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedAnswers not implemented")
+}
+func (*UnimplementedAdminServiceServer) EraseAnswers(context.Context, *EraseAnswersRequest) (*EraseAnswersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseAnswers not implemented")
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_ExportAnswers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAnswersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).ExportAnswers(m, &adminServiceExportAnswersServer{stream})
+}
+
+type AdminService_ExportAnswersServer interface {
+	Send(*ExportAnswersResponse) error
+	grpc.ServerStream
+}
+
+type adminServiceExportAnswersServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceExportAnswersServer) Send(m *ExportAnswersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_ImportAnswers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AdminServiceServer).ImportAnswers(&adminServiceImportAnswersServer{stream})
+}
+
+type AdminService_ImportAnswersServer interface {
+	SendAndClose(*ImportAnswersResponse) error
+	Recv() (*ImportAnswersRequest, error)
+	grpc.ServerStream
+}
+
+type adminServiceImportAnswersServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceImportAnswersServer) SendAndClose(m *ImportAnswersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *adminServiceImportAnswersServer) Recv() (*ImportAnswersRequest, error) {
+	m := new(ImportAnswersRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _AdminService_ListDeletedAnswers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedAnswersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListDeletedAnswers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dochq.co.uk.answerservice.generated.service.v1.AdminService/ListDeletedAnswers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListDeletedAnswers(ctx, req.(*ListDeletedAnswersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_EraseAnswers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseAnswersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).EraseAnswers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dochq.co.uk.answerservice.generated.service.v1.AdminService/EraseAnswers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).EraseAnswers(ctx, req.(*EraseAnswersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "dochq.co.uk.answerservice.generated.service.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDeletedAnswers",
+			Handler:    _AdminService_ListDeletedAnswers_Handler,
+		},
+		{
+			MethodName: "EraseAnswers",
+			Handler:    _AdminService_EraseAnswers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAnswers",
+			Handler:       _AdminService_ExportAnswers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportAnswers",
+			Handler:       _AdminService_ImportAnswers_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "admin_service.proto",
+}
@@ -9,6 +9,7 @@ package v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -29,6 +30,9 @@ const (
 	AnswerEventType_ANSWER_EVENT_TYPE_CREATE  AnswerEventType = 1
 	AnswerEventType_ANSWER_EVENT_TYPE_UPDATE  AnswerEventType = 2
 	AnswerEventType_ANSWER_EVENT_TYPE_DELETE  AnswerEventType = 3
+	AnswerEventType_ANSWER_EVENT_TYPE_RESTORE AnswerEventType = 4
+	// The answer and its earlier history were erased, the data only holds the key.
+	AnswerEventType_ANSWER_EVENT_TYPE_ERASE AnswerEventType = 5
 )
 
 // Enum value maps for AnswerEventType.
@@ -38,12 +42,16 @@ var (
 		1: "ANSWER_EVENT_TYPE_CREATE",
 		2: "ANSWER_EVENT_TYPE_UPDATE",
 		3: "ANSWER_EVENT_TYPE_DELETE",
+		4: "ANSWER_EVENT_TYPE_RESTORE",
+		5: "ANSWER_EVENT_TYPE_ERASE",
 	}
 	AnswerEventType_value = map[string]int32{
 		"ANSWER_EVENT_TYPE_UNKNOWN": 0,
 		"ANSWER_EVENT_TYPE_CREATE":  1,
 		"ANSWER_EVENT_TYPE_UPDATE":  2,
 		"ANSWER_EVENT_TYPE_DELETE":  3,
+		"ANSWER_EVENT_TYPE_RESTORE": 4,
+		"ANSWER_EVENT_TYPE_ERASE":   5,
 	}
 )
 
@@ -74,6 +82,65 @@ func (AnswerEventType) EnumDescriptor() ([]byte, []int) {
 	return file_answer_model_proto_rawDescGZIP(), []int{0}
 }
 
+//*
+// Represents the way a search term matches the key or the value of an answer.
+type AnswerMatchType int32
+
+const (
+	AnswerMatchType_ANSWER_MATCH_TYPE_UNKNOWN AnswerMatchType = 0
+	// The text equals the key or the value, the case is not ignored.
+	AnswerMatchType_ANSWER_MATCH_TYPE_EXACT     AnswerMatchType = 1
+	AnswerMatchType_ANSWER_MATCH_TYPE_PREFIX    AnswerMatchType = 2
+	AnswerMatchType_ANSWER_MATCH_TYPE_SUBSTRING AnswerMatchType = 3
+	// A word of the key or the value is at most the fuzziness of edits away from the text.
+	AnswerMatchType_ANSWER_MATCH_TYPE_FUZZY AnswerMatchType = 4
+)
+
+// Enum value maps for AnswerMatchType.
+var (
+	AnswerMatchType_name = map[int32]string{
+		0: "ANSWER_MATCH_TYPE_UNKNOWN",
+		1: "ANSWER_MATCH_TYPE_EXACT",
+		2: "ANSWER_MATCH_TYPE_PREFIX",
+		3: "ANSWER_MATCH_TYPE_SUBSTRING",
+		4: "ANSWER_MATCH_TYPE_FUZZY",
+	}
+	AnswerMatchType_value = map[string]int32{
+		"ANSWER_MATCH_TYPE_UNKNOWN":   0,
+		"ANSWER_MATCH_TYPE_EXACT":     1,
+		"ANSWER_MATCH_TYPE_PREFIX":    2,
+		"ANSWER_MATCH_TYPE_SUBSTRING": 3,
+		"ANSWER_MATCH_TYPE_FUZZY":     4,
+	}
+)
+
+func (x AnswerMatchType) Enum() *AnswerMatchType {
+	p := new(AnswerMatchType)
+	*p = x
+	return p
+}
+
+func (x AnswerMatchType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnswerMatchType) Descriptor() protoreflect.EnumDescriptor {
+	return file_answer_model_proto_enumTypes[1].Descriptor()
+}
+
+func (AnswerMatchType) Type() protoreflect.EnumType {
+	return &file_answer_model_proto_enumTypes[1]
+}
+
+func (x AnswerMatchType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnswerMatchType.Descriptor instead.
+func (AnswerMatchType) EnumDescriptor() ([]byte, []int) {
+	return file_answer_model_proto_rawDescGZIP(), []int{1}
+}
+
 //*
 // Represents the answer model.
 type Answer struct {
@@ -138,8 +205,9 @@ type AnswerEvent struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	EventType AnswerEventType `protobuf:"varint,1,opt,name=event_type,json=eventType,proto3,enum=dochq.co.uk.answerservice.generated.model.v1.AnswerEventType" json:"event_type,omitempty"`
-	Data      *Answer         `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	EventType  AnswerEventType        `protobuf:"varint,1,opt,name=event_type,json=eventType,proto3,enum=dochq.co.uk.answerservice.generated.model.v1.AnswerEventType" json:"event_type,omitempty"`
+	Data       *Answer                `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
 }
 
 func (x *AnswerEvent) Reset() {
@@ -188,6 +256,210 @@ func (x *AnswerEvent) GetData() *Answer {
 	return nil
 }
 
+func (x *AnswerEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+//*
+// Represents an answer together with its history, the unit of export and import.
+// The answer is absent when only the history of a deleted answer remains.
+type AnswerRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string         `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Answer *Answer        `protobuf:"bytes,2,opt,name=answer,proto3" json:"answer,omitempty"`
+	Events []*AnswerEvent `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *AnswerRecord) Reset() {
+	*x = AnswerRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answer_model_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnswerRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerRecord) ProtoMessage() {}
+
+func (x *AnswerRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_answer_model_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerRecord.ProtoReflect.Descriptor instead.
+func (*AnswerRecord) Descriptor() ([]byte, []int) {
+	return file_answer_model_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AnswerRecord) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AnswerRecord) GetAnswer() *Answer {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+func (x *AnswerRecord) GetEvents() []*AnswerEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+//*
+// Represents a deleted answer, it is retained until its purge time.
+type DeletedAnswer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Answer    *Answer                `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	PurgeAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`
+}
+
+func (x *DeletedAnswer) Reset() {
+	*x = DeletedAnswer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answer_model_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletedAnswer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletedAnswer) ProtoMessage() {}
+
+func (x *DeletedAnswer) ProtoReflect() protoreflect.Message {
+	mi := &file_answer_model_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletedAnswer.ProtoReflect.Descriptor instead.
+func (*DeletedAnswer) Descriptor() ([]byte, []int) {
+	return file_answer_model_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeletedAnswer) GetAnswer() *Answer {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+func (x *DeletedAnswer) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *DeletedAnswer) GetPurgeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return nil
+}
+
+//*
+// Represents the text matched against the key or the value of an answer.
+type AnswerSearchTerm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text  string          `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Match AnswerMatchType `protobuf:"varint,2,opt,name=match,proto3,enum=dochq.co.uk.answerservice.generated.model.v1.AnswerMatchType" json:"match,omitempty"`
+	// The number of edits of a fuzzy match up to 2, it depends on the length of the text if zero.
+	Fuzziness int32 `protobuf:"varint,3,opt,name=fuzziness,proto3" json:"fuzziness,omitempty"`
+}
+
+func (x *AnswerSearchTerm) Reset() {
+	*x = AnswerSearchTerm{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answer_model_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnswerSearchTerm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerSearchTerm) ProtoMessage() {}
+
+func (x *AnswerSearchTerm) ProtoReflect() protoreflect.Message {
+	mi := &file_answer_model_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerSearchTerm.ProtoReflect.Descriptor instead.
+func (*AnswerSearchTerm) Descriptor() ([]byte, []int) {
+	return file_answer_model_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AnswerSearchTerm) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *AnswerSearchTerm) GetMatch() AnswerMatchType {
+	if x != nil {
+		return x.Match
+	}
+	return AnswerMatchType_ANSWER_MATCH_TYPE_UNKNOWN
+}
+
+func (x *AnswerSearchTerm) GetFuzziness() int32 {
+	if x != nil {
+		return x.Fuzziness
+	}
+	return 0
+}
+
 var File_answer_model_proto protoreflect.FileDescriptor
 
 var file_answer_model_proto_rawDesc = []byte{
@@ -195,21 +467,62 @@ var file_answer_model_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2c, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75,
 	0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
 	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e,
-	0x76, 0x31, 0x22, 0x30, 0x0a, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x22, 0xb5, 0x01, 0x0a, 0x0b, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x12, 0x5c, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3d, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71,
-	0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d,
-	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x48, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x30, 0x0a, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xf2, 0x01, 0x0a, 0x0b, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x5c, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3d, 0x2e, 0x64, 0x6f, 0x63, 0x68,
+	0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x48, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x3b, 0x0a,
+	0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a,
+	0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x41, 0x74, 0x22, 0xc1, 0x01, 0x0a, 0x0c, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x4c, 0x0a,
+	0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e,
+	0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x51, 0x0a, 0x06, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x64, 0x6f,
+	0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xcf,
+	0x01, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x12, 0x4c, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61,
 	0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e,
 	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e,
-	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x2a, 0x8a, 0x01, 0x0a,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x39,
+	0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x35, 0x0a, 0x08, 0x70, 0x75, 0x72,
+	0x67, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x70, 0x75, 0x72, 0x67, 0x65, 0x41, 0x74,
+	0x22, 0x99, 0x01, 0x0a, 0x10, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x53, 0x0a, 0x05, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3d, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71,
+	0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1c,
+	0x0a, 0x09, 0x66, 0x75, 0x7a, 0x7a, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x66, 0x75, 0x7a, 0x7a, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x2a, 0xc6, 0x01, 0x0a,
 	0x0f, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
 	0x12, 0x1d, 0x0a, 0x19, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54,
 	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
@@ -218,10 +531,24 @@ var file_answer_model_proto_rawDesc = []byte{
 	0x18, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59,
 	0x50, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x41,
 	0x4e, 0x53, 0x57, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x42, 0x21, 0x5a, 0x1f, 0x64, 0x6f, 0x63,
-	0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x03, 0x12, 0x1d, 0x0a, 0x19, 0x41, 0x4e, 0x53,
+	0x57, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52,
+	0x45, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x4e, 0x53, 0x57,
+	0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x52,
+	0x41, 0x53, 0x45, 0x10, 0x05, 0x2a, 0xa9, 0x01, 0x0a, 0x0f, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x4d, 0x61, 0x74, 0x63, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x41, 0x4e, 0x53,
+	0x57, 0x45, 0x52, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55,
+	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x4e, 0x53, 0x57,
+	0x45, 0x52, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x45, 0x58,
+	0x41, 0x43, 0x54, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x5f,
+	0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x52, 0x45, 0x46, 0x49,
+	0x58, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x5f, 0x4d, 0x41,
+	0x54, 0x43, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x55, 0x42, 0x53, 0x54, 0x52, 0x49,
+	0x4e, 0x47, 0x10, 0x03, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x5f, 0x4d,
+	0x41, 0x54, 0x43, 0x48, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x46, 0x55, 0x5a, 0x5a, 0x59, 0x10,
+	0x04, 0x42, 0x21, 0x5a, 0x1f, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b,
+	0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x61, 0x70,
+	0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -236,21 +563,33 @@ func file_answer_model_proto_rawDescGZIP() []byte {
 	return file_answer_model_proto_rawDescData
 }
 
-var file_answer_model_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_answer_model_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_answer_model_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_answer_model_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_answer_model_proto_goTypes = []interface{}{
-	(AnswerEventType)(0), // 0: dochq.co.uk.answerservice.generated.model.v1.AnswerEventType
-	(*Answer)(nil),       // 1: dochq.co.uk.answerservice.generated.model.v1.Answer
-	(*AnswerEvent)(nil),  // 2: dochq.co.uk.answerservice.generated.model.v1.AnswerEvent
+	(AnswerEventType)(0),          // 0: dochq.co.uk.answerservice.generated.model.v1.AnswerEventType
+	(AnswerMatchType)(0),          // 1: dochq.co.uk.answerservice.generated.model.v1.AnswerMatchType
+	(*Answer)(nil),                // 2: dochq.co.uk.answerservice.generated.model.v1.Answer
+	(*AnswerEvent)(nil),           // 3: dochq.co.uk.answerservice.generated.model.v1.AnswerEvent
+	(*AnswerRecord)(nil),          // 4: dochq.co.uk.answerservice.generated.model.v1.AnswerRecord
+	(*DeletedAnswer)(nil),         // 5: dochq.co.uk.answerservice.generated.model.v1.DeletedAnswer
+	(*AnswerSearchTerm)(nil),      // 6: dochq.co.uk.answerservice.generated.model.v1.AnswerSearchTerm
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
 }
 var file_answer_model_proto_depIdxs = []int32{
 	0, // 0: dochq.co.uk.answerservice.generated.model.v1.AnswerEvent.event_type:type_name -> dochq.co.uk.answerservice.generated.model.v1.AnswerEventType
-	1, // 1: dochq.co.uk.answerservice.generated.model.v1.AnswerEvent.data:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 1: dochq.co.uk.answerservice.generated.model.v1.AnswerEvent.data:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	7, // 2: dochq.co.uk.answerservice.generated.model.v1.AnswerEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	2, // 3: dochq.co.uk.answerservice.generated.model.v1.AnswerRecord.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	3, // 4: dochq.co.uk.answerservice.generated.model.v1.AnswerRecord.events:type_name -> dochq.co.uk.answerservice.generated.model.v1.AnswerEvent
+	2, // 5: dochq.co.uk.answerservice.generated.model.v1.DeletedAnswer.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	7, // 6: dochq.co.uk.answerservice.generated.model.v1.DeletedAnswer.deleted_at:type_name -> google.protobuf.Timestamp
+	7, // 7: dochq.co.uk.answerservice.generated.model.v1.DeletedAnswer.purge_at:type_name -> google.protobuf.Timestamp
+	1, // 8: dochq.co.uk.answerservice.generated.model.v1.AnswerSearchTerm.match:type_name -> dochq.co.uk.answerservice.generated.model.v1.AnswerMatchType
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_answer_model_proto_init() }
@@ -283,14 +622,50 @@ func file_answer_model_proto_init() {
 				return nil
 			}
 		}
+		file_answer_model_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnswerRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answer_model_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletedAnswer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answer_model_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnswerSearchTerm); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_answer_model_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -14,6 +14,7 @@ import (
 	status "google.golang.org/grpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -76,6 +77,15 @@ type CreateAnswerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The created answer.
+	Answer *Answer `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	// The time the answer was created, the create event occurred at the same time.
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// The ID of the message of the create event, or the ID written with the answer if the events are captured from the change stream.
+	EventId string `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// The version of the created answer, one for a new key, a recreated answer counts on from its deletion.
+	Version int32 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 }
 
 func (x *CreateAnswerResponse) Reset() {
@@ -110,6 +120,34 @@ func (*CreateAnswerResponse) Descriptor() ([]byte, []int) {
 	return file_answers_service_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *CreateAnswerResponse) GetAnswer() *Answer {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+func (x *CreateAnswerResponse) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *CreateAnswerResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *CreateAnswerResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type UpdateAnswerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -161,6 +199,15 @@ type UpdateAnswerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// The answer with its new value.
+	Answer *Answer `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	// The time the value was replaced, the update event occurred at the same time.
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// The ID of the message of the update event, or the ID written with the answer if the events are captured from the change stream.
+	EventId string `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// The version of the new value, one more than the version of the replaced one.
+	Version int32 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 }
 
 func (x *UpdateAnswerResponse) Reset() {
@@ -195,16 +242,45 @@ func (*UpdateAnswerResponse) Descriptor() ([]byte, []int) {
 	return file_answers_service_proto_rawDescGZIP(), []int{3}
 }
 
-type DeleteAnswerRequest struct {
+func (x *UpdateAnswerResponse) GetAnswer() *Answer {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+func (x *UpdateAnswerResponse) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *UpdateAnswerResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *UpdateAnswerResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpsertAnswerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *DeleteAnswerRequest) Reset() {
-	*x = DeleteAnswerRequest{}
+func (x *UpsertAnswerRequest) Reset() {
+	*x = UpsertAnswerRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_answers_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -212,13 +288,13 @@ func (x *DeleteAnswerRequest) Reset() {
 	}
 }
 
-func (x *DeleteAnswerRequest) String() string {
+func (x *UpsertAnswerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAnswerRequest) ProtoMessage() {}
+func (*UpsertAnswerRequest) ProtoMessage() {}
 
-func (x *DeleteAnswerRequest) ProtoReflect() protoreflect.Message {
+func (x *UpsertAnswerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_answers_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -230,26 +306,44 @@ func (x *DeleteAnswerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAnswerRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAnswerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpsertAnswerRequest.ProtoReflect.Descriptor instead.
+func (*UpsertAnswerRequest) Descriptor() ([]byte, []int) {
 	return file_answers_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *DeleteAnswerRequest) GetKey() string {
+func (x *UpsertAnswerRequest) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-type DeleteAnswerResponse struct {
+func (x *UpsertAnswerRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type UpsertAnswerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-}
 
-func (x *DeleteAnswerResponse) Reset() {
-	*x = DeleteAnswerResponse{}
+	// The answer as stored, created or replaced.
+	Answer *Answer `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	// The time the answer was stored, its create or update event occurred at the same time.
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// The ID of the message of the create or update event, or the ID written with the answer if the events are captured from the change stream.
+	EventId string `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// True if the key was not in use and the answer was created, false if the answer was replaced.
+	Created bool `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+	// The version of the stored value, it continues the versions of the key whether the answer was created or replaced.
+	Version int32 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *UpsertAnswerResponse) Reset() {
+	*x = UpsertAnswerResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_answers_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -257,13 +351,13 @@ func (x *DeleteAnswerResponse) Reset() {
 	}
 }
 
-func (x *DeleteAnswerResponse) String() string {
+func (x *UpsertAnswerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAnswerResponse) ProtoMessage() {}
+func (*UpsertAnswerResponse) ProtoMessage() {}
 
-func (x *DeleteAnswerResponse) ProtoReflect() protoreflect.Message {
+func (x *UpsertAnswerResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_answers_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -275,12 +369,47 @@ func (x *DeleteAnswerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAnswerResponse.ProtoReflect.Descriptor instead.
-func (*DeleteAnswerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpsertAnswerResponse.ProtoReflect.Descriptor instead.
+func (*UpsertAnswerResponse) Descriptor() ([]byte, []int) {
 	return file_answers_service_proto_rawDescGZIP(), []int{5}
 }
 
-type GetAnswerRequest struct {
+func (x *UpsertAnswerResponse) GetAnswer() *Answer {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+func (x *UpsertAnswerResponse) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *UpsertAnswerResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *UpsertAnswerResponse) GetCreated() bool {
+	if x != nil {
+		return x.Created
+	}
+	return false
+}
+
+func (x *UpsertAnswerResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteAnswerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -288,8 +417,8 @@ type GetAnswerRequest struct {
 	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-func (x *GetAnswerRequest) Reset() {
-	*x = GetAnswerRequest{}
+func (x *DeleteAnswerRequest) Reset() {
+	*x = DeleteAnswerRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_answers_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -297,13 +426,13 @@ func (x *GetAnswerRequest) Reset() {
 	}
 }
 
-func (x *GetAnswerRequest) String() string {
+func (x *DeleteAnswerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAnswerRequest) ProtoMessage() {}
+func (*DeleteAnswerRequest) ProtoMessage() {}
 
-func (x *GetAnswerRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteAnswerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_answers_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -315,28 +444,35 @@ func (x *GetAnswerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAnswerRequest.ProtoReflect.Descriptor instead.
-func (*GetAnswerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteAnswerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAnswerRequest) Descriptor() ([]byte, []int) {
 	return file_answers_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetAnswerRequest) GetKey() string {
+func (x *DeleteAnswerRequest) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-type GetAnswerResponse struct {
+type DeleteAnswerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// The deleted answer as it was before the deletion.
 	Answer *Answer `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	// The time the answer was deleted, its retention period starts then.
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// The ID of the message of the delete event, or the ID written with the answer if the events are captured from the change stream.
+	EventId string `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// The version of the deletion, the deletion counts as a change of the answer.
+	Version int32 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *GetAnswerResponse) Reset() {
-	*x = GetAnswerResponse{}
+func (x *DeleteAnswerResponse) Reset() {
+	*x = DeleteAnswerResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_answers_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -344,13 +480,13 @@ func (x *GetAnswerResponse) Reset() {
 	}
 }
 
-func (x *GetAnswerResponse) String() string {
+func (x *DeleteAnswerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAnswerResponse) ProtoMessage() {}
+func (*DeleteAnswerResponse) ProtoMessage() {}
 
-func (x *GetAnswerResponse) ProtoReflect() protoreflect.Message {
+func (x *DeleteAnswerResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_answers_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -362,28 +498,50 @@ func (x *GetAnswerResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAnswerResponse.ProtoReflect.Descriptor instead.
-func (*GetAnswerResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteAnswerResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAnswerResponse) Descriptor() ([]byte, []int) {
 	return file_answers_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetAnswerResponse) GetAnswer() *Answer {
+func (x *DeleteAnswerResponse) GetAnswer() *Answer {
 	if x != nil {
 		return x.Answer
 	}
 	return nil
 }
 
-type GetAnswerHistoryRequest struct {
+func (x *DeleteAnswerResponse) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *DeleteAnswerResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *DeleteAnswerResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RestoreAnswerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Key     string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Version int32  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *GetAnswerHistoryRequest) Reset() {
-	*x = GetAnswerHistoryRequest{}
+func (x *RestoreAnswerRequest) Reset() {
+	*x = RestoreAnswerRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_answers_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -391,13 +549,13 @@ func (x *GetAnswerHistoryRequest) Reset() {
 	}
 }
 
-func (x *GetAnswerHistoryRequest) String() string {
+func (x *RestoreAnswerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAnswerHistoryRequest) ProtoMessage() {}
+func (*RestoreAnswerRequest) ProtoMessage() {}
 
-func (x *GetAnswerHistoryRequest) ProtoReflect() protoreflect.Message {
+func (x *RestoreAnswerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_answers_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -409,28 +567,42 @@ func (x *GetAnswerHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAnswerHistoryRequest.ProtoReflect.Descriptor instead.
-func (*GetAnswerHistoryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreAnswerRequest.ProtoReflect.Descriptor instead.
+func (*RestoreAnswerRequest) Descriptor() ([]byte, []int) {
 	return file_answers_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetAnswerHistoryRequest) GetKey() string {
+func (x *RestoreAnswerRequest) GetKey() string {
 	if x != nil {
 		return x.Key
 	}
 	return ""
 }
 
-type GetAnswerHistoryResponse struct {
+func (x *RestoreAnswerRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type RestoreAnswerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AnswerEvents []*AnswerEvent `protobuf:"bytes,1,rep,name=answer_events,json=answerEvents,proto3" json:"answer_events,omitempty"`
+	// The answer with the value of the restored version.
+	Answer *Answer `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	// The time the value was restored, the restore event occurred at the same time.
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// The ID of the message of the restore event, or the ID written with the answer if the events are captured from the change stream.
+	EventId string `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// The new version the restored value is stored with, the restored version is not reused.
+	Version int32 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *GetAnswerHistoryResponse) Reset() {
-	*x = GetAnswerHistoryResponse{}
+func (x *RestoreAnswerResponse) Reset() {
+	*x = RestoreAnswerResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_answers_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -438,13 +610,13 @@ func (x *GetAnswerHistoryResponse) Reset() {
 	}
 }
 
-func (x *GetAnswerHistoryResponse) String() string {
+func (x *RestoreAnswerResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAnswerHistoryResponse) ProtoMessage() {}
+func (*RestoreAnswerResponse) ProtoMessage() {}
 
-func (x *GetAnswerHistoryResponse) ProtoReflect() protoreflect.Message {
+func (x *RestoreAnswerResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_answers_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -456,185 +628,1204 @@ func (x *GetAnswerHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAnswerHistoryResponse.ProtoReflect.Descriptor instead.
-func (*GetAnswerHistoryResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreAnswerResponse.ProtoReflect.Descriptor instead.
+func (*RestoreAnswerResponse) Descriptor() ([]byte, []int) {
 	return file_answers_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GetAnswerHistoryResponse) GetAnswerEvents() []*AnswerEvent {
+func (x *RestoreAnswerResponse) GetAnswer() *Answer {
 	if x != nil {
-		return x.AnswerEvents
+		return x.Answer
 	}
 	return nil
 }
 
-var File_answers_service_proto protoreflect.FileDescriptor
+func (x *RestoreAnswerResponse) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
 
-var file_answers_service_proto_rawDesc = []byte{
-	0x0a, 0x15, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63,
-	0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x5f, 0x6d, 0x6f,
-	0x64, 0x65, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x63, 0x0a, 0x13, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x4c, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61,
-	0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e,
-	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e,
-	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0x16,
-	0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x63, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4c, 0x0a,
-	0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e,
-	0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77,
-	0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
-	0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73,
-	0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0x16, 0x0a, 0x14, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x27, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6e, 0x73,
-	0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x16, 0x0a, 0x14,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65,
-	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x61, 0x0a, 0x11, 0x47, 0x65,
-	0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x4c, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e,
-	0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65,
-	0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41,
-	0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0x2b, 0x0a,
-	0x17, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
-	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x7a, 0x0a, 0x18, 0x47, 0x65,
-	0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0d, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
-	0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e,
-	0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77,
-	0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
-	0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73,
-	0x77, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x0c, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x32, 0xa5, 0x07, 0x0a, 0x0d, 0x41, 0x6e, 0x73, 0x77, 0x65,
-	0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xb6, 0x01, 0x0a, 0x0c, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x43, 0x2e, 0x64, 0x6f, 0x63, 0x68,
-	0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x44,
-	0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73,
-	0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72,
-	0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x22, 0x0b, 0x2f, 0x76,
-	0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x3a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65,
-	0x72, 0x12, 0xb6, 0x01, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77,
-	0x65, 0x72, 0x12, 0x43, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b,
-	0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67,
-	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x44, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e,
-	0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41,
-	0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82,
-	0xd3, 0xe4, 0x93, 0x02, 0x15, 0x1a, 0x0b, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65,
-	0x72, 0x73, 0x3a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0xae, 0x01, 0x0a, 0x0c, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x43, 0x2e, 0x64, 0x6f,
-	0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x44, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61,
-	0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e,
-	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x2a, 0x0b,
-	0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0xa5, 0x01, 0x0a, 0x09,
-	0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x40, 0x2e, 0x64, 0x6f, 0x63, 0x68,
-	0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6e,
-	0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x41, 0x2e, 0x64, 0x6f,
-	0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
-	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12, 0x0b, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77,
-	0x65, 0x72, 0x73, 0x12, 0xc8, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65,
-	0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x47, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71,
-	0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73,
-	0x77, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x48, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e,
-	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65,
-	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74,
-	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
-	0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x2f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x42, 0x21,
-	0x5a, 0x1f, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2f, 0x61, 0x6e,
-	0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x61, 0x70, 0x69, 0x2f, 0x76,
-	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *RestoreAnswerResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
 }
 
-var (
-	file_answers_service_proto_rawDescOnce sync.Once
-	file_answers_service_proto_rawDescData = file_answers_service_proto_rawDesc
-)
+func (x *RestoreAnswerResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
 
-func file_answers_service_proto_rawDescGZIP() []byte {
-	file_answers_service_proto_rawDescOnce.Do(func() {
-		file_answers_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_answers_service_proto_rawDescData)
-	})
-	return file_answers_service_proto_rawDescData
+type GetAnswerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
 }
 
-var file_answers_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
-var file_answers_service_proto_goTypes = []interface{}{
-	(*CreateAnswerRequest)(nil),      // 0: dochq.co.uk.answerservice.generated.service.v1.CreateAnswerRequest
-	(*CreateAnswerResponse)(nil),     // 1: dochq.co.uk.answerservice.generated.service.v1.CreateAnswerResponse
-	(*UpdateAnswerRequest)(nil),      // 2: dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerRequest
-	(*UpdateAnswerResponse)(nil),     // 3: dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerResponse
-	(*DeleteAnswerRequest)(nil),      // 4: dochq.co.uk.answerservice.generated.service.v1.DeleteAnswerRequest
-	(*DeleteAnswerResponse)(nil),     // 5: dochq.co.uk.answerservice.generated.service.v1.DeleteAnswerResponse
-	(*GetAnswerRequest)(nil),         // 6: dochq.co.uk.answerservice.generated.service.v1.GetAnswerRequest
-	(*GetAnswerResponse)(nil),        // 7: dochq.co.uk.answerservice.generated.service.v1.GetAnswerResponse
-	(*GetAnswerHistoryRequest)(nil),  // 8: dochq.co.uk.answerservice.generated.service.v1.GetAnswerHistoryRequest
-	(*GetAnswerHistoryResponse)(nil), // 9: dochq.co.uk.answerservice.generated.service.v1.GetAnswerHistoryResponse
-	(*Answer)(nil),                   // 10: dochq.co.uk.answerservice.generated.model.v1.Answer
-	(*AnswerEvent)(nil),              // 11: dochq.co.uk.answerservice.generated.model.v1.AnswerEvent
+func (x *GetAnswerRequest) Reset() {
+	*x = GetAnswerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
-var file_answers_service_proto_depIdxs = []int32{
-	10, // 0: dochq.co.uk.answerservice.generated.service.v1.CreateAnswerRequest.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
-	10, // 1: dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerRequest.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
-	10, // 2: dochq.co.uk.answerservice.generated.service.v1.GetAnswerResponse.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
-	11, // 3: dochq.co.uk.answerservice.generated.service.v1.GetAnswerHistoryResponse.answer_events:type_name -> dochq.co.uk.answerservice.generated.model.v1.AnswerEvent
-	0,  // 4: dochq.co.uk.answerservice.generated.service.v1.AnswerService.CreateAnswer:input_type -> dochq.co.uk.answerservice.generated.service.v1.CreateAnswerRequest
-	2,  // 5: dochq.co.uk.answerservice.generated.service.v1.AnswerService.UpdateAnswer:input_type -> dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerRequest
-	4,  // 6: dochq.co.uk.answerservice.generated.service.v1.AnswerService.DeleteAnswer:input_type -> dochq.co.uk.answerservice.generated.service.v1.DeleteAnswerRequest
-	6,  // 7: dochq.co.uk.answerservice.generated.service.v1.AnswerService.GetAnswer:input_type -> dochq.co.uk.answerservice.generated.service.v1.GetAnswerRequest
-	8,  // 8: dochq.co.uk.answerservice.generated.service.v1.AnswerService.GetAnswerHistory:input_type -> dochq.co.uk.answerservice.generated.service.v1.GetAnswerHistoryRequest
-	1,  // 9: dochq.co.uk.answerservice.generated.service.v1.AnswerService.CreateAnswer:output_type -> dochq.co.uk.answerservice.generated.service.v1.CreateAnswerResponse
-	3,  // 10: dochq.co.uk.answerservice.generated.service.v1.AnswerService.UpdateAnswer:output_type -> dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerResponse
-	5,  // 11: dochq.co.uk.answerservice.generated.service.v1.AnswerService.DeleteAnswer:output_type -> dochq.co.uk.answerservice.generated.service.v1.DeleteAnswerResponse
-	7,  // 12: dochq.co.uk.answerservice.generated.service.v1.AnswerService.GetAnswer:output_type -> dochq.co.uk.answerservice.generated.service.v1.GetAnswerResponse
-	9,  // 13: dochq.co.uk.answerservice.generated.service.v1.AnswerService.GetAnswerHistory:output_type -> dochq.co.uk.answerservice.generated.service.v1.GetAnswerHistoryResponse
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+
+func (x *GetAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_answers_service_proto_init() }
-func file_answers_service_proto_init() {
-	if File_answers_service_proto != nil {
-		return
-	}
+func (*GetAnswerRequest) ProtoMessage() {}
+
+func (x *GetAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnswerRequest.ProtoReflect.Descriptor instead.
+func (*GetAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAnswerRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetAnswerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Answer *Answer `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+}
+
+func (x *GetAnswerResponse) Reset() {
+	*x = GetAnswerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAnswerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnswerResponse) ProtoMessage() {}
+
+func (x *GetAnswerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnswerResponse.ProtoReflect.Descriptor instead.
+func (*GetAnswerResponse) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetAnswerResponse) GetAnswer() *Answer {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+type GetAnswerHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *GetAnswerHistoryRequest) Reset() {
+	*x = GetAnswerHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAnswerHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnswerHistoryRequest) ProtoMessage() {}
+
+func (x *GetAnswerHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnswerHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAnswerHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetAnswerHistoryRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetAnswerHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AnswerEvents []*AnswerEvent `protobuf:"bytes,1,rep,name=answer_events,json=answerEvents,proto3" json:"answer_events,omitempty"`
+}
+
+func (x *GetAnswerHistoryResponse) Reset() {
+	*x = GetAnswerHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAnswerHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnswerHistoryResponse) ProtoMessage() {}
+
+func (x *GetAnswerHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnswerHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetAnswerHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetAnswerHistoryResponse) GetAnswerEvents() []*AnswerEvent {
+	if x != nil {
+		return x.AnswerEvents
+	}
+	return nil
+}
+
+type GetAnswerAtRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Types that are assignable to Point:
+	//	*GetAnswerAtRequest_Time
+	//	*GetAnswerAtRequest_Version
+	Point isGetAnswerAtRequest_Point `protobuf_oneof:"point"`
+}
+
+func (x *GetAnswerAtRequest) Reset() {
+	*x = GetAnswerAtRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAnswerAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnswerAtRequest) ProtoMessage() {}
+
+func (x *GetAnswerAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnswerAtRequest.ProtoReflect.Descriptor instead.
+func (*GetAnswerAtRequest) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetAnswerAtRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (m *GetAnswerAtRequest) GetPoint() isGetAnswerAtRequest_Point {
+	if m != nil {
+		return m.Point
+	}
+	return nil
+}
+
+func (x *GetAnswerAtRequest) GetTime() *timestamppb.Timestamp {
+	if x, ok := x.GetPoint().(*GetAnswerAtRequest_Time); ok {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *GetAnswerAtRequest) GetVersion() int32 {
+	if x, ok := x.GetPoint().(*GetAnswerAtRequest_Version); ok {
+		return x.Version
+	}
+	return 0
+}
+
+type isGetAnswerAtRequest_Point interface {
+	isGetAnswerAtRequest_Point()
+}
+
+type GetAnswerAtRequest_Time struct {
+	Time *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3,oneof"`
+}
+
+type GetAnswerAtRequest_Version struct {
+	Version int32 `protobuf:"varint,3,opt,name=version,proto3,oneof"`
+}
+
+func (*GetAnswerAtRequest_Time) isGetAnswerAtRequest_Point() {}
+
+func (*GetAnswerAtRequest_Version) isGetAnswerAtRequest_Point() {}
+
+type GetAnswerAtResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Answer     *Answer                `protobuf:"bytes,1,opt,name=answer,proto3" json:"answer,omitempty"`
+	Version    int32                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+}
+
+func (x *GetAnswerAtResponse) Reset() {
+	*x = GetAnswerAtResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAnswerAtResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAnswerAtResponse) ProtoMessage() {}
+
+func (x *GetAnswerAtResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAnswerAtResponse.ProtoReflect.Descriptor instead.
+func (*GetAnswerAtResponse) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetAnswerAtResponse) GetAnswer() *Answer {
+	if x != nil {
+		return x.Answer
+	}
+	return nil
+}
+
+func (x *GetAnswerAtResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetAnswerAtResponse) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type ListAnswersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageSize  int32  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListAnswersRequest) Reset() {
+	*x = ListAnswersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAnswersRequest) ProtoMessage() {}
+
+func (x *ListAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAnswersRequest.ProtoReflect.Descriptor instead.
+func (*ListAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListAnswersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAnswersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAnswersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Answers       []*Answer `protobuf:"bytes,1,rep,name=answers,proto3" json:"answers,omitempty"`
+	NextPageToken string    `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListAnswersResponse) Reset() {
+	*x = ListAnswersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAnswersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAnswersResponse) ProtoMessage() {}
+
+func (x *ListAnswersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAnswersResponse.ProtoReflect.Descriptor instead.
+func (*ListAnswersResponse) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListAnswersResponse) GetAnswers() []*Answer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *ListAnswersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SearchAnswersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key       *AnswerSearchTerm `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value     *AnswerSearchTerm `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	PageSize  int32             `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string            `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *SearchAnswersRequest) Reset() {
+	*x = SearchAnswersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAnswersRequest) ProtoMessage() {}
+
+func (x *SearchAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAnswersRequest.ProtoReflect.Descriptor instead.
+func (*SearchAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SearchAnswersRequest) GetKey() *AnswerSearchTerm {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *SearchAnswersRequest) GetValue() *AnswerSearchTerm {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SearchAnswersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchAnswersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchAnswersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Answers       []*Answer `protobuf:"bytes,1,rep,name=answers,proto3" json:"answers,omitempty"`
+	NextPageToken string    `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *SearchAnswersResponse) Reset() {
+	*x = SearchAnswersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchAnswersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAnswersResponse) ProtoMessage() {}
+
+func (x *SearchAnswersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAnswersResponse.ProtoReflect.Descriptor instead.
+func (*SearchAnswersResponse) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SearchAnswersResponse) GetAnswers() []*Answer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *SearchAnswersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type WatchAnswersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Selector:
+	//	*WatchAnswersRequest_Key
+	//	*WatchAnswersRequest_Prefix
+	//	*WatchAnswersRequest_Namespace
+	Selector isWatchAnswersRequest_Selector `protobuf_oneof:"selector"`
+	// Only supported by key watches.
+	FromVersion int32 `protobuf:"varint,4,opt,name=from_version,json=fromVersion,proto3" json:"from_version,omitempty"`
+}
+
+func (x *WatchAnswersRequest) Reset() {
+	*x = WatchAnswersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAnswersRequest) ProtoMessage() {}
+
+func (x *WatchAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAnswersRequest.ProtoReflect.Descriptor instead.
+func (*WatchAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (m *WatchAnswersRequest) GetSelector() isWatchAnswersRequest_Selector {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+func (x *WatchAnswersRequest) GetKey() string {
+	if x, ok := x.GetSelector().(*WatchAnswersRequest_Key); ok {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *WatchAnswersRequest) GetPrefix() string {
+	if x, ok := x.GetSelector().(*WatchAnswersRequest_Prefix); ok {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *WatchAnswersRequest) GetNamespace() string {
+	if x, ok := x.GetSelector().(*WatchAnswersRequest_Namespace); ok {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *WatchAnswersRequest) GetFromVersion() int32 {
+	if x != nil {
+		return x.FromVersion
+	}
+	return 0
+}
+
+type isWatchAnswersRequest_Selector interface {
+	isWatchAnswersRequest_Selector()
+}
+
+type WatchAnswersRequest_Key struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3,oneof"`
+}
+
+type WatchAnswersRequest_Prefix struct {
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3,oneof"`
+}
+
+type WatchAnswersRequest_Namespace struct {
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3,oneof"`
+}
+
+func (*WatchAnswersRequest_Key) isWatchAnswersRequest_Selector() {}
+
+func (*WatchAnswersRequest_Prefix) isWatchAnswersRequest_Selector() {}
+
+func (*WatchAnswersRequest_Namespace) isWatchAnswersRequest_Selector() {}
+
+type WatchAnswersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AnswerEvent *AnswerEvent `protobuf:"bytes,1,opt,name=answer_event,json=answerEvent,proto3" json:"answer_event,omitempty"`
+	Version     int32        `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *WatchAnswersResponse) Reset() {
+	*x = WatchAnswersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_answers_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchAnswersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchAnswersResponse) ProtoMessage() {}
+
+func (x *WatchAnswersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_answers_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchAnswersResponse.ProtoReflect.Descriptor instead.
+func (*WatchAnswersResponse) Descriptor() ([]byte, []int) {
+	return file_answers_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchAnswersResponse) GetAnswerEvent() *AnswerEvent {
+	if x != nil {
+		return x.AnswerEvent
+	}
+	return nil
+}
+
+func (x *WatchAnswersResponse) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+var File_answers_service_proto protoreflect.FileDescriptor
+
+var file_answers_service_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63,
+	0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x63, 0x0a, 0x13, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x4c, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22,
+	0xd6, 0x01, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71,
+	0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x63, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x4c, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x22, 0xd6, 0x01,
+	0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63,
+	0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x3d, 0x0a, 0x13, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xf0, 0x01, 0x0a, 0x14, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c,
+	0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34,
+	0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b,
+	0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f,
+	0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x27, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x22, 0xd6, 0x01, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x06, 0x61, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63,
+	0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x42, 0x0a, 0x14, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xd7,
+	0x01, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71,
+	0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x24, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x61,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75,
+	0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x22, 0x2b, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x7a,
+	0x0a, 0x18, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0d, 0x61, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x39, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x0c, 0x61, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x7d, 0x0a, 0x12, 0x47, 0x65,
+	0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x41, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x48, 0x00, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x42, 0x07, 0x0a, 0x05, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0xba, 0x01, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x41, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4c, 0x0a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x63, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x64, 0x41, 0x74, 0x22, 0x50, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4e, 0x0a, 0x07, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x07, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xfa, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x50, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e,
+	0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65, 0x72, 0x6d, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x54, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b,
+	0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x65,
+	0x72, 0x6d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61,
+	0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x8f, 0x01, 0x0a, 0x15, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4e, 0x0a, 0x07, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x34, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x07, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12,
+	0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x92, 0x01, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1e, 0x0a,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x72, 0x6f, 0x6d, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x42, 0x0a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x8e, 0x01, 0x0a,
+	0x14, 0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0c, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x64, 0x6f,
+	0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x0b, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x32, 0xe9, 0x0f,
+	0x0a, 0x0d, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0xb6, 0x01, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x12, 0x43, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x44, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f,
+	0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x15, 0x22, 0x0b, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x3a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0xb6, 0x01, 0x0a, 0x0c, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x43, 0x2e, 0x64, 0x6f, 0x63, 0x68,
+	0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x44,
+	0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x1a, 0x0b, 0x2f, 0x76,
+	0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x3a, 0x06, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x12, 0xb7, 0x01, 0x0a, 0x0c, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x12, 0x43, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b,
+	0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x44, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e,
+	0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x65, 0x72, 0x74, 0x41,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x16, 0x1a, 0x11, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x3a, 0x01, 0x2a, 0x12, 0xae, 0x01, 0x0a, 0x0c,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x43, 0x2e, 0x64,
+	0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x44, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x2a,
+	0x0b, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0xc2, 0x01, 0x0a,
+	0x0d, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x44,
+	0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x45, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e,
+	0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x1e, 0x22, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x2f, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x3a, 0x01,
+	0x2a, 0x12, 0xa5, 0x01, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12,
+	0x40, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x41, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65,
+	0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12, 0x0b, 0x2f, 0x76,
+	0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0xc8, 0x01, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x47,
+	0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x48, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e,
+	0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x61,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x2f, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x12, 0xb4, 0x01, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x41, 0x74, 0x12, 0x42, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e,
+	0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x41,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x43, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71,
+	0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x41, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x2f, 0x61, 0x74, 0x12, 0xb0, 0x01, 0x0a, 0x0b,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0x42, 0x2e, 0x64, 0x6f,
+	0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x43, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x76,
+	0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x12, 0xb8,
+	0x01, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x12, 0x44, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x45, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63,
+	0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x73, 0x3a, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x9d, 0x01, 0x0a, 0x0c, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x12, 0x43, 0x2e, 0x64, 0x6f, 0x63,
+	0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x44, 0x2e, 0x64, 0x6f, 0x63, 0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2e, 0x61, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x67, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x21, 0x5a, 0x1f, 0x64, 0x6f, 0x63,
+	0x68, 0x71, 0x2e, 0x63, 0x6f, 0x2e, 0x75, 0x6b, 0x2f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_answers_service_proto_rawDescOnce sync.Once
+	file_answers_service_proto_rawDescData = file_answers_service_proto_rawDesc
+)
+
+func file_answers_service_proto_rawDescGZIP() []byte {
+	file_answers_service_proto_rawDescOnce.Do(func() {
+		file_answers_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_answers_service_proto_rawDescData)
+	})
+	return file_answers_service_proto_rawDescData
+}
+
+var file_answers_service_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_answers_service_proto_goTypes = []interface{}{
+	(*CreateAnswerRequest)(nil),      // 0: dochq.co.uk.answerservice.generated.service.v1.CreateAnswerRequest
+	(*CreateAnswerResponse)(nil),     // 1: dochq.co.uk.answerservice.generated.service.v1.CreateAnswerResponse
+	(*UpdateAnswerRequest)(nil),      // 2: dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerRequest
+	(*UpdateAnswerResponse)(nil),     // 3: dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerResponse
+	(*UpsertAnswerRequest)(nil),      // 4: dochq.co.uk.answerservice.generated.service.v1.UpsertAnswerRequest
+	(*UpsertAnswerResponse)(nil),     // 5: dochq.co.uk.answerservice.generated.service.v1.UpsertAnswerResponse
+	(*DeleteAnswerRequest)(nil),      // 6: dochq.co.uk.answerservice.generated.service.v1.DeleteAnswerRequest
+	(*DeleteAnswerResponse)(nil),     // 7: dochq.co.uk.answerservice.generated.service.v1.DeleteAnswerResponse
+	(*RestoreAnswerRequest)(nil),     // 8: dochq.co.uk.answerservice.generated.service.v1.RestoreAnswerRequest
+	(*RestoreAnswerResponse)(nil),    // 9: dochq.co.uk.answerservice.generated.service.v1.RestoreAnswerResponse
+	(*GetAnswerRequest)(nil),         // 10: dochq.co.uk.answerservice.generated.service.v1.GetAnswerRequest
+	(*GetAnswerResponse)(nil),        // 11: dochq.co.uk.answerservice.generated.service.v1.GetAnswerResponse
+	(*GetAnswerHistoryRequest)(nil),  // 12: dochq.co.uk.answerservice.generated.service.v1.GetAnswerHistoryRequest
+	(*GetAnswerHistoryResponse)(nil), // 13: dochq.co.uk.answerservice.generated.service.v1.GetAnswerHistoryResponse
+	(*GetAnswerAtRequest)(nil),       // 14: dochq.co.uk.answerservice.generated.service.v1.GetAnswerAtRequest
+	(*GetAnswerAtResponse)(nil),      // 15: dochq.co.uk.answerservice.generated.service.v1.GetAnswerAtResponse
+	(*ListAnswersRequest)(nil),       // 16: dochq.co.uk.answerservice.generated.service.v1.ListAnswersRequest
+	(*ListAnswersResponse)(nil),      // 17: dochq.co.uk.answerservice.generated.service.v1.ListAnswersResponse
+	(*SearchAnswersRequest)(nil),     // 18: dochq.co.uk.answerservice.generated.service.v1.SearchAnswersRequest
+	(*SearchAnswersResponse)(nil),    // 19: dochq.co.uk.answerservice.generated.service.v1.SearchAnswersResponse
+	(*WatchAnswersRequest)(nil),      // 20: dochq.co.uk.answerservice.generated.service.v1.WatchAnswersRequest
+	(*WatchAnswersResponse)(nil),     // 21: dochq.co.uk.answerservice.generated.service.v1.WatchAnswersResponse
+	(*Answer)(nil),                   // 22: dochq.co.uk.answerservice.generated.model.v1.Answer
+	(*timestamppb.Timestamp)(nil),    // 23: google.protobuf.Timestamp
+	(*AnswerEvent)(nil),              // 24: dochq.co.uk.answerservice.generated.model.v1.AnswerEvent
+	(*AnswerSearchTerm)(nil),         // 25: dochq.co.uk.answerservice.generated.model.v1.AnswerSearchTerm
+}
+var file_answers_service_proto_depIdxs = []int32{
+	22, // 0: dochq.co.uk.answerservice.generated.service.v1.CreateAnswerRequest.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	22, // 1: dochq.co.uk.answerservice.generated.service.v1.CreateAnswerResponse.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	23, // 2: dochq.co.uk.answerservice.generated.service.v1.CreateAnswerResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	22, // 3: dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerRequest.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	22, // 4: dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerResponse.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	23, // 5: dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	22, // 6: dochq.co.uk.answerservice.generated.service.v1.UpsertAnswerResponse.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	23, // 7: dochq.co.uk.answerservice.generated.service.v1.UpsertAnswerResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	22, // 8: dochq.co.uk.answerservice.generated.service.v1.DeleteAnswerResponse.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	23, // 9: dochq.co.uk.answerservice.generated.service.v1.DeleteAnswerResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	22, // 10: dochq.co.uk.answerservice.generated.service.v1.RestoreAnswerResponse.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	23, // 11: dochq.co.uk.answerservice.generated.service.v1.RestoreAnswerResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	22, // 12: dochq.co.uk.answerservice.generated.service.v1.GetAnswerResponse.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	24, // 13: dochq.co.uk.answerservice.generated.service.v1.GetAnswerHistoryResponse.answer_events:type_name -> dochq.co.uk.answerservice.generated.model.v1.AnswerEvent
+	23, // 14: dochq.co.uk.answerservice.generated.service.v1.GetAnswerAtRequest.time:type_name -> google.protobuf.Timestamp
+	22, // 15: dochq.co.uk.answerservice.generated.service.v1.GetAnswerAtResponse.answer:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	23, // 16: dochq.co.uk.answerservice.generated.service.v1.GetAnswerAtResponse.occurred_at:type_name -> google.protobuf.Timestamp
+	22, // 17: dochq.co.uk.answerservice.generated.service.v1.ListAnswersResponse.answers:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	25, // 18: dochq.co.uk.answerservice.generated.service.v1.SearchAnswersRequest.key:type_name -> dochq.co.uk.answerservice.generated.model.v1.AnswerSearchTerm
+	25, // 19: dochq.co.uk.answerservice.generated.service.v1.SearchAnswersRequest.value:type_name -> dochq.co.uk.answerservice.generated.model.v1.AnswerSearchTerm
+	22, // 20: dochq.co.uk.answerservice.generated.service.v1.SearchAnswersResponse.answers:type_name -> dochq.co.uk.answerservice.generated.model.v1.Answer
+	24, // 21: dochq.co.uk.answerservice.generated.service.v1.WatchAnswersResponse.answer_event:type_name -> dochq.co.uk.answerservice.generated.model.v1.AnswerEvent
+	0,  // 22: dochq.co.uk.answerservice.generated.service.v1.AnswerService.CreateAnswer:input_type -> dochq.co.uk.answerservice.generated.service.v1.CreateAnswerRequest
+	2,  // 23: dochq.co.uk.answerservice.generated.service.v1.AnswerService.UpdateAnswer:input_type -> dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerRequest
+	4,  // 24: dochq.co.uk.answerservice.generated.service.v1.AnswerService.UpsertAnswer:input_type -> dochq.co.uk.answerservice.generated.service.v1.UpsertAnswerRequest
+	6,  // 25: dochq.co.uk.answerservice.generated.service.v1.AnswerService.DeleteAnswer:input_type -> dochq.co.uk.answerservice.generated.service.v1.DeleteAnswerRequest
+	8,  // 26: dochq.co.uk.answerservice.generated.service.v1.AnswerService.RestoreAnswer:input_type -> dochq.co.uk.answerservice.generated.service.v1.RestoreAnswerRequest
+	10, // 27: dochq.co.uk.answerservice.generated.service.v1.AnswerService.GetAnswer:input_type -> dochq.co.uk.answerservice.generated.service.v1.GetAnswerRequest
+	12, // 28: dochq.co.uk.answerservice.generated.service.v1.AnswerService.GetAnswerHistory:input_type -> dochq.co.uk.answerservice.generated.service.v1.GetAnswerHistoryRequest
+	14, // 29: dochq.co.uk.answerservice.generated.service.v1.AnswerService.GetAnswerAt:input_type -> dochq.co.uk.answerservice.generated.service.v1.GetAnswerAtRequest
+	16, // 30: dochq.co.uk.answerservice.generated.service.v1.AnswerService.ListAnswers:input_type -> dochq.co.uk.answerservice.generated.service.v1.ListAnswersRequest
+	18, // 31: dochq.co.uk.answerservice.generated.service.v1.AnswerService.SearchAnswers:input_type -> dochq.co.uk.answerservice.generated.service.v1.SearchAnswersRequest
+	20, // 32: dochq.co.uk.answerservice.generated.service.v1.AnswerService.WatchAnswers:input_type -> dochq.co.uk.answerservice.generated.service.v1.WatchAnswersRequest
+	1,  // 33: dochq.co.uk.answerservice.generated.service.v1.AnswerService.CreateAnswer:output_type -> dochq.co.uk.answerservice.generated.service.v1.CreateAnswerResponse
+	3,  // 34: dochq.co.uk.answerservice.generated.service.v1.AnswerService.UpdateAnswer:output_type -> dochq.co.uk.answerservice.generated.service.v1.UpdateAnswerResponse
+	5,  // 35: dochq.co.uk.answerservice.generated.service.v1.AnswerService.UpsertAnswer:output_type -> dochq.co.uk.answerservice.generated.service.v1.UpsertAnswerResponse
+	7,  // 36: dochq.co.uk.answerservice.generated.service.v1.AnswerService.DeleteAnswer:output_type -> dochq.co.uk.answerservice.generated.service.v1.DeleteAnswerResponse
+	9,  // 37: dochq.co.uk.answerservice.generated.service.v1.AnswerService.RestoreAnswer:output_type -> dochq.co.uk.answerservice.generated.service.v1.RestoreAnswerResponse
+	11, // 38: dochq.co.uk.answerservice.generated.service.v1.AnswerService.GetAnswer:output_type -> dochq.co.uk.answerservice.generated.service.v1.GetAnswerResponse
+	13, // 39: dochq.co.uk.answerservice.generated.service.v1.AnswerService.GetAnswerHistory:output_type -> dochq.co.uk.answerservice.generated.service.v1.GetAnswerHistoryResponse
+	15, // 40: dochq.co.uk.answerservice.generated.service.v1.AnswerService.GetAnswerAt:output_type -> dochq.co.uk.answerservice.generated.service.v1.GetAnswerAtResponse
+	17, // 41: dochq.co.uk.answerservice.generated.service.v1.AnswerService.ListAnswers:output_type -> dochq.co.uk.answerservice.generated.service.v1.ListAnswersResponse
+	19, // 42: dochq.co.uk.answerservice.generated.service.v1.AnswerService.SearchAnswers:output_type -> dochq.co.uk.answerservice.generated.service.v1.SearchAnswersResponse
+	21, // 43: dochq.co.uk.answerservice.generated.service.v1.AnswerService.WatchAnswers:output_type -> dochq.co.uk.answerservice.generated.service.v1.WatchAnswersResponse
+	33, // [33:44] is the sub-list for method output_type
+	22, // [22:33] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_answers_service_proto_init() }
+func file_answers_service_proto_init() {
+	if File_answers_service_proto != nil {
+		return
+	}
 	file_answer_model_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_answers_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
@@ -649,8 +1840,56 @@ func file_answers_service_proto_init() {
 				return nil
 			}
 		}
-		file_answers_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateAnswerResponse); i {
+		file_answers_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateAnswerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateAnswerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateAnswerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpsertAnswerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpsertAnswerResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -661,8 +1900,8 @@ func file_answers_service_proto_init() {
 				return nil
 			}
 		}
-		file_answers_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateAnswerRequest); i {
+		file_answers_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteAnswerRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -673,8 +1912,8 @@ func file_answers_service_proto_init() {
 				return nil
 			}
 		}
-		file_answers_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateAnswerResponse); i {
+		file_answers_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteAnswerResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -685,8 +1924,8 @@ func file_answers_service_proto_init() {
 				return nil
 			}
 		}
-		file_answers_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteAnswerRequest); i {
+		file_answers_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreAnswerRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -697,8 +1936,8 @@ func file_answers_service_proto_init() {
 				return nil
 			}
 		}
-		file_answers_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteAnswerResponse); i {
+		file_answers_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreAnswerResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -709,7 +1948,7 @@ func file_answers_service_proto_init() {
 				return nil
 			}
 		}
-		file_answers_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_answers_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetAnswerRequest); i {
 			case 0:
 				return &v.state
@@ -721,7 +1960,7 @@ func file_answers_service_proto_init() {
 				return nil
 			}
 		}
-		file_answers_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_answers_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetAnswerResponse); i {
 			case 0:
 				return &v.state
@@ -733,7 +1972,7 @@ func file_answers_service_proto_init() {
 				return nil
 			}
 		}
-		file_answers_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_answers_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetAnswerHistoryRequest); i {
 			case 0:
 				return &v.state
@@ -745,7 +1984,7 @@ func file_answers_service_proto_init() {
 				return nil
 			}
 		}
-		file_answers_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_answers_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetAnswerHistoryResponse); i {
 			case 0:
 				return &v.state
@@ -757,6 +1996,111 @@ func file_answers_service_proto_init() {
 				return nil
 			}
 		}
+		file_answers_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAnswerAtRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAnswerAtResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAnswersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListAnswersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchAnswersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchAnswersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchAnswersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_answers_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchAnswersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_answers_service_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*GetAnswerAtRequest_Time)(nil),
+		(*GetAnswerAtRequest_Version)(nil),
+	}
+	file_answers_service_proto_msgTypes[20].OneofWrappers = []interface{}{
+		(*WatchAnswersRequest_Key)(nil),
+		(*WatchAnswersRequest_Prefix)(nil),
+		(*WatchAnswersRequest_Namespace)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -764,7 +2108,7 @@ func file_answers_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_answers_service_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -791,18 +2135,27 @@ const _ = grpc.SupportPackageIsVersion6
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type AnswerServiceClient interface {
 	//*
-	// Creates a new answer.
+	// Creates a new answer and returns it with the time and the event ID of the change.
 	// If the answer exists, an error "Already exists" will be returned.
 	CreateAnswer(ctx context.Context, in *CreateAnswerRequest, opts ...grpc.CallOption) (*CreateAnswerResponse, error)
 	//*
-	// Updates an existing answer.
+	// Updates an existing answer and returns it with the time and the event ID of the change.
 	// If the answer does not exist, an error "Not found" will be returned.
 	UpdateAnswer(ctx context.Context, in *UpdateAnswerRequest, opts ...grpc.CallOption) (*UpdateAnswerResponse, error)
 	//*
-	// Deletes an existing answer.
+	// Creates the answer or replaces the existing one in one step and returns it with the time and the event ID
+	// of the change. A deleted answer is created again. Created tells whether a create or an update event was recorded.
+	UpsertAnswer(ctx context.Context, in *UpsertAnswerRequest, opts ...grpc.CallOption) (*UpsertAnswerResponse, error)
+	//*
+	// Deletes an existing answer and returns it as it was with the time and the event ID of the change.
 	// If the answer does not exist, an error "Not found" will be returned.
 	DeleteAnswer(ctx context.Context, in *DeleteAnswerRequest, opts ...grpc.CallOption) (*DeleteAnswerResponse, error)
 	//*
+	// Restores the answer to the value of an event of its history, a deleted answer is recreated.
+	// The version is the version of the answer the event made, zero restores the value of the last event.
+	// If the answer has no history or the version does not exist, an error "Not found" will be returned.
+	RestoreAnswer(ctx context.Context, in *RestoreAnswerRequest, opts ...grpc.CallOption) (*RestoreAnswerResponse, error)
+	//*
 	// Returns an answer by the provided key.
 	// If the answer does not exist, an error "Not found" will be returned.
 	GetAnswer(ctx context.Context, in *GetAnswerRequest, opts ...grpc.CallOption) (*GetAnswerResponse, error)
@@ -810,6 +2163,29 @@ type AnswerServiceClient interface {
 	// Returns an answer history by the provided key.
 	// If the answer does not exist, an error "Not found" will be returned.
 	GetAnswerHistory(ctx context.Context, in *GetAnswerHistoryRequest, opts ...grpc.CallOption) (*GetAnswerHistoryResponse, error)
+	//*
+	// Returns the answer as it was at a point of its history, rebuilt from the answer events.
+	// The point is either a time or a version, every change of the answer counts its version up by one.
+	// If the answer did not exist at the point, an error "Not found" will be returned.
+	GetAnswerAt(ctx context.Context, in *GetAnswerAtRequest, opts ...grpc.CallOption) (*GetAnswerAtResponse, error)
+	//*
+	// Returns a page of answers in an unspecified order, which is stable for paging.
+	// The next page is requested with the returned page token, the last page has no page token.
+	ListAnswers(ctx context.Context, in *ListAnswersRequest, opts ...grpc.CallOption) (*ListAnswersResponse, error)
+	//*
+	// Returns a page of the answers matching all of the provided key and value terms, ordered by key.
+	// The answers are searched in an index kept up to date by the answer events, so a change is found
+	// shortly after it is made. If the search is not enabled, an error "Failed precondition" will be returned.
+	SearchAnswers(ctx context.Context, in *SearchAnswersRequest, opts ...grpc.CallOption) (*SearchAnswersResponse, error)
+	//*
+	// Streams the events of the answers selected by a key, a key prefix or a namespace as they are recorded.
+	// A key watch first replays the history after from_version, so a reconnecting client passes the last version
+	// it received. Its events are sent in the order of their versions, a missed version is read from the history.
+	// from_version is rejected for prefix and namespace watches, they only get the new events and may miss some,
+	// e.g. while reconnecting. If the client falls behind the events or a missed version is not recorded yet,
+	// the stream ends with an error "Aborted".
+	// Over HTTP the events are served as server-sent events by GET /v1/answers:watch.
+	WatchAnswers(ctx context.Context, in *WatchAnswersRequest, opts ...grpc.CallOption) (AnswerService_WatchAnswersClient, error)
 }
 
 type answerServiceClient struct {
@@ -838,6 +2214,15 @@ func (c *answerServiceClient) UpdateAnswer(ctx context.Context, in *UpdateAnswer
 	return out, nil
 }
 
+func (c *answerServiceClient) UpsertAnswer(ctx context.Context, in *UpsertAnswerRequest, opts ...grpc.CallOption) (*UpsertAnswerResponse, error) {
+	out := new(UpsertAnswerResponse)
+	err := c.cc.Invoke(ctx, "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/UpsertAnswer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *answerServiceClient) DeleteAnswer(ctx context.Context, in *DeleteAnswerRequest, opts ...grpc.CallOption) (*DeleteAnswerResponse, error) {
 	out := new(DeleteAnswerResponse)
 	err := c.cc.Invoke(ctx, "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/DeleteAnswer", in, out, opts...)
@@ -847,6 +2232,15 @@ func (c *answerServiceClient) DeleteAnswer(ctx context.Context, in *DeleteAnswer
 	return out, nil
 }
 
+func (c *answerServiceClient) RestoreAnswer(ctx context.Context, in *RestoreAnswerRequest, opts ...grpc.CallOption) (*RestoreAnswerResponse, error) {
+	out := new(RestoreAnswerResponse)
+	err := c.cc.Invoke(ctx, "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/RestoreAnswer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *answerServiceClient) GetAnswer(ctx context.Context, in *GetAnswerRequest, opts ...grpc.CallOption) (*GetAnswerResponse, error) {
 	out := new(GetAnswerResponse)
 	err := c.cc.Invoke(ctx, "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/GetAnswer", in, out, opts...)
@@ -865,21 +2259,89 @@ func (c *answerServiceClient) GetAnswerHistory(ctx context.Context, in *GetAnswe
 	return out, nil
 }
 
+func (c *answerServiceClient) GetAnswerAt(ctx context.Context, in *GetAnswerAtRequest, opts ...grpc.CallOption) (*GetAnswerAtResponse, error) {
+	out := new(GetAnswerAtResponse)
+	err := c.cc.Invoke(ctx, "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/GetAnswerAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerServiceClient) ListAnswers(ctx context.Context, in *ListAnswersRequest, opts ...grpc.CallOption) (*ListAnswersResponse, error) {
+	out := new(ListAnswersResponse)
+	err := c.cc.Invoke(ctx, "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/ListAnswers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerServiceClient) SearchAnswers(ctx context.Context, in *SearchAnswersRequest, opts ...grpc.CallOption) (*SearchAnswersResponse, error) {
+	out := new(SearchAnswersResponse)
+	err := c.cc.Invoke(ctx, "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/SearchAnswers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *answerServiceClient) WatchAnswers(ctx context.Context, in *WatchAnswersRequest, opts ...grpc.CallOption) (AnswerService_WatchAnswersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AnswerService_serviceDesc.Streams[0], "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/WatchAnswers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &answerServiceWatchAnswersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AnswerService_WatchAnswersClient interface {
+	Recv() (*WatchAnswersResponse, error)
+	grpc.ClientStream
+}
+
+type answerServiceWatchAnswersClient struct {
+	grpc.ClientStream
+}
+
+func (x *answerServiceWatchAnswersClient) Recv() (*WatchAnswersResponse, error) {
+	m := new(WatchAnswersResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // AnswerServiceServer is the server API for AnswerService service.
 type AnswerServiceServer interface {
 	//*
-	// Creates a new answer.
+	// Creates a new answer and returns it with the time and the event ID of the change.
 	// If the answer exists, an error "Already exists" will be returned.
 	CreateAnswer(context.Context, *CreateAnswerRequest) (*CreateAnswerResponse, error)
 	//*
-	// Updates an existing answer.
+	// Updates an existing answer and returns it with the time and the event ID of the change.
 	// If the answer does not exist, an error "Not found" will be returned.
 	UpdateAnswer(context.Context, *UpdateAnswerRequest) (*UpdateAnswerResponse, error)
 	//*
-	// Deletes an existing answer.
+	// Creates the answer or replaces the existing one in one step and returns it with the time and the event ID
+	// of the change. A deleted answer is created again. Created tells whether a create or an update event was recorded.
+	UpsertAnswer(context.Context, *UpsertAnswerRequest) (*UpsertAnswerResponse, error)
+	//*
+	// Deletes an existing answer and returns it as it was with the time and the event ID of the change.
 	// If the answer does not exist, an error "Not found" will be returned.
 	DeleteAnswer(context.Context, *DeleteAnswerRequest) (*DeleteAnswerResponse, error)
 	//*
+	// Restores the answer to the value of an event of its history, a deleted answer is recreated.
+	// The version is the version of the answer the event made, zero restores the value of the last event.
+	// If the answer has no history or the version does not exist, an error "Not found" will be returned.
+	RestoreAnswer(context.Context, *RestoreAnswerRequest) (*RestoreAnswerResponse, error)
+	//*
 	// Returns an answer by the provided key.
 	// If the answer does not exist, an error "Not found" will be returned.
 	GetAnswer(context.Context, *GetAnswerRequest) (*GetAnswerResponse, error)
@@ -887,6 +2349,29 @@ type AnswerServiceServer interface {
 	// Returns an answer history by the provided key.
 	// If the answer does not exist, an error "Not found" will be returned.
 	GetAnswerHistory(context.Context, *GetAnswerHistoryRequest) (*GetAnswerHistoryResponse, error)
+	//*
+	// Returns the answer as it was at a point of its history, rebuilt from the answer events.
+	// The point is either a time or a version, every change of the answer counts its version up by one.
+	// If the answer did not exist at the point, an error "Not found" will be returned.
+	GetAnswerAt(context.Context, *GetAnswerAtRequest) (*GetAnswerAtResponse, error)
+	//*
+	// Returns a page of answers in an unspecified order, which is stable for paging.
+	// The next page is requested with the returned page token, the last page has no page token.
+	ListAnswers(context.Context, *ListAnswersRequest) (*ListAnswersResponse, error)
+	//*
+	// Returns a page of the answers matching all of the provided key and value terms, ordered by key.
+	// The answers are searched in an index kept up to date by the answer events, so a change is found
+	// shortly after it is made. If the search is not enabled, an error "Failed precondition" will be returned.
+	SearchAnswers(context.Context, *SearchAnswersRequest) (*SearchAnswersResponse, error)
+	//*
+	// Streams the events of the answers selected by a key, a key prefix or a namespace as they are recorded.
+	// A key watch first replays the history after from_version, so a reconnecting client passes the last version
+	// it received. Its events are sent in the order of their versions, a missed version is read from the history.
+	// from_version is rejected for prefix and namespace watches, they only get the new events and may miss some,
+	// e.g. while reconnecting. If the client falls behind the events or a missed version is not recorded yet,
+	// the stream ends with an error "Aborted".
+	// Over HTTP the events are served as server-sent events by GET /v1/answers:watch.
+	WatchAnswers(*WatchAnswersRequest, AnswerService_WatchAnswersServer) error
 }
 
 // UnimplementedAnswerServiceServer can be embedded to have forward compatible implementations.
@@ -899,15 +2384,33 @@ func (*UnimplementedAnswerServiceServer) CreateAnswer(context.Context, *CreateAn
 func (*UnimplementedAnswerServiceServer) UpdateAnswer(context.Context, *UpdateAnswerRequest) (*UpdateAnswerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAnswer not implemented")
 }
+func (*UnimplementedAnswerServiceServer) UpsertAnswer(context.Context, *UpsertAnswerRequest) (*UpsertAnswerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertAnswer not implemented")
+}
 func (*UnimplementedAnswerServiceServer) DeleteAnswer(context.Context, *DeleteAnswerRequest) (*DeleteAnswerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAnswer not implemented")
 }
+func (*UnimplementedAnswerServiceServer) RestoreAnswer(context.Context, *RestoreAnswerRequest) (*RestoreAnswerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreAnswer not implemented")
+}
 func (*UnimplementedAnswerServiceServer) GetAnswer(context.Context, *GetAnswerRequest) (*GetAnswerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAnswer not implemented")
 }
 func (*UnimplementedAnswerServiceServer) GetAnswerHistory(context.Context, *GetAnswerHistoryRequest) (*GetAnswerHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAnswerHistory not implemented")
 }
+func (*UnimplementedAnswerServiceServer) GetAnswerAt(context.Context, *GetAnswerAtRequest) (*GetAnswerAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnswerAt not implemented")
+}
+func (*UnimplementedAnswerServiceServer) ListAnswers(context.Context, *ListAnswersRequest) (*ListAnswersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAnswers not implemented")
+}
+func (*UnimplementedAnswerServiceServer) SearchAnswers(context.Context, *SearchAnswersRequest) (*SearchAnswersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchAnswers not implemented")
+}
+func (*UnimplementedAnswerServiceServer) WatchAnswers(*WatchAnswersRequest, AnswerService_WatchAnswersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchAnswers not implemented")
+}
 
 func RegisterAnswerServiceServer(s *grpc.Server, srv AnswerServiceServer) {
 	s.RegisterService(&_AnswerService_serviceDesc, srv)
@@ -949,6 +2452,24 @@ func _AnswerService_UpdateAnswer_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AnswerService_UpsertAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerServiceServer).UpsertAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/UpsertAnswer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerServiceServer).UpsertAnswer(ctx, req.(*UpsertAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AnswerService_DeleteAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteAnswerRequest)
 	if err := dec(in); err != nil {
@@ -967,6 +2488,24 @@ func _AnswerService_DeleteAnswer_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AnswerService_RestoreAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerServiceServer).RestoreAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/RestoreAnswer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerServiceServer).RestoreAnswer(ctx, req.(*RestoreAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AnswerService_GetAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAnswerRequest)
 	if err := dec(in); err != nil {
@@ -1003,6 +2542,81 @@ func _AnswerService_GetAnswerHistory_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AnswerService_GetAnswerAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAnswerAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerServiceServer).GetAnswerAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/GetAnswerAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerServiceServer).GetAnswerAt(ctx, req.(*GetAnswerAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerService_ListAnswers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAnswersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerServiceServer).ListAnswers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/ListAnswers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerServiceServer).ListAnswers(ctx, req.(*ListAnswersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerService_SearchAnswers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchAnswersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnswerServiceServer).SearchAnswers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dochq.co.uk.answerservice.generated.service.v1.AnswerService/SearchAnswers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnswerServiceServer).SearchAnswers(ctx, req.(*SearchAnswersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnswerService_WatchAnswers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchAnswersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AnswerServiceServer).WatchAnswers(m, &answerServiceWatchAnswersServer{stream})
+}
+
+type AnswerService_WatchAnswersServer interface {
+	Send(*WatchAnswersResponse) error
+	grpc.ServerStream
+}
+
+type answerServiceWatchAnswersServer struct {
+	grpc.ServerStream
+}
+
+func (x *answerServiceWatchAnswersServer) Send(m *WatchAnswersResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _AnswerService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "dochq.co.uk.answerservice.generated.service.v1.AnswerService",
 	HandlerType: (*AnswerServiceServer)(nil),
@@ -1015,10 +2629,18 @@ var _AnswerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateAnswer",
 			Handler:    _AnswerService_UpdateAnswer_Handler,
 		},
+		{
+			MethodName: "UpsertAnswer",
+			Handler:    _AnswerService_UpsertAnswer_Handler,
+		},
 		{
 			MethodName: "DeleteAnswer",
 			Handler:    _AnswerService_DeleteAnswer_Handler,
 		},
+		{
+			MethodName: "RestoreAnswer",
+			Handler:    _AnswerService_RestoreAnswer_Handler,
+		},
 		{
 			MethodName: "GetAnswer",
 			Handler:    _AnswerService_GetAnswer_Handler,
@@ -1027,7 +2649,25 @@ var _AnswerService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetAnswerHistory",
 			Handler:    _AnswerService_GetAnswerHistory_Handler,
 		},
+		{
+			MethodName: "GetAnswerAt",
+			Handler:    _AnswerService_GetAnswerAt_Handler,
+		},
+		{
+			MethodName: "ListAnswers",
+			Handler:    _AnswerService_ListAnswers_Handler,
+		},
+		{
+			MethodName: "SearchAnswers",
+			Handler:    _AnswerService_SearchAnswers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchAnswers",
+			Handler:       _AnswerService_WatchAnswers_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "answers_service.proto",
 }
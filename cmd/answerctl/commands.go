@@ -0,0 +1,281 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func runGet(c *cli, args []string) error {
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	key := fs.String("key", "", "answer key")
+	if err := c.parseFlags(fs, args, "key"); err != nil {
+		return err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	resp, err := c.client.GetAnswer(ctx, &pkgApi.GetAnswerRequest{Key: *key})
+	if err != nil {
+		return err
+	}
+	return c.printer.printAnswers(c.stdout, []*pkgApi.Answer{resp.Answer})
+}
+
+func runCreate(c *cli, args []string) error {
+	return runWrite(c, "create", args, func(c *cli, answer *pkgApi.Answer) (*pkgApi.Answer, error) {
+		ctx, cancel := c.context()
+		defer cancel()
+		resp, err := c.client.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: answer})
+		return resp.GetAnswer(), err
+	})
+}
+
+func runUpdate(c *cli, args []string) error {
+	return runWrite(c, "update", args, func(c *cli, answer *pkgApi.Answer) (*pkgApi.Answer, error) {
+		ctx, cancel := c.context()
+		defer cancel()
+		resp, err := c.client.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: answer})
+		return resp.GetAnswer(), err
+	})
+}
+
+func runUpsert(c *cli, args []string) error {
+	return runWrite(c, "upsert", args, func(c *cli, answer *pkgApi.Answer) (*pkgApi.Answer, error) {
+		ctx, cancel := c.context()
+		defer cancel()
+		resp, err := c.client.UpsertAnswer(ctx, &pkgApi.UpsertAnswerRequest{Key: answer.Key, Value: answer.Value})
+		return resp.GetAnswer(), err
+	})
+}
+
+// runWrite - parses the answer flags, writes the answer and prints the written answer.
+func runWrite(c *cli, name string, args []string, write func(c *cli, answer *pkgApi.Answer) (*pkgApi.Answer, error)) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	key := fs.String("key", "", "answer key")
+	value := fs.String("value", "", "answer value")
+	if err := c.parseFlags(fs, args, "key", "value"); err != nil {
+		return err
+	}
+	written, err := write(c, &pkgApi.Answer{Key: *key, Value: *value})
+	if err != nil {
+		return err
+	}
+	return c.printer.printAnswers(c.stdout, []*pkgApi.Answer{written})
+}
+
+func runDelete(c *cli, args []string) error {
+	fs := flag.NewFlagSet("delete", flag.ContinueOnError)
+	key := fs.String("key", "", "answer key")
+	if err := c.parseFlags(fs, args, "key"); err != nil {
+		return err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	_, err := c.client.DeleteAnswer(ctx, &pkgApi.DeleteAnswerRequest{Key: *key})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stderr, "deleted %s\n", *key)
+	return nil
+}
+
+func runRestore(c *cli, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	key := fs.String("key", "", "answer key")
+	version := fs.Int("version", 0, "version of the history to restore, the last one if zero")
+	if err := c.parseFlags(fs, args, "key"); err != nil {
+		return err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	resp, err := c.client.RestoreAnswer(ctx, &pkgApi.RestoreAnswerRequest{Key: *key, Version: int32(*version)})
+	if err != nil {
+		return err
+	}
+	return c.printer.printAnswers(c.stdout, []*pkgApi.Answer{resp.Answer})
+}
+
+func runHistory(c *cli, args []string) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	key := fs.String("key", "", "answer key")
+	if err := c.parseFlags(fs, args, "key"); err != nil {
+		return err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	resp, err := c.client.GetAnswerHistory(ctx, &pkgApi.GetAnswerHistoryRequest{Key: *key})
+	if err != nil {
+		return err
+	}
+	return c.printer.printEvents(c.stdout, resp.AnswerEvents)
+}
+
+func runAt(c *cli, args []string) error {
+	fs := flag.NewFlagSet("at", flag.ContinueOnError)
+	key := fs.String("key", "", "answer key")
+	at := fs.String("time", "", "return the answer as it was at the RFC3339 time")
+	version := fs.Int("version", 0, "return the answer as it was at the version of its history")
+	if err := c.parseFlags(fs, args, "key"); err != nil {
+		return err
+	}
+	req := &pkgApi.GetAnswerAtRequest{Key: *key}
+	switch {
+	case len(*at) > 0 && *version > 0:
+		return errors.New("flags -time and -version are mutually exclusive")
+	case len(*at) > 0:
+		parsed, err := time.Parse(time.RFC3339Nano, *at)
+		if err != nil {
+			return fmt.Errorf("invalid -time: %w", err)
+		}
+		req.Point = &pkgApi.GetAnswerAtRequest_Time{Time: timestamppb.New(parsed)}
+	case *version > 0:
+		req.Point = &pkgApi.GetAnswerAtRequest_Version{Version: int32(*version)}
+	default:
+		return errors.New("flag -time or -version required")
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	resp, err := c.client.GetAnswerAt(ctx, req)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.stderr, "version %d, occurred at %s\n", resp.Version, resp.OccurredAt.AsTime().Format(time.RFC3339Nano))
+	return c.printer.printAnswers(c.stdout, []*pkgApi.Answer{resp.Answer})
+}
+
+func runList(c *cli, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	pageSize := fs.Int("page-size", 0, "maximum number of answers in a page, the server default if zero")
+	pageToken := fs.String("page-token", "", "token of the page to return")
+	all := fs.Bool("all", false, "follow the page tokens and return all answers")
+	if err := c.parseFlags(fs, args); err != nil {
+		return err
+	}
+	req := &pkgApi.ListAnswersRequest{PageSize: int32(*pageSize), PageToken: *pageToken}
+	var answers []*pkgApi.Answer
+	for {
+		ctx, cancel := c.context()
+		resp, err := c.client.ListAnswers(ctx, req)
+		cancel()
+		if err != nil {
+			return err
+		}
+		answers = append(answers, resp.Answers...)
+		if !*all || len(resp.NextPageToken) == 0 {
+			if len(resp.NextPageToken) > 0 {
+				fmt.Fprintf(c.stderr, "next page token: %s\n", resp.NextPageToken)
+			}
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	return c.printer.printAnswers(c.stdout, answers)
+}
+
+func runDeleted(c *cli, args []string) error {
+	fs := flag.NewFlagSet("deleted", flag.ContinueOnError)
+	pageSize := fs.Int("page-size", 0, "maximum number of deleted answers in a page, the server default if zero")
+	pageToken := fs.String("page-token", "", "token of the page to return")
+	all := fs.Bool("all", false, "follow the page tokens and return all deleted answers")
+	if err := c.parseFlags(fs, args); err != nil {
+		return err
+	}
+	req := &pkgApi.ListDeletedAnswersRequest{PageSize: int32(*pageSize), PageToken: *pageToken}
+	var answers []*pkgApi.DeletedAnswer
+	for {
+		ctx, cancel := c.context()
+		resp, err := c.admin.ListDeletedAnswers(ctx, req)
+		cancel()
+		if err != nil {
+			return err
+		}
+		answers = append(answers, resp.DeletedAnswers...)
+		if !*all || len(resp.NextPageToken) == 0 {
+			if len(resp.NextPageToken) > 0 {
+				fmt.Fprintf(c.stderr, "next page token: %s\n", resp.NextPageToken)
+			}
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	return c.printer.printDeletedAnswers(c.stdout, answers)
+}
+
+func runErase(c *cli, args []string) error {
+	fs := flag.NewFlagSet("erase", flag.ContinueOnError)
+	keys := fs.String("keys", "", "comma separated keys of the answers to erase with their history")
+	if err := c.parseFlags(fs, args, "keys"); err != nil {
+		return err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	resp, err := c.admin.EraseAnswers(ctx, &pkgApi.EraseAnswersRequest{Keys: strings.Split(*keys, ",")})
+	if err != nil {
+		return err
+	}
+	return c.printer.printReceipts(c.stdout, resp.Receipts)
+}
+
+func runImport(c *cli, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := fs.String("file", "", "CSV or NDJSON file with key and value of each answer, - for stdin")
+	format := fs.String("format", "", "input format: csv or ndjson, detected by the file extension if empty")
+	upsert := fs.Bool("upsert", false, "update the answers which already exist instead of failing")
+	if err := c.parseFlags(fs, args, "file"); err != nil {
+		return err
+	}
+
+	// Read answers.
+	//
+	in := c.stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	answers, err := readAnswers(in, detectFormat(*file, *format))
+	if err != nil {
+		return err
+	}
+
+	// Write answers, a failed answer does not stop the import.
+	//
+	var created, updated, failed int
+	for _, answer := range answers {
+		ctx, cancel := c.context()
+		if *upsert {
+			var resp *pkgApi.UpsertAnswerResponse
+			resp, err = c.client.UpsertAnswer(ctx, &pkgApi.UpsertAnswerRequest{Key: answer.Key, Value: answer.Value})
+			if err == nil && resp.Created {
+				created++
+			} else if err == nil {
+				updated++
+			}
+		} else {
+			_, err = c.client.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: answer})
+			if err == nil {
+				created++
+			}
+		}
+		cancel()
+		if err != nil {
+			failed++
+			fmt.Fprintf(c.stderr, "%s: %v\n", answer.Key, err)
+		}
+	}
+	fmt.Fprintf(c.stderr, "created %d, updated %d, failed %d\n", created, updated, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d answers failed", failed, len(answers))
+	}
+	return nil
+}
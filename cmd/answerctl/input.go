@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+)
+
+// Input formats.
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// detectFormat - returns the explicit format or the one of the file extension, CSV by default.
+func detectFormat(fileName, format string) string {
+	if len(format) > 0 {
+		return format
+	}
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".ndjson", ".jsonl":
+		return formatNDJSON
+	default:
+		return formatCSV
+	}
+}
+
+// readAnswers - reads the answers in the provided format.
+func readAnswers(r io.Reader, format string) ([]*pkgApi.Answer, error) {
+	switch format {
+	case formatCSV:
+		return readCSV(r)
+	case formatNDJSON:
+		return readNDJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported input format %q", format)
+	}
+}
+
+// readCSV - reads the key and value columns, the first row may be a "key,value" header.
+func readCSV(r io.Reader) ([]*pkgApi.Answer, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	var answers []*pkgApi.Answer
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return answers, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && strings.EqualFold(record[0], "key") && strings.EqualFold(record[1], "value") {
+			continue
+		}
+		answers = append(answers, &pkgApi.Answer{Key: record[0], Value: record[1]})
+	}
+}
+
+// readNDJSON - reads one {"key": ..., "value": ...} object per line, blank lines are skipped.
+func readNDJSON(r io.Reader) ([]*pkgApi.Answer, error) {
+	scanner := bufio.NewScanner(r)
+	var answers []*pkgApi.Answer
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var row struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		}
+		decoder := json.NewDecoder(strings.NewReader(scanner.Text()))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&row); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		answers = append(answers, &pkgApi.Answer{Key: row.Key, Value: row.Value})
+	}
+	return answers, scanner.Err()
+}
@@ -0,0 +1,179 @@
+// Command answerctl is a command-line client of the answer service.
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// Environment variables.
+const (
+	envAddr  = "ANSWERCTL_ADDR"
+	envToken = "ANSWERCTL_TOKEN"
+)
+
+const defaultAddr = "localhost:6565"
+
+// cli - the state shared by the commands.
+type cli struct {
+	client  pkgApi.AnswerServiceClient
+	admin   pkgApi.AdminServiceClient
+	token   string
+	timeout time.Duration
+	printer printer
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+// command - a subcommand of the client.
+type command struct {
+	usage string
+	run   func(c *cli, args []string) error
+}
+
+var commands = map[string]command{
+	"get":     {usage: "get -key KEY", run: runGet},
+	"create":  {usage: "create -key KEY -value VALUE", run: runCreate},
+	"update":  {usage: "update -key KEY -value VALUE", run: runUpdate},
+	"upsert":  {usage: "upsert -key KEY -value VALUE", run: runUpsert},
+	"delete":  {usage: "delete -key KEY", run: runDelete},
+	"restore": {usage: "restore -key KEY [-version N]", run: runRestore},
+	"history": {usage: "history -key KEY", run: runHistory},
+	"at":      {usage: "at -key KEY (-time RFC3339 | -version N)", run: runAt},
+	"list":    {usage: "list [-page-size N] [-page-token TOKEN] [-all]", run: runList},
+	"deleted": {usage: "deleted [-page-size N] [-page-token TOKEN] [-all]", run: runDeleted},
+	"erase":   {usage: "erase -keys KEY[,KEY...]", run: runErase},
+	"import":  {usage: "import -file FILE|- [-format csv|ndjson] [-upsert]", run: runImport},
+	"export":  {usage: "export -file FILE|- [-checkpoint-file FILE]", run: runExport},
+	"load":    {usage: "load -file FILE|- -mode replay|preserve", run: runLoad},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "answerctl:", err)
+		os.Exit(1)
+	}
+}
+
+// run - parses the global flags, connects to the service and runs the subcommand.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("answerctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", envOrDefault(envAddr, defaultAddr), "gRPC address of the answer service")
+	token := fs.String("token", os.Getenv(envToken), "bearer token sent with every request")
+	useTLS := fs.Bool("tls", false, "connect with TLS")
+	timeout := fs.Duration("timeout", 10*time.Second, "timeout of a single request")
+	output := fs.String("o", outputTable, "output format: table, json or yaml")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: answerctl [flags] COMMAND [command flags]")
+		fmt.Fprintln(stderr, "\nCommands:")
+		var names []string
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintln(stderr, "  "+commands[name].usage)
+		}
+		fmt.Fprintln(stderr, "\nFlags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("command required")
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fs.Usage()
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+	p, err := newPrinter(*output)
+	if err != nil {
+		return err
+	}
+
+	// Connect.
+	//
+	transportCredentials := grpc.WithInsecure()
+	if *useTLS {
+		transportCredentials = grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))
+	}
+	conn, err := grpc.Dial(*addr, transportCredentials)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := &cli{
+		client:  pkgApi.NewAnswerServiceClient(conn),
+		admin:   pkgApi.NewAdminServiceClient(conn),
+		token:   *token,
+		timeout: *timeout,
+		printer: p,
+		stdin:   stdin,
+		stdout:  stdout,
+		stderr:  stderr,
+	}
+	return cmd.run(c, fs.Args()[1:])
+}
+
+// context - returns the context of a single request, it carries the bearer token.
+func (c *cli) context() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	return c.withToken(ctx), cancel
+}
+
+// streamContext - returns the context of a stream, it is not limited by the request timeout.
+func (c *cli) streamContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return c.withToken(ctx), cancel
+}
+
+func (c *cli) withToken(ctx context.Context) context.Context {
+	if len(c.token) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+	}
+	return ctx
+}
+
+// parseFlags - parses the flags of a subcommand and checks the required ones.
+func (c *cli) parseFlags(fs *flag.FlagSet, args []string, required ...string) error {
+	fs.SetOutput(c.stderr)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %v", strings.Join(fs.Args(), " "))
+	}
+	for _, name := range required {
+		if len(fs.Lookup(name).Value.String()) == 0 {
+			return fmt.Errorf("flag -%s required", name)
+		}
+	}
+	return nil
+}
+
+func envOrDefault(name, defaultValue string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"github.com/go-test/deep"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeServer - stores the answers in a map and records the authorization headers.
+type fakeServer struct {
+	pkgApi.UnimplementedAnswerServiceServer
+	mu             sync.Mutex
+	answers        map[string]string
+	authorizations []string
+}
+
+func (s *fakeServer) authorize(ctx context.Context) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.authorizations = append(s.authorizations, md.Get("authorization")...)
+}
+
+func (s *fakeServer) CreateAnswer(ctx context.Context, req *pkgApi.CreateAnswerRequest) (*pkgApi.CreateAnswerResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authorize(ctx)
+	if _, ok := s.answers[req.Answer.Key]; ok {
+		return nil, status.Error(codes.AlreadyExists, "exists")
+	}
+	s.answers[req.Answer.Key] = req.Answer.Value
+	return &pkgApi.CreateAnswerResponse{Answer: req.Answer, EventId: "event-" + req.Answer.Key}, nil
+}
+
+func (s *fakeServer) UpdateAnswer(ctx context.Context, req *pkgApi.UpdateAnswerRequest) (*pkgApi.UpdateAnswerResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authorize(ctx)
+	s.answers[req.Answer.Key] = req.Answer.Value
+	return &pkgApi.UpdateAnswerResponse{Answer: req.Answer, EventId: "event-" + req.Answer.Key}, nil
+}
+
+func (s *fakeServer) UpsertAnswer(ctx context.Context, req *pkgApi.UpsertAnswerRequest) (*pkgApi.UpsertAnswerResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authorize(ctx)
+	_, ok := s.answers[req.Key]
+	s.answers[req.Key] = req.Value
+	return &pkgApi.UpsertAnswerResponse{Answer: &pkgApi.Answer{Key: req.Key, Value: req.Value}, Created: !ok}, nil
+}
+
+func (s *fakeServer) ListAnswers(ctx context.Context, req *pkgApi.ListAnswersRequest) (*pkgApi.ListAnswersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authorize(ctx)
+
+	// One answer per page, the token is the key of the next answer.
+	//
+	keys := []string{"city", "name"}
+	for i, key := range keys {
+		if key >= req.PageToken {
+			resp := &pkgApi.ListAnswersResponse{Answers: []*pkgApi.Answer{{Key: key, Value: s.answers[key]}}}
+			if i+1 < len(keys) {
+				resp.NextPageToken = keys[i+1]
+			}
+			return resp, nil
+		}
+	}
+	return &pkgApi.ListAnswersResponse{}, nil
+}
+
+// ListDeletedAnswers - returns a single deleted answer.
+func (s *fakeServer) ListDeletedAnswers(ctx context.Context, req *pkgApi.ListDeletedAnswersRequest) (*pkgApi.ListDeletedAnswersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authorize(ctx)
+	deletedAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	return &pkgApi.ListDeletedAnswersResponse{
+		DeletedAnswers: []*pkgApi.DeletedAnswer{
+			{
+				Answer:    &pkgApi.Answer{Key: "city", Value: "NY"},
+				DeletedAt: timestamppb.New(deletedAt),
+				PurgeAt:   timestamppb.New(deletedAt.Add(24 * time.Hour)),
+			},
+		},
+	}, nil
+}
+
+// EraseAnswers - erases the stored answers, the history of every key holds a single event.
+func (s *fakeServer) EraseAnswers(ctx context.Context, req *pkgApi.EraseAnswersRequest) (*pkgApi.EraseAnswersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authorize(ctx)
+	erasedAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	resp := &pkgApi.EraseAnswersResponse{}
+	for _, key := range req.Keys {
+		_, ok := s.answers[key]
+		delete(s.answers, key)
+		receipt := &pkgApi.ErasureReceipt{KeyHash: "hash-" + key, ErasedAt: timestamppb.New(erasedAt), AnswerErased: ok, RequestedBy: "admin"}
+		if ok {
+			receipt.EventsErased = 1
+		}
+		resp.Receipts = append(resp.Receipts, receipt)
+	}
+	return resp, nil
+}
+
+func startFakeServer(t *testing.T) (*fakeServer, string) {
+	t.Helper()
+	server := &fakeServer{answers: map[string]string{"name": "John"}}
+	grpcServer := grpc.NewServer()
+	pkgApi.RegisterAnswerServiceServer(grpcServer, server)
+	pkgApi.RegisterAdminServiceServer(grpcServer, server)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+	return server, listener.Addr().String()
+}
+
+func TestImportUpsert(t *testing.T) {
+	server, addr := startFakeServer(t)
+	stdin := strings.NewReader("key,value\nname,Sam\ncity,NY\n")
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"-addr", addr, "-token", "secret", "import", "-file", "-", "-upsert"}, stdin, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected err: %v, stderr: %v", err, stderr.String())
+	}
+	if diff := deep.Equal(server.answers, map[string]string{"name": "Sam", "city": "NY"}); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal(stderr.String(), "created 1, updated 1, failed 0\n"); diff != nil {
+		t.Error(diff)
+	}
+	for _, authorization := range server.authorizations {
+		if authorization != "Bearer secret" {
+			t.Errorf("unexpected authorization %q", authorization)
+		}
+	}
+}
+
+func TestImportFailure(t *testing.T) {
+	_, addr := startFakeServer(t)
+	stdin := strings.NewReader(`{"key":"name","value":"Sam"}` + "\n")
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"-addr", addr, "import", "-file", "-", "-format", "ndjson"}, stdin, &stdout, &stderr)
+	if err == nil || err.Error() != "1 of 1 answers failed" {
+		t.Errorf("unexpected err: %v", err)
+	}
+}
+
+func TestListAll(t *testing.T) {
+	server, addr := startFakeServer(t)
+	server.answers["city"] = "NY"
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"-addr", addr, "-o", "json", "list", "-all"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := "[\n  {\n    \"key\": \"city\",\n    \"value\": \"NY\"\n  },\n  {\n    \"key\": \"name\",\n    \"value\": \"John\"\n  }\n]\n"
+	if diff := deep.Equal(stdout.String(), want); diff != nil {
+		t.Error(diff)
+	}
+	if len(server.authorizations) != 0 {
+		t.Errorf("unexpected authorizations %v", server.authorizations)
+	}
+}
+
+func TestDeleted(t *testing.T) {
+	_, addr := startFakeServer(t)
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"-addr", addr, "deleted"}, nil, &stdout, &stderr); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := "KEY   VALUE  DELETED AT            PURGE AT\n" +
+		"city  NY     2022-03-01T10:00:00Z  2022-03-02T10:00:00Z\n"
+	if diff := deep.Equal(stdout.String(), want); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestErase(t *testing.T) {
+	server, addr := startFakeServer(t)
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"-addr", addr, "erase", "-keys", "name,city"}, nil, &stdout, &stderr); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := "KEY HASH   ERASED AT             ANSWER ERASED  EVENTS ERASED  REQUESTED BY\n" +
+		"hash-name  2022-03-01T10:00:00Z  true           1              admin\n" +
+		"hash-city  2022-03-01T10:00:00Z  false          0              admin\n"
+	if diff := deep.Equal(stdout.String(), want); diff != nil {
+		t.Error(diff)
+	}
+	if len(server.answers) != 0 {
+		t.Errorf("unexpected answers %v", server.answers)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "no command", wantErr: "command required"},
+		{name: "unknown command", args: []string{"drop"}, wantErr: `unknown command "drop"`},
+		{name: "unknown output", args: []string{"-o", "xml", "list"}, wantErr: `unsupported output format "xml"`},
+		{name: "missing flag", args: []string{"get"}, wantErr: "flag -key required"},
+		{name: "missing keys", args: []string{"erase"}, wantErr: "flag -keys required"},
+		{name: "missing point", args: []string{"at", "-key", "name"}, wantErr: "flag -time or -version required"},
+		{name: "two points", args: []string{"at", "-key", "name", "-time", "2022-03-01T10:00:00Z", "-version", "1"}, wantErr: "flags -time and -version are mutually exclusive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(append([]string{"-addr", "127.0.0.1:1"}, tt.args...), nil, &stdout, &stderr)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected err %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestReadAnswers(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		input   string
+		want    []*pkgApi.Answer
+		wantErr bool
+	}{
+		{
+			name:   "csv without header",
+			format: formatCSV,
+			input:  "name,John\ncity, \"New York, NY\"\n",
+			want:   []*pkgApi.Answer{{Key: "name", Value: "John"}, {Key: "city", Value: "New York, NY"}},
+		},
+		{name: "csv extra column", format: formatCSV, input: "name,John,x\n", wantErr: true},
+		{
+			name:   "ndjson",
+			format: formatNDJSON,
+			input:  "{\"key\":\"name\",\"value\":\"John\"}\n\n{\"key\":\"city\",\"value\":\"NY\"}\n",
+			want:   []*pkgApi.Answer{{Key: "name", Value: "John"}, {Key: "city", Value: "NY"}},
+		},
+		{name: "ndjson unknown field", format: formatNDJSON, input: "{\"kye\":\"name\"}\n", wantErr: true},
+		{name: "unknown format", format: "xml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readAnswers(strings.NewReader(tt.input), tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			var gotViews, wantViews []answerView
+			for _, a := range got {
+				gotViews = append(gotViews, answerView{Key: a.Key, Value: a.Value})
+			}
+			for _, a := range tt.want {
+				wantViews = append(wantViews, answerView{Key: a.Key, Value: a.Value})
+			}
+			if diff := deep.Equal(gotViews, wantViews); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestPrintTable(t *testing.T) {
+	var out bytes.Buffer
+	events := []*pkgApi.AnswerEvent{
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_CREATE, Data: &pkgApi.Answer{Key: "name", Value: "John"}},
+	}
+	if err := printer(outputTable).printEvents(&out, events); err != nil {
+		t.Fatal(err)
+	}
+	want := "EVENT                     KEY   VALUE\nANSWER_EVENT_TYPE_CREATE  name  John\n"
+	if diff := deep.Equal(out.String(), want); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// answerView - the printed answer.
+type answerView struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// eventView - the printed answer event.
+type eventView struct {
+	EventType string `json:"eventType" yaml:"eventType"`
+	Key       string `json:"key" yaml:"key"`
+	Value     string `json:"value" yaml:"value"`
+}
+
+// deletedAnswerView - the printed deleted answer, the times are in RFC 3339.
+type deletedAnswerView struct {
+	Key       string `json:"key" yaml:"key"`
+	Value     string `json:"value" yaml:"value"`
+	DeletedAt string `json:"deletedAt" yaml:"deletedAt"`
+	PurgeAt   string `json:"purgeAt" yaml:"purgeAt"`
+}
+
+// receiptView - the printed erasure receipt, the time is in RFC 3339.
+type receiptView struct {
+	KeyHash      string `json:"keyHash" yaml:"keyHash"`
+	ErasedAt     string `json:"erasedAt" yaml:"erasedAt"`
+	AnswerErased bool   `json:"answerErased" yaml:"answerErased"`
+	EventsErased int32  `json:"eventsErased" yaml:"eventsErased"`
+	RequestedBy  string `json:"requestedBy" yaml:"requestedBy"`
+}
+
+// printer - prints the results in one of the output formats.
+type printer string
+
+func newPrinter(format string) (printer, error) {
+	switch format {
+	case outputTable, outputJSON, outputYAML:
+		return printer(format), nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+func (p printer) printAnswers(w io.Writer, answers []*pkgApi.Answer) error {
+	views := make([]answerView, len(answers))
+	rows := make([][]string, len(answers))
+	for i, a := range answers {
+		views[i] = answerView{Key: a.GetKey(), Value: a.GetValue()}
+		rows[i] = []string{a.GetKey(), a.GetValue()}
+	}
+	return p.print(w, views, []string{"KEY", "VALUE"}, rows)
+}
+
+func (p printer) printEvents(w io.Writer, events []*pkgApi.AnswerEvent) error {
+	views := make([]eventView, len(events))
+	rows := make([][]string, len(events))
+	for i, e := range events {
+		views[i] = eventView{
+			EventType: e.GetEventType().String(),
+			Key:       e.GetData().GetKey(),
+			Value:     e.GetData().GetValue(),
+		}
+		rows[i] = []string{views[i].EventType, views[i].Key, views[i].Value}
+	}
+	return p.print(w, views, []string{"EVENT", "KEY", "VALUE"}, rows)
+}
+
+func (p printer) printDeletedAnswers(w io.Writer, answers []*pkgApi.DeletedAnswer) error {
+	views := make([]deletedAnswerView, len(answers))
+	rows := make([][]string, len(answers))
+	for i, a := range answers {
+		views[i] = deletedAnswerView{
+			Key:       a.GetAnswer().GetKey(),
+			Value:     a.GetAnswer().GetValue(),
+			DeletedAt: a.GetDeletedAt().AsTime().Format(time.RFC3339),
+			PurgeAt:   a.GetPurgeAt().AsTime().Format(time.RFC3339),
+		}
+		rows[i] = []string{views[i].Key, views[i].Value, views[i].DeletedAt, views[i].PurgeAt}
+	}
+	return p.print(w, views, []string{"KEY", "VALUE", "DELETED AT", "PURGE AT"}, rows)
+}
+
+func (p printer) printReceipts(w io.Writer, receipts []*pkgApi.ErasureReceipt) error {
+	views := make([]receiptView, len(receipts))
+	rows := make([][]string, len(receipts))
+	for i, r := range receipts {
+		views[i] = receiptView{
+			KeyHash:      r.GetKeyHash(),
+			ErasedAt:     r.GetErasedAt().AsTime().Format(time.RFC3339),
+			AnswerErased: r.GetAnswerErased(),
+			EventsErased: r.GetEventsErased(),
+			RequestedBy:  r.GetRequestedBy(),
+		}
+		rows[i] = []string{views[i].KeyHash, views[i].ErasedAt, strconv.FormatBool(views[i].AnswerErased), strconv.Itoa(int(views[i].EventsErased)), views[i].RequestedBy}
+	}
+	return p.print(w, views, []string{"KEY HASH", "ERASED AT", "ANSWER ERASED", "EVENTS ERASED", "REQUESTED BY"}, rows)
+}
+
+func (p printer) print(w io.Writer, views interface{}, header []string, rows [][]string) error {
+	switch p {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(views)
+	case outputYAML:
+		out, err := yaml.Marshal(views)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, row := range append([][]string{header}, rows...) {
+			for i, cell := range row {
+				if i > 0 {
+					fmt.Fprint(tw, "\t")
+				}
+				fmt.Fprint(tw, cell)
+			}
+			fmt.Fprintln(tw)
+		}
+		return tw.Flush()
+	}
+}
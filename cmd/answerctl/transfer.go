@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Import modes by their flag values.
+var importModes = map[string]pkgApi.ImportMode{
+	"replay":   pkgApi.ImportMode_IMPORT_MODE_REPLAY,
+	"preserve": pkgApi.ImportMode_IMPORT_MODE_PRESERVE,
+}
+
+// runExport - writes every answer with its history as one NDJSON record per line.
+// With a checkpoint file an interrupted export appends the remaining records on the next run,
+// the last record may then be written twice, which the import tolerates.
+func runExport(c *cli, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := fs.String("file", "", "NDJSON file the records are written to, - for stdout")
+	checkpointFile := fs.String("checkpoint-file", "", "file keeping the checkpoint of the last written record, it is removed once the export completes")
+	if err := c.parseFlags(fs, args, "file"); err != nil {
+		return err
+	}
+	if len(*checkpointFile) > 0 && *file == "-" {
+		return errors.New("flag -checkpoint-file requires an output file")
+	}
+
+	// Resume after the checkpoint of the previous run.
+	//
+	var checkpoint string
+	fileFlags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if len(*checkpointFile) > 0 {
+		content, err := ioutil.ReadFile(*checkpointFile)
+		switch {
+		case err == nil:
+			checkpoint = strings.TrimSpace(string(content))
+			fileFlags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		case !os.IsNotExist(err):
+			return err
+		}
+	}
+	out := c.stdout
+	if *file != "-" {
+		f, err := os.OpenFile(*file, fileFlags, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	// Write records.
+	//
+	ctx, cancel := c.streamContext()
+	defer cancel()
+	stream, err := c.admin.ExportAnswers(ctx, &pkgApi.ExportAnswersRequest{Checkpoint: checkpoint})
+	if err != nil {
+		return err
+	}
+	var exported int
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		line, err := protojson.Marshal(resp.Record)
+		if err != nil {
+			return err
+		}
+		if _, err := out.Write(append(line, '\n')); err != nil {
+			return err
+		}
+		if len(*checkpointFile) > 0 {
+			if err := ioutil.WriteFile(*checkpointFile, []byte(resp.Checkpoint), 0600); err != nil {
+				return err
+			}
+		}
+		exported++
+	}
+	if len(*checkpointFile) > 0 {
+		if err := os.Remove(*checkpointFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	fmt.Fprintf(c.stderr, "exported %d\n", exported)
+	return nil
+}
+
+// runLoad - imports the records written by export, repeating a load is harmless.
+func runLoad(c *cli, args []string) error {
+	fs := flag.NewFlagSet("load", flag.ContinueOnError)
+	file := fs.String("file", "", "NDJSON file written by export, - for stdin")
+	modeName := fs.String("mode", "", "replay writes through the service and regenerates the events, preserve stores the records as they are")
+	if err := c.parseFlags(fs, args, "file", "mode"); err != nil {
+		return err
+	}
+	mode, ok := importModes[*modeName]
+	if !ok {
+		return fmt.Errorf("unsupported import mode %q", *modeName)
+	}
+	in := c.stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	// Stream records, the server reports the failed ones once the stream is closed.
+	//
+	ctx, cancel := c.streamContext()
+	defer cancel()
+	stream, err := c.admin.ImportAnswers(ctx)
+	if err != nil {
+		return err
+	}
+	reader := bufio.NewReader(in)
+	var sent int
+	for line := 1; ; line++ {
+		text, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+		if len(bytes.TrimSpace(text)) > 0 {
+			record := &pkgApi.AnswerRecord{}
+			if err := protojson.Unmarshal(text, record); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			// The server closed the stream, its status is returned by CloseAndRecv.
+			if err := stream.Send(&pkgApi.ImportAnswersRequest{Mode: mode, Record: record}); err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+			sent++
+		}
+		if readErr == io.EOF {
+			break
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	for _, failure := range resp.Failures {
+		fmt.Fprintf(c.stderr, "%s: %s\n", failure.Key, failure.Message)
+	}
+	fmt.Fprintf(c.stderr, "imported %d, failed %d\n", resp.Imported, len(resp.Failures))
+	if len(resp.Failures) > 0 {
+		return fmt.Errorf("%d of %d records failed", len(resp.Failures), sent)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"github.com/go-test/deep"
+)
+
+// ExportAnswers - streams the answers ordered by key, the checkpoint is the key.
+func (s *fakeServer) ExportAnswers(req *pkgApi.ExportAnswersRequest, stream pkgApi.AdminService_ExportAnswersServer) error {
+	s.mu.Lock()
+	var keys []string
+	for key := range s.answers {
+		if key > req.Checkpoint {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var responses []*pkgApi.ExportAnswersResponse
+	for _, key := range keys {
+		responses = append(responses, &pkgApi.ExportAnswersResponse{
+			Record: &pkgApi.AnswerRecord{
+				Key:    key,
+				Answer: &pkgApi.Answer{Key: key, Value: s.answers[key]},
+				Events: []*pkgApi.AnswerEvent{
+					{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_CREATE, Data: &pkgApi.Answer{Key: key, Value: s.answers[key]}},
+				},
+			},
+			Checkpoint: key,
+		})
+	}
+	s.mu.Unlock()
+	for _, resp := range responses {
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportAnswers - stores the answers of the records, only the preserve mode is supported.
+func (s *fakeServer) ImportAnswers(stream pkgApi.AdminService_ImportAnswersServer) error {
+	resp := &pkgApi.ImportAnswersResponse{}
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(resp)
+		}
+		if err != nil {
+			return err
+		}
+		if req.Mode != pkgApi.ImportMode_IMPORT_MODE_PRESERVE {
+			resp.Failures = append(resp.Failures, &pkgApi.ImportFailure{Key: req.Record.Key, Message: "unsupported mode"})
+			continue
+		}
+		s.mu.Lock()
+		s.answers[req.Record.Key] = req.Record.Answer.GetValue()
+		s.mu.Unlock()
+		resp.Imported++
+	}
+}
+
+func TestExportResumeAndLoad(t *testing.T) {
+	source, sourceAddr := startFakeServer(t)
+	source.answers["city"] = "NY"
+	dir := t.TempDir()
+	file := filepath.Join(dir, "answers.ndjson")
+	checkpointFile := filepath.Join(dir, "answers.checkpoint")
+
+	// Simulate an export interrupted after the first record.
+	//
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"-addr", sourceAddr, "export", "-file", file}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected err: %v, stderr: %v", err, stderr.String())
+	}
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 2 records, got %q", content)
+	}
+	writeTestFile(t, file, lines[0])
+	writeTestFile(t, checkpointFile, "city")
+
+	// The resumed export appends the remaining record and removes the checkpoint.
+	//
+	stderr.Reset()
+	err = run([]string{"-addr", sourceAddr, "export", "-file", file, "-checkpoint-file", checkpointFile}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected err: %v, stderr: %v", err, stderr.String())
+	}
+	if diff := deep.Equal(stderr.String(), "exported 1\n"); diff != nil {
+		t.Error(diff)
+	}
+	resumed, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(string(resumed), string(content)); diff != nil {
+		t.Error(diff)
+	}
+	if _, err := os.Stat(checkpointFile); !os.IsNotExist(err) {
+		t.Errorf("expected the checkpoint file to be removed, got %v", err)
+	}
+
+	// Load the export into another service.
+	//
+	target, targetAddr := startFakeServer(t)
+	delete(target.answers, "name")
+	stderr.Reset()
+	err = run([]string{"-addr", targetAddr, "load", "-file", file, "-mode", "preserve"}, nil, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected err: %v, stderr: %v", err, stderr.String())
+	}
+	if diff := deep.Equal(target.answers, map[string]string{"name": "John", "city": "NY"}); diff != nil {
+		t.Error(diff)
+	}
+	err = run([]string{"-addr", targetAddr, "load", "-file", file, "-mode", "replay"}, nil, &stdout, &stderr)
+	if err == nil || err.Error() != "2 of 2 records failed" {
+		t.Errorf("unexpected err: %v", err)
+	}
+}
+
+func writeTestFile(t *testing.T, fileName, content string) {
+	t.Helper()
+	if err := ioutil.WriteFile(fileName, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
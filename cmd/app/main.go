@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"net"
 	"net/http"
@@ -11,31 +10,30 @@ import (
 	"syscall"
 
 	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	pkgApiV2 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v2"
+	pkgAdmin "dochq.co.uk.answerservice/internal/admin"
 	pkgAnswer "dochq.co.uk.answerservice/internal/answer"
+	pkgChangeCapture "dochq.co.uk.answerservice/internal/changecapture"
+	"dochq.co.uk.answerservice/internal/config"
 	"dochq.co.uk.answerservice/internal/domain"
-	pkgDynamodb "dochq.co.uk.answerservice/internal/dynamodb"
+	pkgErasure "dochq.co.uk.answerservice/internal/erasure"
 	pkgHelpers "dochq.co.uk.answerservice/internal/helpers"
-	"dochq.co.uk.answerservice/internal/sqsqueue"
+	pkgRetention "dochq.co.uk.answerservice/internal/retention"
+	pkgSearch "dochq.co.uk.answerservice/internal/search"
+	pkgTransfer "dochq.co.uk.answerservice/internal/transfer"
+	pkgWatch "dochq.co.uk.answerservice/internal/watch"
+	pkgWebhook "dochq.co.uk.answerservice/internal/webhook"
+	workers "dochq.co.uk.answerservice/internal/worker"
 
-	"github.com/aws/aws-sdk-go/service/sqs"
-	kitzapadapter "github.com/go-kit/kit/log/zap"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
-	"github.com/go-kit/log"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/oklog/run"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 )
 
 func main() {
 
-	var (
-		answerTableName      = os.Getenv(domain.EnvAnswerTableName)
-		answerEventTableName = os.Getenv(domain.EnvAnswerEventTableName)
-		answerEventQueueName = os.Getenv(domain.EnvAnswerEventQueueName)
-	)
-
 	// Create a single logger, which we'll use and give to other components.
 	//
 	zapLogger, _ := zap.NewProduction()
@@ -43,80 +41,152 @@ func main() {
 		_ = zapLogger.Sync()
 	}()
 
-	var logger log.Logger
-	logger = kitzapadapter.NewZapSugarLogger(zapLogger, zapcore.InfoLevel)
-	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
-	logger = log.With(logger, "caller", log.DefaultCaller)
+	logger := pkgHelpers.NewLogger(zapLogger, nil)
 	// Logging helper function
 	logFatal := func(args ...interface{}) {
 		_ = logger.Log(args...)
 		os.Exit(1)
 	}
 
-	// Define our flags.
+	// Load configuration from the flags, the environment and the optional file.
 	//
-	fs := flag.NewFlagSet("", flag.ExitOnError)
-	grpcAddr := fs.String("grpc-addr", ":6565", "gRPC listen address")
-	httpAddr := fs.String("http-addr", ":8000", "HTTP listen address")
-	err := fs.Parse(os.Args[1:])
+	cfg, err := config.Load(config.AppCommand, os.Args[1:])
 	if err != nil {
-		logFatal(err)
+		logFatal("during", "Config", "err", err)
+	}
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logFatal("during", "Config", "err", err)
+		}
+		return
 	}
 
-	// Setup AWS session.
+	// Redact the answers in the logs from now on.
 	//
-	awsSession := pkgHelpers.GetAwsSession()
-	sqsClient := sqs.New(awsSession)
+	redactor, err := pkgHelpers.NewRedactor(cfg)
+	if err != nil {
+		logFatal("during", "Config", "err", err)
+	}
+	logger = pkgHelpers.NewLogger(zapLogger, redactor)
+	keyHasher := pkgHelpers.NewAnswerKeyHasher(cfg)
+	answerEventDestination := cfg.Queue.AnswerEventDestination()
+	deletedAnswerRetention := cfg.Storage.DeletedAnswerRetentionPeriod()
+
+	// Setup queue backend.
+	//
+	queue, err := pkgHelpers.NewQueue(cfg, logger)
+	if err != nil {
+		logFatal("during", "Setup", "err", err)
+	}
+	defer queue.Close()
 
 	// Repository layer.
 	//
-	answerRepository := pkgDynamodb.NewAnswerRepository(awsSession, answerTableName)
-	answerEventRepository := pkgDynamodb.NewAnswerEventRepository(awsSession, answerEventTableName)
+	storage, err := pkgHelpers.NewStorage(cfg, logger)
+	if err != nil {
+		logFatal("during", "Setup", "err", err)
+	}
+	defer storage.Close()
+	answerRepository := storage.AnswerRepository
+	answerEventRepository := storage.AnswerEventRepository
+
+	// Service layer, the answer events are sent by the answer service unless the worker captures them.
+	//
+	var answerEventQueueService domain.QueueService = queue.Service
+	if cfg.Queue.AnswerEventSource == config.StreamEventSource {
+		answerEventQueueService = nil
+	}
+	answerService := pkgAnswer.NewService(answerRepository, answerEventRepository, answerEventQueueService, answerEventDestination, deletedAnswerRetention, logger)
+	transferService := pkgTransfer.NewService(answerService, answerRepository, answerEventRepository, deletedAnswerRetention, logger)
+	retentionService := pkgRetention.NewService(answerRepository, logger)
+	erasureService := pkgErasure.NewService(answerRepository, answerEventRepository, storage.ErasureRepository, storage.IdempotencyRepository,
+		queue.Service, answerEventDestination, keyHasher, logger)
+	watchHub := pkgWatch.NewHub()
+	watchService := pkgWatch.NewService(answerEventRepository, watchHub, logger)
+	webhookAddressPolicy, err := pkgWebhook.NewAddressPolicy(cfg.Webhook.AllowedNetworks)
+	if err != nil {
+		logFatal("during", "Setup", "err", err)
+	}
+	webhookService := pkgWebhook.NewService(storage.WebhookRepository, webhookAddressPolicy, logger)
 
-	// Service layer.
+	// The search index is embedded in the replica which consumes the search queue, it is built from the stored answers
+	// and then kept up to date by the events of the queue. The other replicas do not serve the search.
 	//
-	queueService := sqsqueue.NewQueueService(sqsClient, logger)
-	answerService := pkgAnswer.NewService(answerRepository, answerEventRepository, queueService, answerEventQueueName, logger)
+	var searchIndex domain.AnswerSearchIndex
+	if cfg.Queue.ConsumeSearchQueue {
+		searchIndex = pkgSearch.NewIndex()
+		indexed, err := pkgSearch.BuildIndex(answerRepository, answerEventRepository, searchIndex)
+		if err != nil {
+			logFatal("during", "Setup", "err", err)
+		}
+		_ = logger.Log("index", "search", "answers", indexed)
+	}
+	searchService := pkgSearch.NewService(searchIndex, logger)
 
 	// Endpoints layer.
 	//
-	answerEndpoints := pkgAnswer.NewEndpoint(answerService, logger)
+	authentication := pkgHelpers.NewAuthentication(cfg)
+	idempotency := pkgHelpers.NewIdempotency(storage.IdempotencyRepository, cfg.Storage.IdempotencyKeyRetentionPeriod(),
+		cfg.Storage.IdempotencyKeyLeasePeriod(), keyHasher, authentication, logger)
+	rateLimit := pkgHelpers.NewRateLimit(cfg, storage, logger)
+	answerEndpoints := pkgAnswer.NewEndpoint(answerService, searchService, authentication, idempotency, rateLimit, logger)
+	webhookEndpoints := pkgWebhook.NewEndpoint(webhookService, authentication, rateLimit, logger)
 
 	// GRPC Server layer.
 	//
-	answerGrpcServer := pkgAnswer.NewGRPCServer(answerEndpoints, logger)
+	answerGrpcServer := pkgAnswer.NewGRPCServer(answerEndpoints, watchService, logger)
+	answerGrpcServerV2 := pkgAnswer.NewGRPCServerV2(answerEndpoints, logger)
+	adminEndpoints := pkgAdmin.NewEndpoint(transferService, retentionService, erasureService, authentication, rateLimit, logger)
+	adminGrpcServer := pkgAdmin.NewGRPCServer(adminEndpoints, logger)
+	webhookGrpcServer := pkgWebhook.NewGRPCServer(webhookEndpoints, logger)
 
 	// Setup base grpc-server.
 	//
 	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(kitgrpc.Interceptor))
 	pkgApi.RegisterAnswerServiceServer(grpcServer, answerGrpcServer)
+	pkgApiV2.RegisterAnswerServiceServer(grpcServer, answerGrpcServerV2)
+	pkgApi.RegisterAdminServiceServer(grpcServer, adminGrpcServer)
+	pkgApi.RegisterWebhookServiceServer(grpcServer, webhookGrpcServer)
 
 	// gRPC Gateway setup.
 	//
 	ctx := context.Background()
-	rmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{}))
+	rmux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{}),
+		runtime.WithIncomingHeaderMatcher(pkgHelpers.IncomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(pkgHelpers.OutgoingHeaderMatcher),
+	)
 	mux := http.NewServeMux()
 
 	// Serve the swagger specs.
 	//
 	mux.Handle("/", rmux)
-	mux.HandleFunc("/swagger", pkgHelpers.ServeSwagger)
+	mux.HandleFunc("/swagger", pkgHelpers.ServeSwagger(cfg.SwaggerPath))
+	mux.Handle(pkgAnswer.WatchAnswersPath, pkgAnswer.NewWatchHandler(watchService))
 	{
 		err := pkgApi.RegisterAnswerServiceHandlerServer(ctx, rmux, answerGrpcServer)
 		if err != nil {
 			logFatal("during", "Setup", "err", err)
 		}
+		err = pkgApi.RegisterWebhookServiceHandlerServer(ctx, rmux, webhookGrpcServer)
+		if err != nil {
+			logFatal("during", "Setup", "err", err)
+		}
+		err = pkgApiV2.RegisterAnswerServiceHandlerServer(ctx, rmux, answerGrpcServerV2)
+		if err != nil {
+			logFatal("during", "Setup", "err", err)
+		}
 	}
 	var g run.Group
 	// Startup the gRPC listener
 	{
-		grpcListener, err := net.Listen("tcp", *grpcAddr)
+		grpcListener, err := net.Listen("tcp", cfg.GRPCAddr)
 		if err != nil {
 			logFatal("transport", "gRPC", "during", "Listen", "err", err)
 		}
 
 		g.Add(func() error {
-			_ = logger.Log("transport", "gRPC", "addr", *grpcAddr)
+			_ = logger.Log("transport", "gRPC", "addr", cfg.GRPCAddr)
 			return grpcServer.Serve(grpcListener)
 		}, func(error) {
 			grpcListener.Close()
@@ -124,18 +194,116 @@ func main() {
 	}
 	// Startup the HTTP listener
 	{
-		httpListener, err := net.Listen("tcp", *httpAddr)
+		httpListener, err := net.Listen("tcp", cfg.HTTPAddr)
 		if err != nil {
 			logFatal("transport", "HTTP", "during", "Listen", "err", err)
 		}
 
 		g.Add(func() error {
-			_ = logger.Log("transport", "HTTP", "addr", *httpAddr)
+			_ = logger.Log("transport", "HTTP", "addr", cfg.HTTPAddr)
 			return http.Serve(httpListener, mux)
 		}, func(err error) {
 			httpListener.Close()
 		})
 	}
+	// Update the search index, a single replica of the application consumes the search queue.
+	if searchIndex != nil {
+		searchCtx, cancelSearch := context.WithCancel(ctx)
+		sw := workers.NewSearchIndexWorker(
+			&workers.Props{
+				WorkerName: "search-index-worker",
+				QueueName:  cfg.Queue.SearchQueueName,
+			},
+			queue.Consumer,
+			searchIndex,
+			storage.ErasureRepository,
+			keyHasher,
+			logger,
+		)
+		g.Add(func() error {
+			_ = logger.Log("worker", sw.Props.WorkerName, "queue", queue.Backend)
+			return sw.Run(searchCtx)
+		}, func(error) {
+			cancelSearch()
+		})
+	}
+	// Pass the answer events to the watches, every process of the application consumes its own watch queue.
+	if len(cfg.Queue.WatchQueueName) > 0 {
+		watchCtx, cancelWatch := context.WithCancel(ctx)
+		ww := workers.NewWatchWorker(
+			&workers.Props{
+				WorkerName: "watch-worker",
+				QueueName:  cfg.Queue.WatchQueueName,
+			},
+			queue.Consumer,
+			watchHub,
+			storage.ErasureRepository,
+			keyHasher,
+			logger,
+		)
+		g.Add(func() error {
+			_ = logger.Log("worker", ww.Props.WorkerName, "queue", queue.Backend)
+			return ww.Run(watchCtx)
+		}, func(error) {
+			cancelWatch()
+		})
+	}
+	// Run the workers in-process if the queue backend cannot be shared between processes.
+	if queue.Backend.IsInProcess() {
+		workerCtx, cancelWorkers := context.WithCancel(ctx)
+		w := workers.NewAnswerWorker(
+			&workers.Props{
+				WorkerName: "answer-event-worker",
+				QueueName:  cfg.Queue.AnswerEventQueueName,
+			},
+			queue.Consumer,
+			answerEventRepository,
+			storage.ErasureRepository,
+			keyHasher,
+			logger,
+		)
+		g.Add(func() error {
+			_ = logger.Log("worker", w.Props.WorkerName, "queue", queue.Backend)
+			return w.Run(workerCtx)
+		}, func(error) {
+			cancelWorkers()
+		})
+		if len(cfg.Queue.WebhookQueueName) > 0 {
+			dispatcher := pkgWebhook.NewDispatcher(storage.WebhookRepository, pkgWebhook.DispatcherOptions{
+				MaxAttempts:    int(cfg.Webhook.MaxAttempts),
+				InitialBackoff: cfg.Webhook.InitialBackoffPeriod(),
+				Timeout:        cfg.Webhook.TimeoutPeriod(),
+				AddressPolicy:  webhookAddressPolicy,
+				KeyHasher:      keyHasher,
+			}, logger)
+			nw := workers.NewNotifierWorker(
+				&workers.Props{
+					WorkerName: "webhook-worker",
+					QueueName:  cfg.Queue.WebhookQueueName,
+				},
+				queue.Consumer,
+				dispatcher,
+				storage.ErasureRepository,
+				keyHasher,
+				logger,
+			)
+			g.Add(func() error {
+				_ = logger.Log("worker", nw.Props.WorkerName, "queue", queue.Backend)
+				return nw.Run(workerCtx)
+			}, func(error) {
+				cancelWorkers()
+			})
+		}
+		if storage.AnswerChangeStream != nil {
+			relay := pkgChangeCapture.NewRelay(storage.AnswerChangeStream, queue.Service, answerEventDestination, logger)
+			g.Add(func() error {
+				_ = logger.Log("relay", answerEventDestination, "source", cfg.Queue.AnswerEventSource)
+				return relay.Run(workerCtx)
+			}, func(error) {
+				cancelWorkers()
+			})
+		}
+	}
 	// This function just sits and waits for ctrl-C.
 	{
 		cancelInterrupt := make(chan struct{})
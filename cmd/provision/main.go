@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+
+	"dochq.co.uk.answerservice/internal/config"
+	pkgHelpers "dochq.co.uk.answerservice/internal/helpers"
+
+	kitzapadapter "github.com/go-kit/kit/log/zap"
+	"github.com/go-kit/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func main() {
+
+	// Create a single logger, which we'll use and give to other components.
+	//
+	zapLogger, _ := zap.NewProduction()
+	defer func() {
+		_ = zapLogger.Sync()
+	}()
+
+	var logger log.Logger
+	logger = kitzapadapter.NewZapSugarLogger(zapLogger, zapcore.InfoLevel)
+	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
+	logger = log.With(logger, "caller", log.DefaultCaller)
+	// Logging helper function
+	logFatal := func(args ...interface{}) {
+		_ = logger.Log(args...)
+		os.Exit(1)
+	}
+
+	// Load configuration from the flags, the environment and the optional file.
+	//
+	cfg, err := config.Load(config.ProvisionCommand, os.Args[1:])
+	if err != nil {
+		logFatal("during", "Config", "err", err)
+	}
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logFatal("during", "Config", "err", err)
+		}
+		return
+	}
+
+	// Create or update the tables and the queues.
+	//
+	if err := pkgHelpers.Provision(cfg, logger); err != nil {
+		logFatal("during", "Provision", "err", err)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"dochq.co.uk.answerservice/internal/config"
+	pkgHelpers "dochq.co.uk.answerservice/internal/helpers"
+	"dochq.co.uk.answerservice/internal/retention"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+
+	// Create a single logger, which we'll use and give to other components.
+	//
+	zapLogger, _ := zap.NewProduction()
+	defer func() {
+		_ = zapLogger.Sync()
+	}()
+
+	logger := pkgHelpers.NewLogger(zapLogger, nil)
+	// Logging helper function
+	logFatal := func(args ...interface{}) {
+		_ = logger.Log(args...)
+		os.Exit(1)
+	}
+
+	// Load configuration from the flags, the environment and the optional file.
+	//
+	cfg, err := config.Load(config.PurgeCommand, os.Args[1:])
+	if err != nil {
+		logFatal("during", "Config", "err", err)
+	}
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logFatal("during", "Config", "err", err)
+		}
+		return
+	}
+
+	// Redact the answers in the logs from now on.
+	//
+	redactor, err := pkgHelpers.NewRedactor(cfg)
+	if err != nil {
+		logFatal("during", "Config", "err", err)
+	}
+	logger = pkgHelpers.NewLogger(zapLogger, redactor)
+
+	// Repository layer.
+	//
+	storage, err := pkgHelpers.NewStorage(cfg, logger)
+	if err != nil {
+		logFatal("during", "Setup", "err", err)
+	}
+	defer storage.Close()
+
+	// Purge the deleted answers whose retention period is over.
+	//
+	retentionService := retention.NewService(storage.AnswerRepository, logger)
+	if _, err := retentionService.PurgeDeletedAnswers(context.Background()); err != nil {
+		logFatal("during", "Purge", "err", err)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"dochq.co.uk.answerservice/internal/config"
+	pkgHelpers "dochq.co.uk.answerservice/internal/helpers"
+	"dochq.co.uk.answerservice/internal/reconcile"
+
+	"go.uber.org/zap"
+)
+
+func main() {
+
+	// Create a single logger, which we'll use and give to other components.
+	//
+	zapLogger, _ := zap.NewProduction()
+	defer func() {
+		_ = zapLogger.Sync()
+	}()
+
+	logger := pkgHelpers.NewLogger(zapLogger, nil)
+	// Logging helper function
+	logFatal := func(args ...interface{}) {
+		_ = logger.Log(args...)
+		os.Exit(1)
+	}
+
+	// Load configuration from the flags, the environment and the optional file.
+	//
+	cfg, err := config.Load(config.ReconcileCommand, os.Args[1:])
+	if err != nil {
+		logFatal("during", "Config", "err", err)
+	}
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logFatal("during", "Config", "err", err)
+		}
+		return
+	}
+
+	// Redact the answers in the logs from now on.
+	//
+	redactor, err := pkgHelpers.NewRedactor(cfg)
+	if err != nil {
+		logFatal("during", "Config", "err", err)
+	}
+	logger = pkgHelpers.NewLogger(zapLogger, redactor)
+
+	// Repository layer.
+	//
+	storage, err := pkgHelpers.NewStorage(cfg, logger)
+	if err != nil {
+		logFatal("during", "Setup", "err", err)
+	}
+	defer storage.Close()
+
+	// Print every divergence as a JSON line.
+	//
+	encoder := json.NewEncoder(os.Stdout)
+	reconciler := reconcile.NewReconciler(storage.AnswerRepository, storage.AnswerEventRepository)
+	summary, err := reconciler.Reconcile(context.Background(), func(divergence *reconcile.Divergence) error {
+		return encoder.Encode(divergence)
+	})
+	if err != nil {
+		logFatal("during", "Reconcile", "err", err)
+	}
+	_ = logger.Log("checked", summary.Checked, "divergences", summary.Divergences)
+	if summary.Divergences > 0 {
+		_ = zapLogger.Sync()
+		os.Exit(1)
+	}
+}
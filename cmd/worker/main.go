@@ -2,30 +2,20 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"os"
 
-	"dochq.co.uk.answerservice/internal/domain"
-	pkgDynamodb "dochq.co.uk.answerservice/internal/dynamodb"
-	errors "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/changecapture"
+	"dochq.co.uk.answerservice/internal/config"
 	pkgHelpers "dochq.co.uk.answerservice/internal/helpers"
+	"dochq.co.uk.answerservice/internal/webhook"
 	workers "dochq.co.uk.answerservice/internal/worker"
 
-	"github.com/aws/aws-sdk-go/service/sqs"
-	kitzapadapter "github.com/go-kit/kit/log/zap"
-	"github.com/go-kit/log"
+	"github.com/oklog/run"
 	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
 )
 
 func main() {
 
-	var (
-		answerEventTableName = os.Getenv(domain.EnvAnswerEventTableName)
-		answerEventQueueName = os.Getenv(domain.EnvAnswerEventQueueName)
-	)
-
 	// Create a single logger, which we'll use and give to other components.
 	//
 	zapLogger, _ := zap.NewProduction()
@@ -33,55 +23,121 @@ func main() {
 		_ = zapLogger.Sync()
 	}()
 
-	var logger log.Logger
-	logger = kitzapadapter.NewZapSugarLogger(zapLogger, zapcore.InfoLevel)
-	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
-	logger = log.With(logger, "caller", log.DefaultCaller)
+	logger := pkgHelpers.NewLogger(zapLogger, nil)
+	// Logging helper function
+	logFatal := func(args ...interface{}) {
+		_ = logger.Log(args...)
+		os.Exit(1)
+	}
 
-	// Setup AWS services.
+	// Load configuration from the flags, the environment and the optional file.
+	// In-process backends are rejected, they never share data with the main application.
 	//
-	awsSession := pkgHelpers.GetAwsSession()
-	sqsClient := sqs.New(awsSession)
+	cfg, err := config.Load(config.WorkerCommand, os.Args[1:])
+	if err != nil {
+		logFatal("during", "Config", "err", err)
+	}
+	if cfg.PrintConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			logFatal("during", "Config", "err", err)
+		}
+		return
+	}
+
+	// Redact the answers in the logs from now on.
+	//
+	redactor, err := pkgHelpers.NewRedactor(cfg)
+	if err != nil {
+		logFatal("during", "Config", "err", err)
+	}
+	logger = pkgHelpers.NewLogger(zapLogger, redactor)
+	keyHasher := pkgHelpers.NewAnswerKeyHasher(cfg)
+
+	// Setup queue backend.
+	//
+	queue, err := pkgHelpers.NewQueue(cfg, logger)
+	if err != nil {
+		logFatal("during", "Setup", "err", err)
+	}
+	defer queue.Close()
 
 	// Setup repository.
 	//
-	eventRepository := pkgDynamodb.NewAnswerEventRepository(awsSession, answerEventTableName)
+	storage, err := pkgHelpers.NewStorage(cfg, logger)
+	if err != nil {
+		logFatal("during", "Setup", "err", err)
+	}
+	defer storage.Close()
+	eventRepository := storage.AnswerEventRepository
 
-	// Define worker properties.
+	// Run the answer event worker.
 	//
-	workerProps := &workers.Props{
-		WorkerName:          "answer-event-worker",
-		QueueName:           answerEventQueueName,
-		MaxNumberOfMessages: 10,
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var g run.Group
+	{
+		w := workers.NewAnswerWorker(
+			&workers.Props{
+				WorkerName: "answer-event-worker",
+				QueueName:  cfg.Queue.AnswerEventQueueName,
+			},
+			queue.Consumer,
+			eventRepository,
+			storage.ErasureRepository,
+			keyHasher,
+			logger,
+		)
+		g.Add(func() error {
+			return w.Run(ctx)
+		}, func(error) {
+			cancel()
+		})
 	}
 
-	w := workers.NewAnswerWorker(
-		workerProps,
-		sqsClient,
-		eventRepository,
-		logger,
-	)
-	w.Start(context.Background(), domain.QueueHandlerFunc(func(ctx context.Context, msg *sqs.Message) error {
-		// Define message type.
-		//
-		messageTypeValue, ok := msg.MessageAttributes[domain.MessageTypeAttributeKey]
-		if !ok {
-			return errors.NewErrNotFound("Message type not found in attributes")
+	// Deliver the events of the webhook queue to the webhook subscriptions, a failed attempt is retried
+	// when the queue delivers the event again. The webhooks are not delivered without the queue.
+	//
+	if len(cfg.Queue.WebhookQueueName) == 0 {
+		_ = logger.Log("msg", "Webhooks are not delivered without a webhook queue")
+	} else {
+		addressPolicy, err := webhook.NewAddressPolicy(cfg.Webhook.AllowedNetworks)
+		if err != nil {
+			logFatal("during", "Setup", "err", err)
 		}
-		messageType := domain.MessageType(*messageTypeValue.StringValue)
+		dispatcher := webhook.NewDispatcher(storage.WebhookRepository, webhook.DispatcherOptions{
+			MaxAttempts:    int(cfg.Webhook.MaxAttempts),
+			InitialBackoff: cfg.Webhook.InitialBackoffPeriod(),
+			Timeout:        cfg.Webhook.TimeoutPeriod(),
+			AddressPolicy:  addressPolicy,
+			KeyHasher:      keyHasher,
+		}, logger)
+		w := workers.NewNotifierWorker(
+			&workers.Props{
+				WorkerName: "webhook-worker",
+				QueueName:  cfg.Queue.WebhookQueueName,
+			},
+			queue.Consumer,
+			dispatcher,
+			storage.ErasureRepository,
+			keyHasher,
+			logger,
+		)
+		g.Add(func() error {
+			return w.Run(ctx)
+		}, func(error) {
+			cancel()
+		})
+	}
 
-		switch messageType {
-		case domain.AnswerEventMessageType:
-			// Unmarshal payload.
-			//
-			payload := &domain.AnswerEventMessage{}
-			err := json.Unmarshal([]byte(*msg.Body), payload)
-			if err != nil {
-				return err
-			}
-			return w.HandleAnswerEventMessage(ctx, payload)
-		default:
-			return errors.NewErrNotFound(fmt.Sprintf("Unsupported message type %v", messageType))
-		}
-	}))
+	// Relay the events captured from the change stream of the answer table, the application sends none then.
+	//
+	if storage.AnswerChangeStream != nil {
+		relay := changecapture.NewRelay(storage.AnswerChangeStream, queue.Service, cfg.Queue.AnswerEventDestination(), logger)
+		g.Add(func() error {
+			return relay.Run(ctx)
+		}, func(error) {
+			cancel()
+		})
+	}
+	_ = logger.Log("exit", g.Run())
 }
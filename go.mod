@@ -8,11 +8,16 @@ require (
 	github.com/go-kit/kit v0.12.0
 	github.com/go-kit/log v0.2.0
 	github.com/go-test/deep v1.0.8
+	github.com/golang-jwt/jwt/v4 v4.0.0
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.10.0
+	github.com/lib/pq v1.10.4
+	github.com/nats-io/nats.go v1.12.1
+	github.com/nats-io/nuid v1.0.1
 	github.com/oklog/run v1.1.0
 	github.com/testcontainers/testcontainers-go v0.13.0
 	go.uber.org/zap v1.19.1
 	google.golang.org/genproto v0.0.0-20220317150908-0efb43f6373e
 	google.golang.org/grpc v1.45.0
 	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,451 @@
+package integrationtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"github.com/go-test/deep"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// historyEntry - the comparable part of an answer event.
+type historyEntry struct {
+	EventType string
+	Key       string
+	Value     string
+}
+
+func TestAnswerLifecycleOverGRPC(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("grpc-name")
+
+	// Create, update and delete the answer.
+	//
+	_, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	got, err := testGrpcClient.GetAnswer(ctx, &pkgApi.GetAnswerRequest{Key: key})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if diff := deep.Equal(got.Answer.GetValue(), "John"); diff != nil {
+		t.Error(diff)
+	}
+	_, err = testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Sam"}})
+	expectCode(t, err, codes.AlreadyExists)
+	_, err = testGrpcClient.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Sam"}})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	_, err = testGrpcClient.DeleteAnswer(ctx, &pkgApi.DeleteAnswerRequest{Key: key})
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	_, err = testGrpcClient.GetAnswer(ctx, &pkgApi.GetAnswerRequest{Key: key})
+	expectCode(t, err, codes.NotFound)
+
+	// History eventually reflects each write.
+	//
+	want := []historyEntry{
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_CREATE.String(), Key: key, Value: "John"},
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_UPDATE.String(), Key: key, Value: "Sam"},
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_DELETE.String(), Key: key, Value: "Sam"},
+	}
+	eventually(t, func() error {
+		resp, err := testGrpcClient.GetAnswerHistory(ctx, &pkgApi.GetAnswerHistoryRequest{Key: key})
+		if err != nil {
+			return err
+		}
+		var history []historyEntry
+		for _, e := range resp.AnswerEvents {
+			history = append(history, historyEntry{
+				EventType: e.GetEventType().String(),
+				Key:       e.GetData().GetKey(),
+				Value:     e.GetData().GetValue(),
+			})
+		}
+		if diff := deep.Equal(history, want); diff != nil {
+			return fmt.Errorf("history: %v", diff)
+		}
+		return nil
+	})
+}
+
+func TestAnswerLifecycleOverHTTP(t *testing.T) {
+	key := uniqueKey("http-name")
+
+	// Create, update and delete the answer.
+	//
+	doHTTP(t, http.MethodPost, "/v1/answers", map[string]string{"key": key, "value": "John"}, http.StatusOK, nil)
+	doHTTP(t, http.MethodPost, "/v1/answers", map[string]string{"key": key, "value": "Sam"}, http.StatusConflict, nil)
+	var got struct {
+		Answer struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"answer"`
+	}
+	doHTTP(t, http.MethodGet, "/v1/answers?key="+url.QueryEscape(key), nil, http.StatusOK, &got)
+	if diff := deep.Equal(got.Answer.Value, "John"); diff != nil {
+		t.Error(diff)
+	}
+	doHTTP(t, http.MethodPut, "/v1/answers", map[string]string{"key": key, "value": "Sam"}, http.StatusOK, nil)
+	doHTTP(t, http.MethodDelete, "/v1/answers?key="+url.QueryEscape(key), nil, http.StatusOK, nil)
+	doHTTP(t, http.MethodGet, "/v1/answers?key="+url.QueryEscape(key), nil, http.StatusNotFound, nil)
+	doHTTP(t, http.MethodPut, "/v1/answers", map[string]string{"key": key, "value": "Tom"}, http.StatusNotFound, nil)
+
+	// History eventually reflects each write.
+	//
+	want := []historyEntry{
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_CREATE.String(), Key: key, Value: "John"},
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_UPDATE.String(), Key: key, Value: "Sam"},
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_DELETE.String(), Key: key, Value: "Sam"},
+	}
+	eventually(t, func() error {
+		var resp struct {
+			AnswerEvents []struct {
+				EventType string `json:"eventType"`
+				Data      struct {
+					Key   string `json:"key"`
+					Value string `json:"value"`
+				} `json:"data"`
+			} `json:"answerEvents"`
+		}
+		doHTTP(t, http.MethodGet, "/v1/answers/"+url.PathEscape(key)+"/history", nil, http.StatusOK, &resp)
+		var history []historyEntry
+		for _, e := range resp.AnswerEvents {
+			history = append(history, historyEntry{EventType: e.EventType, Key: e.Data.Key, Value: e.Data.Value})
+		}
+		if diff := deep.Equal(history, want); diff != nil {
+			return fmt.Errorf("history: %v", diff)
+		}
+		return nil
+	})
+}
+
+func TestListAnswers(t *testing.T) {
+	ctx := context.Background()
+	prefix := uniqueKey("list")
+	var want []string
+	for _, suffix := range []string{"a", "b", "c"} {
+		key := prefix + "-" + suffix
+		want = append(want, key)
+		_, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: suffix}})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	// Page through all answers with the smallest page size over gRPC.
+	//
+	var got []string
+	req := &pkgApi.ListAnswersRequest{PageSize: 1}
+	for {
+		resp, err := testGrpcClient.ListAnswers(ctx, req)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		for _, a := range resp.Answers {
+			if strings.HasPrefix(a.Key, prefix) {
+				got = append(got, a.Key)
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+
+	// The gateway serves the same listing.
+	//
+	var resp struct {
+		Answers []struct {
+			Key string `json:"key"`
+		} `json:"answers"`
+	}
+	doHTTP(t, http.MethodGet, "/v1/answers/list?pageSize=1000", nil, http.StatusOK, &resp)
+	got = nil
+	for _, a := range resp.Answers {
+		if strings.HasPrefix(a.Key, prefix) {
+			got = append(got, a.Key)
+		}
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+	doHTTP(t, http.MethodGet, "/v1/answers/list?pageSize=1001", nil, http.StatusBadRequest, nil)
+}
+
+func TestInvalidArgument(t *testing.T) {
+	_, err := testGrpcClient.CreateAnswer(context.Background(), &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: "invalid"}})
+	expectCode(t, err, codes.InvalidArgument)
+	doHTTP(t, http.MethodPost, "/v1/answers", map[string]string{"value": "John"}, http.StatusBadRequest, nil)
+}
+
+// doHTTP - sends the request to the gateway, checks the status code and decodes the response.
+func doHTTP(t *testing.T, method, path string, body interface{}, wantStatus int, out interface{}) {
+	t.Helper()
+	doHTTPWithHeader(t, method, path, nil, body, wantStatus, out)
+}
+
+// doHTTPWithHeader - sends the request with the additional headers, see doHTTP.
+func doHTTPWithHeader(t *testing.T, method, path string, header http.Header, body interface{}, wantStatus int, out interface{}) {
+	t.Helper()
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, testHTTPURL+path, &reqBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != wantStatus {
+		t.Fatalf("%v %v: expected status %v, got %v", method, path, wantStatus, resp.StatusCode)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func expectCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+	if got := status.Code(err); got != want {
+		t.Errorf("expected code %v, got %v (%v)", want, got, err)
+	}
+}
+
+func TestGetAnswerAt(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("at")
+	_, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	_, err = testGrpcClient.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Sam"}})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	// Wait for the history, then rebuild the first version over gRPC.
+	//
+	var first *pkgApi.GetAnswerAtResponse
+	eventually(t, func() error {
+		resp, err := testGrpcClient.GetAnswerAt(ctx, &pkgApi.GetAnswerAtRequest{
+			Key:   key,
+			Point: &pkgApi.GetAnswerAtRequest_Version{Version: 2},
+		})
+		if err != nil {
+			return err
+		}
+		first, err = testGrpcClient.GetAnswerAt(ctx, &pkgApi.GetAnswerAtRequest{
+			Key:   key,
+			Point: &pkgApi.GetAnswerAtRequest_Version{Version: 1},
+		})
+		if resp.Answer.GetValue() != "Sam" {
+			return fmt.Errorf("unexpected answer %v", resp.Answer)
+		}
+		return err
+	})
+	if diff := deep.Equal(first.Answer.GetValue(), "John"); diff != nil {
+		t.Error(diff)
+	}
+
+	// The gateway accepts the time of the first event.
+	//
+	var resp struct {
+		Answer struct {
+			Value string `json:"value"`
+		} `json:"answer"`
+		Version int `json:"version"`
+	}
+	at := url.QueryEscape(first.OccurredAt.AsTime().Format(time.RFC3339Nano))
+	doHTTP(t, http.MethodGet, "/v1/answers/"+key+"/at?time="+at, nil, http.StatusOK, &resp)
+	if diff := deep.Equal([]interface{}{resp.Answer.Value, resp.Version}, []interface{}{"John", 1}); diff != nil {
+		t.Error(diff)
+	}
+	doHTTP(t, http.MethodGet, "/v1/answers/"+key+"/at?version=3", nil, http.StatusNotFound, nil)
+	doHTTP(t, http.MethodGet, "/v1/answers/"+key+"/at", nil, http.StatusBadRequest, nil)
+}
+
+func TestRestoreAnswer(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("restore")
+	_, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	_, err = testGrpcClient.DeleteAnswer(ctx, &pkgApi.DeleteAnswerRequest{Key: key})
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	// Undelete over HTTP once the history is recorded.
+	//
+	eventually(t, func() error {
+		resp, err := testGrpcClient.GetAnswerHistory(ctx, &pkgApi.GetAnswerHistoryRequest{Key: key})
+		if err != nil {
+			return err
+		}
+		if len(resp.AnswerEvents) != 2 {
+			return fmt.Errorf("unexpected history %v", resp.AnswerEvents)
+		}
+		return nil
+	})
+	var resp struct {
+		Answer struct {
+			Value string `json:"value"`
+		} `json:"answer"`
+		Version int `json:"version"`
+	}
+	doHTTP(t, http.MethodPost, "/v1/answers/"+key+"/restore", map[string]int{}, http.StatusOK, &resp)
+	if diff := deep.Equal([]interface{}{resp.Answer.Value, resp.Version}, []interface{}{"John", 3}); diff != nil {
+		t.Error(diff)
+	}
+	doHTTP(t, http.MethodPost, "/v1/answers/"+key+"/restore", map[string]int{"version": 9}, http.StatusNotFound, nil)
+
+	// The audit trail shows the restoration.
+	//
+	eventually(t, func() error {
+		resp, err := testGrpcClient.GetAnswerHistory(ctx, &pkgApi.GetAnswerHistoryRequest{Key: key})
+		if err != nil {
+			return err
+		}
+		n := len(resp.AnswerEvents)
+		if n != 3 || resp.AnswerEvents[n-1].EventType != pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_RESTORE {
+			return fmt.Errorf("unexpected history %v", resp.AnswerEvents)
+		}
+		return nil
+	})
+	got, err := testGrpcClient.GetAnswer(ctx, &pkgApi.GetAnswerRequest{Key: key})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if diff := deep.Equal(got.Answer.GetValue(), "John"); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestMutationResponses(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("mutation")
+
+	// Each mutation returns the answer with the time, the event ID and the version of its change.
+	//
+	created, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	updated, err := testGrpcClient.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Sam"}})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	deleted, err := testGrpcClient.DeleteAnswer(ctx, &pkgApi.DeleteAnswerRequest{Key: key})
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	restored, err := testGrpcClient.RestoreAnswer(ctx, &pkgApi.RestoreAnswerRequest{Key: key})
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	answers := []string{created.Answer.GetValue(), updated.Answer.GetValue(), deleted.Answer.GetValue(), restored.Answer.GetValue()}
+	if diff := deep.Equal(answers, []string{"John", "Sam", "Sam", "Sam"}); diff != nil {
+		t.Error(diff)
+	}
+	versions := []int32{created.Version, updated.Version, deleted.Version, restored.Version}
+	if diff := deep.Equal(versions, []int32{1, 2, 3, 4}); diff != nil {
+		t.Errorf("versions: %v", diff)
+	}
+	eventIDs := map[string]bool{created.EventId: true, updated.EventId: true, deleted.EventId: true, restored.EventId: true}
+	if len(eventIDs) != 4 || eventIDs[""] {
+		t.Errorf("expected distinct event IDs, got %v, %v, %v and %v", created.EventId, updated.EventId, deleted.EventId, restored.EventId)
+	}
+	if restored.OccurredAt.AsTime().Before(deleted.OccurredAt.AsTime()) {
+		t.Errorf("expected ordered change times, got %v and %v", deleted.OccurredAt.AsTime(), restored.OccurredAt.AsTime())
+	}
+	if created.OccurredAt.AsTime().IsZero() || deleted.OccurredAt.AsTime().Before(created.OccurredAt.AsTime()) {
+		t.Errorf("expected ordered change times, got %v and %v", created.OccurredAt.AsTime(), deleted.OccurredAt.AsTime())
+	}
+}
+
+func TestUpsertAnswer(t *testing.T) {
+	key := uniqueKey("upsert")
+
+	// The first upsert creates the answer, the next one replaces it and one after a delete creates it again.
+	//
+	var created []bool
+	for _, value := range []string{"John", "Sam"} {
+		var resp struct {
+			Created bool `json:"created"`
+		}
+		doHTTP(t, http.MethodPut, "/v1/answers/"+url.PathEscape(key), map[string]string{"value": value}, http.StatusOK, &resp)
+		created = append(created, resp.Created)
+	}
+	doHTTP(t, http.MethodDelete, "/v1/answers?key="+url.QueryEscape(key), nil, http.StatusOK, nil)
+	upserted, err := testGrpcClient.UpsertAnswer(context.Background(), &pkgApi.UpsertAnswerRequest{Key: key, Value: "Tom"})
+	if err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	created = append(created, upserted.Created)
+	if diff := deep.Equal(created, []bool{true, false, true}); diff != nil {
+		t.Error(diff)
+	}
+	if upserted.Version != 4 {
+		t.Errorf("expected version 4 after the delete, got %d", upserted.Version)
+	}
+	doHTTP(t, http.MethodPut, "/v1/answers/"+url.PathEscape(key), map[string]string{}, http.StatusBadRequest, nil)
+
+	// The history records a create or an update event for each upsert.
+	//
+	want := []historyEntry{
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_CREATE.String(), Key: key, Value: "John"},
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_UPDATE.String(), Key: key, Value: "Sam"},
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_DELETE.String(), Key: key, Value: "Sam"},
+		{EventType: pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_CREATE.String(), Key: key, Value: "Tom"},
+	}
+	eventually(t, func() error {
+		var resp struct {
+			AnswerEvents []struct {
+				EventType string `json:"eventType"`
+				Data      struct {
+					Key   string `json:"key"`
+					Value string `json:"value"`
+				} `json:"data"`
+			} `json:"answerEvents"`
+		}
+		doHTTP(t, http.MethodGet, "/v1/answers/"+url.PathEscape(key)+"/history", nil, http.StatusOK, &resp)
+		var history []historyEntry
+		for _, e := range resp.AnswerEvents {
+			history = append(history, historyEntry{EventType: e.EventType, Key: e.Data.Key, Value: e.Data.Value})
+		}
+		if diff := deep.Equal(history, want); diff != nil {
+			return fmt.Errorf("history: %v", diff)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,114 @@
+package integrationtest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	pkgApiV2 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v2"
+
+	"github.com/go-test/deep"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestAnswerLifecycleOverGRPCV2(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("v2/name")
+	name := "answers/" + key
+
+	// Create returns the created resource.
+	//
+	created, err := testGrpcClientV2.CreateAnswer(ctx, &pkgApiV2.CreateAnswerRequest{AnswerId: key, Answer: &pkgApiV2.Answer{Value: "John"}})
+	if err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+	if diff := deep.Equal([]string{created.Name, created.Value}, []string{name, "John"}); diff != nil {
+		t.Error(diff)
+	}
+	_, err = testGrpcClientV2.CreateAnswer(ctx, &pkgApiV2.CreateAnswerRequest{AnswerId: key, Answer: &pkgApiV2.Answer{Value: "Sam"}})
+	expectCode(t, err, codes.AlreadyExists)
+
+	// The update mask selects the value.
+	//
+	updated, err := testGrpcClientV2.UpdateAnswer(ctx, &pkgApiV2.UpdateAnswerRequest{
+		Answer:     &pkgApiV2.Answer{Name: name, Value: "Sam"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"value"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateAnswer() error = %v", err)
+	}
+	if diff := deep.Equal(updated.Value, "Sam"); diff != nil {
+		t.Error(diff)
+	}
+	_, err = testGrpcClientV2.UpdateAnswer(ctx, &pkgApiV2.UpdateAnswerRequest{
+		Answer:     &pkgApiV2.Answer{Name: name, Value: "Tom"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+	expectCode(t, err, codes.InvalidArgument)
+
+	// The v1 API serves the same answer.
+	//
+	got, err := testGrpcClient.GetAnswer(ctx, &pkgApi.GetAnswerRequest{Key: key})
+	if err != nil {
+		t.Fatalf("GetAnswer() error = %v", err)
+	}
+	if diff := deep.Equal(got.Answer.Value, "Sam"); diff != nil {
+		t.Error(diff)
+	}
+
+	// Delete the answer.
+	//
+	if _, err := testGrpcClientV2.DeleteAnswer(ctx, &pkgApiV2.DeleteAnswerRequest{Name: name}); err != nil {
+		t.Fatalf("DeleteAnswer() error = %v", err)
+	}
+	_, err = testGrpcClientV2.GetAnswer(ctx, &pkgApiV2.GetAnswerRequest{Name: name})
+	expectCode(t, err, codes.NotFound)
+	_, err = testGrpcClientV2.GetAnswer(ctx, &pkgApiV2.GetAnswerRequest{Name: key})
+	expectCode(t, err, codes.InvalidArgument)
+}
+
+func TestAnswerLifecycleOverHTTPV2(t *testing.T) {
+	key := uniqueKey("http/v2")
+	path := "/v2/answers/" + url.PathEscape(key)
+	type answer struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+
+	// Create, patch and delete the answer, its key holds an escaped "/".
+	//
+	var created answer
+	doHTTP(t, http.MethodPost, "/v2/answers?answerId="+url.QueryEscape(key), map[string]string{"value": "John"}, http.StatusOK, &created)
+	if diff := deep.Equal(created, answer{Name: "answers/" + key, Value: "John"}); diff != nil {
+		t.Error(diff)
+	}
+	var updated answer
+	doHTTP(t, http.MethodPatch, path, map[string]string{"value": "Sam"}, http.StatusOK, &updated)
+	if diff := deep.Equal(updated, answer{Name: "answers/" + key, Value: "Sam"}); diff != nil {
+		t.Error(diff)
+	}
+	for _, getPath := range []string{path, "/v2/answers/" + key} {
+		var got answer
+		doHTTP(t, http.MethodGet, getPath, nil, http.StatusOK, &got)
+		if diff := deep.Equal(got, updated); diff != nil {
+			t.Error(diff)
+		}
+	}
+	doHTTP(t, http.MethodPatch, path+"?updateMask=name", map[string]string{"value": "Tom"}, http.StatusBadRequest, nil)
+	doHTTP(t, http.MethodDelete, path, nil, http.StatusOK, nil)
+	doHTTP(t, http.MethodGet, path, nil, http.StatusNotFound, nil)
+
+	// List the answers.
+	//
+	var list struct {
+		Answers       []answer `json:"answers"`
+		NextPageToken string   `json:"nextPageToken"`
+	}
+	doHTTP(t, http.MethodGet, "/v2/answers?pageSize=1", nil, http.StatusOK, &list)
+	if diff := deep.Equal(len(list.Answers), 1); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,4 @@
+// Package integrationtest contains the end-to-end tests of the answer service.
+// The tests start the gRPC server, the gRPC gateway and the answer worker in-process
+// against the in-memory storage and queue, and drive the public API over gRPC and HTTP.
+package integrationtest
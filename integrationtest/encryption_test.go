@@ -0,0 +1,39 @@
+package integrationtest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+func TestAnswerValueEncrypted(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("encrypted")
+	_, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// The clients read the plaintext.
+	//
+	resp, err := testGrpcClient.GetAnswer(ctx, &pkgApi.GetAnswerRequest{Key: key})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if resp.Answer.Value != "John" {
+		t.Errorf("unexpected value: %v", resp.Answer.Value)
+	}
+
+	// But the storage holds the encrypted value.
+	//
+	stored, err := testStoredAnswers.Get(domain.AnswerKey(key))
+	if err != nil {
+		t.Fatalf("get stored: %v", err)
+	}
+	if !strings.HasPrefix(string(stored.Value), "enc:") || strings.Contains(string(stored.Value), "John") {
+		t.Errorf("value stored in plaintext: %v", stored.Value)
+	}
+}
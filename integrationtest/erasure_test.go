@@ -0,0 +1,123 @@
+package integrationtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/helpers"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestEraseAnswers(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("erased")
+	_, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	_, err = testGrpcClient.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Sam"}})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	// Wait for the history, the erasure must find it stored.
+	//
+	eventually(t, func() error {
+		resp, err := testGrpcClient.GetAnswerHistory(ctx, &pkgApi.GetAnswerHistoryRequest{Key: key})
+		if err != nil {
+			return err
+		}
+		if len(resp.AnswerEvents) != 2 {
+			return fmt.Errorf("expected 2 events, got %v", len(resp.AnswerEvents))
+		}
+		return nil
+	})
+
+	resp, err := testAdminClient.EraseAnswers(ctx, &pkgApi.EraseAnswersRequest{Keys: []string{key}})
+	if err != nil {
+		t.Fatalf("erase: %v", err)
+	}
+	if len(resp.Receipts) != 1 {
+		t.Fatalf("expected 1 receipt, got %v", resp.Receipts)
+	}
+	receipt := resp.Receipts[0]
+	if receipt.KeyHash != testKeyHasher.Hash(domain.AnswerKey(key)) || !receipt.AnswerErased || receipt.EventsErased != 2 ||
+		receipt.RequestedBy != testAdminSubject {
+		t.Errorf("unexpected receipt: %v", receipt)
+	}
+
+	// The answer and its history are gone.
+	//
+	_, err = testGrpcClient.GetAnswer(ctx, &pkgApi.GetAnswerRequest{Key: key})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	history, err := testGrpcClient.GetAnswerHistory(ctx, &pkgApi.GetAnswerHistoryRequest{Key: key})
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if len(history.AnswerEvents) != 0 {
+		t.Fatalf("unexpected history: %v", history.AnswerEvents)
+	}
+
+	// The erase event reaches even the subscription filtering the delete events.
+	//
+	eventually(t, func() error {
+		eventTypes := testAnalyticsEvents.get(domain.AnswerKey(key))
+		if len(eventTypes) != 1 || eventTypes[0] != domain.EraseAnswerEventType {
+			return fmt.Errorf("got event types %v, want [%v]", eventTypes, domain.EraseAnswerEventType)
+		}
+		return nil
+	})
+
+	// Without keys nothing is erased.
+	//
+	_, err = testAdminClient.EraseAnswers(ctx, &pkgApi.EraseAnswersRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestAdminMethodsRequireAdmin(t *testing.T) {
+	anonymousCtx := context.Background()
+	clientCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", testToken(t))
+	otherAdminCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", signTestToken(t, &helpers.Claims{
+		StandardClaims: jwt.StandardClaims{Subject: uniqueKey("admin")},
+		Admin:          true,
+	}))
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{name: "anonymous", ctx: anonymousCtx, wantCode: codes.Unauthenticated},
+		{name: "client without the admin claim", ctx: clientCtx, wantCode: codes.PermissionDenied},
+		{name: "admin", ctx: otherAdminCtx, wantCode: codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testAnonymousAdminClient.EraseAnswers(tt.ctx, &pkgApi.EraseAnswersRequest{Keys: []string{uniqueKey("unknown")}})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("EraseAnswers: expected %v, got %v", tt.wantCode, err)
+			}
+			_, err = testAnonymousAdminClient.ListDeletedAnswers(tt.ctx, &pkgApi.ListDeletedAnswersRequest{})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("ListDeletedAnswers: expected %v, got %v", tt.wantCode, err)
+			}
+			stream, err := testAnonymousAdminClient.ExportAnswers(tt.ctx, &pkgApi.ExportAnswersRequest{})
+			if err == nil && tt.wantCode != codes.OK {
+				_, err = stream.Recv()
+			}
+			if tt.wantCode != codes.OK && status.Code(err) != tt.wantCode {
+				t.Errorf("ExportAnswers: expected %v, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
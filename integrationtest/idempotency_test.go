@@ -0,0 +1,135 @@
+package integrationtest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/helpers"
+
+	"github.com/go-test/deep"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestIdempotentCreateOverHTTP(t *testing.T) {
+	key := uniqueKey("idempotent-http")
+	header := http.Header{
+		helpers.IdempotencyKeyHeader: []string{uniqueKey("request")},
+		"Authorization":              []string{testToken(t)},
+	}
+	type response struct {
+		Answer struct {
+			Value string `json:"value"`
+		} `json:"answer"`
+		EventID string `json:"eventId"`
+	}
+
+	// The retry replays the response of the first request instead of failing with a conflict.
+	//
+	var first, retried response
+	body := map[string]string{"key": key, "value": "John"}
+	doHTTPWithHeader(t, http.MethodPost, "/v1/answers", header, body, http.StatusOK, &first)
+	doHTTPWithHeader(t, http.MethodPost, "/v1/answers", header, body, http.StatusOK, &retried)
+	if diff := deep.Equal(retried, first); diff != nil {
+		t.Error(diff)
+	}
+	if len(first.EventID) == 0 {
+		t.Error("expected an event ID")
+	}
+
+	// The key cannot be used with another request, a request without the key or by another client is not replayed.
+	//
+	doHTTPWithHeader(t, http.MethodPost, "/v1/answers", header, map[string]string{"key": key, "value": "Sam"}, http.StatusBadRequest, nil)
+	doHTTP(t, http.MethodPost, "/v1/answers", body, http.StatusConflict, nil)
+	otherClient := http.Header{
+		helpers.IdempotencyKeyHeader: header[helpers.IdempotencyKeyHeader],
+		"Authorization":              []string{testToken(t)},
+	}
+	doHTTPWithHeader(t, http.MethodPost, "/v1/answers", otherClient, body, http.StatusConflict, nil)
+
+	// The history records a single create.
+	//
+	waitForHistory(t, key, 1)
+	doHTTP(t, http.MethodDelete, "/v1/answers?key="+url.QueryEscape(key), nil, http.StatusOK, nil)
+}
+
+func TestIdempotentDeleteOverGRPC(t *testing.T) {
+	key := uniqueKey("idempotent-grpc")
+	if _, err := testGrpcClient.CreateAnswer(context.Background(), &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	ctx := idempotentContext(t)
+
+	// The retried delete returns the deleted answer, the answer is not found without the key.
+	//
+	first, err := testGrpcClient.DeleteAnswer(ctx, &pkgApi.DeleteAnswerRequest{Key: key})
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	retried, err := testGrpcClient.DeleteAnswer(ctx, &pkgApi.DeleteAnswerRequest{Key: key})
+	if err != nil {
+		t.Fatalf("retried delete: %v", err)
+	}
+	if first.EventId != retried.EventId || retried.Answer.GetValue() != "John" {
+		t.Errorf("expected the replayed response %v, got %v", first, retried)
+	}
+	_, err = testGrpcClient.DeleteAnswer(context.Background(), &pkgApi.DeleteAnswerRequest{Key: key})
+	expectCode(t, err, codes.NotFound)
+
+	// A failed request releases the key, so it may be retried.
+	//
+	ctx = idempotentContext(t)
+	_, err = testGrpcClient.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Sam"}})
+	expectCode(t, err, codes.NotFound)
+	_, err = testGrpcClient.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Sam"}})
+	expectCode(t, err, codes.NotFound)
+}
+
+func TestErasureForgetsStoredResponses(t *testing.T) {
+	key := uniqueKey("idempotent-erased")
+	ctx := idempotentContext(t)
+	request := &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}}
+	if _, err := testGrpcClient.CreateAnswer(ctx, request); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := testAdminClient.EraseAnswers(context.Background(), &pkgApi.EraseAnswersRequest{Keys: []string{key}}); err != nil {
+		t.Fatalf("erase: %v", err)
+	}
+
+	// The retry is not replayed with the erased value, the answer is created again.
+	//
+	if _, err := testGrpcClient.CreateAnswer(ctx, request); err != nil {
+		t.Fatalf("retried create: %v", err)
+	}
+	if _, err := testGrpcClient.GetAnswer(context.Background(), &pkgApi.GetAnswerRequest{Key: key}); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if _, err := testGrpcClient.DeleteAnswer(context.Background(), &pkgApi.DeleteAnswerRequest{Key: key}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+}
+
+func TestIdempotencyRequiresAuthenticatedClient(t *testing.T) {
+	request := &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: uniqueKey("idempotent-anonymous"), Value: "John"}}
+
+	// An anonymous client cannot send a key, a token which cannot be verified is rejected.
+	//
+	ctx := metadata.AppendToOutgoingContext(context.Background(), helpers.IdempotencyKeyHeader, uniqueKey("request"))
+	_, err := testGrpcClient.CreateAnswer(ctx, request)
+	expectCode(t, err, codes.Unauthenticated)
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+uniqueKey("token"))
+	_, err = testGrpcClient.CreateAnswer(ctx, request)
+	expectCode(t, err, codes.Unauthenticated)
+	header := http.Header{"Authorization": []string{"Bearer " + uniqueKey("token")}}
+	doHTTPWithHeader(t, http.MethodGet, "/v1/answers?key="+url.QueryEscape(request.Answer.Key), header, nil, http.StatusUnauthorized, nil)
+}
+
+// idempotentContext - returns the context of a request with a new idempotency key by a new client.
+func idempotentContext(t *testing.T) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(),
+		helpers.IdempotencyKeyHeader, uniqueKey("request"),
+		"authorization", testToken(t))
+}
@@ -0,0 +1,353 @@
+package integrationtest
+
+import (
+	"context"
+	"encoding/base64"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	pkgApiV2 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v2"
+	"dochq.co.uk.answerservice/internal/admin"
+	pkgAnswer "dochq.co.uk.answerservice/internal/answer"
+	"dochq.co.uk.answerservice/internal/config"
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/encryption"
+	"dochq.co.uk.answerservice/internal/erasure"
+	"dochq.co.uk.answerservice/internal/helpers"
+	"dochq.co.uk.answerservice/internal/inmemory"
+	"dochq.co.uk.answerservice/internal/memqueue"
+	"dochq.co.uk.answerservice/internal/retention"
+	"dochq.co.uk.answerservice/internal/search"
+	"dochq.co.uk.answerservice/internal/topic"
+	"dochq.co.uk.answerservice/internal/transfer"
+	"dochq.co.uk.answerservice/internal/watch"
+	"dochq.co.uk.answerservice/internal/webhook"
+	workers "dochq.co.uk.answerservice/internal/worker"
+
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	kitlog "github.com/go-kit/log"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+)
+
+const (
+	testAnswerEventTopicName   = "answer-events"
+	testAnswerEventQueueName   = "answer-history"
+	testWebhookQueueName       = "webhook-deliveries"
+	testAnalyticsQueueName     = "analytics-deletes"
+	testSearchQueueName        = "answer-search"
+	testWatchQueueName         = "answer-watch"
+	testDeletedAnswerRetention = 24 * time.Hour
+	eventuallyTimeout          = 5 * time.Second
+	eventuallyInterval         = 20 * time.Millisecond
+
+	// testRateLimitedMethod - the method limited to a few requests of a client, the other tests use it
+	// a couple of times at most.
+	testRateLimitedMethod = "GetWebhookSubscription"
+	testRateLimitBurst    = 3
+
+	// testJWTSecret - signs the tokens of the clients of the tests.
+	testJWTSecret = "test-secret"
+	// testAdminSubject - the subject of the token of the admin client.
+	testAdminSubject = "test-admin"
+	// testHashSalt - keys the hashes of the answer keys.
+	testHashSalt = "test-salt"
+)
+
+var (
+	testGrpcClient   pkgApi.AnswerServiceClient
+	testGrpcClientV2 pkgApiV2.AnswerServiceClient
+	testAdminClient  pkgApi.AdminServiceClient
+	// testAnonymousAdminClient - calls the admin methods without a token.
+	testAnonymousAdminClient pkgApi.AdminServiceClient
+	testWebhookClient        pkgApi.WebhookServiceClient
+	testHTTPURL              string
+	testKeySequence          int64
+	// testKeyHasher - hashes the answer keys as the services do.
+	testKeyHasher = domain.NewAnswerKeyHasher(testHashSalt)
+
+	// testStoredAnswers - the answers as stored, their values are encrypted.
+	testStoredAnswers domain.AnswerRepository
+
+	// testAnalyticsEvents - the events received by the analytics subscription queue, which filters the delete events.
+	testAnalyticsEvents = &receivedEvents{}
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run - starts the application in-process, runs the tests and stops the application.
+func run(m *testing.M) int {
+	logger := kitlog.NewNopLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Setup storage and queue, the values are encrypted as the application does with a key provider.
+	//
+	keyProvider, err := encryption.NewLocalKeyProvider(&encryption.LocalKeyFile{
+		PrimaryKeyID: "test",
+		Keys:         map[string]string{"test": base64.StdEncoding.EncodeToString(make([]byte, 32))},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create key provider %v", err)
+	}
+	cipher := encryption.NewValueCipher(keyProvider, time.Hour)
+	testStoredAnswers = inmemory.NewAnswerRepository()
+	answerRepository := encryption.NewAnswerRepository(testStoredAnswers, cipher)
+	watchHub := watch.NewHub()
+	answerEventRepository := encryption.NewAnswerEventRepository(inmemory.NewAnswerEventRepository(), cipher)
+	broker := memqueue.NewBroker(logger)
+	broker.SetRedeliveryDelay(10 * time.Millisecond)
+	topicService := topic.NewFanOutService(broker, map[string][]domain.TopicSubscription{
+		testAnswerEventTopicName: {
+			{QueueName: testAnswerEventQueueName},
+			{QueueName: testWebhookQueueName},
+			{QueueName: testSearchQueueName},
+			{QueueName: testWatchQueueName},
+			{
+				QueueName:    testAnalyticsQueueName,
+				FilterPolicy: domain.FilterPolicy{domain.EventTypeAttributeKey: {string(domain.DeleteAnswerEventType)}},
+			},
+		},
+	})
+	queueService := encryption.NewQueueService(topic.NewQueueService(topicService), cipher)
+	queueConsumer := encryption.NewQueueConsumer(broker, cipher)
+	webhookRepository := encryption.NewWebhookRepository(inmemory.NewWebhookRepository(), cipher)
+	erasureRepository := inmemory.NewErasureRepository()
+	idempotencyRepository := encryption.NewIdempotencyRepository(inmemory.NewIdempotencyRepository(), cipher)
+	authentication := helpers.NewAuthentication(&config.Config{Auth: config.Auth{JWTSecret: testJWTSecret}})
+	idempotency := helpers.NewIdempotency(idempotencyRepository, time.Hour, time.Minute, testKeyHasher, authentication, logger)
+	rateLimit := helpers.NewRateLimit(&config.Config{RateLimit: config.RateLimit{
+		Backend:           config.MemoryRateLimitBackend,
+		RequestsPerMinute: 60000,
+		Burst:             10000,
+		Methods: []config.MethodRateLimit{
+			{Method: testRateLimitedMethod, RequestsPerMinute: 1, Burst: testRateLimitBurst},
+		},
+	}}, nil, logger)
+
+	// Setup service and transports as the application does.
+	//
+	answerService := pkgAnswer.NewService(answerRepository, answerEventRepository, queueService, testAnswerEventTopicName, testDeletedAnswerRetention, logger)
+	watchService := watch.NewService(answerEventRepository, watchHub, logger)
+	searchIndex := search.NewIndex()
+	searchService := search.NewService(searchIndex, logger)
+	answerEndpoints := pkgAnswer.NewEndpoint(answerService, searchService, authentication, idempotency, rateLimit, logger)
+	answerGrpcServer := pkgAnswer.NewGRPCServer(answerEndpoints, watchService, logger)
+	answerGrpcServerV2 := pkgAnswer.NewGRPCServerV2(answerEndpoints, logger)
+	transferService := transfer.NewService(answerService, answerRepository, answerEventRepository, testDeletedAnswerRetention, logger)
+	retentionService := retention.NewService(answerRepository, logger)
+	erasureService := erasure.NewService(answerRepository, answerEventRepository, erasureRepository,
+		idempotencyRepository, queueService, testAnswerEventTopicName, testKeyHasher, logger)
+	// The webhook receivers of the tests listen on the loopback addresses.
+	//
+	webhookAddressPolicy, err := webhook.NewAddressPolicy([]string{"127.0.0.0/8"})
+	if err != nil {
+		log.Fatalf("Failed to create address policy %v", err)
+	}
+	webhookService := webhook.NewService(webhookRepository, webhookAddressPolicy, logger)
+	webhookGrpcServer := webhook.NewGRPCServer(webhook.NewEndpoint(webhookService, authentication, rateLimit, logger), logger)
+
+	// Start gRPC server.
+	//
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(kitgrpc.Interceptor))
+	pkgApi.RegisterAnswerServiceServer(grpcServer, answerGrpcServer)
+	pkgApiV2.RegisterAnswerServiceServer(grpcServer, answerGrpcServerV2)
+	pkgApi.RegisterAdminServiceServer(grpcServer, admin.NewGRPCServer(admin.NewEndpoint(transferService, retentionService, erasureService, authentication, rateLimit, logger), logger))
+	pkgApi.RegisterWebhookServiceServer(grpcServer, webhookGrpcServer)
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Fatalf("Failed to listen %v", err)
+	}
+	go func() { _ = grpcServer.Serve(grpcListener) }()
+	defer grpcServer.Stop()
+
+	// Start HTTP gateway.
+	//
+	rmux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{}),
+		runtime.WithIncomingHeaderMatcher(helpers.IncomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(helpers.OutgoingHeaderMatcher),
+	)
+	if err := pkgApi.RegisterAnswerServiceHandlerServer(ctx, rmux, answerGrpcServer); err != nil {
+		log.Fatalf("Failed to register gateway %v", err)
+	}
+	if err := pkgApi.RegisterWebhookServiceHandlerServer(ctx, rmux, webhookGrpcServer); err != nil {
+		log.Fatalf("Failed to register gateway %v", err)
+	}
+	if err := pkgApiV2.RegisterAnswerServiceHandlerServer(ctx, rmux, answerGrpcServerV2); err != nil {
+		log.Fatalf("Failed to register gateway %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", rmux)
+	mux.Handle(pkgAnswer.WatchAnswersPath, pkgAnswer.NewWatchHandler(watchService))
+	httpServer := httptest.NewServer(mux)
+	defer httpServer.Close()
+	testHTTPURL = httpServer.URL
+
+	// Start workers, each consumes its subscription queue and the webhook deliveries are retried quickly.
+	//
+	dispatcher := webhook.NewDispatcher(webhookRepository, webhook.DispatcherOptions{
+		MaxAttempts:    3,
+		InitialBackoff: 10 * time.Millisecond,
+		Timeout:        time.Second,
+		AddressPolicy:  webhookAddressPolicy,
+		KeyHasher:      testKeyHasher,
+	}, logger)
+	answerWorker := workers.NewAnswerWorker(
+		&workers.Props{
+			WorkerName: "answer-event-worker",
+			QueueName:  testAnswerEventQueueName,
+		},
+		queueConsumer,
+		answerEventRepository,
+		erasureRepository,
+		testKeyHasher,
+		logger,
+	)
+	notifierWorker := workers.NewNotifierWorker(
+		&workers.Props{
+			WorkerName: "webhook-worker",
+			QueueName:  testWebhookQueueName,
+		},
+		queueConsumer,
+		dispatcher,
+		erasureRepository,
+		testKeyHasher,
+		logger,
+	)
+	searchIndexWorker := workers.NewSearchIndexWorker(
+		&workers.Props{
+			WorkerName: "search-index-worker",
+			QueueName:  testSearchQueueName,
+		},
+		queueConsumer,
+		searchIndex,
+		erasureRepository,
+		testKeyHasher,
+		logger,
+	)
+	watchWorker := workers.NewWatchWorker(
+		&workers.Props{
+			WorkerName: "watch-worker",
+			QueueName:  testWatchQueueName,
+		},
+		queueConsumer,
+		watchHub,
+		erasureRepository,
+		testKeyHasher,
+		logger,
+	)
+	consumers := []func(){
+		func() { _ = answerWorker.Run(ctx) },
+		func() { _ = notifierWorker.Run(ctx) },
+		func() { _ = searchIndexWorker.Run(ctx) },
+		func() { _ = watchWorker.Run(ctx) },
+		func() { _ = queueConsumer.Consume(ctx, testAnalyticsQueueName, testAnalyticsEvents) },
+	}
+	var workersDone sync.WaitGroup
+	for _, consume := range consumers {
+		consume := consume
+		workersDone.Add(1)
+		go func() {
+			defer workersDone.Done()
+			consume()
+		}()
+	}
+	defer func() {
+		cancel()
+		workersDone.Wait()
+	}()
+
+	// Setup gRPC client.
+	//
+	conn, err := grpc.Dial(grpcListener.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("Failed to dial %v", err)
+	}
+	defer conn.Close()
+	testGrpcClient = pkgApi.NewAnswerServiceClient(conn)
+	testGrpcClientV2 = pkgApiV2.NewAnswerServiceClient(conn)
+	testAnonymousAdminClient = pkgApi.NewAdminServiceClient(conn)
+	testWebhookClient = pkgApi.NewWebhookServiceClient(conn)
+
+	// The admin methods require a token with the admin claim.
+	//
+	adminToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &helpers.Claims{
+		StandardClaims: jwt.StandardClaims{Subject: testAdminSubject},
+		Admin:          true,
+	}).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		log.Fatalf("Failed to sign the admin token %v", err)
+	}
+	adminConn, err := grpc.Dial(grpcListener.Addr().String(), grpc.WithInsecure(),
+		grpc.WithPerRPCCredentials(bearerCredentials("Bearer "+adminToken)))
+	if err != nil {
+		log.Fatalf("Failed to dial %v", err)
+	}
+	defer adminConn.Close()
+	testAdminClient = pkgApi.NewAdminServiceClient(adminConn)
+
+	return m.Run()
+}
+
+// eventually - retries the condition till it returns nil or the timeout expires.
+func eventually(t *testing.T, condition func() error) {
+	t.Helper()
+	deadline := time.Now().Add(eventuallyTimeout)
+	for {
+		err := condition()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met within %v: %v", eventuallyTimeout, err)
+		}
+		time.Sleep(eventuallyInterval)
+	}
+}
+
+// uniqueKey - returns a key not used by other tests, so the tests can be repeated.
+func uniqueKey(prefix string) string {
+	return prefix + "-" + strconv.FormatInt(atomic.AddInt64(&testKeySequence, 1), 10)
+}
+
+// testToken - returns the bearer token of a client with a new subject, signed as the clients of the application are.
+func testToken(t *testing.T) string {
+	t.Helper()
+	return signTestToken(t, &jwt.StandardClaims{Subject: uniqueKey("client")})
+}
+
+// signTestToken - returns the bearer token of the claims.
+func signTestToken(t *testing.T, claims jwt.Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "Bearer " + token
+}
+
+// bearerCredentials - sends the bearer token with every call.
+type bearerCredentials string
+
+// GetRequestMetadata Impl.
+func (c bearerCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": string(c)}, nil
+}
+
+// RequireTransportSecurity Impl.
+func (c bearerCredentials) RequireTransportSecurity() bool {
+	return false
+}
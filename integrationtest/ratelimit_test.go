@@ -0,0 +1,95 @@
+package integrationtest
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/helpers"
+
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitOverHTTP(t *testing.T) {
+	token := testToken(t)
+
+	// The burst is served, then the client is told to retry after a minute.
+	//
+	get := func() *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, testHTTPURL+"/v1/webhooks/missing", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", token)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		return resp
+	}
+	for i := 0; i < testRateLimitBurst; i++ {
+		if resp := get(); resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("request %v: expected status %v, got %v", i, http.StatusNotFound, resp.StatusCode)
+		}
+	}
+	resp := get()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected status %v, got %v", http.StatusTooManyRequests, resp.StatusCode)
+	}
+	retryAfter, err := strconv.Atoi(resp.Header.Get(helpers.RetryAfterHeader))
+	if err != nil || retryAfter < 1 || retryAfter > 60 {
+		t.Errorf("expected %v of up to a minute, got %q", helpers.RetryAfterHeader, resp.Header.Get(helpers.RetryAfterHeader))
+	}
+}
+
+func TestRateLimitOverGRPC(t *testing.T) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", testToken(t))
+	otherCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", testToken(t))
+
+	// The buckets of the clients are separate, the other methods are not limited as much.
+	//
+	for i := 0; i < testRateLimitBurst; i++ {
+		_, err := testWebhookClient.GetWebhookSubscription(ctx, &pkgApi.GetWebhookSubscriptionRequest{Id: "missing"})
+		expectCode(t, err, codes.NotFound)
+	}
+	_, err := testWebhookClient.GetWebhookSubscription(ctx, &pkgApi.GetWebhookSubscriptionRequest{Id: "missing"})
+	expectCode(t, err, codes.ResourceExhausted)
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+	if retryInfo == nil || retryInfo.RetryDelay.AsDuration() <= 0 {
+		t.Errorf("expected a retry delay, got %v", status.Convert(err).Details())
+	}
+	_, err = testWebhookClient.GetWebhookSubscription(otherCtx, &pkgApi.GetWebhookSubscriptionRequest{Id: "missing"})
+	expectCode(t, err, codes.NotFound)
+	_, err = testWebhookClient.ListWebhookSubscriptions(ctx, &pkgApi.ListWebhookSubscriptionsRequest{})
+	expectCode(t, err, codes.OK)
+}
+
+func TestRateLimitBySubject(t *testing.T) {
+	subject := uniqueKey("client")
+
+	// Every token of the subject draws from its bucket, a new token does not get a new one.
+	//
+	for i := 0; i < testRateLimitBurst; i++ {
+		token := signTestToken(t, &jwt.StandardClaims{Subject: subject, Id: uniqueKey("token")})
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", token)
+		_, err := testWebhookClient.GetWebhookSubscription(ctx, &pkgApi.GetWebhookSubscriptionRequest{Id: "missing"})
+		expectCode(t, err, codes.NotFound)
+	}
+	token := signTestToken(t, &jwt.StandardClaims{Subject: subject, Id: uniqueKey("token")})
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", token)
+	_, err := testWebhookClient.GetWebhookSubscription(ctx, &pkgApi.GetWebhookSubscriptionRequest{Id: "missing"})
+	expectCode(t, err, codes.ResourceExhausted)
+}
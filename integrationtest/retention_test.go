@@ -0,0 +1,59 @@
+package integrationtest
+
+import (
+	"context"
+	"testing"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListDeletedAnswers(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("deleted")
+	_, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := testGrpcClient.DeleteAnswer(ctx, &pkgApi.DeleteAnswerRequest{Key: key}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	// The deleted answer is hidden from the clients.
+	//
+	_, err = testGrpcClient.GetAnswer(ctx, &pkgApi.GetAnswerRequest{Key: key})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	// But listed to the admins until its purge time.
+	//
+	var found *pkgApi.DeletedAnswer
+	req := &pkgApi.ListDeletedAnswersRequest{}
+	for {
+		resp, err := testAdminClient.ListDeletedAnswers(ctx, req)
+		if err != nil {
+			t.Fatalf("list deleted: %v", err)
+		}
+		for _, a := range resp.DeletedAnswers {
+			if a.Answer.GetKey() == key {
+				found = a
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	if found == nil {
+		t.Fatalf("deleted answer %v not listed", key)
+	}
+	if found.Answer.Value != "John" {
+		t.Errorf("unexpected value: %v", found.Answer.Value)
+	}
+	if retention := found.PurgeAt.AsTime().Sub(found.DeletedAt.AsTime()); retention != testDeletedAnswerRetention {
+		t.Errorf("unexpected retention: %v", retention)
+	}
+}
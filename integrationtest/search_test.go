@@ -0,0 +1,167 @@
+package integrationtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"github.com/go-test/deep"
+	"google.golang.org/grpc/codes"
+)
+
+func TestSearchAnswersOverGRPC(t *testing.T) {
+	ctx := context.Background()
+	prefix := uniqueKey("search")
+	answers := []*pkgApi.Answer{
+		{Key: prefix + ".symptom.headache", Value: "Severe headache since Monday"},
+		{Key: prefix + ".symptom.cough", Value: "Dry cough"},
+		{Key: prefix + ".allergy", Value: "Penicillin"},
+	}
+	for _, answer := range answers {
+		if _, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: answer}); err != nil {
+			t.Fatalf("CreateAnswer() error = %v", err)
+		}
+	}
+	keyTerm := &pkgApi.AnswerSearchTerm{Text: prefix + ".SYMPTOM.", Match: pkgApi.AnswerMatchType_ANSWER_MATCH_TYPE_PREFIX}
+
+	// The index eventually holds the answers, the key prefix matches the symptoms.
+	//
+	eventually(t, func() error {
+		resp, err := testGrpcClient.SearchAnswers(ctx, &pkgApi.SearchAnswersRequest{Key: keyTerm})
+		if err != nil {
+			return err
+		}
+		if diff := deep.Equal(answerKeys(resp.Answers), []string{answers[1].Key, answers[0].Key}); diff != nil {
+			return fmt.Errorf("keys: %v", diff)
+		}
+		return nil
+	})
+
+	// The terms are combined and the fuzzy match tolerates a misspelt word.
+	//
+	resp, err := testGrpcClient.SearchAnswers(ctx, &pkgApi.SearchAnswersRequest{
+		Key:   keyTerm,
+		Value: &pkgApi.AnswerSearchTerm{Text: "hedache", Match: pkgApi.AnswerMatchType_ANSWER_MATCH_TYPE_FUZZY},
+	})
+	if err != nil {
+		t.Fatalf("SearchAnswers() error = %v", err)
+	}
+	if diff := deep.Equal(answerKeys(resp.Answers), []string{answers[0].Key}); diff != nil {
+		t.Error(diff)
+	}
+
+	// The matches are paged.
+	//
+	prefixTerm := &pkgApi.AnswerSearchTerm{Text: prefix + ".", Match: pkgApi.AnswerMatchType_ANSWER_MATCH_TYPE_PREFIX}
+	var keys []string
+	var pageToken string
+	for {
+		resp, err := testGrpcClient.SearchAnswers(ctx, &pkgApi.SearchAnswersRequest{Key: prefixTerm, PageSize: 2, PageToken: pageToken})
+		if err != nil {
+			t.Fatalf("SearchAnswers() error = %v", err)
+		}
+		keys = append(keys, answerKeys(resp.Answers)...)
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	if diff := deep.Equal(keys, []string{answers[2].Key, answers[1].Key, answers[0].Key}); diff != nil {
+		t.Error(diff)
+	}
+
+	// A deleted answer is eventually removed from the index.
+	//
+	if _, err := testGrpcClient.DeleteAnswer(ctx, &pkgApi.DeleteAnswerRequest{Key: answers[1].Key}); err != nil {
+		t.Fatalf("DeleteAnswer() error = %v", err)
+	}
+	eventually(t, func() error {
+		resp, err := testGrpcClient.SearchAnswers(ctx, &pkgApi.SearchAnswersRequest{Key: keyTerm})
+		if err != nil {
+			return err
+		}
+		if diff := deep.Equal(answerKeys(resp.Answers), []string{answers[0].Key}); diff != nil {
+			return fmt.Errorf("keys: %v", diff)
+		}
+		return nil
+	})
+
+	// A query without terms is rejected.
+	//
+	_, err = testGrpcClient.SearchAnswers(ctx, &pkgApi.SearchAnswersRequest{})
+	expectCode(t, err, codes.InvalidArgument)
+}
+
+func TestSearchAnswersAfterErasure(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("search.erased")
+	if _, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Penicillin"}}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+	keyTerm := &pkgApi.AnswerSearchTerm{Text: key, Match: pkgApi.AnswerMatchType_ANSWER_MATCH_TYPE_EXACT}
+	eventually(t, func() error {
+		resp, err := testGrpcClient.SearchAnswers(ctx, &pkgApi.SearchAnswersRequest{Key: keyTerm})
+		if err != nil {
+			return err
+		}
+		if diff := deep.Equal(answerKeys(resp.Answers), []string{key}); diff != nil {
+			return fmt.Errorf("keys: %v", diff)
+		}
+		return nil
+	})
+
+	// The erase event reaches the search queue, the erased answer is no longer found.
+	//
+	if _, err := testAdminClient.EraseAnswers(ctx, &pkgApi.EraseAnswersRequest{Keys: []string{key}}); err != nil {
+		t.Fatalf("EraseAnswers() error = %v", err)
+	}
+	eventually(t, func() error {
+		resp, err := testGrpcClient.SearchAnswers(ctx, &pkgApi.SearchAnswersRequest{Key: keyTerm})
+		if err != nil {
+			return err
+		}
+		if len(resp.Answers) > 0 {
+			return fmt.Errorf("erased answer found: %v", answerKeys(resp.Answers))
+		}
+		return nil
+	})
+}
+
+func TestSearchAnswersOverHTTP(t *testing.T) {
+	key := uniqueKey("http-search")
+	doHTTP(t, http.MethodPost, "/v1/answers", map[string]string{"key": key, "value": "Blood pressure 120/80"}, http.StatusOK, nil)
+
+	query := url.Values{
+		"key.text":    {key},
+		"key.match":   {pkgApi.AnswerMatchType_ANSWER_MATCH_TYPE_EXACT.String()},
+		"value.text":  {"pressure"},
+		"value.match": {pkgApi.AnswerMatchType_ANSWER_MATCH_TYPE_SUBSTRING.String()},
+	}
+	eventually(t, func() error {
+		var resp struct {
+			Answers []struct {
+				Key   string `json:"key"`
+				Value string `json:"value"`
+			} `json:"answers"`
+		}
+		doHTTP(t, http.MethodGet, "/v1/answers:search?"+query.Encode(), nil, http.StatusOK, &resp)
+		if len(resp.Answers) != 1 || resp.Answers[0].Key != key {
+			return fmt.Errorf("got answers %v, want %v", resp.Answers, key)
+		}
+		return nil
+	})
+	doHTTP(t, http.MethodGet, "/v1/answers:search?key.text=x&key.match=ANSWER_MATCH_TYPE_UNKNOWN", nil, http.StatusBadRequest, nil)
+}
+
+// answerKeys - returns the keys of the answers in order.
+func answerKeys(answers []*pkgApi.Answer) []string {
+	var keys []string
+	for _, answer := range answers {
+		keys = append(keys, answer.Key)
+	}
+	return keys
+}
@@ -0,0 +1,83 @@
+package integrationtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+// receivedEvents - a queue handler recording the event types received for each answer key.
+type receivedEvents struct {
+	mu         sync.Mutex
+	eventTypes map[domain.AnswerKey][]domain.AnswerEventType
+}
+
+func (r *receivedEvents) HandleMessage(ctx context.Context, msg *domain.ReceivedMessage) error {
+	payload := &domain.AnswerEventMessage{}
+	if err := json.Unmarshal(msg.Body, payload); err != nil {
+		return err
+	}
+	if payload.Event == nil || payload.Event.Data == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.eventTypes == nil {
+		r.eventTypes = make(map[domain.AnswerKey][]domain.AnswerEventType)
+	}
+	key := payload.Event.Data.Key
+	r.eventTypes[key] = append(r.eventTypes[key], payload.Event.EventType)
+	return nil
+}
+
+// get - returns the event types received for the key.
+func (r *receivedEvents) get(key domain.AnswerKey) []domain.AnswerEventType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.AnswerEventType(nil), r.eventTypes[key]...)
+}
+
+func TestTopicFanOut(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("topic")
+
+	// Create, update and delete an answer.
+	//
+	if _, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "first"}}); err != nil {
+		t.Fatalf("CreateAnswer() error = %v", err)
+	}
+	if _, err := testGrpcClient.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "second"}}); err != nil {
+		t.Fatalf("UpdateAnswer() error = %v", err)
+	}
+	if _, err := testGrpcClient.DeleteAnswer(ctx, &pkgApi.DeleteAnswerRequest{Key: key}); err != nil {
+		t.Fatalf("DeleteAnswer() error = %v", err)
+	}
+
+	// The history subscription receives every event.
+	//
+	eventually(t, func() error {
+		history, err := testGrpcClient.GetAnswerHistory(ctx, &pkgApi.GetAnswerHistoryRequest{Key: key})
+		if err != nil {
+			return err
+		}
+		if len(history.AnswerEvents) != 3 {
+			return fmt.Errorf("got %d events, want 3", len(history.AnswerEvents))
+		}
+		return nil
+	})
+
+	// The analytics subscription receives only the delete event.
+	//
+	eventually(t, func() error {
+		eventTypes := testAnalyticsEvents.get(domain.AnswerKey(key))
+		if len(eventTypes) != 1 || eventTypes[0] != domain.DeleteAnswerEventType {
+			return fmt.Errorf("got event types %v, want [%v]", eventTypes, domain.DeleteAnswerEventType)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,179 @@
+package integrationtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"github.com/go-test/deep"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestExportAnswers(t *testing.T) {
+	ctx := context.Background()
+	prefix := uniqueKey("export")
+	keys := []string{prefix + "-a", prefix + "-b"}
+	for _, key := range keys {
+		_, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	// The export carries the history once the worker stored it.
+	//
+	var checkpoints []string
+	eventually(t, func() error {
+		records, recordCheckpoints, err := exportAnswers(ctx, "", prefix)
+		if err != nil {
+			return err
+		}
+		for i, r := range records {
+			if len(r.Events) != 1 || r.Events[0].OccurredAt == nil {
+				return fmt.Errorf("record %v: unexpected history %v", i, r.Events)
+			}
+		}
+		checkpoints = recordCheckpoints
+		return nil
+	})
+	if len(checkpoints) != len(keys) {
+		t.Fatalf("expected %v records, got %v", len(keys), len(checkpoints))
+	}
+
+	// An export resumed at the checkpoint skips the exported records.
+	//
+	records, _, err := exportAnswers(ctx, checkpoints[0], prefix)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	var got []string
+	for _, r := range records {
+		got = append(got, r.Key)
+	}
+	if diff := deep.Equal(got, keys[1:]); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestImportAnswers(t *testing.T) {
+	ctx := context.Background()
+	preservedKey := uniqueKey("import-preserve")
+	replayedKey := uniqueKey("import-replay")
+	occurredAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	preservedEvents := []*pkgApi.AnswerEvent{
+		{
+			EventType:  pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_CREATE,
+			Data:       &pkgApi.Answer{Key: preservedKey, Value: "John"},
+			OccurredAt: timestamppb.New(occurredAt),
+		},
+		{
+			EventType:  pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_UPDATE,
+			Data:       &pkgApi.Answer{Key: preservedKey, Value: "Sam"},
+			OccurredAt: timestamppb.New(occurredAt.Add(time.Minute)),
+		},
+	}
+
+	// Import each record twice, a repeated import changes nothing.
+	//
+	stream, err := testAdminClient.ImportAnswers(ctx)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	requests := []*pkgApi.ImportAnswersRequest{
+		{
+			Mode: pkgApi.ImportMode_IMPORT_MODE_PRESERVE,
+			Record: &pkgApi.AnswerRecord{
+				Key:    preservedKey,
+				Answer: &pkgApi.Answer{Key: preservedKey, Value: "Sam"},
+				Events: preservedEvents,
+			},
+		},
+		{
+			Mode: pkgApi.ImportMode_IMPORT_MODE_REPLAY,
+			Record: &pkgApi.AnswerRecord{
+				Key:    replayedKey,
+				Answer: &pkgApi.Answer{Key: replayedKey, Value: "Jane"},
+				Events: []*pkgApi.AnswerEvent{
+					{
+						EventType:  pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_CREATE,
+						Data:       &pkgApi.Answer{Key: replayedKey, Value: "Jane"},
+						OccurredAt: timestamppb.New(occurredAt),
+					},
+				},
+			},
+		},
+		{
+			Mode:   pkgApi.ImportMode_IMPORT_MODE_UNKNOWN,
+			Record: &pkgApi.AnswerRecord{Key: replayedKey},
+		},
+	}
+	for _, req := range append(requests, requests...) {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if diff := deep.Equal(resp.Imported, int32(4)); diff != nil {
+		t.Error(diff)
+	}
+	if len(resp.Failures) != 2 || !strings.Contains(resp.Failures[0].Message, "Import mode") {
+		t.Errorf("unexpected failures %v", resp.Failures)
+	}
+
+	// The preserved history is stored as it is.
+	//
+	history, err := testGrpcClient.GetAnswerHistory(ctx, &pkgApi.GetAnswerHistoryRequest{Key: preservedKey})
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if diff := deep.Equal(history.AnswerEvents, preservedEvents); diff != nil {
+		t.Error(diff)
+	}
+
+	// The replayed answer gets a single new event.
+	//
+	eventually(t, func() error {
+		history, err := testGrpcClient.GetAnswerHistory(ctx, &pkgApi.GetAnswerHistoryRequest{Key: replayedKey})
+		if err != nil {
+			return err
+		}
+		if len(history.AnswerEvents) != 1 || history.AnswerEvents[0].Data.GetValue() != "Jane" {
+			return fmt.Errorf("unexpected history %v", history.AnswerEvents)
+		}
+		if history.AnswerEvents[0].OccurredAt.AsTime().Equal(occurredAt) {
+			return fmt.Errorf("replayed event kept the imported time")
+		}
+		return nil
+	})
+}
+
+// exportAnswers - exports the answers after the checkpoint and returns the records with the key prefix.
+func exportAnswers(ctx context.Context, checkpoint, prefix string) ([]*pkgApi.AnswerRecord, []string, error) {
+	stream, err := testAdminClient.ExportAnswers(ctx, &pkgApi.ExportAnswersRequest{Checkpoint: checkpoint})
+	if err != nil {
+		return nil, nil, err
+	}
+	var records []*pkgApi.AnswerRecord
+	var checkpoints []string
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return records, checkpoints, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if strings.HasPrefix(resp.Record.Key, prefix) {
+			records = append(records, resp.Record)
+			checkpoints = append(checkpoints, resp.Checkpoint)
+		}
+	}
+}
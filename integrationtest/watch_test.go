@@ -0,0 +1,179 @@
+package integrationtest
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestWatchAnswersOverGRPC(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventuallyTimeout)
+	defer cancel()
+	key := uniqueKey("watch")
+	createAndWaitForHistory(t, key)
+
+	// The key watch replays the history before the live events.
+	//
+	stream, err := testGrpcClient.WatchAnswers(ctx, &pkgApi.WatchAnswersRequest{Selector: &pkgApi.WatchAnswersRequest_Key{Key: key}})
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	expectWatched(t, stream, "John", 1)
+	if _, err := testGrpcClient.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Sam"}}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	expectWatched(t, stream, "Sam", 2)
+
+	// A resumed watch continues after the version.
+	//
+	resumed, err := testGrpcClient.WatchAnswers(ctx, &pkgApi.WatchAnswersRequest{Selector: &pkgApi.WatchAnswersRequest_Key{Key: key}, FromVersion: 1})
+	if err != nil {
+		t.Fatalf("watch resumed: %v", err)
+	}
+	expectWatched(t, resumed, "Sam", 2)
+}
+
+func TestWatchNamespaceOverGRPC(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventuallyTimeout)
+	defer cancel()
+	namespace := uniqueKey("watch-namespace")
+	stream, err := testGrpcClient.WatchAnswers(ctx, &pkgApi.WatchAnswersRequest{Selector: &pkgApi.WatchAnswersRequest_Namespace{Namespace: namespace}})
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	received := make(chan *pkgApi.WatchAnswersResponse, 100)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			received <- resp
+		}
+	}()
+
+	// The server subscribes the watch after the call returned, so answers are created till one is watched.
+	//
+	_, err = testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: namespace + "-other/name", Value: "Ann"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	eventually(t, func() error {
+		key := uniqueKey(namespace + "/name")
+		_, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}})
+		if err != nil {
+			return err
+		}
+		select {
+		case resp := <-received:
+			if !strings.HasPrefix(resp.AnswerEvent.Data.Key, namespace+"/") {
+				t.Fatalf("unexpected key: %v", resp.AnswerEvent.Data.Key)
+			}
+			return nil
+		case <-time.After(eventuallyInterval):
+			return fmt.Errorf("no event of %v watched", key)
+		}
+	})
+}
+
+func TestWatchAnswersOverHTTP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), eventuallyTimeout)
+	defer cancel()
+	key := uniqueKey("watch-http")
+	createAndWaitForHistory(t, key)
+	if _, err := testGrpcClient.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Sam"}}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	waitForHistory(t, key, 2)
+
+	// The Last-Event-ID of a reconnecting EventSource resumes the watch.
+	//
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, testHTTPURL+"/v1/answers:watch?key="+url.QueryEscape(key), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Last-Event-ID", "1")
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK || httpResp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected response: %v %v", httpResp.Status, httpResp.Header.Get("Content-Type"))
+	}
+	scanner := bufio.NewScanner(httpResp.Body)
+	var id, data string
+	for scanner.Scan() && len(data) == 0 {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	var resp pkgApi.WatchAnswersResponse
+	if err := protojson.Unmarshal([]byte(data), &resp); err != nil {
+		t.Fatalf("unmarshal %q: %v", data, err)
+	}
+	if id != "2" || resp.Version != 2 || resp.AnswerEvent.Data.Value != "Sam" {
+		t.Errorf("unexpected event: id %v, %v", id, data)
+	}
+}
+
+func TestWatchAnswersInvalidArgumentOverHTTP(t *testing.T) {
+	resp, err := http.Get(testHTTPURL + "/v1/answers:watch?key=a&prefix=a")
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unexpected status: %v", resp.Status)
+	}
+}
+
+// createAndWaitForHistory - creates the answer with the value "John" and waits for its event.
+func createAndWaitForHistory(t *testing.T, key string) {
+	t.Helper()
+	_, err := testGrpcClient.CreateAnswer(context.Background(), &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	waitForHistory(t, key, 1)
+}
+
+// waitForHistory - waits till the worker recorded the number of events of the key.
+func waitForHistory(t *testing.T, key string, events int) {
+	t.Helper()
+	eventually(t, func() error {
+		resp, err := testGrpcClient.GetAnswerHistory(context.Background(), &pkgApi.GetAnswerHistoryRequest{Key: key})
+		if err != nil {
+			return err
+		}
+		if len(resp.AnswerEvents) != events {
+			return fmt.Errorf("expected %v events, got %v", events, len(resp.AnswerEvents))
+		}
+		return nil
+	})
+}
+
+// expectWatched - receives the next event and checks its value and version.
+func expectWatched(t *testing.T, stream pkgApi.AnswerService_WatchAnswersClient, value string, version int32) {
+	t.Helper()
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	if resp.AnswerEvent.Data.Value != value || resp.Version != version {
+		t.Errorf("unexpected event: %v, want %v at version %v", resp, value, version)
+	}
+}
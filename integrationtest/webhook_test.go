@@ -0,0 +1,170 @@
+package integrationtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	pkgApi "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/webhook"
+
+	"github.com/go-test/deep"
+	"google.golang.org/grpc/codes"
+)
+
+// webhookReceiver - records the deliveries of a key, the first one is refused with a retryable status.
+type webhookReceiver struct {
+	key string
+
+	mu       sync.Mutex
+	payloads []*webhook.Payload
+	err      error
+	secret   string
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	payload := &webhook.Payload{}
+	if err := json.Unmarshal(body, payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// The other tests create answers too, their deliveries are accepted and ignored.
+	//
+	if payload.Event == nil || payload.Event.Data == nil || string(payload.Event.Data.Key) != r.key {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !webhook.VerifySignature(r.secret, req.Header.Get(webhook.HeaderID), req.Header.Get(webhook.HeaderTimestamp), body, req.Header.Get(webhook.HeaderSignature)) {
+		r.err = fmt.Errorf("invalid signature %q", req.Header.Get(webhook.HeaderSignature))
+	}
+	r.payloads = append(r.payloads, payload)
+	if len(r.payloads) == 1 {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *webhookReceiver) received() ([]*webhook.Payload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*webhook.Payload(nil), r.payloads...), r.err
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	ctx := context.Background()
+	key := uniqueKey("webhook-name")
+	receiver := &webhookReceiver{key: key}
+	receiverServer := httptest.NewServer(receiver)
+	defer receiverServer.Close()
+
+	// Subscribe to the created answers over HTTP, the secret is only returned on creation.
+	//
+	var created struct {
+		Subscription struct {
+			ID     string `json:"id"`
+			Secret string `json:"secret"`
+		} `json:"subscription"`
+	}
+	doHTTP(t, http.MethodPost, "/v1/webhooks", map[string]interface{}{
+		"url":        receiverServer.URL,
+		"eventTypes": []string{pkgApi.AnswerEventType_ANSWER_EVENT_TYPE_CREATE.String()},
+	}, http.StatusOK, &created)
+	id := created.Subscription.ID
+	if len(id) == 0 || len(created.Subscription.Secret) == 0 {
+		t.Fatalf("expected id and secret, got %+v", created.Subscription)
+	}
+	defer func() {
+		doHTTP(t, http.MethodDelete, "/v1/webhooks/"+url.PathEscape(id), nil, http.StatusOK, nil)
+		doHTTP(t, http.MethodGet, "/v1/webhooks/"+url.PathEscape(id), nil, http.StatusNotFound, nil)
+	}()
+	receiver.mu.Lock()
+	receiver.secret = created.Subscription.Secret
+	receiver.mu.Unlock()
+	got, err := testWebhookClient.GetWebhookSubscription(ctx, &pkgApi.GetWebhookSubscriptionRequest{Id: id})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got.Subscription.Secret) > 0 {
+		t.Error("secret returned after creation")
+	}
+
+	// The created answer is delivered after a retry, the update is not subscribed to.
+	//
+	if _, err := testGrpcClient.CreateAnswer(ctx, &pkgApi.CreateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "John"}}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if _, err := testGrpcClient.UpdateAnswer(ctx, &pkgApi.UpdateAnswerRequest{Answer: &pkgApi.Answer{Key: key, Value: "Sam"}}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	waitForHistory(t, key, 2)
+	var deliveries []*pkgApi.WebhookDelivery
+	eventually(t, func() error {
+		deliveries = nil
+		resp, err := testWebhookClient.ListWebhookDeliveries(ctx, &pkgApi.ListWebhookDeliveriesRequest{SubscriptionId: id})
+		if err != nil {
+			return err
+		}
+		for _, delivery := range resp.Deliveries {
+			if delivery.KeyHash == testKeyHasher.Hash(domain.AnswerKey(key)) {
+				deliveries = append(deliveries, delivery)
+			}
+		}
+		if len(deliveries) != 2 {
+			return fmt.Errorf("expected 2 deliveries, got %v", len(deliveries))
+		}
+		return nil
+	})
+	payloads, err := receiver.received()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(payloads) != 2 {
+		t.Fatalf("expected 2 payloads, got %v", len(payloads))
+	}
+	if diff := deep.Equal(payloads[1].Event.Data, &domain.Answer{Key: domain.AnswerKey(key), Value: "John", Version: 1}); diff != nil {
+		t.Error(diff)
+	}
+	type attempt struct {
+		ID         string
+		Attempt    int32
+		StatusCode int32
+		Succeeded  bool
+	}
+	var attempts []attempt
+	for _, delivery := range deliveries {
+		attempts = append(attempts, attempt{ID: delivery.Id, Attempt: delivery.Attempt, StatusCode: delivery.StatusCode, Succeeded: delivery.Succeeded})
+	}
+	wantAttempts := []attempt{
+		{ID: payloads[0].ID, Attempt: 1, StatusCode: http.StatusServiceUnavailable},
+		{ID: payloads[0].ID, Attempt: 2, StatusCode: http.StatusNoContent, Succeeded: true},
+	}
+	if diff := deep.Equal(attempts, wantAttempts); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestWebhookInvalidArgument(t *testing.T) {
+	ctx := context.Background()
+	_, err := testWebhookClient.CreateWebhookSubscription(ctx, &pkgApi.CreateWebhookSubscriptionRequest{
+		Subscription: &pkgApi.WebhookSubscription{Url: "ftp://example.com"},
+	})
+	expectCode(t, err, codes.InvalidArgument)
+	_, err = testWebhookClient.ListWebhookDeliveries(ctx, &pkgApi.ListWebhookDeliveriesRequest{SubscriptionId: "unknown"})
+	expectCode(t, err, codes.NotFound)
+}
@@ -0,0 +1,192 @@
+package admin
+
+import (
+	"context"
+	"io"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/helpers"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+)
+
+// Endpoints collects all of the endpoints of the admin services.
+type Endpoints struct {
+	ExportAnswersEndpoint      endpoint.Endpoint
+	ImportAnswersEndpoint      endpoint.Endpoint
+	ListDeletedAnswersEndpoint endpoint.Endpoint
+	EraseAnswersEndpoint       endpoint.Endpoint
+}
+
+// NewEndpoint returns a Set that wraps the provided services, and wires in all of the
+// expected endpoint middlewares via the various parameters.
+// Every method requires a verified client with the admin claim, so the admin API is closed if authentication is nil.
+// The requests are rate limited, unless rateLimit is nil.
+func NewEndpoint(transferService domain.AnswerTransferService,
+	retentionService domain.AnswerRetentionService,
+	erasureService domain.AnswerErasureService,
+	authentication *helpers.Authentication,
+	rateLimit *helpers.RateLimit,
+	logger log.Logger) Endpoints {
+	factory := func(handler endpoint.Endpoint, logKey string) endpoint.Endpoint {
+		return helpers.SetupEndpoint(helpers.RequireAdmin()(handler), authentication, rateLimit, logger, "AdminEndpoints", logKey)
+	}
+	return Endpoints{
+		ExportAnswersEndpoint:      factory(MakeExportAnswersEndpoint(transferService), "ExportAnswers"),
+		ImportAnswersEndpoint:      factory(MakeImportAnswersEndpoint(transferService), "ImportAnswers"),
+		ListDeletedAnswersEndpoint: factory(MakeListDeletedAnswersEndpoint(retentionService), "ListDeletedAnswers"),
+		EraseAnswersEndpoint:       factory(MakeEraseAnswersEndpoint(erasureService), "EraseAnswers"),
+	}
+}
+
+// MakeExportAnswersEndpoint Impl.
+func MakeExportAnswersEndpoint(service domain.AnswerTransferService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(ExportAnswersRequest)
+
+		// Call the service, the records are sent while they are read.
+		err = service.ExportAnswers(ctx, req.Checkpoint, req.Send)
+		return ExportAnswersResponse{
+			Err: err,
+		}, nil
+	}
+}
+
+// ExportAnswersRequest - request, the records are passed to Send as they are exported.
+type ExportAnswersRequest struct {
+	Checkpoint string
+	Send       func(record *domain.AnswerRecord, checkpoint string) error
+}
+
+// ExportAnswersResponse - response.
+type ExportAnswersResponse struct {
+	Err error
+}
+
+// MakeImportAnswersEndpoint Impl.
+func MakeImportAnswersEndpoint(service domain.AnswerTransferService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(ImportAnswersRequest)
+
+		// A failed record does not stop the import, it is reported in the summary.
+		//
+		resp := ImportAnswersResponse{}
+		for {
+			item, err := req.Next()
+			if err == io.EOF {
+				return resp, nil
+			}
+			if err != nil {
+				resp.Err = err
+				return resp, nil
+			}
+			err = item.Err
+			if err == nil {
+				err = service.ImportAnswer(ctx, item.Record, item.Mode)
+			}
+			if err != nil {
+				resp.Failures = append(resp.Failures, ImportFailure{Key: item.Key, Message: err.Error()})
+				continue
+			}
+			resp.Imported++
+		}
+	}
+}
+
+// ImportAnswersRequest - request, Next returns the records to import one by one and io.EOF after the last one.
+type ImportAnswersRequest struct {
+	Next func() (*ImportItem, error)
+}
+
+// ImportItem - a record to import, Err is set instead of the record if the received record is invalid.
+type ImportItem struct {
+	Key    string
+	Record *domain.AnswerRecord
+	Mode   domain.ImportMode
+	Err    error
+}
+
+// ImportAnswersResponse - response.
+type ImportAnswersResponse struct {
+	Imported int
+	Failures []ImportFailure
+	Err      error
+}
+
+// ImportFailure - a record which was not imported.
+type ImportFailure struct {
+	Key     string
+	Message string
+}
+
+// MakeListDeletedAnswersEndpoint Impl.
+func MakeListDeletedAnswersEndpoint(service domain.AnswerRetentionService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(ListDeletedAnswersRequest)
+
+		// Call the service.
+		answers, nextPageToken, err := service.ListDeletedAnswers(ctx, req.Page)
+		return ListDeletedAnswersResponse{
+			DeletedAnswers: answers,
+			NextPageToken:  nextPageToken,
+			Err:            err,
+		}, nil
+	}
+}
+
+// ListDeletedAnswersRequest - request.
+type ListDeletedAnswersRequest struct {
+	Page domain.PageRequest
+}
+
+// ListDeletedAnswersResponse - response.
+type ListDeletedAnswersResponse struct {
+	DeletedAnswers []*domain.DeletedAnswer
+	NextPageToken  string
+	Err            error
+}
+
+// MakeEraseAnswersEndpoint Impl.
+func MakeEraseAnswersEndpoint(service domain.AnswerErasureService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(EraseAnswersRequest)
+
+		// Call the service, the receipts record the client.
+		receipts, err := service.EraseAnswers(ctx, req.Keys, helpers.VerifiedSubject(ctx))
+		return EraseAnswersResponse{
+			Receipts: receipts,
+			Err:      err,
+		}, nil
+	}
+}
+
+// EraseAnswersRequest - request.
+type EraseAnswersRequest struct {
+	Keys []domain.AnswerKey
+}
+
+// EraseAnswersResponse - response.
+type EraseAnswersResponse struct {
+	Receipts []*domain.ErasureReceipt
+	Err      error
+}
+
+var (
+	_ endpoint.Failer = ExportAnswersResponse{}
+	_ endpoint.Failer = ImportAnswersResponse{}
+	_ endpoint.Failer = ListDeletedAnswersResponse{}
+	_ endpoint.Failer = EraseAnswersResponse{}
+)
+
+// Failed implements endpoint.Failer.
+func (r ExportAnswersResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r ImportAnswersResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r ListDeletedAnswersResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r EraseAnswersResponse) Failed() error { return r.Err }
@@ -0,0 +1,284 @@
+package admin
+
+import (
+	"context"
+	"io"
+
+	apiv1 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/answer"
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/helpers"
+
+	"github.com/go-kit/kit/endpoint"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"github.com/go-kit/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcServer - the streaming methods call the endpoints directly, go-kit transports only handle unary calls.
+type grpcServer struct {
+	exportAnswers      endpoint.Endpoint
+	importAnswers      endpoint.Endpoint
+	listDeletedAnswers grpctransport.Handler
+	eraseAnswers       grpctransport.Handler
+}
+
+// NewGRPCServer makes a set of endpoints available as a gRPC AdminServiceServer.
+func NewGRPCServer(endpoints Endpoints, logger log.Logger) apiv1.AdminServiceServer {
+	options := helpers.SetupServerOptions(logger)
+	return &grpcServer{
+		exportAnswers: endpoints.ExportAnswersEndpoint,
+		importAnswers: endpoints.ImportAnswersEndpoint,
+		listDeletedAnswers: grpctransport.NewServer(
+			endpoints.ListDeletedAnswersEndpoint,
+			decodeListDeletedAnswersRequest,
+			encodeListDeletedAnswersResponse,
+			options...,
+		),
+		eraseAnswers: grpctransport.NewServer(
+			endpoints.EraseAnswersEndpoint,
+			decodeEraseAnswersRequest,
+			encodeEraseAnswersResponse,
+			options...,
+		),
+	}
+}
+
+// ExportAnswers Impl.
+func (s *grpcServer) ExportAnswers(req *apiv1.ExportAnswersRequest, stream apiv1.AdminService_ExportAnswersServer) error {
+	resp, err := s.exportAnswers(helpers.StreamContext(stream.Context()), ExportAnswersRequest{
+		Checkpoint: req.Checkpoint,
+		Send: func(record *domain.AnswerRecord, checkpoint string) error {
+			encodedRecord, err := EncodeAnswerRecord(record)
+			if err != nil {
+				return err
+			}
+			return stream.Send(&apiv1.ExportAnswersResponse{
+				Record:     encodedRecord,
+				Checkpoint: checkpoint,
+			})
+		},
+	})
+	if err != nil {
+		return errors.GRPCErrorEncoder(err)
+	}
+	return errors.GRPCErrorEncoder(resp.(ExportAnswersResponse).Err)
+}
+
+// ImportAnswers Impl.
+func (s *grpcServer) ImportAnswers(stream apiv1.AdminService_ImportAnswersServer) error {
+
+	// The errors of the stream are returned as they are, not as the errors of the service.
+	//
+	var recvErr error
+	resp, err := s.importAnswers(helpers.StreamContext(stream.Context()), ImportAnswersRequest{
+		Next: func() (*ImportItem, error) {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				return nil, err
+			}
+			if err != nil {
+				recvErr = err
+				return nil, err
+			}
+			record, err := DecodeAnswerRecord(req.Record)
+			return &ImportItem{
+				Key:    req.GetRecord().GetKey(),
+				Record: record,
+				Mode:   decodeImportMode(req.Mode),
+				Err:    err,
+			}, nil
+		},
+	})
+	if recvErr != nil {
+		return recvErr
+	}
+	if err != nil {
+		return errors.GRPCErrorEncoder(err)
+	}
+	importResp := resp.(ImportAnswersResponse)
+	if importResp.Err != nil {
+		return errors.GRPCErrorEncoder(importResp.Err)
+	}
+	encodedResp := &apiv1.ImportAnswersResponse{
+		Imported: int32(importResp.Imported),
+		Failures: make([]*apiv1.ImportFailure, len(importResp.Failures)),
+	}
+	for i, f := range importResp.Failures {
+		encodedResp.Failures[i] = &apiv1.ImportFailure{
+			Key:     f.Key,
+			Message: f.Message,
+		}
+	}
+	return stream.SendAndClose(encodedResp)
+}
+
+// ListDeletedAnswers Impl.
+func (s *grpcServer) ListDeletedAnswers(ctx context.Context, req *apiv1.ListDeletedAnswersRequest) (*apiv1.ListDeletedAnswersResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.listDeletedAnswers)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.ListDeletedAnswersResponse), nil
+}
+
+func decodeListDeletedAnswersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.ListDeletedAnswersRequest)
+	return ListDeletedAnswersRequest{
+		Page: domain.PageRequest{
+			PageSize:  int(req.PageSize),
+			PageToken: req.PageToken,
+		},
+	}, nil
+}
+
+func encodeListDeletedAnswersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(ListDeletedAnswersResponse)
+	if resp.Err != nil {
+		return &apiv1.ListDeletedAnswersResponse{}, resp.Err
+	}
+	encodedResp := &apiv1.ListDeletedAnswersResponse{
+		DeletedAnswers: make([]*apiv1.DeletedAnswer, len(resp.DeletedAnswers)),
+		NextPageToken:  resp.NextPageToken,
+	}
+	for i, a := range resp.DeletedAnswers {
+		encodedAnswer, err := EncodeDeletedAnswer(a)
+		if err != nil {
+			return nil, err
+		}
+		encodedResp.DeletedAnswers[i] = encodedAnswer
+	}
+	return encodedResp, nil
+}
+
+// EraseAnswers Impl.
+func (s *grpcServer) EraseAnswers(ctx context.Context, req *apiv1.EraseAnswersRequest) (*apiv1.EraseAnswersResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.eraseAnswers)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.EraseAnswersResponse), nil
+}
+
+func decodeEraseAnswersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.EraseAnswersRequest)
+	keys := make([]domain.AnswerKey, len(req.Keys))
+	for i, key := range req.Keys {
+		keys[i] = domain.AnswerKey(key)
+	}
+	return EraseAnswersRequest{
+		Keys: keys,
+	}, nil
+}
+
+func encodeEraseAnswersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(EraseAnswersResponse)
+	if resp.Err != nil {
+		return &apiv1.EraseAnswersResponse{}, resp.Err
+	}
+	encodedResp := &apiv1.EraseAnswersResponse{
+		Receipts: make([]*apiv1.ErasureReceipt, len(resp.Receipts)),
+	}
+	for i, r := range resp.Receipts {
+		encodedReceipt, err := EncodeErasureReceipt(r)
+		if err != nil {
+			return nil, err
+		}
+		encodedResp.Receipts[i] = encodedReceipt
+	}
+	return encodedResp, nil
+}
+
+// DecodeAnswerRecord - converts the API answer record to the domain answer record.
+func DecodeAnswerRecord(record *apiv1.AnswerRecord) (*domain.AnswerRecord, error) {
+	if record == nil {
+		return nil, errors.NewErrInvalidArgument("Record required")
+	}
+	decodedRecord := &domain.AnswerRecord{
+		Key:    domain.AnswerKey(record.Key),
+		Events: make([]*domain.AnswerEvent, len(record.Events)),
+	}
+	if record.Answer != nil {
+		decodedAnswer, err := answer.DecodeAnswer(record.Answer)
+		if err != nil {
+			return nil, err
+		}
+		decodedRecord.Answer = decodedAnswer
+	}
+	for i, e := range record.Events {
+		decodedEvent, err := answer.DecodeAnswerEvent(e)
+		if err != nil {
+			return nil, err
+		}
+		decodedRecord.Events[i] = decodedEvent
+	}
+	return decodedRecord, nil
+}
+
+// EncodeAnswerRecord - converts the domain answer record to the API answer record.
+func EncodeAnswerRecord(record *domain.AnswerRecord) (*apiv1.AnswerRecord, error) {
+	if record == nil {
+		return nil, errors.NewErrInternal("Cannot encode nil value")
+	}
+	encodedRecord := &apiv1.AnswerRecord{
+		Key:    string(record.Key),
+		Events: make([]*apiv1.AnswerEvent, len(record.Events)),
+	}
+	if record.Answer != nil {
+		encodedAnswer, err := answer.EncodeAnswer(record.Answer)
+		if err != nil {
+			return nil, err
+		}
+		encodedRecord.Answer = encodedAnswer
+	}
+	for i, e := range record.Events {
+		encodedEvent, err := answer.EncodeAnswerEvent(e)
+		if err != nil {
+			return nil, err
+		}
+		encodedRecord.Events[i] = encodedEvent
+	}
+	return encodedRecord, nil
+}
+
+// EncodeDeletedAnswer - converts the domain deleted answer to the API deleted answer.
+func EncodeDeletedAnswer(deletedAnswer *domain.DeletedAnswer) (*apiv1.DeletedAnswer, error) {
+	if deletedAnswer == nil {
+		return nil, errors.NewErrInternal("Cannot encode nil value")
+	}
+	encodedAnswer, err := answer.EncodeAnswer(&deletedAnswer.Answer)
+	if err != nil {
+		return nil, err
+	}
+	return &apiv1.DeletedAnswer{
+		Answer:    encodedAnswer,
+		DeletedAt: timestamppb.New(deletedAnswer.DeletedAt),
+		PurgeAt:   timestamppb.New(deletedAnswer.PurgeAt),
+	}, nil
+}
+
+// EncodeErasureReceipt - converts the domain erasure receipt to the API erasure receipt.
+func EncodeErasureReceipt(receipt *domain.ErasureReceipt) (*apiv1.ErasureReceipt, error) {
+	if receipt == nil {
+		return nil, errors.NewErrInternal("Cannot encode nil value")
+	}
+	return &apiv1.ErasureReceipt{
+		KeyHash:      receipt.KeyHash,
+		ErasedAt:     timestamppb.New(receipt.ErasedAt),
+		AnswerErased: receipt.AnswerErased,
+		EventsErased: int32(receipt.EventsErased),
+		RequestedBy:  receipt.RequestedBy,
+	}, nil
+}
+
+func decodeImportMode(mode apiv1.ImportMode) domain.ImportMode {
+	switch mode {
+	case apiv1.ImportMode_IMPORT_MODE_REPLAY:
+		return domain.ReplayImportMode
+	case apiv1.ImportMode_IMPORT_MODE_PRESERVE:
+		return domain.PreserveImportMode
+	default:
+		return domain.ImportMode(mode.String())
+	}
+}
@@ -0,0 +1,147 @@
+package answer
+
+import (
+	"fmt"
+
+	apiv1 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DecodeAnswer - converts the API answer to the domain answer.
+func DecodeAnswer(answer *apiv1.Answer) (*domain.Answer, error) {
+	if answer == nil {
+		return nil, errors.NewErrInternal("Cannot decode nil value")
+	}
+	return &domain.Answer{
+		Key:   domain.AnswerKey(answer.Key),
+		Value: domain.AnswerValue(answer.Value),
+	}, nil
+}
+
+// EncodeAnswer - converts the domain answer to the API answer.
+func EncodeAnswer(answer *domain.Answer) (*apiv1.Answer, error) {
+	if answer == nil {
+		return nil, errors.NewErrInternal("Cannot encode nil value")
+	}
+	return &apiv1.Answer{
+		Key:   string(answer.Key),
+		Value: string(answer.Value),
+	}, nil
+}
+
+// DecodeAnswerEvent - converts the API answer event to the domain answer event.
+func DecodeAnswerEvent(event *apiv1.AnswerEvent) (*domain.AnswerEvent, error) {
+	if event == nil {
+		return nil, errors.NewErrInternal("Cannot decode nil value")
+	}
+	data, err := DecodeAnswer(event.Data)
+	if err != nil {
+		return nil, err
+	}
+	eventType, err := DecodeAnswerEventType(event.EventType)
+	if err != nil {
+		return nil, err
+	}
+	decodedEvent := &domain.AnswerEvent{
+		EventType: eventType,
+		Data:      data,
+	}
+	if event.OccurredAt != nil {
+		decodedEvent.OccurredAt = event.OccurredAt.AsTime()
+	}
+	return decodedEvent, nil
+}
+
+// EncodeAnswerEvent - converts the domain answer event to the API answer event.
+func EncodeAnswerEvent(event *domain.AnswerEvent) (*apiv1.AnswerEvent, error) {
+	if event == nil {
+		return nil, errors.NewErrInternal("Cannot encode nil value")
+	}
+	data, err := EncodeAnswer(event.Data)
+	if err != nil {
+		return nil, err
+	}
+	eventType, err := EncodeAnswerEventType(event.EventType)
+	if err != nil {
+		return nil, err
+	}
+	encodedEvent := &apiv1.AnswerEvent{
+		EventType: eventType,
+		Data:      data,
+	}
+	if !event.OccurredAt.IsZero() {
+		encodedEvent.OccurredAt = timestamppb.New(event.OccurredAt)
+	}
+	return encodedEvent, nil
+}
+
+// EncodeAnswerEventType - converts the domain event type to the API event type.
+func EncodeAnswerEventType(eventType domain.AnswerEventType) (apiv1.AnswerEventType, error) {
+	switch eventType {
+	case domain.CreateAnswerEventType:
+		return apiv1.AnswerEventType_ANSWER_EVENT_TYPE_CREATE, nil
+	case domain.UpdateAnswerEventType:
+		return apiv1.AnswerEventType_ANSWER_EVENT_TYPE_UPDATE, nil
+	case domain.DeleteAnswerEventType:
+		return apiv1.AnswerEventType_ANSWER_EVENT_TYPE_DELETE, nil
+	case domain.RestoreAnswerEventType:
+		return apiv1.AnswerEventType_ANSWER_EVENT_TYPE_RESTORE, nil
+	case domain.EraseAnswerEventType:
+		return apiv1.AnswerEventType_ANSWER_EVENT_TYPE_ERASE, nil
+	default:
+		return apiv1.AnswerEventType_ANSWER_EVENT_TYPE_UNKNOWN, fmt.Errorf("Unknown event type %v", eventType)
+	}
+}
+
+// DecodeAnswerEventType - converts the API event type to the domain event type.
+func DecodeAnswerEventType(eventType apiv1.AnswerEventType) (domain.AnswerEventType, error) {
+	switch eventType {
+	case apiv1.AnswerEventType_ANSWER_EVENT_TYPE_CREATE:
+		return domain.CreateAnswerEventType, nil
+	case apiv1.AnswerEventType_ANSWER_EVENT_TYPE_UPDATE:
+		return domain.UpdateAnswerEventType, nil
+	case apiv1.AnswerEventType_ANSWER_EVENT_TYPE_DELETE:
+		return domain.DeleteAnswerEventType, nil
+	case apiv1.AnswerEventType_ANSWER_EVENT_TYPE_RESTORE:
+		return domain.RestoreAnswerEventType, nil
+	case apiv1.AnswerEventType_ANSWER_EVENT_TYPE_ERASE:
+		return domain.EraseAnswerEventType, nil
+	default:
+		return "", errors.NewErrInvalidArgument(fmt.Sprintf("Unknown event type %v", eventType))
+	}
+}
+
+// DecodeAnswerSearchTerm - converts the API search term to the domain search term, an absent term is nil.
+func DecodeAnswerSearchTerm(term *apiv1.AnswerSearchTerm) (*domain.AnswerSearchTerm, error) {
+	if term == nil {
+		return nil, nil
+	}
+	match, err := DecodeAnswerMatchType(term.Match)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AnswerSearchTerm{
+		Text:      term.Text,
+		Match:     match,
+		Fuzziness: int(term.Fuzziness),
+	}, nil
+}
+
+// DecodeAnswerMatchType - converts the API match type to the domain match type.
+func DecodeAnswerMatchType(match apiv1.AnswerMatchType) (domain.AnswerMatchType, error) {
+	switch match {
+	case apiv1.AnswerMatchType_ANSWER_MATCH_TYPE_EXACT:
+		return domain.ExactAnswerMatch, nil
+	case apiv1.AnswerMatchType_ANSWER_MATCH_TYPE_PREFIX:
+		return domain.PrefixAnswerMatch, nil
+	case apiv1.AnswerMatchType_ANSWER_MATCH_TYPE_SUBSTRING:
+		return domain.SubstringAnswerMatch, nil
+	case apiv1.AnswerMatchType_ANSWER_MATCH_TYPE_FUZZY:
+		return domain.FuzzyAnswerMatch, nil
+	default:
+		return "", errors.NewErrInvalidArgument(fmt.Sprintf("Unknown match type %v", match))
+	}
+}
@@ -0,0 +1,68 @@
+package answer
+
+import (
+	"fmt"
+	"strings"
+
+	apiv2 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v2"
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// AnswerNamePrefix - the prefix of the v2 resource names of the answers.
+const AnswerNamePrefix = "answers/"
+
+// Updatable fields of the v2 answer.
+const (
+	updateMaskAll   = "*"
+	updateMaskValue = "value"
+)
+
+// EncodeAnswerName - returns the resource name of the answer with the key.
+func EncodeAnswerName(key domain.AnswerKey) string {
+	return AnswerNamePrefix + string(key)
+}
+
+// DecodeAnswerName - returns the key of the answer with the resource name.
+func DecodeAnswerName(name string) (domain.AnswerKey, error) {
+	if !strings.HasPrefix(name, AnswerNamePrefix) || len(name) == len(AnswerNamePrefix) {
+		return "", errors.NewErrInvalidArgument(fmt.Sprintf("Name %q is not valid, it must be %v{key}", name, AnswerNamePrefix))
+	}
+	return domain.AnswerKey(strings.TrimPrefix(name, AnswerNamePrefix)), nil
+}
+
+// EncodeAnswerV2 - converts the domain answer to the v2 API answer.
+func EncodeAnswerV2(answer *domain.Answer) (*apiv2.Answer, error) {
+	if answer == nil {
+		return nil, errors.NewErrInternal("Cannot encode nil value")
+	}
+	return &apiv2.Answer{
+		Name:  EncodeAnswerName(answer.Key),
+		Value: string(answer.Value),
+	}, nil
+}
+
+// DecodeAnswerUpdate - converts the v2 API answer to the domain answer to update with, all the fields are updated
+// without an update mask. The mask may only hold the value, the only field which can be updated.
+func DecodeAnswerUpdate(answer *apiv2.Answer, mask *fieldmaskpb.FieldMask) (*domain.Answer, error) {
+	if answer == nil {
+		return nil, errors.NewErrInvalidArgument("Answer required")
+	}
+	key, err := DecodeAnswerName(answer.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range mask.GetPaths() {
+		switch path {
+		case updateMaskAll, updateMaskValue:
+		default:
+			return nil, errors.NewErrInvalidArgument(fmt.Sprintf("Update mask path %q is not valid, only %q can be updated", path, updateMaskValue))
+		}
+	}
+	return &domain.Answer{
+		Key:   key,
+		Value: domain.AnswerValue(answer.Value),
+	}, nil
+}
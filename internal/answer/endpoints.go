@@ -16,23 +16,39 @@ import (
 type Endpoints struct {
 	CreateAnswerEndpoint     endpoint.Endpoint
 	UpdateAnswerEndpoint     endpoint.Endpoint
+	UpsertAnswerEndpoint     endpoint.Endpoint
 	DeleteAnswerEndpoint     endpoint.Endpoint
+	RestoreAnswerEndpoint    endpoint.Endpoint
 	GetAnswerEndpoint        endpoint.Endpoint
 	GetAnswerHistoryEndpoint endpoint.Endpoint
+	GetAnswerAtEndpoint      endpoint.Endpoint
+	ListAnswersEndpoint      endpoint.Endpoint
+	SearchAnswersEndpoint    endpoint.Endpoint
 }
 
 // NewEndpoint returns a Set that wraps the provided server, and wires in all of the
 // expected endpoint middlewares via the various parameters.
-func NewEndpoint(service domain.AnswerService, logger log.Logger) Endpoints {
+// The tokens are verified, unless authentication is nil, the mutations replay their responses for the retried
+// idempotency keys, unless idempotency is nil, and the requests are rate limited, unless rateLimit is nil.
+func NewEndpoint(service domain.AnswerService, searchService domain.AnswerSearchService, authentication *helpers.Authentication,
+	idempotency *helpers.Idempotency, rateLimit *helpers.RateLimit, logger log.Logger) Endpoints {
 	factory := func(creator func(domain.AnswerService) endpoint.Endpoint, logKey string) endpoint.Endpoint {
-		return helpers.SetupEndpoint(creator(service), logger, "AnswerEndpoints", logKey)
+		return helpers.SetupEndpoint(creator(service), authentication, rateLimit, logger, "AnswerEndpoints", logKey)
+	}
+	idempotent := func(creator func(domain.AnswerService) endpoint.Endpoint, logKey string, response interface{}) endpoint.Endpoint {
+		return helpers.SetupEndpoint(idempotency.Middleware(logKey, response)(creator(service)), authentication, rateLimit, logger, "AnswerEndpoints", logKey)
 	}
 	return Endpoints{
-		CreateAnswerEndpoint:     factory(MakeCreateAnswerEndpoint, "CreateAnswer"),
-		UpdateAnswerEndpoint:     factory(MakeUpdateAnswerEndpoint, "UpdateAnswer"),
-		DeleteAnswerEndpoint:     factory(MakeDeleteAnswerEndpoint, "DeleteAnswer"),
+		CreateAnswerEndpoint:     idempotent(MakeCreateAnswerEndpoint, "CreateAnswer", CreateAnswerResponse{}),
+		UpdateAnswerEndpoint:     idempotent(MakeUpdateAnswerEndpoint, "UpdateAnswer", UpdateAnswerResponse{}),
+		UpsertAnswerEndpoint:     idempotent(MakeUpsertAnswerEndpoint, "UpsertAnswer", UpsertAnswerResponse{}),
+		DeleteAnswerEndpoint:     idempotent(MakeDeleteAnswerEndpoint, "DeleteAnswer", DeleteAnswerResponse{}),
+		RestoreAnswerEndpoint:    factory(MakeRestoreAnswerEndpoint, "RestoreAnswer"),
 		GetAnswerEndpoint:        factory(MakeGetAnswerEndpoint, "GetAnswer"),
 		GetAnswerHistoryEndpoint: factory(MakeGetAnswerHistoryEndpoint, "GetAnswerHistory"),
+		GetAnswerAtEndpoint:      factory(MakeGetAnswerAtEndpoint, "GetAnswerAt"),
+		ListAnswersEndpoint:      factory(MakeListAnswersEndpoint, "ListAnswers"),
+		SearchAnswersEndpoint:    helpers.SetupEndpoint(MakeSearchAnswersEndpoint(searchService), authentication, rateLimit, logger, "AnswerEndpoints", "SearchAnswers"),
 	}
 }
 
@@ -42,9 +58,10 @@ func MakeCreateAnswerEndpoint(service domain.AnswerService) endpoint.Endpoint {
 		req := request.(CreateAnswerRequest)
 
 		// Call the service.
-		err = service.CreateAnswer(ctx, req.Answer)
+		mutation, err := service.CreateAnswer(ctx, req.Answer)
 		return CreateAnswerResponse{
-			Err: err,
+			Mutation: mutation,
+			Err:      err,
 		}, nil
 	}
 }
@@ -54,9 +71,18 @@ type CreateAnswerRequest struct {
 	Answer *domain.Answer
 }
 
+// AnswerKey - returns the key of the changed answer, the stored response is erased with it.
+func (r CreateAnswerRequest) AnswerKey() domain.AnswerKey {
+	if r.Answer == nil {
+		return ""
+	}
+	return r.Answer.Key
+}
+
 // CreateAnswerResponse - response.
 type CreateAnswerResponse struct {
-	Err error
+	Mutation *domain.AnswerMutation
+	Err      error
 }
 
 // MakeUpdateAnswerEndpoint Impl.
@@ -65,9 +91,10 @@ func MakeUpdateAnswerEndpoint(service domain.AnswerService) endpoint.Endpoint {
 		req := request.(UpdateAnswerRequest)
 
 		// Call the service.
-		err = service.UpdateAnswer(ctx, req.Answer)
+		mutation, err := service.UpdateAnswer(ctx, req.Answer)
 		return UpdateAnswerResponse{
-			Err: err,
+			Mutation: mutation,
+			Err:      err,
 		}, nil
 	}
 }
@@ -77,9 +104,51 @@ type UpdateAnswerRequest struct {
 	Answer *domain.Answer
 }
 
+// AnswerKey - returns the key of the changed answer, the stored response is erased with it.
+func (r UpdateAnswerRequest) AnswerKey() domain.AnswerKey {
+	if r.Answer == nil {
+		return ""
+	}
+	return r.Answer.Key
+}
+
 // UpdateAnswerResponse - response.
 type UpdateAnswerResponse struct {
-	Err error
+	Mutation *domain.AnswerMutation
+	Err      error
+}
+
+// MakeUpsertAnswerEndpoint Impl.
+func MakeUpsertAnswerEndpoint(service domain.AnswerService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(UpsertAnswerRequest)
+
+		// Call the service.
+		mutation, err := service.UpsertAnswer(ctx, req.Answer)
+		return UpsertAnswerResponse{
+			Mutation: mutation,
+			Err:      err,
+		}, nil
+	}
+}
+
+// UpsertAnswerRequest - request.
+type UpsertAnswerRequest struct {
+	Answer *domain.Answer
+}
+
+// AnswerKey - returns the key of the changed answer, the stored response is erased with it.
+func (r UpsertAnswerRequest) AnswerKey() domain.AnswerKey {
+	if r.Answer == nil {
+		return ""
+	}
+	return r.Answer.Key
+}
+
+// UpsertAnswerResponse - response.
+type UpsertAnswerResponse struct {
+	Mutation *domain.AnswerMutation
+	Err      error
 }
 
 // MakeDeleteAnswerEndpoint Impl.
@@ -88,9 +157,10 @@ func MakeDeleteAnswerEndpoint(service domain.AnswerService) endpoint.Endpoint {
 		req := request.(DeleteAnswerRequest)
 
 		// Call the service.
-		err = service.DeleteAnswer(ctx, req.Key)
+		mutation, err := service.DeleteAnswer(ctx, req.Key)
 		return DeleteAnswerResponse{
-			Err: err,
+			Mutation: mutation,
+			Err:      err,
 		}, nil
 	}
 }
@@ -100,9 +170,41 @@ type DeleteAnswerRequest struct {
 	Key domain.AnswerKey
 }
 
+// AnswerKey - returns the key of the deleted answer, the stored response is erased with it.
+func (r DeleteAnswerRequest) AnswerKey() domain.AnswerKey {
+	return r.Key
+}
+
 // DeleteAnswerResponse - response.
 type DeleteAnswerResponse struct {
-	Err error
+	Mutation *domain.AnswerMutation
+	Err      error
+}
+
+// MakeRestoreAnswerEndpoint Impl.
+func MakeRestoreAnswerEndpoint(service domain.AnswerService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(RestoreAnswerRequest)
+
+		// Call the service.
+		mutation, err := service.RestoreAnswer(ctx, req.Key, req.Version)
+		return RestoreAnswerResponse{
+			Mutation: mutation,
+			Err:      err,
+		}, nil
+	}
+}
+
+// RestoreAnswerRequest - request.
+type RestoreAnswerRequest struct {
+	Key     domain.AnswerKey
+	Version int
+}
+
+// RestoreAnswerResponse - response.
+type RestoreAnswerResponse struct {
+	Mutation *domain.AnswerMutation
+	Err      error
 }
 
 // MakeGetAnswerEndpoint Impl.
@@ -159,6 +261,93 @@ type GetAnswerHistoryResponse struct {
 	Err    error
 }
 
+// MakeGetAnswerAtEndpoint Impl.
+func MakeGetAnswerAtEndpoint(service domain.AnswerService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetAnswerAtRequest)
+
+		// Call the service.
+		snapshot, err := service.GetAnswerAt(ctx, req.Key, req.At)
+		if err != nil {
+			return nil, err
+		}
+		return GetAnswerAtResponse{
+			Snapshot: snapshot,
+		}, nil
+	}
+}
+
+// GetAnswerAtRequest request.
+type GetAnswerAtRequest struct {
+	Key domain.AnswerKey
+	At  domain.AnswerPoint
+}
+
+// GetAnswerAtResponse response.
+type GetAnswerAtResponse struct {
+	Snapshot *domain.AnswerSnapshot
+	Err      error
+}
+
+// MakeListAnswersEndpoint Impl.
+func MakeListAnswersEndpoint(service domain.AnswerService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(ListAnswersRequest)
+
+		// Call the service.
+		answers, nextPageToken, err := service.ListAnswers(ctx, req.Page)
+		if err != nil {
+			return nil, err
+		}
+		return ListAnswersResponse{
+			Answers:       answers,
+			NextPageToken: nextPageToken,
+		}, nil
+	}
+}
+
+// ListAnswersRequest request.
+type ListAnswersRequest struct {
+	Page domain.PageRequest
+}
+
+// ListAnswersResponse response.
+type ListAnswersResponse struct {
+	Answers       []*domain.Answer
+	NextPageToken string
+	Err           error
+}
+
+// MakeSearchAnswersEndpoint Impl.
+func MakeSearchAnswersEndpoint(service domain.AnswerSearchService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(SearchAnswersRequest)
+
+		// Call the service.
+		answers, nextPageToken, err := service.SearchAnswers(ctx, req.Query, req.Page)
+		if err != nil {
+			return nil, err
+		}
+		return SearchAnswersResponse{
+			Answers:       answers,
+			NextPageToken: nextPageToken,
+		}, nil
+	}
+}
+
+// SearchAnswersRequest request.
+type SearchAnswersRequest struct {
+	Query domain.AnswerSearchQuery
+	Page  domain.PageRequest
+}
+
+// SearchAnswersResponse response.
+type SearchAnswersResponse struct {
+	Answers       []*domain.Answer
+	NextPageToken string
+	Err           error
+}
+
 // //
 //
 // Error interceptors.
@@ -169,9 +358,14 @@ type GetAnswerHistoryResponse struct {
 var (
 	_ endpoint.Failer = CreateAnswerResponse{}
 	_ endpoint.Failer = UpdateAnswerResponse{}
+	_ endpoint.Failer = UpsertAnswerResponse{}
 	_ endpoint.Failer = DeleteAnswerResponse{}
+	_ endpoint.Failer = RestoreAnswerResponse{}
 	_ endpoint.Failer = GetAnswerResponse{}
 	_ endpoint.Failer = GetAnswerHistoryResponse{}
+	_ endpoint.Failer = GetAnswerAtResponse{}
+	_ endpoint.Failer = ListAnswersResponse{}
+	_ endpoint.Failer = SearchAnswersResponse{}
 )
 
 // Failed implements endpoint.Failer.
@@ -180,11 +374,26 @@ func (r CreateAnswerResponse) Failed() error { return r.Err }
 // Failed implements endpoint.Failer.
 func (r UpdateAnswerResponse) Failed() error { return r.Err }
 
+// Failed implements endpoint.Failer.
+func (r UpsertAnswerResponse) Failed() error { return r.Err }
+
 // Failed implements endpoint.Failer.
 func (r DeleteAnswerResponse) Failed() error { return r.Err }
 
+// Failed implements endpoint.Failer.
+func (r RestoreAnswerResponse) Failed() error { return r.Err }
+
 // Failed implements endpoint.Failer.
 func (r GetAnswerResponse) Failed() error { return r.Err }
 
 // Failed implements endpoint.Failer.
 func (r GetAnswerHistoryResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetAnswerAtResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r ListAnswersResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r SearchAnswersResponse) Failed() error { return r.Err }
@@ -0,0 +1,259 @@
+package answer
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+// fakeAnswerRepository - in-memory repository with injectable errors.
+type fakeAnswerRepository struct {
+	mu      sync.Mutex
+	answers map[domain.AnswerKey]domain.Answer
+	deleted map[domain.AnswerKey]domain.DeletedAnswer
+	errs    map[string]error
+}
+
+func newFakeAnswerRepository(answers ...*domain.Answer) *fakeAnswerRepository {
+	r := &fakeAnswerRepository{
+		answers: make(map[domain.AnswerKey]domain.Answer),
+		deleted: make(map[domain.AnswerKey]domain.DeletedAnswer),
+		errs:    make(map[string]error),
+	}
+	for _, a := range answers {
+		r.answers[a.Key] = *a
+	}
+	return r
+}
+
+func (r *fakeAnswerRepository) Create(answer *domain.Answer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["Create"]; err != nil {
+		return err
+	}
+	if _, ok := r.answers[answer.Key]; ok {
+		return errors.NewErrAlreadyExist("Answer already exists")
+	}
+	answer.Version = r.nextVersion(answer.Key, answer.Version)
+	delete(r.deleted, answer.Key)
+	r.answers[answer.Key] = *answer
+	return nil
+}
+
+func (r *fakeAnswerRepository) Update(answer *domain.Answer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["Update"]; err != nil {
+		return err
+	}
+	if _, ok := r.answers[answer.Key]; !ok {
+		return errors.NewErrNotFound("Answer not found")
+	}
+	answer.Version = r.nextVersion(answer.Key, 0)
+	r.answers[answer.Key] = *answer
+	return nil
+}
+
+func (r *fakeAnswerRepository) Upsert(answer *domain.Answer) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["Upsert"]; err != nil {
+		return false, err
+	}
+	_, ok := r.answers[answer.Key]
+	answer.Version = r.nextVersion(answer.Key, answer.Version)
+	delete(r.deleted, answer.Key)
+	r.answers[answer.Key] = *answer
+	return !ok, nil
+}
+
+func (r *fakeAnswerRepository) Delete(key domain.AnswerKey, tombstone domain.Tombstone) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["Delete"]; err != nil {
+		return 0, err
+	}
+	answer, ok := r.answers[key]
+	if !ok {
+		return 0, errors.NewErrNotFound("Answer not found")
+	}
+	answer.Version = r.nextVersion(key, 0)
+	delete(r.answers, key)
+	r.deleted[key] = domain.DeletedAnswer{Answer: answer, Tombstone: tombstone}
+	return answer.Version, nil
+}
+
+// nextVersion - counts on from the stored version of the key or from the given one, the caller must hold the lock.
+func (r *fakeAnswerRepository) nextVersion(key domain.AnswerKey, version int) int {
+	if answer, ok := r.answers[key]; ok && answer.Version > 0 {
+		version = answer.Version
+	} else if answer, ok := r.deleted[key]; ok && answer.Version > 0 {
+		version = answer.Version
+	}
+	return version + 1
+}
+
+func (r *fakeAnswerRepository) Get(key domain.AnswerKey) (*domain.Answer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["Get"]; err != nil {
+		return nil, err
+	}
+	answer, ok := r.answers[key]
+	if !ok {
+		return nil, errors.NewErrNotFound("Answer not found")
+	}
+	return &answer, nil
+}
+
+func (r *fakeAnswerRepository) List(page domain.PageRequest) ([]*domain.Answer, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["List"]; err != nil {
+		return nil, "", err
+	}
+	var items []*domain.Answer
+	for _, a := range r.answers {
+		answer := a
+		items = append(items, &answer)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+	return items, "", nil
+}
+
+func (r *fakeAnswerRepository) ListDeleted(page domain.PageRequest) ([]*domain.DeletedAnswer, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["ListDeleted"]; err != nil {
+		return nil, "", err
+	}
+	var items []*domain.DeletedAnswer
+	for _, a := range r.deleted {
+		answer := a
+		items = append(items, &answer)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+	return items, "", nil
+}
+
+func (r *fakeAnswerRepository) Purge(key domain.AnswerKey, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["Purge"]; err != nil {
+		return err
+	}
+	answer, ok := r.deleted[key]
+	if !ok || !answer.IsDue(now) {
+		return errors.NewErrNotFound("Deleted answer not found")
+	}
+	delete(r.deleted, key)
+	return nil
+}
+
+func (r *fakeAnswerRepository) Erase(key domain.AnswerKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["Erase"]; err != nil {
+		return err
+	}
+	_, ok := r.answers[key]
+	_, deleted := r.deleted[key]
+	if !ok && !deleted {
+		return errors.NewErrNotFound("Answer not found")
+	}
+	delete(r.answers, key)
+	delete(r.deleted, key)
+	return nil
+}
+
+// fakeAnswerEventRepository - in-memory repository with injectable errors.
+type fakeAnswerEventRepository struct {
+	mu     sync.Mutex
+	events []*domain.AnswerEvent
+	errs   map[string]error
+}
+
+func newFakeAnswerEventRepository(events ...*domain.AnswerEvent) *fakeAnswerEventRepository {
+	return &fakeAnswerEventRepository{
+		events: events,
+		errs:   make(map[string]error),
+	}
+}
+
+func (r *fakeAnswerEventRepository) Create(answerEvent *domain.AnswerEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["Create"]; err != nil {
+		return err
+	}
+	r.events = append(r.events, answerEvent)
+	return nil
+}
+
+func (r *fakeAnswerEventRepository) ListEvents(key domain.AnswerKey) ([]*domain.AnswerEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["ListEvents"]; err != nil {
+		return nil, err
+	}
+	var items []*domain.AnswerEvent
+	for _, e := range r.events {
+		if e.Data.Key == key {
+			items = append(items, e)
+		}
+	}
+	return items, nil
+}
+
+func (r *fakeAnswerEventRepository) ListEventsPage(key domain.AnswerKey, page domain.PageRequest) ([]*domain.AnswerEvent, string, error) {
+	items, err := r.ListEvents(key)
+	return items, "", err
+}
+
+func (r *fakeAnswerEventRepository) ListKeys(page domain.PageRequest) ([]domain.AnswerKey, string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["ListKeys"]; err != nil {
+		return nil, "", err
+	}
+	var keys []domain.AnswerKey
+	seen := make(map[domain.AnswerKey]bool)
+	for _, e := range r.events {
+		if !seen[e.Data.Key] {
+			seen[e.Data.Key] = true
+			keys = append(keys, e.Data.Key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys, "", nil
+}
+
+func (r *fakeAnswerEventRepository) EraseEvents(key domain.AnswerKey, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.errs["EraseEvents"]; err != nil {
+		return 0, err
+	}
+	var (
+		kept   []*domain.AnswerEvent
+		erased int
+	)
+	for _, e := range r.events {
+		if e.Data.Key == key && e.OccurredAt.Before(before) {
+			erased++
+			continue
+		}
+		kept = append(kept, e)
+	}
+	r.events = kept
+	return erased, nil
+}
+
+var (
+	_ domain.AnswerRepository      = &fakeAnswerRepository{}
+	_ domain.AnswerEventRepository = &fakeAnswerEventRepository{}
+)
@@ -2,29 +2,38 @@ package answer
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"dochq.co.uk.answerservice/internal/domain"
 	errors "dochq.co.uk.answerservice/internal/error"
 
 	"github.com/go-kit/log"
+	"github.com/nats-io/nuid"
 )
 
 type service struct {
-	repository      domain.AnswerRepository
+	repository      domain.TaggedAnswerRepository
 	eventRepository domain.AnswerEventRepository
 	queueService    domain.QueueService
 	eventQueueName  string
+	retention       time.Duration
+	now             func() time.Time
+	newEventID      func() string
 }
 
 // NewService creates a new service with necessary dependencies.
+// The queue service is nil if the answer events are captured from the change stream of the answer table,
+// the service then sends no events and tags the writes with the events, so the stream reports them.
 func NewService(repository domain.AnswerRepository,
 	eventRepository domain.AnswerEventRepository,
 	queueService domain.QueueService,
 	eventQueueName string,
+	deletedAnswerRetention time.Duration,
 	logger log.Logger) domain.AnswerService {
 	var service domain.AnswerService
 	{
-		service = newBasicService(repository, eventRepository, queueService, eventQueueName)
+		service = newBasicService(repository, eventRepository, queueService, eventQueueName, deletedAnswerRetention)
 		service = LoggingServiceMiddleware(logger)(service)
 	}
 	return service
@@ -34,121 +43,222 @@ func NewService(repository domain.AnswerRepository,
 func newBasicService(repository domain.AnswerRepository,
 	eventRepository domain.AnswerEventRepository,
 	queueService domain.QueueService,
-	eventQueueName string) domain.AnswerService {
+	eventQueueName string,
+	deletedAnswerRetention time.Duration) domain.AnswerService {
 	return &service{
-		repository:      repository,
+		repository:      domain.NewTaggedAnswerRepository(repository),
 		eventRepository: eventRepository,
 		queueService:    queueService,
 		eventQueueName:  eventQueueName,
+		retention:       deletedAnswerRetention,
+		now:             time.Now,
+		newEventID:      nuid.Next,
 	}
 }
 
-func (s *service) CreateAnswer(ctx context.Context, answer *domain.Answer) error {
+func (s *service) CreateAnswer(ctx context.Context, answer *domain.Answer) (*domain.AnswerMutation, error) {
 
 	// Check for nil.
 	//
 	if answer == nil {
-		return errors.NewErrInvalidArgument("Answer required")
+		return nil, errors.NewErrInvalidArgument("Answer required")
 	}
 
 	// Validate fields.
 	//
 	if err := answer.Validate(); err != nil {
-		return errors.NewErrInvalidArgument(err.Error())
+		return nil, errors.NewErrInvalidArgument(err.Error())
 	}
 
 	// If the answer already exist, we must return an error.
 	//
-	foundAnswer, _ := s.repository.Get(answer.Key)
+	foundAnswer, err := s.findAnswer(answer.Key)
+	if err != nil {
+		return nil, err
+	}
 	if foundAnswer != nil {
-		return errors.NewErrAlreadyExist("Answer with the provided key is already in use")
+		return nil, errors.NewErrAlreadyExist("Answer with the provided key is already in use")
 	}
 
-	// Create answer.
+	// Create answer, it counts on from its history.
 	//
-	if err := s.repository.Create(answer); err != nil {
-		return err
+	if answer.Version, err = s.lastVersion(answer.Key); err != nil {
+		return nil, err
+	}
+	tag := s.newTag(domain.CreateAnswerEventType)
+	if err := s.repository.CreateTagged(answer, tag); err != nil {
+		return nil, err
 	}
 
 	// Send event message.
 	//
-	_, err := s.queueService.SendMessage(ctx, s.eventQueueName, &domain.AnswerEventMessage{
-		Event: &domain.AnswerEvent{
-			EventType: domain.CreateAnswerEventType,
-			Data:      answer,
-		},
-	})
-	return err
+	return s.sendEvent(ctx, tag, answer)
 }
 
-func (s *service) UpdateAnswer(ctx context.Context, answer *domain.Answer) error {
+func (s *service) UpdateAnswer(ctx context.Context, answer *domain.Answer) (*domain.AnswerMutation, error) {
 
 	// Check for nil.
 	//
 	if answer == nil {
-		return errors.NewErrInvalidArgument("Answer required")
+		return nil, errors.NewErrInvalidArgument("Answer required")
 	}
 
 	// Validate fields.
 	//
 	if err := answer.Validate(); err != nil {
-		return errors.NewErrInvalidArgument(err.Error())
+		return nil, errors.NewErrInvalidArgument(err.Error())
 	}
 
 	// If the answer does not exist, we must return an error.
 	//
-	foundAnswer, _ := s.repository.Get(answer.Key)
+	foundAnswer, err := s.findAnswer(answer.Key)
+	if err != nil {
+		return nil, err
+	}
 	if foundAnswer == nil {
-		return errors.NewErrNotFound("Answer with the provided key not found")
+		return nil, errors.NewErrNotFound("Answer with the provided key not found")
 	}
 
 	// Update answer.
 	//
-	if err := s.repository.Update(answer); err != nil {
-		return err
+	tag := s.newTag(domain.UpdateAnswerEventType)
+	if err := s.repository.UpdateTagged(answer, tag); err != nil {
+		return nil, err
 	}
 
 	// Send event message.
 	//
-	_, err := s.queueService.SendMessage(ctx, s.eventQueueName, &domain.AnswerEventMessage{
-		Event: &domain.AnswerEvent{
-			EventType: domain.UpdateAnswerEventType,
-			Data:      answer,
-		},
-	})
-	return err
+	return s.sendEvent(ctx, tag, answer)
 }
 
-func (s *service) DeleteAnswer(ctx context.Context, key domain.AnswerKey) error {
+func (s *service) UpsertAnswer(ctx context.Context, answer *domain.Answer) (*domain.AnswerMutation, error) {
+
+	// Check for nil.
+	//
+	if answer == nil {
+		return nil, errors.NewErrInvalidArgument("Answer required")
+	}
+
+	// Validate fields.
+	//
+	if err := answer.Validate(); err != nil {
+		return nil, errors.NewErrInvalidArgument(err.Error())
+	}
+
+	// Create or replace the answer in one step, the repository tells which of them it was.
+	// A created answer counts on from its history.
+	//
+	foundAnswer, err := s.findAnswer(answer.Key)
+	if err != nil {
+		return nil, err
+	}
+	answer.Version = 0
+	if foundAnswer == nil {
+		if answer.Version, err = s.lastVersion(answer.Key); err != nil {
+			return nil, err
+		}
+	}
+	tag := s.newTag("")
+	created, err := s.repository.UpsertTagged(answer, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send event message.
+	//
+	tag.EventType = domain.UpdateAnswerEventType
+	if created {
+		tag.EventType = domain.CreateAnswerEventType
+	}
+	return s.sendEvent(ctx, tag, answer)
+}
+
+func (s *service) DeleteAnswer(ctx context.Context, key domain.AnswerKey) (*domain.AnswerMutation, error) {
 
 	// Check key.
 	//
 	if len(key) == 0 {
-		return errors.NewErrInvalidArgument("AnswerKey required")
+		return nil, errors.NewErrInvalidArgument("AnswerKey required")
 	}
 
 	// If the answer does not exist, we must return an error.
 	//
-	foundAnswer, _ := s.repository.Get(key)
+	foundAnswer, err := s.findAnswer(key)
+	if err != nil {
+		return nil, err
+	}
 	if foundAnswer == nil {
-		return errors.NewErrNotFound("Answer with the provided key not found")
+		return nil, errors.NewErrNotFound("Answer with the provided key not found")
 	}
 
-	// Delete answer.
+	// Delete answer, it is kept for the retention period.
 	//
-	if err := s.repository.Delete(key); err != nil {
-		return err
+	tag := s.newTag(domain.DeleteAnswerEventType)
+	foundAnswer.Version, err = s.repository.DeleteTagged(key, domain.NewTombstone(tag.OccurredAt, s.retention), tag)
+	if err != nil {
+		return nil, err
 	}
 
 	// Send event message.
 	//
-	_, err := s.queueService.SendMessage(ctx, s.eventQueueName, &domain.AnswerEventMessage{
-		Event: &domain.AnswerEvent{
-			EventType: domain.DeleteAnswerEventType,
-			Data:      foundAnswer,
-		},
-	})
-	return err
+	return s.sendEvent(ctx, tag, foundAnswer)
+}
+
+func (s *service) RestoreAnswer(ctx context.Context, key domain.AnswerKey, version int) (*domain.AnswerMutation, error) {
+
+	// Check arguments.
+	//
+	if len(key) == 0 {
+		return nil, errors.NewErrInvalidArgument("AnswerKey required")
+	}
+	if version < 0 {
+		return nil, errors.NewErrInvalidArgument("Version must not be negative")
+	}
+
+	// Find the value of the version, a delete event holds the deleted value.
+	//
+	events, err := s.eventRepository.ListEvents(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errors.NewErrNotFound("Answer history not found")
+	}
+	event := events[len(events)-1]
+	if version > 0 {
+		event = findVersion(events, version)
+	}
+	if event == nil {
+		return nil, errors.NewErrNotFound("Answer version not found")
+	}
+	if event.EventType == domain.EraseAnswerEventType {
+		return nil, errors.NewErrFailedPrecondition("Answer version was erased")
+	}
+	restoredAnswer := &domain.Answer{
+		Key:   key,
+		Value: event.Data.Value,
+	}
+
+	// Recreate or roll back the answer, a recreated answer counts on from its history.
+	//
+	foundAnswer, err := s.findAnswer(key)
+	if err != nil {
+		return nil, err
+	}
+	tag := s.newTag(domain.RestoreAnswerEventType)
+	if foundAnswer == nil {
+		restoredAnswer.Version = domain.LastAnswerVersion(events)
+		err = s.repository.CreateTagged(restoredAnswer, tag)
+	} else {
+		err = s.repository.UpdateTagged(restoredAnswer, tag)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Send event message.
+	//
+	return s.sendEvent(ctx, tag, restoredAnswer)
 }
 
 func (s *service) GetAnswer(ctx context.Context, key domain.AnswerKey) (*domain.Answer, error) {
@@ -176,3 +286,118 @@ func (s *service) GetAnswerHistory(ctx context.Context, key domain.AnswerKey) ([
 	//
 	return s.eventRepository.ListEvents(key)
 }
+
+func (s *service) GetAnswerAt(ctx context.Context, key domain.AnswerKey, at domain.AnswerPoint) (*domain.AnswerSnapshot, error) {
+
+	// Check arguments.
+	//
+	if len(key) == 0 {
+		return nil, errors.NewErrInvalidArgument("AnswerKey required")
+	}
+	if at.Version < 0 {
+		return nil, errors.NewErrInvalidArgument("Version must not be negative")
+	}
+	if at.Version == 0 && at.Time.IsZero() {
+		return nil, errors.NewErrInvalidArgument("Time or version required")
+	}
+	if at.Version > 0 && !at.Time.IsZero() {
+		return nil, errors.NewErrInvalidArgument("Time and version are mutually exclusive")
+	}
+
+	// Rebuild the state from the history.
+	//
+	events, err := s.eventRepository.ListEvents(key)
+	if err != nil {
+		return nil, err
+	}
+	events, ok := at.EventsAt(events)
+	if !ok {
+		return nil, errors.NewErrNotFound("Answer version not found")
+	}
+	snapshot := domain.ReplayAnswerEvents(events)
+	if snapshot.Answer == nil {
+		return nil, errors.NewErrNotFound("Answer did not exist at the requested point")
+	}
+	return snapshot, nil
+}
+
+func (s *service) ListAnswers(ctx context.Context, page domain.PageRequest) ([]*domain.Answer, string, error) {
+
+	// Check page size.
+	//
+	if page.PageSize < 0 || page.PageSize > domain.MaxPageSize {
+		return nil, "", errors.NewErrInvalidArgument(fmt.Sprintf("PageSize must be between 0 and %d", domain.MaxPageSize))
+	}
+
+	// Return result.
+	//
+	return s.repository.List(page)
+}
+
+// findAnswer - returns the answer or nil if it does not exist.
+// Other storage errors are returned, so they are not mistaken for a missing answer.
+func (s *service) findAnswer(key domain.AnswerKey) (*domain.Answer, error) {
+	foundAnswer, err := s.repository.Get(key)
+	if _, ok := err.(*errors.ErrNotFound); ok {
+		return nil, nil
+	}
+	return foundAnswer, err
+}
+
+// lastVersion - returns the last version of the history, an answer counts on from it if the key has
+// no version stored, e.g. the deleted answer was purged.
+func (s *service) lastVersion(key domain.AnswerKey) (int, error) {
+	events, err := s.eventRepository.ListEvents(key)
+	if err != nil {
+		return 0, err
+	}
+	return domain.LastAnswerVersion(events), nil
+}
+
+// findVersion - returns the event of the version or nil if the history has none.
+func findVersion(events []*domain.AnswerEvent, version int) *domain.AnswerEvent {
+	for _, event := range events {
+		if event.Version() == version {
+			return event
+		}
+	}
+	return nil
+}
+
+// newTag - returns the tag of a write made now. The ID of an event captured from the change stream is generated
+// and written with the tag, the ID of a sent event is the ID of its message.
+func (s *service) newTag(eventType domain.AnswerEventType) domain.AnswerChangeTag {
+	tag := domain.AnswerChangeTag{
+		EventType:  eventType,
+		OccurredAt: s.now().UTC(),
+	}
+	if s.queueService == nil {
+		tag.EventID = s.newEventID()
+	}
+	return tag
+}
+
+// sendEvent - sends the answer event message of the tagged write to the event queue and returns the mutation it records.
+func (s *service) sendEvent(ctx context.Context, tag domain.AnswerChangeTag, answer *domain.Answer) (*domain.AnswerMutation, error) {
+	mutation := &domain.AnswerMutation{
+		Answer:     answer,
+		EventType:  tag.EventType,
+		OccurredAt: tag.OccurredAt,
+		EventID:    tag.EventID,
+	}
+	if s.queueService == nil {
+		return mutation, nil
+	}
+	messageID, err := s.queueService.SendMessage(ctx, s.eventQueueName, &domain.AnswerEventMessage{
+		Event: &domain.AnswerEvent{
+			EventType:  tag.EventType,
+			Data:       answer,
+			OccurredAt: tag.OccurredAt,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	mutation.EventID = messageID
+	return mutation, nil
+}
@@ -0,0 +1,696 @@
+package answer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/servicetest"
+
+	"github.com/go-test/deep"
+)
+
+const testMessageID = "message-id"
+
+var (
+	testRetention = 24 * time.Hour
+	testEventID   = "event-1"
+	errQueue      = errors.NewErrInternal("queue is down")
+)
+
+// serviceFixture - the service under test together with its fake dependencies.
+type serviceFixture struct {
+	repository      *fakeAnswerRepository
+	eventRepository *fakeAnswerEventRepository
+	queueService    *servicetest.QueueService
+	service         *service
+}
+
+func newServiceFixture(answers ...*domain.Answer) *serviceFixture {
+	f := &serviceFixture{
+		repository:      newFakeAnswerRepository(answers...),
+		eventRepository: newFakeAnswerEventRepository(),
+		queueService:    &servicetest.QueueService{MessageID: testMessageID},
+	}
+	f.service = newBasicService(f.repository, f.eventRepository, f.queueService, servicetest.EventQueueName, testRetention).(*service)
+	f.service.now = servicetest.Clock
+	f.service.newEventID = func() string { return testEventID }
+	return f
+}
+
+// eventMessage - returns the message the service is expected to send.
+func eventMessage(eventType domain.AnswerEventType, answer *domain.Answer) servicetest.SentMessage {
+	return servicetest.SentMessage{
+		QueueName: servicetest.EventQueueName,
+		Message: &domain.AnswerEventMessage{
+			Event: &domain.AnswerEvent{
+				EventType:  eventType,
+				Data:       answer,
+				OccurredAt: servicetest.Now,
+			},
+		},
+	}
+}
+
+// writeTestCase - describes a call of a mutating service method.
+type writeTestCase struct {
+	name         string
+	stored       []*domain.Answer
+	setup        func(f *serviceFixture)
+	wantErr      error
+	wantMessages []servicetest.SentMessage
+	wantStored   *domain.Answer
+	wantMutation *domain.AnswerMutation
+}
+
+func TestCreateAnswer(t *testing.T) {
+	answer := &domain.Answer{Key: "name", Value: "John"}
+	tests := []struct {
+		writeTestCase
+		answer *domain.Answer
+	}{
+		{writeTestCase: writeTestCase{
+			name:         "created",
+			wantMessages: []servicetest.SentMessage{eventMessage(domain.CreateAnswerEventType, answer)},
+			wantStored:   answer,
+			wantMutation: &domain.AnswerMutation{Answer: answer, EventType: domain.CreateAnswerEventType, OccurredAt: servicetest.Now, EventID: testMessageID},
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:    "nil answer",
+			wantErr: errors.NewErrInvalidArgument("Answer required"),
+		}},
+		{writeTestCase: writeTestCase{
+			name:    "missing key",
+			wantErr: errors.NewErrInvalidArgument("Key required"),
+		}, answer: &domain.Answer{Value: "John"}},
+		{writeTestCase: writeTestCase{
+			name:    "missing value",
+			wantErr: errors.NewErrInvalidArgument("Value required"),
+		}, answer: &domain.Answer{Key: "name"}},
+		{writeTestCase: writeTestCase{
+			name:       "already exists",
+			stored:     []*domain.Answer{{Key: "name", Value: "Sam"}},
+			wantErr:    errors.NewErrAlreadyExist("Answer with the provided key is already in use"),
+			wantStored: &domain.Answer{Key: "name", Value: "Sam"},
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:    "get failed",
+			setup:   func(f *serviceFixture) { f.repository.errs["Get"] = servicetest.ErrStorage },
+			wantErr: servicetest.ErrStorage,
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:    "create failed",
+			setup:   func(f *serviceFixture) { f.repository.errs["Create"] = servicetest.ErrStorage },
+			wantErr: servicetest.ErrStorage,
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:       "send failed",
+			setup:      func(f *serviceFixture) { f.queueService.Err = errQueue },
+			wantErr:    errQueue,
+			wantStored: answer,
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:         "events captured from the stream",
+			setup:        func(f *serviceFixture) { f.service.queueService = nil },
+			wantStored:   answer,
+			wantMutation: &domain.AnswerMutation{Answer: answer, EventType: domain.CreateAnswerEventType, OccurredAt: servicetest.Now, EventID: testEventID},
+		}, answer: answer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, answer.Key, func(f *serviceFixture) (*domain.AnswerMutation, error) {
+				return f.service.CreateAnswer(context.Background(), tt.answer)
+			})
+		})
+	}
+}
+
+func TestCreateAnswerCountsOnFromHistory(t *testing.T) {
+	history := []*domain.AnswerEvent{
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John", Version: 1}, OccurredAt: servicetest.Now},
+		{EventType: domain.DeleteAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John", Version: 2}, OccurredAt: servicetest.Now},
+	}
+
+	// The deleted answer was purged, the version is not stored with the answer any more.
+	//
+	for _, upsert := range []bool{false, true} {
+		f := newServiceFixture()
+		f.eventRepository.events = history
+		var (
+			mutation *domain.AnswerMutation
+			err      error
+		)
+		if upsert {
+			mutation, err = f.service.UpsertAnswer(context.Background(), &domain.Answer{Key: "name", Value: "Sam"})
+		} else {
+			mutation, err = f.service.CreateAnswer(context.Background(), &domain.Answer{Key: "name", Value: "Sam"})
+		}
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if mutation.Answer.Version != 3 || mutation.EventType != domain.CreateAnswerEventType {
+			t.Errorf("upsert %v: unexpected mutation %+v", upsert, mutation)
+		}
+	}
+
+	// The history must be read, a guessed version could repeat one.
+	//
+	f := newServiceFixture()
+	f.eventRepository.errs["ListEvents"] = servicetest.ErrStorage
+	_, err := f.service.CreateAnswer(context.Background(), &domain.Answer{Key: "name", Value: "Sam"})
+	expectError(t, err, servicetest.ErrStorage)
+}
+
+func TestUpdateAnswer(t *testing.T) {
+	stored := &domain.Answer{Key: "name", Value: "John"}
+	answer := &domain.Answer{Key: "name", Value: "Sam"}
+	tests := []struct {
+		writeTestCase
+		answer *domain.Answer
+	}{
+		{writeTestCase: writeTestCase{
+			name:         "updated",
+			stored:       []*domain.Answer{stored},
+			wantMessages: []servicetest.SentMessage{eventMessage(domain.UpdateAnswerEventType, answer)},
+			wantStored:   answer,
+			wantMutation: &domain.AnswerMutation{Answer: answer, EventType: domain.UpdateAnswerEventType, OccurredAt: servicetest.Now, EventID: testMessageID},
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:    "nil answer",
+			wantErr: errors.NewErrInvalidArgument("Answer required"),
+		}},
+		{writeTestCase: writeTestCase{
+			name:       "missing value",
+			stored:     []*domain.Answer{stored},
+			wantErr:    errors.NewErrInvalidArgument("Value required"),
+			wantStored: stored,
+		}, answer: &domain.Answer{Key: "name"}},
+		{writeTestCase: writeTestCase{
+			name:    "not found",
+			wantErr: errors.NewErrNotFound("Answer with the provided key not found"),
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:       "get failed",
+			stored:     []*domain.Answer{stored},
+			setup:      func(f *serviceFixture) { f.repository.errs["Get"] = servicetest.ErrStorage },
+			wantErr:    servicetest.ErrStorage,
+			wantStored: stored,
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:       "update failed",
+			stored:     []*domain.Answer{stored},
+			setup:      func(f *serviceFixture) { f.repository.errs["Update"] = servicetest.ErrStorage },
+			wantErr:    servicetest.ErrStorage,
+			wantStored: stored,
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:       "send failed",
+			stored:     []*domain.Answer{stored},
+			setup:      func(f *serviceFixture) { f.queueService.Err = errQueue },
+			wantErr:    errQueue,
+			wantStored: answer,
+		}, answer: answer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, answer.Key, func(f *serviceFixture) (*domain.AnswerMutation, error) {
+				return f.service.UpdateAnswer(context.Background(), tt.answer)
+			})
+		})
+	}
+}
+
+func TestUpsertAnswer(t *testing.T) {
+	stored := &domain.Answer{Key: "name", Value: "John"}
+	answer := &domain.Answer{Key: "name", Value: "Sam"}
+	tests := []struct {
+		writeTestCase
+		answer *domain.Answer
+	}{
+		{writeTestCase: writeTestCase{
+			name:         "created",
+			wantMessages: []servicetest.SentMessage{eventMessage(domain.CreateAnswerEventType, answer)},
+			wantStored:   answer,
+			wantMutation: &domain.AnswerMutation{Answer: answer, EventType: domain.CreateAnswerEventType, OccurredAt: servicetest.Now, EventID: testMessageID},
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:         "replaced",
+			stored:       []*domain.Answer{stored},
+			wantMessages: []servicetest.SentMessage{eventMessage(domain.UpdateAnswerEventType, answer)},
+			wantStored:   answer,
+			wantMutation: &domain.AnswerMutation{Answer: answer, EventType: domain.UpdateAnswerEventType, OccurredAt: servicetest.Now, EventID: testMessageID},
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:    "nil answer",
+			wantErr: errors.NewErrInvalidArgument("Answer required"),
+		}},
+		{writeTestCase: writeTestCase{
+			name:       "missing value",
+			stored:     []*domain.Answer{stored},
+			wantErr:    errors.NewErrInvalidArgument("Value required"),
+			wantStored: stored,
+		}, answer: &domain.Answer{Key: "name"}},
+		{writeTestCase: writeTestCase{
+			name:       "upsert failed",
+			stored:     []*domain.Answer{stored},
+			setup:      func(f *serviceFixture) { f.repository.errs["Upsert"] = servicetest.ErrStorage },
+			wantErr:    servicetest.ErrStorage,
+			wantStored: stored,
+		}, answer: answer},
+		{writeTestCase: writeTestCase{
+			name:       "send failed",
+			setup:      func(f *serviceFixture) { f.queueService.Err = errQueue },
+			wantErr:    errQueue,
+			wantStored: answer,
+		}, answer: answer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, answer.Key, func(f *serviceFixture) (*domain.AnswerMutation, error) {
+				return f.service.UpsertAnswer(context.Background(), tt.answer)
+			})
+		})
+	}
+}
+
+func TestDeleteAnswer(t *testing.T) {
+	stored := &domain.Answer{Key: "name", Value: "John", Version: 1}
+	deleted := &domain.Answer{Key: "name", Value: "John", Version: 2}
+	tests := []struct {
+		writeTestCase
+		key domain.AnswerKey
+	}{
+		{writeTestCase: writeTestCase{
+			name:         "deleted",
+			stored:       []*domain.Answer{stored},
+			wantMessages: []servicetest.SentMessage{eventMessage(domain.DeleteAnswerEventType, deleted)},
+			wantMutation: &domain.AnswerMutation{Answer: deleted, EventType: domain.DeleteAnswerEventType, OccurredAt: servicetest.Now, EventID: testMessageID},
+		}, key: stored.Key},
+		{writeTestCase: writeTestCase{
+			name:    "empty key",
+			wantErr: errors.NewErrInvalidArgument("AnswerKey required"),
+		}},
+		{writeTestCase: writeTestCase{
+			name:    "not found",
+			wantErr: errors.NewErrNotFound("Answer with the provided key not found"),
+		}, key: stored.Key},
+		{writeTestCase: writeTestCase{
+			name:       "get failed",
+			stored:     []*domain.Answer{stored},
+			setup:      func(f *serviceFixture) { f.repository.errs["Get"] = servicetest.ErrStorage },
+			wantErr:    servicetest.ErrStorage,
+			wantStored: stored,
+		}, key: stored.Key},
+		{writeTestCase: writeTestCase{
+			name:       "delete failed",
+			stored:     []*domain.Answer{stored},
+			setup:      func(f *serviceFixture) { f.repository.errs["Delete"] = servicetest.ErrStorage },
+			wantErr:    servicetest.ErrStorage,
+			wantStored: stored,
+		}, key: stored.Key},
+		{writeTestCase: writeTestCase{
+			name:    "send failed",
+			stored:  []*domain.Answer{stored},
+			setup:   func(f *serviceFixture) { f.queueService.Err = errQueue },
+			wantErr: errQueue,
+		}, key: stored.Key},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, stored.Key, func(f *serviceFixture) (*domain.AnswerMutation, error) {
+				return f.service.DeleteAnswer(context.Background(), tt.key)
+			})
+		})
+	}
+}
+
+func TestDeleteAnswerKeepsTombstone(t *testing.T) {
+	f := newServiceFixture(&domain.Answer{Key: "name", Value: "John", Version: 1})
+	if _, err := f.service.DeleteAnswer(context.Background(), "name"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expected := map[domain.AnswerKey]domain.DeletedAnswer{
+		"name": {
+			Answer:    domain.Answer{Key: "name", Value: "John", Version: 2},
+			Tombstone: domain.Tombstone{DeletedAt: servicetest.Now, PurgeAt: servicetest.Now.Add(testRetention)},
+		},
+	}
+	if diff := deep.Equal(f.repository.deleted, expected); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRestoreAnswer(t *testing.T) {
+	john := &domain.Answer{Key: "name", Value: "John", Version: 1}
+	sam := &domain.Answer{Key: "name", Value: "Sam", Version: 2}
+	history := func(f *serviceFixture) {
+		f.eventRepository.events = []*domain.AnswerEvent{
+			{EventType: domain.CreateAnswerEventType, Data: john, OccurredAt: servicetest.Now},
+			{EventType: domain.UpdateAnswerEventType, Data: sam, OccurredAt: servicetest.Now.Add(time.Second)},
+			{EventType: domain.DeleteAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam", Version: 3}, OccurredAt: servicetest.Now.Add(2 * time.Second)},
+		}
+	}
+
+	// A recreated answer counts on from the history, a rolled back one from its stored version.
+	//
+	undeletedSam := &domain.Answer{Key: "name", Value: "Sam", Version: 4}
+	rolledBackJohn := &domain.Answer{Key: "name", Value: "John", Version: 3}
+	withErr := func(method string, err error) func(f *serviceFixture) {
+		return func(f *serviceFixture) {
+			history(f)
+			if method == "ListEvents" {
+				f.eventRepository.errs[method] = err
+			} else {
+				f.repository.errs[method] = err
+			}
+		}
+	}
+	tests := []struct {
+		writeTestCase
+		key     domain.AnswerKey
+		version int
+	}{
+		{writeTestCase: writeTestCase{
+			name:         "undelete last value",
+			setup:        history,
+			wantMessages: []servicetest.SentMessage{eventMessage(domain.RestoreAnswerEventType, undeletedSam)},
+			wantStored:   undeletedSam,
+			wantMutation: &domain.AnswerMutation{Answer: undeletedSam, EventType: domain.RestoreAnswerEventType, OccurredAt: servicetest.Now, EventID: testMessageID},
+		}, key: "name"},
+		{writeTestCase: writeTestCase{
+			name:         "roll back existing answer",
+			stored:       []*domain.Answer{sam},
+			setup:        history,
+			wantMessages: []servicetest.SentMessage{eventMessage(domain.RestoreAnswerEventType, rolledBackJohn)},
+			wantStored:   rolledBackJohn,
+			wantMutation: &domain.AnswerMutation{Answer: rolledBackJohn, EventType: domain.RestoreAnswerEventType, OccurredAt: servicetest.Now, EventID: testMessageID},
+		}, key: "name", version: 1},
+		{writeTestCase: writeTestCase{
+			name:    "empty key",
+			wantErr: errors.NewErrInvalidArgument("AnswerKey required"),
+		}},
+		{writeTestCase: writeTestCase{
+			name:    "negative version",
+			wantErr: errors.NewErrInvalidArgument("Version must not be negative"),
+		}, key: "name", version: -1},
+		{writeTestCase: writeTestCase{
+			name:    "no history",
+			wantErr: errors.NewErrNotFound("Answer history not found"),
+		}, key: "name"},
+		{writeTestCase: writeTestCase{
+			name:    "unknown version",
+			setup:   history,
+			wantErr: errors.NewErrNotFound("Answer version not found"),
+		}, key: "name", version: 4},
+		{writeTestCase: writeTestCase{
+			name:    "list failed",
+			setup:   withErr("ListEvents", servicetest.ErrStorage),
+			wantErr: servicetest.ErrStorage,
+		}, key: "name"},
+		{writeTestCase: writeTestCase{
+			name:       "update failed",
+			stored:     []*domain.Answer{sam},
+			setup:      withErr("Update", servicetest.ErrStorage),
+			wantErr:    servicetest.ErrStorage,
+			wantStored: sam,
+		}, key: "name", version: 1},
+		{writeTestCase: writeTestCase{
+			name: "send failed",
+			setup: func(f *serviceFixture) {
+				history(f)
+				f.queueService.Err = errQueue
+			},
+			wantErr:    errQueue,
+			wantStored: undeletedSam,
+		}, key: "name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, "name", func(f *serviceFixture) (*domain.AnswerMutation, error) {
+				return f.service.RestoreAnswer(context.Background(), tt.key, tt.version)
+			})
+		})
+	}
+}
+
+func TestGetAnswer(t *testing.T) {
+	stored := &domain.Answer{Key: "name", Value: "John"}
+	tests := []struct {
+		name    string
+		key     domain.AnswerKey
+		setup   func(f *serviceFixture)
+		want    *domain.Answer
+		wantErr error
+	}{
+		{name: "found", key: stored.Key, want: stored},
+		{name: "empty key", wantErr: errors.NewErrInvalidArgument("AnswerKey required")},
+		{name: "not found", key: "unknown", wantErr: errors.NewErrNotFound("Answer not found")},
+		{
+			name:    "get failed",
+			key:     stored.Key,
+			setup:   func(f *serviceFixture) { f.repository.errs["Get"] = servicetest.ErrStorage },
+			wantErr: servicetest.ErrStorage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(stored)
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			got, err := f.service.GetAnswer(context.Background(), tt.key)
+			expectError(t, err, tt.wantErr)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+			expectNoMessages(t, f)
+		})
+	}
+}
+
+func TestGetAnswerHistory(t *testing.T) {
+	events := []*domain.AnswerEvent{
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John"}, OccurredAt: servicetest.Now},
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "city", Value: "NY"}, OccurredAt: servicetest.Now},
+		{EventType: domain.UpdateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam"}, OccurredAt: servicetest.Now.Add(time.Second)},
+	}
+	tests := []struct {
+		name    string
+		key     domain.AnswerKey
+		setup   func(f *serviceFixture)
+		want    []*domain.AnswerEvent
+		wantErr error
+	}{
+		{name: "found", key: "name", want: []*domain.AnswerEvent{events[0], events[2]}},
+		{name: "no history", key: "unknown"},
+		{name: "empty key", wantErr: errors.NewErrInvalidArgument("AnswerKey required")},
+		{
+			name:    "list failed",
+			key:     "name",
+			setup:   func(f *serviceFixture) { f.eventRepository.errs["ListEvents"] = servicetest.ErrStorage },
+			wantErr: servicetest.ErrStorage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture()
+			f.eventRepository.events = events
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			got, err := f.service.GetAnswerHistory(context.Background(), tt.key)
+			expectError(t, err, tt.wantErr)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+			expectNoMessages(t, f)
+		})
+	}
+}
+
+func TestGetAnswerAt(t *testing.T) {
+	events := []*domain.AnswerEvent{
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John", Version: 1}, OccurredAt: servicetest.Now},
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "city", Value: "NY", Version: 1}, OccurredAt: servicetest.Now},
+		{EventType: domain.UpdateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam", Version: 2}, OccurredAt: servicetest.Now.Add(time.Minute)},
+		{EventType: domain.DeleteAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam", Version: 3}, OccurredAt: servicetest.Now.Add(2 * time.Minute)},
+	}
+	tests := []struct {
+		name    string
+		key     domain.AnswerKey
+		at      domain.AnswerPoint
+		setup   func(f *serviceFixture)
+		want    *domain.AnswerSnapshot
+		wantErr error
+	}{
+		{
+			name: "at time of create",
+			key:  "name",
+			at:   domain.AnswerPoint{Time: servicetest.Now.Add(time.Second)},
+			want: &domain.AnswerSnapshot{Answer: &domain.Answer{Key: "name", Value: "John", Version: 1}, Version: 1, OccurredAt: servicetest.Now},
+		},
+		{
+			name: "at time of update",
+			key:  "name",
+			at:   domain.AnswerPoint{Time: servicetest.Now.Add(time.Minute)},
+			want: &domain.AnswerSnapshot{Answer: &domain.Answer{Key: "name", Value: "Sam", Version: 2}, Version: 2, OccurredAt: servicetest.Now.Add(time.Minute)},
+		},
+		{
+			name: "at version",
+			key:  "name",
+			at:   domain.AnswerPoint{Version: 1},
+			want: &domain.AnswerSnapshot{Answer: &domain.Answer{Key: "name", Value: "John", Version: 1}, Version: 1, OccurredAt: servicetest.Now},
+		},
+		{
+			name:    "before create",
+			key:     "name",
+			at:      domain.AnswerPoint{Time: servicetest.Now.Add(-time.Second)},
+			wantErr: errors.NewErrNotFound("Answer did not exist at the requested point"),
+		},
+		{
+			name:    "after delete",
+			key:     "name",
+			at:      domain.AnswerPoint{Version: 3},
+			wantErr: errors.NewErrNotFound("Answer did not exist at the requested point"),
+		},
+		{
+			name:    "unknown version",
+			key:     "name",
+			at:      domain.AnswerPoint{Version: 4},
+			wantErr: errors.NewErrNotFound("Answer version not found"),
+		},
+		{
+			name:    "empty key",
+			at:      domain.AnswerPoint{Version: 1},
+			wantErr: errors.NewErrInvalidArgument("AnswerKey required"),
+		},
+		{
+			name:    "no point",
+			key:     "name",
+			wantErr: errors.NewErrInvalidArgument("Time or version required"),
+		},
+		{
+			name:    "negative version",
+			key:     "name",
+			at:      domain.AnswerPoint{Version: -1},
+			wantErr: errors.NewErrInvalidArgument("Version must not be negative"),
+		},
+		{
+			name:    "time and version",
+			key:     "name",
+			at:      domain.AnswerPoint{Time: servicetest.Now, Version: 1},
+			wantErr: errors.NewErrInvalidArgument("Time and version are mutually exclusive"),
+		},
+		{
+			name:    "list failed",
+			key:     "name",
+			at:      domain.AnswerPoint{Version: 1},
+			setup:   func(f *serviceFixture) { f.eventRepository.errs["ListEvents"] = servicetest.ErrStorage },
+			wantErr: servicetest.ErrStorage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture()
+			f.eventRepository.events = events
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			got, err := f.service.GetAnswerAt(context.Background(), tt.key, tt.at)
+			expectError(t, err, tt.wantErr)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+			expectNoMessages(t, f)
+		})
+	}
+}
+
+func TestListAnswers(t *testing.T) {
+	stored := []*domain.Answer{{Key: "name", Value: "John"}, {Key: "city", Value: "NY"}}
+	tests := []struct {
+		name    string
+		page    domain.PageRequest
+		setup   func(f *serviceFixture)
+		want    []*domain.Answer
+		wantErr error
+	}{
+		{name: "listed", want: []*domain.Answer{stored[1], stored[0]}},
+		{name: "max page size", page: domain.PageRequest{PageSize: domain.MaxPageSize}, want: []*domain.Answer{stored[1], stored[0]}},
+		{
+			name:    "negative page size",
+			page:    domain.PageRequest{PageSize: -1},
+			wantErr: errors.NewErrInvalidArgument("PageSize must be between 0 and 1000"),
+		},
+		{
+			name:    "page size too large",
+			page:    domain.PageRequest{PageSize: domain.MaxPageSize + 1},
+			wantErr: errors.NewErrInvalidArgument("PageSize must be between 0 and 1000"),
+		},
+		{
+			name:    "list failed",
+			setup:   func(f *serviceFixture) { f.repository.errs["List"] = servicetest.ErrStorage },
+			wantErr: servicetest.ErrStorage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(stored...)
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			got, _, err := f.service.ListAnswers(context.Background(), tt.page)
+			expectError(t, err, tt.wantErr)
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+			expectNoMessages(t, f)
+		})
+	}
+}
+
+// run - calls the service method and checks the error, the sent messages and the stored answer.
+func (tt writeTestCase) run(t *testing.T, key domain.AnswerKey, call func(f *serviceFixture) (*domain.AnswerMutation, error)) {
+	t.Helper()
+	f := newServiceFixture(tt.stored...)
+	if tt.setup != nil {
+		tt.setup(f)
+	}
+	mutation, err := call(f)
+	expectError(t, err, tt.wantErr)
+	if diff := deep.Equal(mutation, tt.wantMutation); diff != nil {
+		t.Errorf("mutation: %v", diff)
+	}
+	if diff := deep.Equal(f.queueService.Messages, tt.wantMessages); diff != nil {
+		t.Errorf("sent messages: %v", diff)
+	}
+	delete(f.repository.errs, "Get")
+	stored, _ := f.repository.Get(key)
+	if diff := deep.Equal(stored, tt.wantStored); diff != nil {
+		t.Errorf("stored answer: %v", diff)
+	}
+}
+
+// expectError - checks that the error has the expected type and message.
+func expectError(t *testing.T, got, want error) {
+	t.Helper()
+	if want == nil {
+		if got != nil {
+			t.Errorf("unexpected err: %v", got)
+		}
+		return
+	}
+	if reflect.TypeOf(got) != reflect.TypeOf(want) || got.Error() != want.Error() {
+		t.Errorf("expected %T(%v), got %T(%v)", want, want, got, got)
+	}
+}
+
+func expectNoMessages(t *testing.T, f *serviceFixture) {
+	t.Helper()
+	if len(f.queueService.Messages) != 0 {
+		t.Errorf("unexpected messages: %v", f.queueService.Messages)
+	}
+}
@@ -24,36 +24,62 @@ type loggingMiddleware struct {
 	next   domain.AnswerService
 }
 
-func (mw loggingMiddleware) CreateAnswer(ctx context.Context, answer *domain.Answer) (err error) {
+func (mw loggingMiddleware) CreateAnswer(ctx context.Context, answer *domain.Answer) (mutation *domain.AnswerMutation, err error) {
 	defer func() {
 		_ = mw.logger.Log("method", "CreateAnswer",
 			"answer", answer,
+			"eventID", eventID(mutation),
 			"err", err,
 		)
 	}()
 	return mw.next.CreateAnswer(ctx, answer)
 }
 
-func (mw loggingMiddleware) UpdateAnswer(ctx context.Context, answer *domain.Answer) (err error) {
+func (mw loggingMiddleware) UpdateAnswer(ctx context.Context, answer *domain.Answer) (mutation *domain.AnswerMutation, err error) {
 	defer func() {
 		_ = mw.logger.Log("method", "UpdateAnswer",
 			"answer", answer,
+			"eventID", eventID(mutation),
 			"err", err,
 		)
 	}()
 	return mw.next.UpdateAnswer(ctx, answer)
 }
 
-func (mw loggingMiddleware) DeleteAnswer(ctx context.Context, key domain.AnswerKey) (err error) {
+func (mw loggingMiddleware) UpsertAnswer(ctx context.Context, answer *domain.Answer) (mutation *domain.AnswerMutation, err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "UpsertAnswer",
+			"answer", answer,
+			"eventID", eventID(mutation),
+			"err", err,
+		)
+	}()
+	return mw.next.UpsertAnswer(ctx, answer)
+}
+
+func (mw loggingMiddleware) DeleteAnswer(ctx context.Context, key domain.AnswerKey) (mutation *domain.AnswerMutation, err error) {
 	defer func() {
 		_ = mw.logger.Log("method", "DeleteAnswer",
 			"key", key,
+			"eventID", eventID(mutation),
 			"err", err,
 		)
 	}()
 	return mw.next.DeleteAnswer(ctx, key)
 }
 
+func (mw loggingMiddleware) RestoreAnswer(ctx context.Context, key domain.AnswerKey, version int) (mutation *domain.AnswerMutation, err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "RestoreAnswer",
+			"key", key,
+			"version", version,
+			"eventID", eventID(mutation),
+			"err", err,
+		)
+	}()
+	return mw.next.RestoreAnswer(ctx, key, version)
+}
+
 func (mw loggingMiddleware) GetAnswer(ctx context.Context, key domain.AnswerKey) (found *domain.Answer, err error) {
 	defer func() {
 		_ = mw.logger.Log("method", "GetAnswer",
@@ -75,3 +101,36 @@ func (mw loggingMiddleware) GetAnswerHistory(ctx context.Context, key domain.Ans
 	}()
 	return mw.next.GetAnswerHistory(ctx, key)
 }
+
+func (mw loggingMiddleware) GetAnswerAt(ctx context.Context, key domain.AnswerKey, at domain.AnswerPoint) (snapshot *domain.AnswerSnapshot, err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "GetAnswerAt",
+			"key", key,
+			"at", at,
+			"snapshot", snapshot,
+			"err", err,
+		)
+	}()
+	return mw.next.GetAnswerAt(ctx, key, at)
+}
+
+func (mw loggingMiddleware) ListAnswers(ctx context.Context, page domain.PageRequest) (list []*domain.Answer, nextPageToken string, err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "ListAnswers",
+			"pageSize", page.PageSize,
+			"pageToken", domain.RedactPageToken(page.PageToken),
+			"count", len(list),
+			"nextPageToken", domain.RedactPageToken(nextPageToken),
+			"err", err,
+		)
+	}()
+	return mw.next.ListAnswers(ctx, page)
+}
+
+// eventID - returns the event ID of the mutation, the mutation is nil if it failed.
+func eventID(mutation *domain.AnswerMutation) string {
+	if mutation == nil {
+		return ""
+	}
+	return mutation.EventID
+}
@@ -0,0 +1,43 @@
+package answer
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+)
+
+// pagingService - lists a page of answers after the page token, the other methods are not used.
+type pagingService struct {
+	domain.AnswerService
+}
+
+func (s pagingService) ListAnswers(_ context.Context, page domain.PageRequest) ([]*domain.Answer, string, error) {
+	return []*domain.Answer{{Key: "next-secret-key", Value: "John"}}, domain.AnswerPageToken("next-secret-key"), nil
+}
+
+func TestLoggingMiddlewareRedactsPageTokens(t *testing.T) {
+	var out bytes.Buffer
+	service := LoggingServiceMiddleware(log.NewLogfmtLogger(&out))(pagingService{})
+	page := domain.PageRequest{PageToken: domain.AnswerPageToken("secret-key"), PageSize: 10}
+	if _, _, err := service.ListAnswers(context.Background(), page); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// The tokens encode the keys, neither of them may appear in the log.
+	//
+	for _, secret := range []string{"secret-key", page.PageToken, domain.AnswerPageToken("next-secret-key")} {
+		if strings.Contains(out.String(), secret) {
+			t.Errorf("unexpected %q in the log %q", secret, out.String())
+		}
+	}
+	for _, want := range []string{"pageSize=10", "pageToken=redacted", "nextPageToken=redacted", "count=1"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in the log %q", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package answer
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	apiv1 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// WatchAnswersPath - the HTTP path of WatchAnswers, the gateway cannot serve the streaming rpc in-process.
+const WatchAnswersPath = "/v1/answers:watch"
+
+// Query parameters of the watch.
+const (
+	watchParamKey         = "key"
+	watchParamPrefix      = "prefix"
+	watchParamNamespace   = "namespace"
+	watchParamFromVersion = "fromVersion"
+	lastEventIDHeader     = "Last-Event-ID"
+)
+
+// NewWatchHandler - serves WatchAnswers as server-sent events, each event holds a WatchAnswersResponse as JSON.
+// The events of a key watch carry the version as id, so a reconnecting EventSource resumes after the last one.
+// The events of prefix and namespace watches carry no id, they cannot be resumed.
+// An error after the stream started is sent as an "error" event holding the status.
+func NewWatchHandler(watchService domain.AnswerWatchService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+			return
+		}
+		filter, fromVersion, err := decodeWatchQuery(r)
+		if err != nil {
+			writeWatchError(w, false, err)
+			return
+		}
+
+		// The headers are sent with the first event, so the errors of the request get a status code.
+		//
+		var started bool
+		start := func() {
+			if started {
+				return
+			}
+			started = true
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			flusher.Flush()
+		}
+		err = watchService.WatchAnswers(r.Context(), filter, fromVersion, func(event *domain.WatchedAnswerEvent) error {
+			encodedEvent, err := EncodeAnswerEvent(event.Event)
+			if err != nil {
+				return err
+			}
+			data, err := protojson.Marshal(&apiv1.WatchAnswersResponse{
+				AnswerEvent: encodedEvent,
+				Version:     int32(event.Version),
+			})
+			if err != nil {
+				return err
+			}
+			start()
+			if len(filter.Key) > 0 {
+				fmt.Fprintf(w, "id: %d\n", event.Version)
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		})
+		if err != nil {
+			writeWatchError(w, started, err)
+			return
+		}
+		start()
+	})
+}
+
+// decodeWatchQuery - reads the filter and the version from the query, the Last-Event-ID header overrides the version.
+func decodeWatchQuery(r *http.Request) (domain.AnswerWatchFilter, int, error) {
+	query := r.URL.Query()
+	filter := domain.AnswerWatchFilter{
+		Key:       domain.AnswerKey(query.Get(watchParamKey)),
+		Prefix:    query.Get(watchParamPrefix),
+		Namespace: query.Get(watchParamNamespace),
+	}
+	version := query.Get(watchParamFromVersion)
+	if lastEventID := r.Header.Get(lastEventIDHeader); len(lastEventID) > 0 {
+		version = lastEventID
+	}
+	if len(version) == 0 {
+		return filter, 0, nil
+	}
+	fromVersion, err := strconv.Atoi(version)
+	if err != nil {
+		return filter, 0, errors.NewErrInvalidArgument("Version is not valid")
+	}
+	return filter, fromVersion, nil
+}
+
+// writeWatchError - writes the error as a status code, or as an error event once the stream started.
+func writeWatchError(w http.ResponseWriter, started bool, err error) {
+	st := status.Convert(errors.GRPCErrorEncoder(err))
+	data, marshalErr := protojson.Marshal(st.Proto())
+	if marshalErr != nil {
+		data = []byte("{}")
+	}
+	if !started {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+		_, _ = w.Write(data)
+		return
+	}
+	fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+}
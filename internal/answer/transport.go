@@ -2,7 +2,6 @@ package answer
 
 import (
 	"context"
-	"fmt"
 
 	apiv1 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
 	"dochq.co.uk.answerservice/internal/domain"
@@ -11,20 +10,30 @@ import (
 
 	grpctransport "github.com/go-kit/kit/transport/grpc"
 	"github.com/go-kit/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type grpcServer struct {
 	createAnswer     grpctransport.Handler
 	updateAnswer     grpctransport.Handler
+	upsertAnswer     grpctransport.Handler
 	deleteAnswer     grpctransport.Handler
+	restoreAnswer    grpctransport.Handler
 	getAnswer        grpctransport.Handler
 	getAnswerHistory grpctransport.Handler
+	getAnswerAt      grpctransport.Handler
+	listAnswers      grpctransport.Handler
+	searchAnswers    grpctransport.Handler
+
+	// watchService - serves the streaming WatchAnswers directly, go-kit transports only handle unary calls.
+	watchService domain.AnswerWatchService
 }
 
 // NewGRPCServer makes a set of endpoints available as a gRPC AddServer.
-func NewGRPCServer(endpoints Endpoints, logger log.Logger) apiv1.AnswerServiceServer {
+func NewGRPCServer(endpoints Endpoints, watchService domain.AnswerWatchService, logger log.Logger) apiv1.AnswerServiceServer {
 	options := helpers.SetupServerOptions(logger)
 	return &grpcServer{
+		watchService: watchService,
 		createAnswer: grpctransport.NewServer(
 			endpoints.CreateAnswerEndpoint,
 			decodeCreateAnswerRequest,
@@ -37,12 +46,24 @@ func NewGRPCServer(endpoints Endpoints, logger log.Logger) apiv1.AnswerServiceSe
 			encodeUpdateAnswerResponse,
 			options...,
 		),
+		upsertAnswer: grpctransport.NewServer(
+			endpoints.UpsertAnswerEndpoint,
+			decodeUpsertAnswerRequest,
+			encodeUpsertAnswerResponse,
+			options...,
+		),
 		deleteAnswer: grpctransport.NewServer(
 			endpoints.DeleteAnswerEndpoint,
 			decodeDeleteAnswerRequest,
 			encodeDeleteAnswerResponse,
 			options...,
 		),
+		restoreAnswer: grpctransport.NewServer(
+			endpoints.RestoreAnswerEndpoint,
+			decodeRestoreAnswerRequest,
+			encodeRestoreAnswerResponse,
+			options...,
+		),
 		getAnswer: grpctransport.NewServer(
 			endpoints.GetAnswerEndpoint,
 			decodeGetAnswerRequest,
@@ -55,6 +76,24 @@ func NewGRPCServer(endpoints Endpoints, logger log.Logger) apiv1.AnswerServiceSe
 			encodeGetAnswerHistoryResponse,
 			options...,
 		),
+		getAnswerAt: grpctransport.NewServer(
+			endpoints.GetAnswerAtEndpoint,
+			decodeGetAnswerAtRequest,
+			encodeGetAnswerAtResponse,
+			options...,
+		),
+		listAnswers: grpctransport.NewServer(
+			endpoints.ListAnswersEndpoint,
+			decodeListAnswersRequest,
+			encodeListAnswersResponse,
+			options...,
+		),
+		searchAnswers: grpctransport.NewServer(
+			endpoints.SearchAnswersEndpoint,
+			decodeSearchAnswersRequest,
+			encodeSearchAnswersResponse,
+			options...,
+		),
 	}
 }
 
@@ -69,7 +108,7 @@ func (s *grpcServer) CreateAnswer(ctx context.Context, req *apiv1.CreateAnswerRe
 
 func decodeCreateAnswerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*apiv1.CreateAnswerRequest)
-	decodedAnswer, err := decodeAnswer(req.Answer)
+	decodedAnswer, err := DecodeAnswer(req.Answer)
 	if err != nil {
 		return CreateAnswerRequest{}, err
 	}
@@ -83,7 +122,16 @@ func encodeCreateAnswerResponse(_ context.Context, response interface{}) (interf
 	if resp.Err != nil {
 		return &apiv1.CreateAnswerResponse{}, resp.Err
 	}
-	return &apiv1.CreateAnswerResponse{}, nil
+	encodedAnswer, err := EncodeAnswer(resp.Mutation.Answer)
+	if err != nil {
+		return &apiv1.CreateAnswerResponse{}, err
+	}
+	return &apiv1.CreateAnswerResponse{
+		Answer:     encodedAnswer,
+		OccurredAt: timestamppb.New(resp.Mutation.OccurredAt),
+		EventId:    resp.Mutation.EventID,
+		Version:    int32(resp.Mutation.Answer.Version),
+	}, nil
 }
 
 // UpdateAnswer Impl.
@@ -97,7 +145,7 @@ func (s *grpcServer) UpdateAnswer(ctx context.Context, req *apiv1.UpdateAnswerRe
 
 func decodeUpdateAnswerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*apiv1.UpdateAnswerRequest)
-	decodedAnswer, err := decodeAnswer(req.Answer)
+	decodedAnswer, err := DecodeAnswer(req.Answer)
 	if err != nil {
 		return UpdateAnswerRequest{}, err
 	}
@@ -111,7 +159,53 @@ func encodeUpdateAnswerResponse(_ context.Context, response interface{}) (interf
 	if resp.Err != nil {
 		return &apiv1.UpdateAnswerResponse{}, resp.Err
 	}
-	return &apiv1.UpdateAnswerResponse{}, nil
+	encodedAnswer, err := EncodeAnswer(resp.Mutation.Answer)
+	if err != nil {
+		return &apiv1.UpdateAnswerResponse{}, err
+	}
+	return &apiv1.UpdateAnswerResponse{
+		Answer:     encodedAnswer,
+		OccurredAt: timestamppb.New(resp.Mutation.OccurredAt),
+		EventId:    resp.Mutation.EventID,
+		Version:    int32(resp.Mutation.Answer.Version),
+	}, nil
+}
+
+// UpsertAnswer Impl.
+func (s *grpcServer) UpsertAnswer(ctx context.Context, req *apiv1.UpsertAnswerRequest) (*apiv1.UpsertAnswerResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.upsertAnswer)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.UpsertAnswerResponse), nil
+}
+
+func decodeUpsertAnswerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.UpsertAnswerRequest)
+	return UpsertAnswerRequest{
+		Answer: &domain.Answer{
+			Key:   domain.AnswerKey(req.Key),
+			Value: domain.AnswerValue(req.Value),
+		},
+	}, nil
+}
+
+func encodeUpsertAnswerResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(UpsertAnswerResponse)
+	if resp.Err != nil {
+		return &apiv1.UpsertAnswerResponse{}, resp.Err
+	}
+	encodedAnswer, err := EncodeAnswer(resp.Mutation.Answer)
+	if err != nil {
+		return &apiv1.UpsertAnswerResponse{}, err
+	}
+	return &apiv1.UpsertAnswerResponse{
+		Answer:     encodedAnswer,
+		OccurredAt: timestamppb.New(resp.Mutation.OccurredAt),
+		EventId:    resp.Mutation.EventID,
+		Created:    resp.Mutation.EventType == domain.CreateAnswerEventType,
+		Version:    int32(resp.Mutation.Answer.Version),
+	}, nil
 }
 
 // DeleteAnswer Impl.
@@ -135,7 +229,50 @@ func encodeDeleteAnswerResponse(_ context.Context, response interface{}) (interf
 	if resp.Err != nil {
 		return &apiv1.DeleteAnswerResponse{}, resp.Err
 	}
-	return &apiv1.DeleteAnswerResponse{}, nil
+	encodedAnswer, err := EncodeAnswer(resp.Mutation.Answer)
+	if err != nil {
+		return &apiv1.DeleteAnswerResponse{}, err
+	}
+	return &apiv1.DeleteAnswerResponse{
+		Answer:     encodedAnswer,
+		OccurredAt: timestamppb.New(resp.Mutation.OccurredAt),
+		EventId:    resp.Mutation.EventID,
+		Version:    int32(resp.Mutation.Answer.Version),
+	}, nil
+}
+
+// RestoreAnswer Impl.
+func (s *grpcServer) RestoreAnswer(ctx context.Context, req *apiv1.RestoreAnswerRequest) (*apiv1.RestoreAnswerResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.restoreAnswer)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.RestoreAnswerResponse), nil
+}
+
+func decodeRestoreAnswerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.RestoreAnswerRequest)
+	return RestoreAnswerRequest{
+		Key:     domain.AnswerKey(req.Key),
+		Version: int(req.Version),
+	}, nil
+}
+
+func encodeRestoreAnswerResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(RestoreAnswerResponse)
+	if resp.Err != nil {
+		return &apiv1.RestoreAnswerResponse{}, resp.Err
+	}
+	encodedAnswer, err := EncodeAnswer(resp.Mutation.Answer)
+	if err != nil {
+		return &apiv1.RestoreAnswerResponse{}, err
+	}
+	return &apiv1.RestoreAnswerResponse{
+		Answer:     encodedAnswer,
+		OccurredAt: timestamppb.New(resp.Mutation.OccurredAt),
+		EventId:    resp.Mutation.EventID,
+		Version:    int32(resp.Mutation.Answer.Version),
+	}, nil
 }
 
 // GetAnswer Impl.
@@ -159,7 +296,7 @@ func encodeGetAnswerResponse(_ context.Context, response interface{}) (interface
 	if resp.Err != nil {
 		return &apiv1.GetAnswerResponse{}, resp.Err
 	}
-	encodedAnswer, err := encodeAnswer(resp.Answer)
+	encodedAnswer, err := EncodeAnswer(resp.Answer)
 	if err != nil {
 		return &apiv1.GetAnswerResponse{}, err
 	}
@@ -191,53 +328,169 @@ func encodeGetAnswerHistoryResponse(_ context.Context, response interface{}) (in
 	}
 	events := make([]*apiv1.AnswerEvent, len(resp.Events))
 	for i, e := range resp.Events {
-		data, err := encodeAnswer(e.Data)
-		if err != nil {
-			return &apiv1.GetAnswerHistoryResponse{}, err
-		}
-		eventType, err := encodeAnswerEventType(e.EventType)
+		encodedEvent, err := EncodeAnswerEvent(e)
 		if err != nil {
 			return &apiv1.GetAnswerHistoryResponse{}, err
 		}
-		events[i] = &apiv1.AnswerEvent{
-			EventType: eventType,
-			Data:      data,
-		}
+		events[i] = encodedEvent
 	}
 	return &apiv1.GetAnswerHistoryResponse{
 		AnswerEvents: events,
 	}, nil
 }
 
-func decodeAnswer(answer *apiv1.Answer) (*domain.Answer, error) {
-	if answer == nil {
-		return nil, errors.NewErrInternal("Cannot decode nil value")
+// GetAnswerAt Impl.
+func (s *grpcServer) GetAnswerAt(ctx context.Context, req *apiv1.GetAnswerAtRequest) (*apiv1.GetAnswerAtResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.getAnswerAt)
+	if err != nil {
+		return nil, err
 	}
-	return &domain.Answer{
-		Key:   domain.AnswerKey(answer.Key),
-		Value: domain.AnswerValue(answer.Value),
-	}, nil
+	return rep.(*apiv1.GetAnswerAtResponse), nil
 }
 
-func encodeAnswer(answer *domain.Answer) (*apiv1.Answer, error) {
-	if answer == nil {
-		return nil, errors.NewErrInternal("Cannot encode nil value")
+func decodeGetAnswerAtRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.GetAnswerAtRequest)
+	decodedReq := GetAnswerAtRequest{
+		Key: domain.AnswerKey(req.Key),
+	}
+	switch point := req.Point.(type) {
+	case *apiv1.GetAnswerAtRequest_Time:
+		decodedReq.At.Time = point.Time.AsTime()
+	case *apiv1.GetAnswerAtRequest_Version:
+		decodedReq.At.Version = int(point.Version)
 	}
-	return &apiv1.Answer{
-		Key:   string(answer.Key),
-		Value: string(answer.Value),
+	return decodedReq, nil
+}
+
+func encodeGetAnswerAtResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(GetAnswerAtResponse)
+	if resp.Err != nil {
+		return &apiv1.GetAnswerAtResponse{}, resp.Err
+	}
+	encodedAnswer, err := EncodeAnswer(resp.Snapshot.Answer)
+	if err != nil {
+		return &apiv1.GetAnswerAtResponse{}, err
+	}
+	return &apiv1.GetAnswerAtResponse{
+		Answer:     encodedAnswer,
+		Version:    int32(resp.Snapshot.Version),
+		OccurredAt: timestamppb.New(resp.Snapshot.OccurredAt),
 	}, nil
 }
 
-func encodeAnswerEventType(eventType domain.AnswerEventType) (apiv1.AnswerEventType, error) {
-	switch eventType {
-	case domain.CreateAnswerEventType:
-		return apiv1.AnswerEventType_ANSWER_EVENT_TYPE_CREATE, nil
-	case domain.UpdateAnswerEventType:
-		return apiv1.AnswerEventType_ANSWER_EVENT_TYPE_UPDATE, nil
-	case domain.DeleteAnswerEventType:
-		return apiv1.AnswerEventType_ANSWER_EVENT_TYPE_DELETE, nil
+// ListAnswers Impl.
+func (s *grpcServer) ListAnswers(ctx context.Context, req *apiv1.ListAnswersRequest) (*apiv1.ListAnswersResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.listAnswers)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.ListAnswersResponse), nil
+}
+
+// WatchAnswers Impl.
+func (s *grpcServer) WatchAnswers(req *apiv1.WatchAnswersRequest, stream apiv1.AnswerService_WatchAnswersServer) error {
+	err := s.watchService.WatchAnswers(stream.Context(), DecodeWatchFilter(req), int(req.FromVersion), func(event *domain.WatchedAnswerEvent) error {
+		encodedEvent, err := EncodeAnswerEvent(event.Event)
+		if err != nil {
+			return err
+		}
+		return stream.Send(&apiv1.WatchAnswersResponse{
+			AnswerEvent: encodedEvent,
+			Version:     int32(event.Version),
+		})
+	})
+	return errors.GRPCErrorEncoder(err)
+}
+
+// DecodeWatchFilter - converts the selector of the API request to the domain watch filter.
+func DecodeWatchFilter(req *apiv1.WatchAnswersRequest) domain.AnswerWatchFilter {
+	switch selector := req.Selector.(type) {
+	case *apiv1.WatchAnswersRequest_Key:
+		return domain.AnswerWatchFilter{Key: domain.AnswerKey(selector.Key)}
+	case *apiv1.WatchAnswersRequest_Prefix:
+		return domain.AnswerWatchFilter{Prefix: selector.Prefix}
+	case *apiv1.WatchAnswersRequest_Namespace:
+		return domain.AnswerWatchFilter{Namespace: selector.Namespace}
 	default:
-		return apiv1.AnswerEventType_ANSWER_EVENT_TYPE_UNKNOWN, fmt.Errorf("Unknown event type %v", eventType)
+		return domain.AnswerWatchFilter{}
+	}
+}
+
+func decodeListAnswersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.ListAnswersRequest)
+	return ListAnswersRequest{
+		Page: domain.PageRequest{
+			PageToken: req.PageToken,
+			PageSize:  int(req.PageSize),
+		},
+	}, nil
+}
+
+func encodeListAnswersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(ListAnswersResponse)
+	if resp.Err != nil {
+		return &apiv1.ListAnswersResponse{}, resp.Err
+	}
+	answers := make([]*apiv1.Answer, len(resp.Answers))
+	for i, a := range resp.Answers {
+		encodedAnswer, err := EncodeAnswer(a)
+		if err != nil {
+			return &apiv1.ListAnswersResponse{}, err
+		}
+		answers[i] = encodedAnswer
+	}
+	return &apiv1.ListAnswersResponse{
+		Answers:       answers,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+// SearchAnswers Impl.
+func (s *grpcServer) SearchAnswers(ctx context.Context, req *apiv1.SearchAnswersRequest) (*apiv1.SearchAnswersResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.searchAnswers)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.SearchAnswersResponse), nil
+}
+
+func decodeSearchAnswersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.SearchAnswersRequest)
+	keyTerm, err := DecodeAnswerSearchTerm(req.Key)
+	if err != nil {
+		return SearchAnswersRequest{}, err
+	}
+	valueTerm, err := DecodeAnswerSearchTerm(req.Value)
+	if err != nil {
+		return SearchAnswersRequest{}, err
 	}
+	return SearchAnswersRequest{
+		Query: domain.AnswerSearchQuery{
+			Key:   keyTerm,
+			Value: valueTerm,
+		},
+		Page: domain.PageRequest{
+			PageToken: req.PageToken,
+			PageSize:  int(req.PageSize),
+		},
+	}, nil
+}
+
+func encodeSearchAnswersResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(SearchAnswersResponse)
+	if resp.Err != nil {
+		return &apiv1.SearchAnswersResponse{}, resp.Err
+	}
+	answers := make([]*apiv1.Answer, len(resp.Answers))
+	for i, a := range resp.Answers {
+		encodedAnswer, err := EncodeAnswer(a)
+		if err != nil {
+			return &apiv1.SearchAnswersResponse{}, err
+		}
+		answers[i] = encodedAnswer
+	}
+	return &apiv1.SearchAnswersResponse{
+		Answers:       answers,
+		NextPageToken: resp.NextPageToken,
+	}, nil
 }
@@ -0,0 +1,212 @@
+package answer
+
+import (
+	"context"
+
+	apiv2 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v2"
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/helpers"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"github.com/go-kit/log"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// grpcServerV2 - serves the v2 API from the same endpoints as the v1 API.
+type grpcServerV2 struct {
+	listAnswers  grpctransport.Handler
+	getAnswer    grpctransport.Handler
+	createAnswer grpctransport.Handler
+	updateAnswer grpctransport.Handler
+	deleteAnswer grpctransport.Handler
+}
+
+// NewGRPCServerV2 makes a set of endpoints available as a gRPC server of the v2 API.
+func NewGRPCServerV2(endpoints Endpoints, logger log.Logger) apiv2.AnswerServiceServer {
+	options := helpers.SetupServerOptions(logger)
+	return &grpcServerV2{
+		listAnswers: grpctransport.NewServer(
+			endpoints.ListAnswersEndpoint,
+			decodeListAnswersRequestV2,
+			encodeListAnswersResponseV2,
+			options...,
+		),
+		getAnswer: grpctransport.NewServer(
+			endpoints.GetAnswerEndpoint,
+			decodeGetAnswerRequestV2,
+			encodeGetAnswerResponseV2,
+			options...,
+		),
+		createAnswer: grpctransport.NewServer(
+			endpoints.CreateAnswerEndpoint,
+			decodeCreateAnswerRequestV2,
+			encodeCreateAnswerResponseV2,
+			options...,
+		),
+		updateAnswer: grpctransport.NewServer(
+			endpoints.UpdateAnswerEndpoint,
+			decodeUpdateAnswerRequestV2,
+			encodeUpdateAnswerResponseV2,
+			options...,
+		),
+		deleteAnswer: grpctransport.NewServer(
+			endpoints.DeleteAnswerEndpoint,
+			decodeDeleteAnswerRequestV2,
+			encodeDeleteAnswerResponseV2,
+			options...,
+		),
+	}
+}
+
+// ListAnswers Impl.
+func (s *grpcServerV2) ListAnswers(ctx context.Context, req *apiv2.ListAnswersRequest) (*apiv2.ListAnswersResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.listAnswers)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv2.ListAnswersResponse), nil
+}
+
+func decodeListAnswersRequestV2(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv2.ListAnswersRequest)
+	return ListAnswersRequest{
+		Page: domain.PageRequest{
+			PageToken: req.PageToken,
+			PageSize:  int(req.PageSize),
+		},
+	}, nil
+}
+
+func encodeListAnswersResponseV2(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(ListAnswersResponse)
+	if resp.Err != nil {
+		return &apiv2.ListAnswersResponse{}, resp.Err
+	}
+	answers := make([]*apiv2.Answer, len(resp.Answers))
+	for i, a := range resp.Answers {
+		encodedAnswer, err := EncodeAnswerV2(a)
+		if err != nil {
+			return &apiv2.ListAnswersResponse{}, err
+		}
+		answers[i] = encodedAnswer
+	}
+	return &apiv2.ListAnswersResponse{
+		Answers:       answers,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+// GetAnswer Impl.
+func (s *grpcServerV2) GetAnswer(ctx context.Context, req *apiv2.GetAnswerRequest) (*apiv2.Answer, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.getAnswer)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv2.Answer), nil
+}
+
+func decodeGetAnswerRequestV2(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv2.GetAnswerRequest)
+	key, err := DecodeAnswerName(req.Name)
+	if err != nil {
+		return GetAnswerRequest{}, err
+	}
+	return GetAnswerRequest{
+		Key: key,
+	}, nil
+}
+
+func encodeGetAnswerResponseV2(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(GetAnswerResponse)
+	if resp.Err != nil {
+		return &apiv2.Answer{}, resp.Err
+	}
+	return EncodeAnswerV2(resp.Answer)
+}
+
+// CreateAnswer Impl.
+func (s *grpcServerV2) CreateAnswer(ctx context.Context, req *apiv2.CreateAnswerRequest) (*apiv2.Answer, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.createAnswer)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv2.Answer), nil
+}
+
+func decodeCreateAnswerRequestV2(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv2.CreateAnswerRequest)
+	if req.Answer == nil {
+		return CreateAnswerRequest{}, errors.NewErrInvalidArgument("Answer required")
+	}
+	return CreateAnswerRequest{
+		Answer: &domain.Answer{
+			Key:   domain.AnswerKey(req.AnswerId),
+			Value: domain.AnswerValue(req.Answer.Value),
+		},
+	}, nil
+}
+
+func encodeCreateAnswerResponseV2(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(CreateAnswerResponse)
+	if resp.Err != nil {
+		return &apiv2.Answer{}, resp.Err
+	}
+	return EncodeAnswerV2(resp.Mutation.Answer)
+}
+
+// UpdateAnswer Impl.
+func (s *grpcServerV2) UpdateAnswer(ctx context.Context, req *apiv2.UpdateAnswerRequest) (*apiv2.Answer, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.updateAnswer)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv2.Answer), nil
+}
+
+func decodeUpdateAnswerRequestV2(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv2.UpdateAnswerRequest)
+	decodedAnswer, err := DecodeAnswerUpdate(req.Answer, req.UpdateMask)
+	if err != nil {
+		return UpdateAnswerRequest{}, err
+	}
+	return UpdateAnswerRequest{
+		Answer: decodedAnswer,
+	}, nil
+}
+
+func encodeUpdateAnswerResponseV2(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(UpdateAnswerResponse)
+	if resp.Err != nil {
+		return &apiv2.Answer{}, resp.Err
+	}
+	return EncodeAnswerV2(resp.Mutation.Answer)
+}
+
+// DeleteAnswer Impl.
+func (s *grpcServerV2) DeleteAnswer(ctx context.Context, req *apiv2.DeleteAnswerRequest) (*emptypb.Empty, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.deleteAnswer)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*emptypb.Empty), nil
+}
+
+func decodeDeleteAnswerRequestV2(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv2.DeleteAnswerRequest)
+	key, err := DecodeAnswerName(req.Name)
+	if err != nil {
+		return DeleteAnswerRequest{}, err
+	}
+	return DeleteAnswerRequest{
+		Key: key,
+	}, nil
+}
+
+func encodeDeleteAnswerResponseV2(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(DeleteAnswerResponse)
+	if resp.Err != nil {
+		return &emptypb.Empty{}, resp.Err
+	}
+	return &emptypb.Empty{}, nil
+}
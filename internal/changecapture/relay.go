@@ -0,0 +1,55 @@
+package changecapture
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+)
+
+// Relay - publishes the answer events derived from the change stream of the answer table,
+// so the events are captured even for the writes made outside the answer service.
+type Relay struct {
+	stream       domain.AnswerChangeStream
+	queueService domain.QueueService
+	destination  string
+	logger       log.Logger
+}
+
+// NewRelay - sets up a relay sending the events to the destination queue or topic.
+func NewRelay(stream domain.AnswerChangeStream, queueService domain.QueueService, destination string, logger log.Logger) *Relay {
+	return &Relay{
+		stream:       stream,
+		queueService: queueService,
+		destination:  destination,
+		logger:       logger,
+	}
+}
+
+// Run - relays the changes till the context is done.
+func (r *Relay) Run(ctx context.Context) error {
+	return r.stream.Read(ctx, r)
+}
+
+// HandleChange - sends the event of the change with the ID the answer service reported for it,
+// the changes which are not events are skipped. A change whose event is not sent is passed again,
+// so an event may be sent more than once.
+func (r *Relay) HandleChange(ctx context.Context, change *domain.AnswerChange) error {
+	event, eventID, ok := change.Event()
+	if !ok {
+		return nil
+	}
+	_, err := r.queueService.SendMessage(ctx, r.destination, &domain.AnswerEventMessage{
+		Event:   event,
+		EventID: eventID,
+	})
+	if err != nil {
+		_ = r.logger.Log("change", change.ID, "err", err.Error())
+	}
+	return err
+}
+
+var (
+	_ domain.AnswerChangeHandler = &Relay{}
+)
@@ -0,0 +1,181 @@
+package changecapture
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/inmemory"
+	"dochq.co.uk.answerservice/internal/memqueue"
+
+	"github.com/go-kit/log"
+	"github.com/go-test/deep"
+)
+
+// failingQueueService - fails the first messages, then sends them to the next service.
+type failingQueueService struct {
+	next     domain.QueueService
+	failures int
+}
+
+func (s *failingQueueService) SendMessage(ctx context.Context, queueName string, message domain.QueueMessage) (string, error) {
+	if s.failures > 0 {
+		s.failures--
+		return "", fmt.Errorf("queue unavailable")
+	}
+	return s.next.SendMessage(ctx, queueName, message)
+}
+
+func TestRelay(t *testing.T) {
+	repository, stream := inmemory.NewAnswerRepositoryWithChangeStream()
+	broker := memqueue.NewBroker(log.NewNopLogger())
+	relay := NewRelay(stream, &failingQueueService{next: broker, failures: 1}, "answer.events", log.NewNopLogger())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Write the answers directly, as a client bypassing the answer service would.
+	//
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	writes := []func() error{
+		func() error { return repository.Create(&domain.Answer{Key: "name", Value: "John"}) },
+		func() error { return repository.Update(&domain.Answer{Key: "name", Value: "Sam"}) },
+		func() error { _, err := repository.Delete("name", domain.NewTombstone(deletedAt, 0)); return err },
+		func() error { return repository.Purge("name", deletedAt) },
+		func() error { return repository.Create(&domain.Answer{Key: "name", Value: "Ann"}) },
+		func() error { return repository.Erase("name") },
+	}
+	for i, write := range writes {
+		if err := write(); err != nil {
+			t.Fatalf("write %d: unexpected err: %v", i, err)
+		}
+	}
+
+	// Relay the changes, the first event is sent again after the queue failed.
+	//
+	relayCtx, stopRelay := context.WithCancel(ctx)
+	relayDone := make(chan error)
+	go func() { relayDone <- relay.Run(relayCtx) }()
+
+	// The purge and the erasure are not events, the deletion holds the deleted value.
+	//
+	want := []domain.AnswerEvent{
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John", Version: 1}},
+		{EventType: domain.UpdateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam", Version: 2}},
+		{EventType: domain.DeleteAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam", Version: 3}, OccurredAt: deletedAt},
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Ann", Version: 1}},
+	}
+	var got []domain.AnswerEvent
+	consumeCtx, stopConsumer := context.WithCancel(ctx)
+	_ = broker.Consume(consumeCtx, "answer.events", domain.QueueHandlerFunc(func(ctx context.Context, msg *domain.ReceivedMessage) error {
+		payload := &domain.AnswerEventMessage{}
+		if err := json.Unmarshal(msg.Body, payload); err != nil {
+			return err
+		}
+		event := *payload.Event
+		if event.EventType != domain.DeleteAnswerEventType {
+			if event.OccurredAt.IsZero() {
+				t.Errorf("%v event has no occurrence time", event.EventType)
+			}
+			event.OccurredAt = time.Time{}
+		}
+		got = append(got, event)
+		if len(got) == len(want) {
+			stopConsumer()
+		}
+		return nil
+	}))
+	stopConsumer()
+	stopRelay()
+	if err := <-relayDone; err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRelayTaggedWrites(t *testing.T) {
+	repository, stream := inmemory.NewAnswerRepositoryWithChangeStream()
+	tagged := repository.(domain.TaggedAnswerRepository)
+	broker := memqueue.NewBroker(log.NewNopLogger())
+	relay := NewRelay(stream, broker, "answer.events", log.NewNopLogger())
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The answer service tags its writes, the restore is not seen as an update and the untagged
+	// update of a client bypassing the service gets no ID.
+	//
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	restoredAt := createdAt.Add(time.Minute)
+	deletedAt := createdAt.Add(time.Hour)
+	writes := []func() error{
+		func() error {
+			return tagged.CreateTagged(&domain.Answer{Key: "name", Value: "John"},
+				domain.AnswerChangeTag{EventType: domain.CreateAnswerEventType, EventID: "event-1", OccurredAt: createdAt})
+		},
+		func() error { return repository.Update(&domain.Answer{Key: "name", Value: "Sam"}) },
+		func() error {
+			return tagged.UpdateTagged(&domain.Answer{Key: "name", Value: "John"},
+				domain.AnswerChangeTag{EventType: domain.RestoreAnswerEventType, EventID: "event-2", OccurredAt: restoredAt})
+		},
+		func() error {
+			_, err := tagged.DeleteTagged("name", domain.NewTombstone(deletedAt, time.Hour),
+				domain.AnswerChangeTag{EventType: domain.DeleteAnswerEventType, EventID: "event-3", OccurredAt: deletedAt})
+			return err
+		},
+	}
+	for i, write := range writes {
+		if err := write(); err != nil {
+			t.Fatalf("write %d: unexpected err: %v", i, err)
+		}
+	}
+
+	relayCtx, stopRelay := context.WithCancel(ctx)
+	relayDone := make(chan error)
+	go func() { relayDone <- relay.Run(relayCtx) }()
+
+	want := []*domain.AnswerEventMessage{
+		{
+			Event:   &domain.AnswerEvent{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John", Version: 1}, OccurredAt: createdAt},
+			EventID: "event-1",
+		},
+		{
+			Event: &domain.AnswerEvent{EventType: domain.UpdateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam", Version: 2}},
+		},
+		{
+			Event:   &domain.AnswerEvent{EventType: domain.RestoreAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John", Version: 3}, OccurredAt: restoredAt},
+			EventID: "event-2",
+		},
+		{
+			Event:   &domain.AnswerEvent{EventType: domain.DeleteAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John", Version: 4}, OccurredAt: deletedAt},
+			EventID: "event-3",
+		},
+	}
+	var got []*domain.AnswerEventMessage
+	consumeCtx, stopConsumer := context.WithCancel(ctx)
+	_ = broker.Consume(consumeCtx, "answer.events", domain.QueueHandlerFunc(func(ctx context.Context, msg *domain.ReceivedMessage) error {
+		payload := &domain.AnswerEventMessage{}
+		if err := json.Unmarshal(msg.Body, payload); err != nil {
+			return err
+		}
+		if len(payload.EventID) == 0 {
+			payload.Event.OccurredAt = time.Time{}
+		}
+		got = append(got, payload)
+		if len(got) == len(want) {
+			stopConsumer()
+		}
+		return nil
+	}))
+	stopConsumer()
+	stopRelay()
+	if err := <-relayDone; err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+// QueueBackend - name of a queue implementation.
+type QueueBackend string
+
+// Queue backends.
+const (
+	SQSQueueBackend    = QueueBackend("sqs")
+	MemoryQueueBackend = QueueBackend("memory")
+	NATSQueueBackend   = QueueBackend("nats")
+)
+
+// IsInProcess - checks if the publisher and the consumer must run in the same process.
+func (b QueueBackend) IsInProcess() bool {
+	return b == MemoryQueueBackend
+}
+
+// StorageBackend - name of a storage implementation.
+type StorageBackend string
+
+// Storage backends.
+const (
+	DynamoDBStorageBackend = StorageBackend("dynamodb")
+	MemoryStorageBackend   = StorageBackend("memory")
+	PostgresStorageBackend = StorageBackend("postgres")
+)
+
+// IsInProcess - checks if the data is only visible inside the current process.
+func (b StorageBackend) IsInProcess() bool {
+	return b == MemoryStorageBackend
+}
+
+// EventSource - name of the way the answer events are captured.
+type EventSource string
+
+// Event sources.
+const (
+	// ServiceEventSource - the application sends the events after writing the answers.
+	ServiceEventSource = EventSource("service")
+	// StreamEventSource - the worker derives the events from the change stream of the answer table.
+	StreamEventSource = EventSource("stream")
+)
+
+// KeyProvider - name of a master key implementation of the answer value encryption.
+type KeyProvider string
+
+// Key providers.
+const (
+	NoKeyProvider    = KeyProvider("none")
+	LocalKeyProvider = KeyProvider("local")
+	KMSKeyProvider   = KeyProvider("kms")
+)
+
+// RateLimitBackend - name of the place the token buckets of the rate limited clients are kept in.
+type RateLimitBackend string
+
+// Rate limit backends.
+const (
+	NoRateLimitBackend = RateLimitBackend("none")
+	// MemoryRateLimitBackend - every replica limits the requests it serves by itself.
+	MemoryRateLimitBackend = RateLimitBackend("memory")
+	// StorageRateLimitBackend - the replicas share the buckets in the storage backend.
+	StorageRateLimitBackend = RateLimitBackend("storage")
+)
+
+// RedactionPolicy - name of the way an answer value or key is written to the logs.
+type RedactionPolicy string
+
+// Redaction policies.
+const (
+	NoRedactionPolicy   = RedactionPolicy("none")
+	HashRedactionPolicy = RedactionPolicy("hash")
+	MaskRedactionPolicy = RedactionPolicy("mask")
+	DropRedactionPolicy = RedactionPolicy("drop")
+)
+
+// IsValid - checks if the policy is supported.
+func (p RedactionPolicy) IsValid() bool {
+	switch p {
+	case NoRedactionPolicy, HashRedactionPolicy, MaskRedactionPolicy, DropRedactionPolicy:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,717 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Command - name of a binary, it selects the accepted flags and the validation rules.
+type Command string
+
+// Commands.
+const (
+	AppCommand       = Command("app")
+	WorkerCommand    = Command("worker")
+	ProvisionCommand = Command("provision")
+	ReconcileCommand = Command("reconcile")
+	PurgeCommand     = Command("purge")
+)
+
+// Config - the configuration shared by the application, the worker and the provision, reconcile and purge commands.
+type Config struct {
+	GRPCAddr    string     `yaml:"grpcAddr"`
+	HTTPAddr    string     `yaml:"httpAddr"`
+	SwaggerPath string     `yaml:"swaggerPath"`
+	AWS         AWS        `yaml:"aws"`
+	Queue       Queue      `yaml:"queue"`
+	Storage     Storage    `yaml:"storage"`
+	Encryption  Encryption `yaml:"encryption"`
+	Redaction   Redaction  `yaml:"redaction"`
+	Webhook     Webhook    `yaml:"webhook"`
+	RateLimit   RateLimit  `yaml:"rateLimit"`
+	Auth        Auth       `yaml:"auth"`
+
+	// PrintConfig - the configuration must be printed instead of running the command.
+	PrintConfig bool `yaml:"-"`
+}
+
+// AWS - the AWS session configuration.
+type AWS struct {
+	// MockServerAddress - must only be used in development, it overrides the real AWS endpoints.
+	MockServerAddress string `yaml:"mockServerAddress"`
+}
+
+// Queue - the queue backend configuration.
+type Queue struct {
+	Backend              QueueBackend `yaml:"backend"`
+	AnswerEventQueueName string       `yaml:"answerEventQueueName"`
+	// AnswerEventTopicName - the answer events are published to the topic if set,
+	// the answer event queue is then one of its subscription queues.
+	AnswerEventTopicName string `yaml:"answerEventTopicName"`
+	// WebhookQueueName - the subscription queue of the webhook deliveries,
+	// the webhooks are not delivered if empty.
+	WebhookQueueName string `yaml:"webhookQueueName"`
+	// SearchQueueName - the subscription queue of the search index embedded in the application,
+	// the answers cannot be searched if empty.
+	SearchQueueName string `yaml:"searchQueueName"`
+	// ConsumeSearchQueue - the replica embeds the search index and consumes the search queue.
+	// Only one replica may consume the queue, the other replicas do not serve the search.
+	ConsumeSearchQueue bool `yaml:"consumeSearchQueue"`
+	// WatchQueueName - the subscription queue of the watches of the application,
+	// the watches only replay the history if empty.
+	WatchQueueName string `yaml:"watchQueueName"`
+	// Subscriptions - the other subscription queues of the topic, e.g. of the analytics.
+	Subscriptions []Subscription `yaml:"subscriptions"`
+	// AnswerEventSource - whether the application sends the answer events or the worker captures them.
+	AnswerEventSource EventSource `yaml:"answerEventSource"`
+	NATSURL           string      `yaml:"natsURL"`
+	SQS               SQS         `yaml:"sqs"`
+}
+
+// Subscription - a subscription queue of the answer event topic.
+type Subscription struct {
+	QueueName string `yaml:"queueName"`
+	// FilterPolicy - the queue only receives the messages with one of the listed values of every attribute.
+	FilterPolicy map[string][]string `yaml:"filterPolicy"`
+}
+
+// AnswerEventDestination - returns the name the answer events are sent to, the topic if set or else the queue.
+func (q Queue) AnswerEventDestination() string {
+	if len(q.AnswerEventTopicName) > 0 {
+		return q.AnswerEventTopicName
+	}
+	return q.AnswerEventQueueName
+}
+
+// TopicSubscriptions - returns the subscription queues of the answer event topic:
+// the answer event queue, the webhook, the search and the watch queues if set and the other subscriptions.
+func (q Queue) TopicSubscriptions() []Subscription {
+	subscriptions := []Subscription{{QueueName: q.AnswerEventQueueName}}
+	if len(q.WebhookQueueName) > 0 {
+		subscriptions = append(subscriptions, Subscription{QueueName: q.WebhookQueueName})
+	}
+	if len(q.SearchQueueName) > 0 {
+		subscriptions = append(subscriptions, Subscription{QueueName: q.SearchQueueName})
+	}
+	if len(q.WatchQueueName) > 0 {
+		subscriptions = append(subscriptions, Subscription{QueueName: q.WatchQueueName})
+	}
+	return append(subscriptions, q.Subscriptions...)
+}
+
+// SQS - the SQS backend configuration.
+type SQS struct {
+	MaxNumberOfMessages int64 `yaml:"maxNumberOfMessages"`
+	// VisibilityTimeout - in seconds.
+	VisibilityTimeout int64 `yaml:"visibilityTimeout"`
+}
+
+// Storage - the storage backend configuration.
+type Storage struct {
+	Backend              StorageBackend `yaml:"backend"`
+	AnswerTableName      string         `yaml:"answerTableName"`
+	AnswerEventTableName string         `yaml:"answerEventTableName"`
+	PostgresDSN          string         `yaml:"postgresDSN"`
+	// LegacyAnswerEventTableName - the DynamoDB answer event table keyed by the event type, its events are
+	// copied to the answer event table by the provision command.
+	LegacyAnswerEventTableName string `yaml:"legacyAnswerEventTableName"`
+	// WebhookSubscriptionTableName and WebhookDeliveryTableName - the DynamoDB tables of the webhooks.
+	WebhookSubscriptionTableName string `yaml:"webhookSubscriptionTableName"`
+	WebhookDeliveryTableName     string `yaml:"webhookDeliveryTableName"`
+	// DeletedAnswerRetention - in days, deleted answers are purged afterwards.
+	DeletedAnswerRetention int64 `yaml:"deletedAnswerRetentionDays"`
+	// IdempotencyTableName - the DynamoDB table of the idempotency keys.
+	IdempotencyTableName string `yaml:"idempotencyTableName"`
+	// ErasureTableName - the DynamoDB table of the tombstones of the erased answer keys.
+	ErasureTableName string `yaml:"erasureTableName"`
+	// RateLimitTableName - the DynamoDB table of the rate limit buckets, required by the storage rate limit backend.
+	RateLimitTableName string `yaml:"rateLimitTableName"`
+	// IdempotencyKeyRetention - in hours, a retry with an idempotency key is replayed for this long.
+	IdempotencyKeyRetention int64 `yaml:"idempotencyKeyRetentionHours"`
+	// IdempotencyKeyLease - in seconds, a retry may take over the key of a request in progress afterwards,
+	// e.g. if the replica making the request stopped. It must exceed the time a request may take.
+	IdempotencyKeyLease int64 `yaml:"idempotencyKeyLeaseSeconds"`
+}
+
+// DeletedAnswerRetentionPeriod - returns the period a deleted answer is kept for.
+func (s Storage) DeletedAnswerRetentionPeriod() time.Duration {
+	return time.Duration(s.DeletedAnswerRetention) * 24 * time.Hour
+}
+
+// IdempotencyKeyRetentionPeriod - returns the period an idempotency key is kept for.
+func (s Storage) IdempotencyKeyRetentionPeriod() time.Duration {
+	return time.Duration(s.IdempotencyKeyRetention) * time.Hour
+}
+
+// IdempotencyKeyLeasePeriod - returns the period the key of a request in progress is reserved for.
+func (s Storage) IdempotencyKeyLeasePeriod() time.Duration {
+	return time.Duration(s.IdempotencyKeyLease) * time.Second
+}
+
+// Encryption - the envelope encryption of the answer values in the storage and in the queue.
+type Encryption struct {
+	KeyProvider  KeyProvider `yaml:"keyProvider"`
+	LocalKeyFile string      `yaml:"localKeyFile"`
+	KMSKeyID     string      `yaml:"kmsKeyID"`
+	// DataKeyLifetime - in seconds, a data key encrypts the values for this long before a new one is generated.
+	DataKeyLifetime int64 `yaml:"dataKeyLifetime"`
+}
+
+// DataKeyLifetimePeriod - returns the period a data key encrypts the values for.
+func (e Encryption) DataKeyLifetimePeriod() time.Duration {
+	return time.Duration(e.DataKeyLifetime) * time.Second
+}
+
+// Redaction - the redaction of the answers in the logs.
+type Redaction struct {
+	// ValuePolicy - applies to every answer value.
+	ValuePolicy RedactionPolicy `yaml:"valuePolicy"`
+	// KeyPolicy - applies to the answer keys matching one of the sensitive key patterns.
+	KeyPolicy            RedactionPolicy `yaml:"keyPolicy"`
+	SensitiveKeyPatterns []string        `yaml:"sensitiveKeyPatterns"`
+	// HashSalt - keys the hashes, so they cannot be reversed by hashing guessed values.
+	// It also keys the hashes of the answer keys in the erasure tombstones, the application and the worker must share it.
+	HashSalt string `yaml:"hashSalt"`
+}
+
+// Webhook - the delivery of the answer events to the webhook subscriptions.
+type Webhook struct {
+	MaxAttempts int64 `yaml:"maxAttempts"`
+	// InitialBackoff - in seconds, the delay before the second attempt, it is doubled before every further attempt.
+	InitialBackoff int64 `yaml:"initialBackoff"`
+	// Timeout - in seconds, of a single attempt.
+	Timeout int64 `yaml:"timeout"`
+	// AllowedNetworks - in CIDR notation, they may receive the webhooks besides the public addresses.
+	AllowedNetworks []string `yaml:"allowedNetworks"`
+}
+
+// InitialBackoffPeriod - returns the delay before the second attempt.
+func (w Webhook) InitialBackoffPeriod() time.Duration {
+	return time.Duration(w.InitialBackoff) * time.Second
+}
+
+// TimeoutPeriod - returns the timeout of a single attempt.
+func (w Webhook) TimeoutPeriod() time.Duration {
+	return time.Duration(w.Timeout) * time.Second
+}
+
+// RateLimit - the rate limiting of the requests of every client to each method of the APIs.
+// A client is identified by the verified subject of its token, or else by its address.
+// The requests are let through while the buckets cannot be reached.
+type RateLimit struct {
+	Backend RateLimitBackend `yaml:"backend"`
+	// RequestsPerMinute and Burst - the default token bucket of a client, it holds up to Burst requests
+	// and gets RequestsPerMinute requests every minute.
+	RequestsPerMinute int64 `yaml:"requestsPerMinute"`
+	Burst             int64 `yaml:"burst"`
+	// TrustedProxies - the number of proxies in front of the application, each appends the address
+	// it received the request from to X-Forwarded-For.
+	TrustedProxies int64 `yaml:"trustedProxies"`
+	// Methods - the token buckets of some methods instead of the default one.
+	Methods []MethodRateLimit `yaml:"methods"`
+}
+
+// MethodRateLimit - the token bucket of a client for a method, e.g. GetAnswer.
+type MethodRateLimit struct {
+	Method string `yaml:"method"`
+	// RequestsPerMinute - the method is not limited if it is 0.
+	RequestsPerMinute int64 `yaml:"requestsPerMinute"`
+	// Burst - the default burst is used if it is 0.
+	Burst int64 `yaml:"burst"`
+}
+
+// Auth - the authentication of the clients of the APIs.
+type Auth struct {
+	// JWTSecret - the HMAC secret the bearer tokens of the clients are signed with (HS256), the subject of a
+	// verified token identifies the client. The tokens are not verified if empty, the clients are then anonymous
+	// and the idempotency keys are shared by all of them.
+	JWTSecret string `yaml:"jwtSecret"`
+}
+
+// Default - returns the configuration used when nothing is overridden.
+func Default() *Config {
+	return &Config{
+		GRPCAddr:    DefaultGRPCAddr,
+		HTTPAddr:    DefaultHTTPAddr,
+		SwaggerPath: DefaultSwaggerPath,
+		Queue: Queue{
+			Backend:           SQSQueueBackend,
+			AnswerEventSource: ServiceEventSource,
+			NATSURL:           DefaultNATSURL,
+			SQS: SQS{
+				MaxNumberOfMessages: DefaultSQSMaxNumberOfMessages,
+				VisibilityTimeout:   DefaultSQSVisibilityTimeout,
+			},
+		},
+		Storage: Storage{
+			Backend:                 DynamoDBStorageBackend,
+			DeletedAnswerRetention:  DefaultDeletedAnswerRetention,
+			IdempotencyKeyRetention: DefaultIdempotencyKeyRetention,
+			IdempotencyKeyLease:     DefaultIdempotencyKeyLease,
+		},
+		Encryption: Encryption{
+			KeyProvider:     NoKeyProvider,
+			DataKeyLifetime: DefaultDataKeyLifetime,
+		},
+		Redaction: Redaction{
+			ValuePolicy: MaskRedactionPolicy,
+			KeyPolicy:   HashRedactionPolicy,
+		},
+		Webhook: Webhook{
+			MaxAttempts:    DefaultWebhookMaxAttempts,
+			InitialBackoff: DefaultWebhookInitialBackoff,
+			Timeout:        DefaultWebhookTimeout,
+		},
+		RateLimit: RateLimit{
+			Backend:           NoRateLimitBackend,
+			RequestsPerMinute: DefaultRateLimitRequests,
+			Burst:             DefaultRateLimitBurst,
+		},
+	}
+}
+
+// Load - loads the configuration of the command.
+// Each source overrides the previous one: defaults, the YAML file, the environment and the flags.
+func Load(command Command, args []string) (*Config, error) {
+	defaults := Default()
+
+	// Define flags, they are only applied when set explicitly.
+	//
+	fs := flag.NewFlagSet(string(command), flag.ContinueOnError)
+	configFile := fs.String(flagConfig, os.Getenv(EnvConfigFile), "path to an optional YAML configuration file")
+	printConfig := fs.Bool(flagPrintConfig, false, "print the effective configuration and exit")
+	flags := map[string]*string{
+		flagQueueBackend:   fs.String(flagQueueBackend, string(defaults.Queue.Backend), "queue backend: sqs, memory or nats"),
+		flagStorageBackend: fs.String(flagStorageBackend, string(defaults.Storage.Backend), "storage backend: dynamodb, memory or postgres"),
+	}
+	if command == AppCommand {
+		flags[flagGRPCAddr] = fs.String(flagGRPCAddr, defaults.GRPCAddr, "gRPC listen address")
+		flags[flagHTTPAddr] = fs.String(flagHTTPAddr, defaults.HTTPAddr, "HTTP listen address")
+		flags[flagSwaggerPath] = fs.String(flagSwaggerPath, defaults.SwaggerPath, "path to the swagger specification")
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// Apply sources.
+	//
+	cfg := defaults
+	if len(*configFile) > 0 {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if value, ok := flags[f.Name]; ok {
+			*cfg.flagFields()[f.Name] = *value
+		}
+	})
+	cfg.PrintConfig = *printConfig
+
+	// Fail fast on invalid configuration.
+	//
+	if err := cfg.Validate(command); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate - checks that the configuration is usable by the command.
+func (c *Config) Validate(command Command) error {
+	var problems []string
+	required := func(value, name string) {
+		if len(value) == 0 {
+			problems = append(problems, name+" required")
+		}
+	}
+
+	// Queue, the reconcile and purge commands only access the storage.
+	//
+	if command != ReconcileCommand && command != PurgeCommand {
+		required(c.Queue.AnswerEventQueueName, EnvAnswerEventQueueName)
+		switch c.Queue.Backend {
+		case SQSQueueBackend:
+			if c.Queue.SQS.MaxNumberOfMessages < 1 || c.Queue.SQS.MaxNumberOfMessages > maxSQSMaxNumberOfMessages {
+				problems = append(problems, fmt.Sprintf("%v must be between 1 and %v", EnvSQSMaxNumberOfMessages, maxSQSMaxNumberOfMessages))
+			}
+			if c.Queue.SQS.VisibilityTimeout < 0 || c.Queue.SQS.VisibilityTimeout > maxSQSVisibilityTimeout {
+				problems = append(problems, fmt.Sprintf("%v must be between 0 and %v", EnvSQSVisibilityTimeout, maxSQSVisibilityTimeout))
+			}
+		case NATSQueueBackend:
+			required(c.Queue.NATSURL, EnvNATSURL)
+		case MemoryQueueBackend:
+		default:
+			problems = append(problems, fmt.Sprintf("unsupported queue backend %q", c.Queue.Backend))
+		}
+		problems = append(problems, c.Queue.validateSubscriptions()...)
+		if c.Queue.ConsumeSearchQueue && len(c.Queue.SearchQueueName) == 0 {
+			problems = append(problems, fmt.Sprintf("%v required by %v", EnvSearchQueueName, EnvConsumeSearchQueue))
+		}
+
+		// Only the stored answers of some backends can be captured.
+		//
+		switch c.Queue.AnswerEventSource {
+		case ServiceEventSource:
+		case StreamEventSource:
+			if c.Storage.Backend != DynamoDBStorageBackend && c.Storage.Backend != MemoryStorageBackend {
+				problems = append(problems, fmt.Sprintf("answer event source %v requires storage backend %v or %v",
+					StreamEventSource, DynamoDBStorageBackend, MemoryStorageBackend))
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("unsupported answer event source %q", c.Queue.AnswerEventSource))
+		}
+	}
+
+	// Storage.
+	//
+	switch c.Storage.Backend {
+	case DynamoDBStorageBackend:
+		required(c.Storage.AnswerTableName, EnvAnswerTableName)
+		required(c.Storage.AnswerEventTableName, EnvAnswerEventTableName)
+		required(c.Storage.WebhookSubscriptionTableName, EnvWebhookSubscriptionTableName)
+		required(c.Storage.WebhookDeliveryTableName, EnvWebhookDeliveryTableName)
+		required(c.Storage.IdempotencyTableName, EnvIdempotencyTableName)
+		required(c.Storage.ErasureTableName, EnvErasureTableName)
+		if len(c.Storage.LegacyAnswerEventTableName) > 0 && c.Storage.LegacyAnswerEventTableName == c.Storage.AnswerEventTableName {
+			problems = append(problems, fmt.Sprintf("%v must differ from %v", EnvLegacyAnswerEventTableName, EnvAnswerEventTableName))
+		}
+	case PostgresStorageBackend:
+		required(c.Storage.PostgresDSN, EnvPostgresDSN)
+	case MemoryStorageBackend:
+	default:
+		problems = append(problems, fmt.Sprintf("unsupported storage backend %q", c.Storage.Backend))
+	}
+	if c.Storage.DeletedAnswerRetention < 0 {
+		problems = append(problems, fmt.Sprintf("%v must not be negative", EnvDeletedAnswerRetention))
+	}
+	if c.Storage.IdempotencyKeyRetention < 1 {
+		problems = append(problems, fmt.Sprintf("%v must be positive", EnvIdempotencyKeyRetention))
+	}
+	if c.Storage.IdempotencyKeyLease < 1 {
+		problems = append(problems, fmt.Sprintf("%v must be positive", EnvIdempotencyKeyLease))
+	}
+
+	// Encryption.
+	//
+	switch c.Encryption.KeyProvider {
+	case LocalKeyProvider:
+		required(c.Encryption.LocalKeyFile, EnvLocalKeyFile)
+	case KMSKeyProvider:
+		required(c.Encryption.KMSKeyID, EnvKMSKeyID)
+	case NoKeyProvider:
+	default:
+		problems = append(problems, fmt.Sprintf("unsupported key provider %q", c.Encryption.KeyProvider))
+	}
+	if c.Encryption.DataKeyLifetime < 1 {
+		problems = append(problems, fmt.Sprintf("%v must be positive", EnvDataKeyLifetime))
+	}
+
+	// Redaction.
+	//
+	if !c.Redaction.ValuePolicy.IsValid() {
+		problems = append(problems, fmt.Sprintf("unsupported %v %q", EnvValueRedactionPolicy, c.Redaction.ValuePolicy))
+	}
+	if !c.Redaction.KeyPolicy.IsValid() {
+		problems = append(problems, fmt.Sprintf("unsupported %v %q", EnvKeyRedactionPolicy, c.Redaction.KeyPolicy))
+	}
+	for _, pattern := range c.Redaction.SensitiveKeyPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid %v %q", EnvSensitiveKeyPatterns, pattern))
+		}
+	}
+
+	// Webhooks.
+	//
+	if c.Webhook.MaxAttempts < 1 {
+		problems = append(problems, fmt.Sprintf("%v must be positive", EnvWebhookMaxAttempts))
+	}
+	if c.Webhook.InitialBackoff < 0 {
+		problems = append(problems, fmt.Sprintf("%v must not be negative", EnvWebhookInitialBackoff))
+	}
+	if c.Webhook.Timeout < 1 {
+		problems = append(problems, fmt.Sprintf("%v must be positive", EnvWebhookTimeout))
+	}
+	for _, network := range c.Webhook.AllowedNetworks {
+		if _, _, err := net.ParseCIDR(network); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid %v %q", EnvWebhookAllowedNetworks, network))
+		}
+	}
+
+	// Rate limiting.
+	//
+	problems = append(problems, c.validateRateLimit()...)
+
+	// In-process backends are only usable when the application runs the worker itself.
+	//
+	switch command {
+	case AppCommand:
+		required(c.GRPCAddr, EnvGRPCAddr)
+		required(c.HTTPAddr, EnvHTTPAddr)
+		if c.Storage.Backend.IsInProcess() && !c.Queue.Backend.IsInProcess() {
+			problems = append(problems, fmt.Sprintf("storage backend %v requires queue backend %v", c.Storage.Backend, MemoryQueueBackend))
+		}
+	case WorkerCommand:
+		if c.Queue.Backend.IsInProcess() {
+			problems = append(problems, fmt.Sprintf("queue backend %v is served by the main application", c.Queue.Backend))
+		}
+		if c.Storage.Backend.IsInProcess() {
+			problems = append(problems, fmt.Sprintf("storage backend %v is served by the main application", c.Storage.Backend))
+		}
+	case ReconcileCommand, PurgeCommand:
+		if c.Storage.Backend.IsInProcess() {
+			problems = append(problems, fmt.Sprintf("storage backend %v is served by the main application", c.Storage.Backend))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration: %v", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// validateRateLimit - checks the rate limits, the shared buckets require a table of the DynamoDB storage.
+func (c *Config) validateRateLimit() []string {
+	var problems []string
+	switch c.RateLimit.Backend {
+	case NoRateLimitBackend, MemoryRateLimitBackend:
+	case StorageRateLimitBackend:
+		if c.Storage.Backend == DynamoDBStorageBackend && len(c.Storage.RateLimitTableName) == 0 {
+			problems = append(problems, fmt.Sprintf("%v required", EnvRateLimitTableName))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unsupported rate limit backend %q", c.RateLimit.Backend))
+	}
+	if c.RateLimit.RequestsPerMinute < 1 {
+		problems = append(problems, fmt.Sprintf("%v must be positive", EnvRateLimitRequestsPerMinute))
+	}
+	if c.RateLimit.Burst < 1 {
+		problems = append(problems, fmt.Sprintf("%v must be positive", EnvRateLimitBurst))
+	}
+	if c.RateLimit.TrustedProxies < 0 {
+		problems = append(problems, fmt.Sprintf("%v must not be negative", EnvRateLimitTrustedProxies))
+	}
+	methods := make(map[string]bool)
+	for _, method := range c.RateLimit.Methods {
+		if len(method.Method) == 0 {
+			problems = append(problems, "rate limit method required")
+			continue
+		}
+		if methods[method.Method] {
+			problems = append(problems, fmt.Sprintf("rate limit of method %q is not unique", method.Method))
+		}
+		methods[method.Method] = true
+		if method.RequestsPerMinute < 0 || method.Burst < 0 {
+			problems = append(problems, fmt.Sprintf("rate limit of method %q must not be negative", method.Method))
+		}
+	}
+	return problems
+}
+
+// validateSubscriptions - checks the subscription queues, they require the topic.
+func (q Queue) validateSubscriptions() []string {
+	if len(q.AnswerEventTopicName) == 0 {
+		if len(q.WebhookQueueName) > 0 || len(q.SearchQueueName) > 0 || len(q.WatchQueueName) > 0 || len(q.Subscriptions) > 0 {
+			return []string{fmt.Sprintf("%v required by the subscription queues", EnvAnswerEventTopicName)}
+		}
+		return nil
+	}
+	var problems []string
+	queueNames := make(map[string]bool)
+	for _, subscription := range q.TopicSubscriptions() {
+		if len(subscription.QueueName) == 0 {
+			problems = append(problems, "subscription queueName required")
+			continue
+		}
+		if queueNames[subscription.QueueName] {
+			problems = append(problems, fmt.Sprintf("subscription queue %q is not unique", subscription.QueueName))
+		}
+		queueNames[subscription.QueueName] = true
+		for attribute, values := range subscription.FilterPolicy {
+			if len(values) == 0 {
+				problems = append(problems, fmt.Sprintf("filter policy of subscription queue %q lists no values of %v", subscription.QueueName, attribute))
+			}
+		}
+	}
+	return problems
+}
+
+// The values replacing the secrets in the printed configuration, the password of a DSN is masked as url.URL.Redacted does.
+const (
+	maskedValue    = "***"
+	maskedPassword = "xxxxx"
+)
+
+// dsnPasswordPattern - matches the password of a DSN of keyword/value pairs.
+var dsnPasswordPattern = regexp.MustCompile(`(password\s*=\s*)('(\\.|[^'])*'|\S+)`)
+
+// Print - writes the configuration as YAML, the secrets are masked.
+func (c *Config) Print(w io.Writer) error {
+	masked := *c
+	masked.Storage.PostgresDSN = maskDSN(c.Storage.PostgresDSN)
+	masked.Redaction.HashSalt = mask(c.Redaction.HashSalt)
+	masked.Auth.JWTSecret = mask(c.Auth.JWTSecret)
+	out, err := yaml.Marshal(&masked)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// mask - masks the secret, an unset secret stays empty.
+func mask(secret string) string {
+	if len(secret) == 0 {
+		return ""
+	}
+	return maskedValue
+}
+
+// maskDSN - masks the password of the DSN, given as a URL or as keyword/value pairs.
+func maskDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && len(u.Scheme) > 0 {
+		return u.Redacted()
+	}
+	return dsnPasswordPattern.ReplaceAllString(dsn, "${1}"+maskedPassword)
+}
+
+// loadFile - overrides the configuration by the YAML file.
+func (c *Config) loadFile(fileName string) error {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("read configuration file: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(content, c); err != nil {
+		return fmt.Errorf("parse configuration file %v: %w", fileName, err)
+	}
+	return nil
+}
+
+// loadEnv - overrides the configuration by the set environment variables.
+func (c *Config) loadEnv() error {
+	for name, field := range c.stringFields() {
+		if value, ok := os.LookupEnv(name); ok {
+			*field = value
+		}
+	}
+	for name, field := range c.sliceFields() {
+		if value, ok := os.LookupEnv(name); ok {
+			*field = nil
+			if len(value) > 0 {
+				*field = strings.Split(value, ",")
+			}
+		}
+	}
+	for name, field := range c.boolFields() {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid %v: %w", name, err)
+			}
+			*field = parsed
+		}
+	}
+	for name, field := range c.intFields() {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %v: %w", name, err)
+			}
+			*field = parsed
+		}
+	}
+	return nil
+}
+
+// stringFields - returns the string fields by their environment variable names.
+func (c *Config) stringFields() map[string]*string {
+	return map[string]*string{
+		EnvGRPCAddr:                     &c.GRPCAddr,
+		EnvHTTPAddr:                     &c.HTTPAddr,
+		EnvSwaggerPath:                  &c.SwaggerPath,
+		EnvAWSMockServerAddress:         &c.AWS.MockServerAddress,
+		EnvQueueBackend:                 (*string)(&c.Queue.Backend),
+		EnvAnswerEventQueueName:         &c.Queue.AnswerEventQueueName,
+		EnvAnswerEventTopicName:         &c.Queue.AnswerEventTopicName,
+		EnvWebhookQueueName:             &c.Queue.WebhookQueueName,
+		EnvSearchQueueName:              &c.Queue.SearchQueueName,
+		EnvWatchQueueName:               &c.Queue.WatchQueueName,
+		EnvAnswerEventSource:            (*string)(&c.Queue.AnswerEventSource),
+		EnvNATSURL:                      &c.Queue.NATSURL,
+		EnvStorageBackend:               (*string)(&c.Storage.Backend),
+		EnvAnswerTableName:              &c.Storage.AnswerTableName,
+		EnvAnswerEventTableName:         &c.Storage.AnswerEventTableName,
+		EnvPostgresDSN:                  &c.Storage.PostgresDSN,
+		EnvLegacyAnswerEventTableName:   &c.Storage.LegacyAnswerEventTableName,
+		EnvWebhookSubscriptionTableName: &c.Storage.WebhookSubscriptionTableName,
+		EnvWebhookDeliveryTableName:     &c.Storage.WebhookDeliveryTableName,
+		EnvIdempotencyTableName:         &c.Storage.IdempotencyTableName,
+		EnvErasureTableName:             &c.Storage.ErasureTableName,
+		EnvRateLimitTableName:           &c.Storage.RateLimitTableName,
+		EnvRateLimitBackend:             (*string)(&c.RateLimit.Backend),
+		EnvKeyProvider:                  (*string)(&c.Encryption.KeyProvider),
+		EnvLocalKeyFile:                 &c.Encryption.LocalKeyFile,
+		EnvKMSKeyID:                     &c.Encryption.KMSKeyID,
+		EnvValueRedactionPolicy:         (*string)(&c.Redaction.ValuePolicy),
+		EnvKeyRedactionPolicy:           (*string)(&c.Redaction.KeyPolicy),
+		EnvRedactionHashSalt:            &c.Redaction.HashSalt,
+		EnvAuthJWTSecret:                &c.Auth.JWTSecret,
+	}
+}
+
+// flagFields - returns the string fields by their flag names.
+func (c *Config) flagFields() map[string]*string {
+	return map[string]*string{
+		flagGRPCAddr:       &c.GRPCAddr,
+		flagHTTPAddr:       &c.HTTPAddr,
+		flagSwaggerPath:    &c.SwaggerPath,
+		flagQueueBackend:   (*string)(&c.Queue.Backend),
+		flagStorageBackend: (*string)(&c.Storage.Backend),
+	}
+}
+
+// sliceFields - returns the comma separated fields by their environment variable names.
+func (c *Config) sliceFields() map[string]*[]string {
+	return map[string]*[]string{
+		EnvSensitiveKeyPatterns:   &c.Redaction.SensitiveKeyPatterns,
+		EnvWebhookAllowedNetworks: &c.Webhook.AllowedNetworks,
+	}
+}
+
+// boolFields - returns the boolean fields by their environment variable names.
+func (c *Config) boolFields() map[string]*bool {
+	return map[string]*bool{
+		EnvConsumeSearchQueue: &c.Queue.ConsumeSearchQueue,
+	}
+}
+
+// intFields - returns the integer fields by their environment variable names.
+func (c *Config) intFields() map[string]*int64 {
+	return map[string]*int64{
+		EnvSQSMaxNumberOfMessages:     &c.Queue.SQS.MaxNumberOfMessages,
+		EnvSQSVisibilityTimeout:       &c.Queue.SQS.VisibilityTimeout,
+		EnvDeletedAnswerRetention:     &c.Storage.DeletedAnswerRetention,
+		EnvIdempotencyKeyRetention:    &c.Storage.IdempotencyKeyRetention,
+		EnvIdempotencyKeyLease:        &c.Storage.IdempotencyKeyLease,
+		EnvDataKeyLifetime:            &c.Encryption.DataKeyLifetime,
+		EnvWebhookMaxAttempts:         &c.Webhook.MaxAttempts,
+		EnvWebhookInitialBackoff:      &c.Webhook.InitialBackoff,
+		EnvWebhookTimeout:             &c.Webhook.Timeout,
+		EnvRateLimitRequestsPerMinute: &c.RateLimit.RequestsPerMinute,
+		EnvRateLimitBurst:             &c.RateLimit.Burst,
+		EnvRateLimitTrustedProxies:    &c.RateLimit.TrustedProxies,
+	}
+}
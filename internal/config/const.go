@@ -0,0 +1,87 @@
+package config
+
+// Environment variables.
+const (
+	EnvConfigFile                   = "CONFIG_FILE"
+	EnvGRPCAddr                     = "GRPC_ADDR"
+	EnvHTTPAddr                     = "HTTP_ADDR"
+	EnvSwaggerPath                  = "SWAGGER_PATH"
+	EnvAWSMockServerAddress         = "AWS_MOCK_SERVER_ADDRESS"
+	EnvQueueBackend                 = "QUEUE_BACKEND"
+	EnvAnswerEventQueueName         = "ANSWER_EVENT_QUEUE_NAME"
+	EnvAnswerEventTopicName         = "ANSWER_EVENT_TOPIC_NAME"
+	EnvWebhookQueueName             = "WEBHOOK_QUEUE_NAME"
+	EnvSearchQueueName              = "SEARCH_QUEUE_NAME"
+	EnvConsumeSearchQueue           = "CONSUME_SEARCH_QUEUE"
+	EnvWatchQueueName               = "WATCH_QUEUE_NAME"
+	EnvAnswerEventSource            = "ANSWER_EVENT_SOURCE"
+	EnvNATSURL                      = "NATS_URL"
+	EnvSQSMaxNumberOfMessages       = "SQS_MAX_NUMBER_OF_MESSAGES"
+	EnvSQSVisibilityTimeout         = "SQS_VISIBILITY_TIMEOUT"
+	EnvStorageBackend               = "STORAGE_BACKEND"
+	EnvAnswerTableName              = "ANSWER_TABLE_NAME"
+	EnvAnswerEventTableName         = "ANSWER_EVENT_TABLE_NAME"
+	EnvPostgresDSN                  = "POSTGRES_DSN"
+	EnvLegacyAnswerEventTableName   = "LEGACY_ANSWER_EVENT_TABLE_NAME"
+	EnvWebhookSubscriptionTableName = "WEBHOOK_SUBSCRIPTION_TABLE_NAME"
+	EnvWebhookDeliveryTableName     = "WEBHOOK_DELIVERY_TABLE_NAME"
+	EnvDeletedAnswerRetention       = "DELETED_ANSWER_RETENTION_DAYS"
+	EnvIdempotencyTableName         = "IDEMPOTENCY_TABLE_NAME"
+	EnvErasureTableName             = "ERASURE_TABLE_NAME"
+	EnvIdempotencyKeyRetention      = "IDEMPOTENCY_KEY_RETENTION_HOURS"
+	EnvIdempotencyKeyLease          = "IDEMPOTENCY_KEY_LEASE_SECONDS"
+	EnvKeyProvider                  = "ENCRYPTION_KEY_PROVIDER"
+	EnvLocalKeyFile                 = "ENCRYPTION_LOCAL_KEY_FILE"
+	EnvKMSKeyID                     = "ENCRYPTION_KMS_KEY_ID"
+	EnvDataKeyLifetime              = "ENCRYPTION_DATA_KEY_LIFETIME"
+	EnvValueRedactionPolicy         = "REDACTION_VALUE_POLICY"
+	EnvKeyRedactionPolicy           = "REDACTION_KEY_POLICY"
+	EnvSensitiveKeyPatterns         = "REDACTION_SENSITIVE_KEY_PATTERNS"
+	EnvRedactionHashSalt            = "REDACTION_HASH_SALT"
+	EnvWebhookMaxAttempts           = "WEBHOOK_MAX_ATTEMPTS"
+	EnvWebhookInitialBackoff        = "WEBHOOK_INITIAL_BACKOFF"
+	EnvWebhookTimeout               = "WEBHOOK_TIMEOUT"
+	EnvWebhookAllowedNetworks       = "WEBHOOK_ALLOWED_NETWORKS"
+	EnvRateLimitTableName           = "RATE_LIMIT_TABLE_NAME"
+	EnvRateLimitBackend             = "RATE_LIMIT_BACKEND"
+	EnvRateLimitRequestsPerMinute   = "RATE_LIMIT_REQUESTS_PER_MINUTE"
+	EnvRateLimitBurst               = "RATE_LIMIT_BURST"
+	EnvRateLimitTrustedProxies      = "RATE_LIMIT_TRUSTED_PROXIES"
+	EnvAuthJWTSecret                = "AUTH_JWT_SECRET"
+)
+
+// Flags.
+const (
+	flagConfig         = "config"
+	flagPrintConfig    = "print-config"
+	flagGRPCAddr       = "grpc-addr"
+	flagHTTPAddr       = "http-addr"
+	flagSwaggerPath    = "swagger-path"
+	flagQueueBackend   = "queue-backend"
+	flagStorageBackend = "storage-backend"
+)
+
+// Default values.
+const (
+	DefaultGRPCAddr                = ":6565"
+	DefaultHTTPAddr                = ":8000"
+	DefaultSwaggerPath             = "api/generated/app.swagger.json"
+	DefaultNATSURL                 = "nats://127.0.0.1:4222"
+	DefaultSQSMaxNumberOfMessages  = 10
+	DefaultSQSVisibilityTimeout    = 60
+	DefaultDeletedAnswerRetention  = 30
+	DefaultIdempotencyKeyRetention = 24
+	DefaultIdempotencyKeyLease     = 60
+	DefaultDataKeyLifetime         = 3600
+	DefaultWebhookMaxAttempts      = 4
+	DefaultWebhookInitialBackoff   = 1
+	DefaultWebhookTimeout          = 5
+	DefaultRateLimitRequests       = 600
+	DefaultRateLimitBurst          = 100
+)
+
+// SQS limits.
+const (
+	maxSQSMaxNumberOfMessages = 10
+	maxSQSVisibilityTimeout   = 43200
+)
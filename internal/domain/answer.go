@@ -3,12 +3,14 @@ package domain
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // Answer JSON fields.
 const (
-	JSONFieldAnswerKey   = "key"
-	JSONFieldAnswerValue = "value"
+	JSONFieldAnswerKey     = "key"
+	JSONFieldAnswerValue   = "value"
+	JSONFieldAnswerVersion = "version"
 )
 
 type (
@@ -22,6 +24,9 @@ type (
 type Answer struct {
 	Key   AnswerKey   `json:"key"`
 	Value AnswerValue `json:"value"`
+	// Version - the number of the last change of the answer, the repository counts the changes of every key.
+	// Zero for an answer which is not stored yet or was stored before the changes were counted.
+	Version int `json:"version,omitempty"`
 }
 
 // Validate - validates struct.
@@ -35,29 +40,86 @@ func (answer *Answer) Validate() error {
 	return nil
 }
 
+// AnswerMutation - the result of creating, updating, upserting, deleting or restoring an answer.
+// The worker records its event later with the version of the answer.
+type AnswerMutation struct {
+	// Answer - the answer as stored with the version of the change, or as it was before a delete.
+	Answer *Answer
+	// EventType - the type of the change, an upsert is either a create or an update.
+	EventType AnswerEventType
+	// OccurredAt - the time of the change, the occurrence time of its event.
+	OccurredAt time.Time
+	// EventID - the ID of the event message, or the ID written with the answer if the events are captured
+	// from the change stream.
+	EventID string
+}
+
 // AnswerRepository - provides access to a storage.
+//
+// Create returns ErrAlreadyExist if the key is in use, Update, Delete and Get return
+// ErrNotFound if the key is not. List returns the answers in a backend-specific order,
+// a page may hold fewer items than requested and the listing ends with an empty token.
+// The next page token is the AnswerPageToken of the last answer of the page, so a
+// listing may also be resumed after any listed answer.
+//
+// Delete keeps the answer with a tombstone: Get, Update and List ignore it, Create
+// replaces it and ListDeleted lists it the same way List lists the answers. Purge
+// hard deletes it once the tombstone is due and returns ErrNotFound otherwise.
+// Erase hard deletes the answer whether it is deleted or not.
+//
+// Upsert atomically creates or replaces the answer, a deleted answer is replaced.
+// It reports whether the answer was created, i.e. the key was not in use before.
+//
+// Every change increments the version of the key in the same write: Create, Update and Upsert
+// set the version of the answer and Delete returns the version of the delete. A deleted answer
+// keeps its version, so the key is counted on when it is created again. Create and Upsert count
+// on from the version of the given answer if the key has none stored, e.g. it was purged.
 type AnswerRepository interface {
 	Create(answer *Answer) error
 	Update(answer *Answer) error
-	Delete(key AnswerKey) error
+	Upsert(answer *Answer) (created bool, err error)
+	Delete(key AnswerKey, tombstone Tombstone) (version int, err error)
 	Get(key AnswerKey) (*Answer, error)
+	List(page PageRequest) (answers []*Answer, nextPageToken string, err error)
+	ListDeleted(page PageRequest) (answers []*DeletedAnswer, nextPageToken string, err error)
+	Purge(key AnswerKey, now time.Time) error
+	Erase(key AnswerKey) error
+}
+
+// AnswerPageToken - returns the page token which continues a listing of answers after the answer with the key.
+func AnswerPageToken(key AnswerKey) string {
+	return EncodePageToken(string(key))
 }
 
 // AnswerService - provides access to a business logic.
 type AnswerService interface {
 
 	// CreateAnswer - creates a new answer.
-	CreateAnswer(ctx context.Context, answer *Answer) error
+	CreateAnswer(ctx context.Context, answer *Answer) (*AnswerMutation, error)
 
 	// UpdateAnswer - updates an existing answer.
-	UpdateAnswer(ctx context.Context, answer *Answer) error
+	UpdateAnswer(ctx context.Context, answer *Answer) (*AnswerMutation, error)
 
-	// DeleteAnswer - deletes an existing answer.
-	DeleteAnswer(ctx context.Context, key AnswerKey) error
+	// UpsertAnswer - creates the answer or replaces the existing one, the event says which of them happened.
+	UpsertAnswer(ctx context.Context, answer *Answer) (*AnswerMutation, error)
+
+	// DeleteAnswer - deletes an existing answer, it is retained for a period before it is purged.
+	DeleteAnswer(ctx context.Context, key AnswerKey) (*AnswerMutation, error)
 
 	// GetAnswer - returns an existing answer by the provided key.
 	GetAnswer(ctx context.Context, key AnswerKey) (*Answer, error)
 
 	// GetAnswerHistory - returns an answer history by the provided key.
 	GetAnswerHistory(ctx context.Context, key AnswerKey) ([]*AnswerEvent, error)
+
+	// RestoreAnswer - gives the answer the value of the event with the version, or of the last event if the
+	// version is zero. A deleted answer is recreated and a restore event is recorded.
+	RestoreAnswer(ctx context.Context, key AnswerKey, version int) (*AnswerMutation, error)
+
+	// GetAnswerAt - returns the state of an answer at the point of its history, rebuilt from the events.
+	GetAnswerAt(ctx context.Context, key AnswerKey, at AnswerPoint) (*AnswerSnapshot, error)
+
+	// ListAnswers - returns a page of answers in an unspecified order, which is stable for paging, and the token
+	// of the next page.
+	ListAnswers(ctx context.Context, page PageRequest) (answers []*Answer, nextPageToken string, err error)
 }
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AnswerImage - the state of an answer item, a deleted answer is kept till it is purged.
+type AnswerImage struct {
+	Answer  Answer
+	Deleted bool
+	// EventType and EventID - the tag of the last write, empty if the write was not tagged.
+	EventType AnswerEventType
+	EventID   string
+}
+
+// IsLive - checks if the image holds an answer which is not deleted.
+func (i *AnswerImage) IsLive() bool {
+	return i != nil && !i.Deleted
+}
+
+// AnswerChange - a change of an answer item captured from the storage.
+type AnswerChange struct {
+	// ID - identifies the change in the stream, e.g. its sequence number.
+	ID string
+	// Old and New - the item before and after the change, nil if it did not exist.
+	Old *AnswerImage
+	New *AnswerImage
+	// ChangedAt - the time of the change.
+	ChangedAt time.Time
+}
+
+// Event - derives the answer event of the change and its ID from the tag of the write. The type of an untagged
+// write is derived from the images, so a restore made outside the answer service is seen as created or updated.
+// False is returned if the change is not an event, e.g. the removal of a purged or erased answer,
+// the erasure records its event itself.
+func (c *AnswerChange) Event() (event *AnswerEvent, eventID string, ok bool) {
+	var (
+		eventType AnswerEventType
+		answer    Answer
+	)
+	switch {
+	case c.New.IsLive() && c.Old.IsLive():
+		eventType, answer = UpdateAnswerEventType, c.New.Answer
+	case c.New.IsLive():
+		eventType, answer = CreateAnswerEventType, c.New.Answer
+	case c.New != nil && c.Old.IsLive():
+		eventType, answer = DeleteAnswerEventType, c.Old.Answer
+		answer.Version = c.New.Answer.Version
+	default:
+		return nil, "", false
+	}
+	if len(c.New.EventType) > 0 {
+		eventType = c.New.EventType
+	}
+	return &AnswerEvent{
+		EventType:  eventType,
+		Data:       &answer,
+		OccurredAt: c.ChangedAt,
+	}, c.New.EventID, true
+}
+
+// AnswerChangeTag - the event of a write, a repository with a change stream stores it with the item in the same
+// write, so the event captured from the stream has the type, the ID and the time the answer service reported.
+// An empty event type is derived from the change, e.g. for an upsert, a zero time is the time of the write.
+type AnswerChangeTag struct {
+	EventType  AnswerEventType
+	EventID    string
+	OccurredAt time.Time
+}
+
+// TaggedAnswerRepository - an answer repository which stores the tag of a write with the item.
+// The untagged writes of AnswerRepository clear the tag of the item.
+type TaggedAnswerRepository interface {
+	AnswerRepository
+	CreateTagged(answer *Answer, tag AnswerChangeTag) error
+	UpdateTagged(answer *Answer, tag AnswerChangeTag) error
+	UpsertTagged(answer *Answer, tag AnswerChangeTag) (created bool, err error)
+	DeleteTagged(key AnswerKey, tombstone Tombstone, tag AnswerChangeTag) (version int, err error)
+}
+
+// NewTaggedAnswerRepository - returns the repository if it stores the tags, otherwise a repository
+// which makes the untagged writes, e.g. the storage has no change stream.
+func NewTaggedAnswerRepository(repository AnswerRepository) TaggedAnswerRepository {
+	if tagged, ok := repository.(TaggedAnswerRepository); ok {
+		return tagged
+	}
+	return untaggedAnswerRepository{repository}
+}
+
+type untaggedAnswerRepository struct {
+	AnswerRepository
+}
+
+func (r untaggedAnswerRepository) CreateTagged(answer *Answer, _ AnswerChangeTag) error {
+	return r.Create(answer)
+}
+
+func (r untaggedAnswerRepository) UpdateTagged(answer *Answer, _ AnswerChangeTag) error {
+	return r.Update(answer)
+}
+
+func (r untaggedAnswerRepository) UpsertTagged(answer *Answer, _ AnswerChangeTag) (bool, error) {
+	return r.Upsert(answer)
+}
+
+func (r untaggedAnswerRepository) DeleteTagged(key AnswerKey, tombstone Tombstone, _ AnswerChangeTag) (int, error) {
+	return r.Delete(key, tombstone)
+}
+
+// AnswerChangeHandlerFunc - handles an answer change.
+type AnswerChangeHandlerFunc func(ctx context.Context, change *AnswerChange) error
+
+// HandleChange wraps a function for handling answer changes.
+func (f AnswerChangeHandlerFunc) HandleChange(ctx context.Context, change *AnswerChange) error {
+	return f(ctx, change)
+}
+
+// AnswerChangeHandler interface.
+type AnswerChangeHandler interface {
+	HandleChange(ctx context.Context, change *AnswerChange) error
+}
+
+// AnswerChangeStream - the changes of the answer items, the changes of an answer are in the order they were made.
+type AnswerChangeStream interface {
+
+	// Read - passes the changes to the handler from the oldest one retained until the context is done.
+	// A change is passed again until the handler returns no error, so the changes of an answer are not reordered.
+	// The failed reads of the storage are retried, an error is only returned if changes were lost before they
+	// were read, e.g. they are no longer retained.
+	Read(ctx context.Context, h AnswerChangeHandler) error
+}
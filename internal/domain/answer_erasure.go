@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Erasure JSON fields.
+const (
+	JSONFieldErasureKeyHash = "keyHash"
+)
+
+// ErasureReceipt - describes the erasure of an answer key, it holds neither the key nor the answer values.
+// The receipts are returned in the order of the erased keys.
+type ErasureReceipt struct {
+	KeyHash      string
+	ErasedAt     time.Time
+	AnswerErased bool
+	EventsErased int
+	// RequestedBy - the verified subject of the client which asked for the erasure.
+	RequestedBy string
+}
+
+// Erasure - the tombstone of an erased answer key, the key is only stored as its hash.
+type Erasure struct {
+	KeyHash  string
+	ErasedAt time.Time
+}
+
+// ErasedBefore - checks if the key was erased after the event occurred, so the event must be dropped.
+func (e *Erasure) ErasedBefore(event *AnswerEvent) bool {
+	return e != nil && event.OccurredAt.Before(e.ErasedAt)
+}
+
+// ErasureRepository - provides access to the tombstones of the erased answer keys.
+//
+// Record stores the erasure, an earlier erasure of the key is replaced and a later one is kept.
+// Get returns the last erasure of the key hash or ErrNotFound if the key was never erased.
+type ErasureRepository interface {
+	Record(erasure *Erasure) error
+	Get(keyHash string) (*Erasure, error)
+}
+
+// AnswerKeyHasher - hashes the answer keys, a hash identifies the key in the erasure tombstones and receipts,
+// the idempotency records and the webhook delivery log. The hash is the hex-encoded HMAC-SHA256 keyed by the secret,
+// so a key cannot be found by hashing guessed keys, or the SHA-256 of the key without a secret. All the applications
+// must hash with the same secret and it must not change, otherwise the tombstones are not found anymore.
+type AnswerKeyHasher struct {
+	secret []byte
+}
+
+// NewAnswerKeyHasher - returns the hasher of the secret.
+func NewAnswerKeyHasher(secret string) *AnswerKeyHasher {
+	return &AnswerKeyHasher{secret: []byte(secret)}
+}
+
+// Hash - returns the hash of the key.
+func (h *AnswerKeyHasher) Hash(key AnswerKey) string {
+	if len(h.secret) == 0 {
+		sum := sha256.Sum256([]byte(key))
+		return hex.EncodeToString(sum[:])
+	}
+	mac := hmac.New(sha256.New, h.secret)
+	_, _ = mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// AnswerErasureService - erases the answers of a data subject together with their history.
+type AnswerErasureService interface {
+
+	// EraseAnswers - erases the answers, the history and the stored responses of the keys. A tombstone with the
+	// hash of every key is recorded, events which occurred before it are dropped when they are delivered later,
+	// and an erase event is sent to every consumer of the answer events. The receipts record the subject
+	// of the client which asked for the erasure.
+	EraseAnswers(ctx context.Context, keys []AnswerKey, requestedBy string) ([]*ErasureReceipt, error)
+}
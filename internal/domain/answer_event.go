@@ -1,5 +1,9 @@
 package domain
 
+import (
+	"time"
+)
+
 // AnswerEventType - answer event type.
 type AnswerEventType string
 
@@ -14,31 +18,70 @@ const (
 	CreateAnswerEventType = AnswerEventType("create")
 	UpdateAnswerEventType = AnswerEventType("update")
 	DeleteAnswerEventType = AnswerEventType("delete")
+	// RestoreAnswerEventType - the answer got the value of an earlier event of its history.
+	RestoreAnswerEventType = AnswerEventType("restore")
+	// EraseAnswerEventType - the answer and its earlier history were erased, the data only holds the key.
+	EraseAnswerEventType = AnswerEventType("erase")
 )
 
 // JSON fields.
 const (
-	JSONFieldEventType = "eventType"
-	JSONFieldData      = "data"
+	JSONFieldEventType  = "eventType"
+	JSONFieldData       = "data"
+	JSONFieldOccurredAt = "occurredAt"
 )
 
 // List of valid answer event types.
 var (
 	validAnswerEventTypes = map[AnswerEventType]bool{
-		CreateAnswerEventType: true,
-		UpdateAnswerEventType: true,
-		DeleteAnswerEventType: true,
+		CreateAnswerEventType:  true,
+		UpdateAnswerEventType:  true,
+		DeleteAnswerEventType:  true,
+		RestoreAnswerEventType: true,
+		EraseAnswerEventType:   true,
 	}
 )
 
 // AnswerEvent - represents an answer event struct.
 type AnswerEvent struct {
-	EventType AnswerEventType `json:"eventType"`
-	Data      *Answer         `json:"data"`
+	EventType  AnswerEventType `json:"eventType"`
+	Data       *Answer         `json:"data"`
+	OccurredAt time.Time       `json:"occurredAt"`
+}
+
+// Version - returns the version of the answer the event was made with, zero for the events recorded
+// before the changes were counted.
+func (e *AnswerEvent) Version() int {
+	if e.Data == nil {
+		return 0
+	}
+	return e.Data.Version
+}
+
+// LastAnswerVersion - returns the version of the last counted event of the history, the versions increase with
+// the occurrence of the events.
+func LastAnswerVersion(events []*AnswerEvent) int {
+	var version int
+	for _, event := range events {
+		if event.Version() > version {
+			version = event.Version()
+		}
+	}
+	return version
 }
 
 // AnswerEventRepository - provides access to a storage.
+//
+// An event is identified by its answer key and occurrence time, so Create returns
+// ErrAlreadyExist for an event which is already stored. Events are listed in the
+// order of their occurrence, a page may hold fewer items than requested and the
+// listing ends with an empty token. ListKeys lists every key with a history once,
+// in a backend-specific order, and may be resumed after any listed key with its AnswerPageToken. EraseEvents removes the events of the key which
+// occurred before the time and returns their number.
 type AnswerEventRepository interface {
 	Create(answerEvent *AnswerEvent) error
 	ListEvents(key AnswerKey) ([]*AnswerEvent, error)
+	ListEventsPage(key AnswerKey, page PageRequest) (events []*AnswerEvent, nextPageToken string, err error)
+	ListKeys(page PageRequest) (keys []AnswerKey, nextPageToken string, err error)
+	EraseEvents(key AnswerKey, before time.Time) (erased int, err error)
 }
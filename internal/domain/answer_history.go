@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"time"
+)
+
+// AnswerPoint - a point in the history of an answer, defined either by a time or by a version.
+type AnswerPoint struct {
+	// Time - the answer as of the last event which occurred at or before the time.
+	Time time.Time
+	// Version - the answer as of the event with the version.
+	Version int
+}
+
+// AnswerSnapshot - the state of an answer at a point of its history.
+type AnswerSnapshot struct {
+	// Answer - nil if the answer did not exist at the point.
+	Answer *Answer
+	// Version - the version of the last folded event.
+	Version int
+	// OccurredAt - the occurrence time of the last folded event.
+	OccurredAt time.Time
+}
+
+// ReplayAnswerEvents - folds the events, ordered by occurrence, into the state of the answer.
+// The answer is nil if it was never created or it was deleted or erased by the last event.
+func ReplayAnswerEvents(events []*AnswerEvent) *AnswerSnapshot {
+	snapshot := &AnswerSnapshot{}
+	for _, event := range events {
+		switch event.EventType {
+		case CreateAnswerEventType, UpdateAnswerEventType, RestoreAnswerEventType:
+			answer := *event.Data
+			snapshot.Answer = &answer
+		case DeleteAnswerEventType, EraseAnswerEventType:
+			snapshot.Answer = nil
+		}
+		snapshot.Version = event.Version()
+		snapshot.OccurredAt = event.OccurredAt
+	}
+	return snapshot
+}
+
+// EventsAt - returns the events which form the state of the answer at the point.
+// The boolean result is false if the history has not reached the point yet.
+func (p AnswerPoint) EventsAt(events []*AnswerEvent) ([]*AnswerEvent, bool) {
+	if p.Version > 0 {
+		if LastAnswerVersion(events) < p.Version {
+			return nil, false
+		}
+		var folded []*AnswerEvent
+		for _, event := range events {
+			if event.Version() <= p.Version {
+				folded = append(folded, event)
+			}
+		}
+		return folded, true
+	}
+	n := 0
+	for n < len(events) && !events[n].OccurredAt.After(p.Time) {
+		n++
+	}
+	return events[:n], true
+}
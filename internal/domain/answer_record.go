@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+)
+
+// ImportMode - the way imported answer records are written.
+type ImportMode string
+
+// Import modes.
+const (
+	// ReplayImportMode - the answers are written through the answer service, which regenerates their events.
+	ReplayImportMode = ImportMode("replay")
+	// PreserveImportMode - the answers and their events are stored as they are, no events are generated.
+	PreserveImportMode = ImportMode("preserve")
+)
+
+// IsValid - checks if the import mode is valid.
+func (m ImportMode) IsValid() bool {
+	return m == ReplayImportMode || m == PreserveImportMode
+}
+
+// AnswerRecord - represents an answer together with its history, the unit of export and import.
+type AnswerRecord struct {
+	Key AnswerKey `json:"key"`
+	// Answer - nil when only the history of a deleted answer remains.
+	Answer *Answer        `json:"answer"`
+	Events []*AnswerEvent `json:"events"`
+}
+
+// ExportFunc - receives an exported record and the checkpoint which resumes the export after it.
+type ExportFunc func(record *AnswerRecord, checkpoint string) error
+
+// AnswerTransferService - provides the bulk export and import of answers.
+type AnswerTransferService interface {
+
+	// ExportAnswers - calls fn for every answer listed after the checkpoint, an empty checkpoint exports all answers.
+	// The keys without a live answer follow, their records hold the history only. The export stops with the first
+	// error returned by fn.
+	ExportAnswers(ctx context.Context, checkpoint string, fn ExportFunc) error
+
+	// ImportAnswer - writes the record in the provided mode, importing the same record again changes nothing.
+	ImportAnswer(ctx context.Context, record *AnswerRecord, mode ImportMode) error
+}
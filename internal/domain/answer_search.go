@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// AnswerMatchType - the way a search term matches the key or the value of an answer.
+type AnswerMatchType string
+
+// Answer match types, all but the exact match ignore the case.
+const (
+	ExactAnswerMatch     = AnswerMatchType("exact")
+	PrefixAnswerMatch    = AnswerMatchType("prefix")
+	SubstringAnswerMatch = AnswerMatchType("substring")
+	// FuzzyAnswerMatch - a word of the key or the value is at most the fuzziness of edits away from the text.
+	FuzzyAnswerMatch = AnswerMatchType("fuzzy")
+)
+
+// MaxAnswerSearchFuzziness - the maximum number of edits of a fuzzy match.
+const MaxAnswerSearchFuzziness = 2
+
+// AnswerSearchTerm - the text matched against the key or the value of an answer.
+type AnswerSearchTerm struct {
+	Text  string
+	Match AnswerMatchType
+	// Fuzziness - the number of edits a fuzzy match allows, it depends on the length of the text if zero.
+	Fuzziness int
+}
+
+// Validate - checks the term can be matched.
+func (t *AnswerSearchTerm) Validate() error {
+	if len(t.Text) == 0 {
+		return errors.New("Text required")
+	}
+	switch t.Match {
+	case ExactAnswerMatch, PrefixAnswerMatch, SubstringAnswerMatch, FuzzyAnswerMatch:
+	default:
+		return fmt.Errorf("Match type %q is not valid", t.Match)
+	}
+	if t.Fuzziness < 0 || t.Fuzziness > MaxAnswerSearchFuzziness {
+		return fmt.Errorf("Fuzziness must be between 0 and %d", MaxAnswerSearchFuzziness)
+	}
+	if t.Fuzziness > 0 && t.Match != FuzzyAnswerMatch {
+		return errors.New("Fuzziness requires the fuzzy match")
+	}
+	return nil
+}
+
+// Matches - checks if the term matches the key or the value.
+func (t *AnswerSearchTerm) Matches(s string) bool {
+	switch t.Match {
+	case ExactAnswerMatch:
+		return s == t.Text
+	case PrefixAnswerMatch:
+		return strings.HasPrefix(strings.ToLower(s), strings.ToLower(t.Text))
+	case SubstringAnswerMatch:
+		return strings.Contains(strings.ToLower(s), strings.ToLower(t.Text))
+	case FuzzyAnswerMatch:
+		text := []rune(strings.ToLower(t.Text))
+		fuzziness := t.fuzziness(len(text))
+		for _, word := range SearchWords(s) {
+			if editDistance([]rune(word), text, fuzziness) <= fuzziness {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// fuzziness - returns the allowed edits, by default none for up to 2 characters, one for up to 5 and two for longer texts.
+func (t *AnswerSearchTerm) fuzziness(length int) int {
+	switch {
+	case t.Fuzziness > 0:
+		return t.Fuzziness
+	case length <= 2:
+		return 0
+	case length <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// SearchWords - splits the key or the value into lower case words, e.g. "symptom.head_ache" into "symptom", "head" and "ache".
+func SearchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// editDistance - returns the Levenshtein distance of the words, or more than the limit once it is exceeded.
+func editDistance(a, b []rune, limit int) int {
+	if d := len(a) - len(b); d > limit || -d > limit {
+		return limit + 1
+	}
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if current[j] < rowMin {
+				rowMin = current[j]
+			}
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	min := values[0]
+	for _, value := range values[1:] {
+		if value < min {
+			min = value
+		}
+	}
+	return min
+}
+
+// AnswerSearchQuery - selects the answers matching all of the set terms.
+type AnswerSearchQuery struct {
+	Key   *AnswerSearchTerm
+	Value *AnswerSearchTerm
+}
+
+// Validate - checks that a term is set and the set terms are valid.
+func (q AnswerSearchQuery) Validate() error {
+	if q.Key == nil && q.Value == nil {
+		return errors.New("Key or value term required")
+	}
+	if q.Key != nil {
+		if err := q.Key.Validate(); err != nil {
+			return fmt.Errorf("Key term: %w", err)
+		}
+	}
+	if q.Value != nil {
+		if err := q.Value.Validate(); err != nil {
+			return fmt.Errorf("Value term: %w", err)
+		}
+	}
+	return nil
+}
+
+// Matches - checks if the answer matches all of the set terms.
+func (q AnswerSearchQuery) Matches(answer *Answer) bool {
+	if q.Key != nil && !q.Key.Matches(string(answer.Key)) {
+		return false
+	}
+	if q.Value != nil && !q.Value.Matches(string(answer.Value)) {
+		return false
+	}
+	return true
+}
+
+// AnswerSearchIndex - the index of the answers searched by their keys and values.
+// The changes carry their time, so a change older than the last one of the answer is ignored
+// and the changes may be applied in any order.
+type AnswerSearchIndex interface {
+
+	// Index - adds or replaces the answer.
+	Index(answer *Answer, changedAt time.Time) error
+
+	// Remove - removes the answer of the key.
+	Remove(key AnswerKey, changedAt time.Time) error
+
+	// Search - returns a page of the matching answers ordered by key.
+	Search(query AnswerSearchQuery, page PageRequest) ([]*Answer, string, error)
+}
+
+// AnswerSearchService - finds the answers by their keys and values.
+type AnswerSearchService interface {
+
+	// SearchAnswers - returns a page of the matching answers ordered by key.
+	SearchAnswers(ctx context.Context, query AnswerSearchQuery, page PageRequest) ([]*Answer, string, error)
+}
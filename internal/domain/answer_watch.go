@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// AnswerKeyNamespaceSeparator - separates the namespace of a key from the rest, e.g. "patient-42/name".
+const AnswerKeyNamespaceSeparator = "/"
+
+// AnswerWatchFilter - selects the watched answers by exactly one of the key, the key prefix or the namespace.
+type AnswerWatchFilter struct {
+	Key       AnswerKey
+	Prefix    string
+	Namespace string
+}
+
+// Validate - checks that exactly one selector is set.
+func (f AnswerWatchFilter) Validate() error {
+	var selectors int
+	for _, selector := range []string{string(f.Key), f.Prefix, f.Namespace} {
+		if len(selector) > 0 {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return errors.New("Exactly one of key, prefix or namespace required")
+	}
+	return nil
+}
+
+// Matches - checks if the answer of the key is watched.
+func (f AnswerWatchFilter) Matches(key AnswerKey) bool {
+	switch {
+	case len(f.Key) > 0:
+		return key == f.Key
+	case len(f.Prefix) > 0:
+		return strings.HasPrefix(string(key), f.Prefix)
+	case len(f.Namespace) > 0:
+		return strings.HasPrefix(string(key), f.Namespace+AnswerKeyNamespaceSeparator)
+	default:
+		return false
+	}
+}
+
+// WatchedAnswerEvent - an event with the version of its answer.
+type WatchedAnswerEvent struct {
+	Event   *AnswerEvent
+	Version int
+}
+
+// AnswerWatchHub - passes the events to the watches of the process.
+type AnswerWatchHub interface {
+	Publish(event *WatchedAnswerEvent)
+}
+
+// WatchFunc - receives the watched events, an error stops the watch.
+type WatchFunc func(event *WatchedAnswerEvent) error
+
+// AnswerWatchService - notifies the clients of the answer changes as the events are sent.
+type AnswerWatchService interface {
+
+	// WatchAnswers - passes the events of the matching answers to the function till the context is done.
+	// A key watch first replays the recorded events after the version, so a client resumes a watch
+	// with the last version it received, or gets the whole history with version zero. The events of a key watch
+	// follow their versions, a version missed by the live events is read from the history. Prefix and namespace
+	// watches only get the live events, so they reject a version. The watch is aborted if the client does not
+	// keep up with the events or a missed version is not recorded yet.
+	WatchAnswers(ctx context.Context, filter AnswerWatchFilter, fromVersion int, fn WatchFunc) error
+}
@@ -1,8 +0,0 @@
-package domain
-
-// Environment variables.
-const (
-	EnvAnswerTableName      = "ANSWER_TABLE_NAME"
-	EnvAnswerEventTableName = "ANSWER_EVENT_TABLE_NAME"
-	EnvAnswerEventQueueName = "ANSWER_EVENT_QUEUE_NAME"
-)
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Tombstone - marks a soft deleted answer, it is purged once the retention period is over.
+type Tombstone struct {
+	DeletedAt time.Time
+	PurgeAt   time.Time
+}
+
+// NewTombstone - returns the tombstone of an answer deleted at the time and retained for the period.
+func NewTombstone(deletedAt time.Time, retention time.Duration) Tombstone {
+	return Tombstone{
+		DeletedAt: deletedAt,
+		PurgeAt:   deletedAt.Add(retention),
+	}
+}
+
+// IsDue - returns true if the retention period is over at the time.
+func (tombstone Tombstone) IsDue(now time.Time) bool {
+	return !tombstone.PurgeAt.After(now)
+}
+
+// DeletedAnswer - represents a soft deleted answer, it is hidden from the clients until it is purged.
+type DeletedAnswer struct {
+	Answer
+	Tombstone
+}
+
+// AnswerRetentionService - gives the admins access to the deleted answers and purges them.
+type AnswerRetentionService interface {
+
+	// ListDeletedAnswers - returns a page of deleted answers and the token of the next page.
+	ListDeletedAnswers(ctx context.Context, page PageRequest) (answers []*DeletedAnswer, nextPageToken string, err error)
+
+	// PurgeDeletedAnswers - hard deletes the answers whose retention period is over and returns their number.
+	PurgeDeletedAnswers(ctx context.Context) (purged int, err error)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// Idempotency JSON fields.
+const (
+	JSONFieldIdempotencyKey           = "key"
+	JSONFieldIdempotencyAnswerKeyHash = "answerKeyHash"
+)
+
+// MaxIdempotencyKeyLength - the maximum length of an idempotency key sent by a client.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyRecord - a request made with an idempotency key, its response is replayed when the request is retried.
+type IdempotencyRecord struct {
+	// Key - identifies the idempotency key of the client and the method.
+	Key string
+	// Fingerprint - the hash of the request, a retry must send the same request.
+	Fingerprint string
+	// Response - the encoded response, empty while the request is in progress.
+	Response string
+	// ExpiresAt - the key may be used again afterwards.
+	ExpiresAt time.Time
+	// ReservationID - identifies the request which reserved the key, only it may complete the record.
+	ReservationID string
+	// LeaseExpiresAt - a retry may take over the reservation afterwards, zero once the record is complete.
+	LeaseExpiresAt time.Time
+	// AnswerKeyHash - the hash of the key of the changed answer, the record is erased with the answer.
+	AnswerKeyHash string
+}
+
+// IsComplete - checks if the response of the request is stored.
+func (r *IdempotencyRecord) IsComplete() bool {
+	return len(r.Response) > 0
+}
+
+// IdempotencyRepository - provides access to the records of the requests made with an idempotency key.
+//
+// Reserve stores the record of a request in progress and returns ErrAlreadyExist if the key
+// has a record which has not expired by now, unless the record is in progress and its lease
+// has expired by now, then the reservation is taken over. Get returns ErrNotFound if the key
+// has no record or its record has expired. Complete stores the response of a reserved record
+// and returns ErrNotFound if it is missing or was reserved by another reservation ID since.
+// Release removes the record of the reservation ID, so the request may be made again, a missing
+// record is not an error. EraseAnswer removes the records of the answer key hash and returns
+// their number. The backend removes the expired records by itself.
+type IdempotencyRepository interface {
+	Reserve(record *IdempotencyRecord, now time.Time) error
+	Get(key string, now time.Time) (*IdempotencyRecord, error)
+	Complete(record *IdempotencyRecord) error
+	Release(key, reservationID string) error
+	EraseAnswer(answerKeyHash string) (erased int, err error)
+}
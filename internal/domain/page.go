@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+)
+
+// Page size limits.
+const (
+	// DefaultPageSize - page size used when the page request does not define one.
+	DefaultPageSize = 100
+	// MaxPageSize - the largest page size a client may request.
+	MaxPageSize = 1000
+)
+
+// PageRequest - represents a request of a single page of a listing.
+type PageRequest struct {
+	// PageToken - the token returned with the previous page, empty for the first page.
+	PageToken string
+	// PageSize - the maximum number of items in the page, DefaultPageSize if not positive.
+	PageSize int
+}
+
+// Size - returns the effective page size.
+func (p PageRequest) Size() int {
+	if p.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return p.PageSize
+}
+
+// EncodePageToken - encodes a backend-specific cursor to an opaque page token.
+func EncodePageToken(cursor string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+// redactedPageToken - replaces a page token in the logs.
+const redactedPageToken = "redacted"
+
+// RedactPageToken - returns the page token as it may be logged, the cursor it encodes may hold an answer key,
+// so only the presence of the token is logged.
+func RedactPageToken(token string) string {
+	if len(token) == 0 {
+		return ""
+	}
+	return redactedPageToken
+}
+
+// DecodePageToken - decodes a page token created by EncodePageToken.
+func DecodePageToken(token string) (string, error) {
+	cursor, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", errors.New("Page token is not valid")
+	}
+	return string(cursor), nil
+}
@@ -2,30 +2,28 @@ package domain
 
 import (
 	"context"
-
-	"github.com/aws/aws-sdk-go/service/sqs"
 )
 
-// QueueAPI - is the minimum interface required from a queue implementation.
-type QueueAPI interface {
-	CreateQueue(*sqs.CreateQueueInput) (*sqs.CreateQueueOutput, error)
-	GetQueueUrl(*sqs.GetQueueUrlInput) (*sqs.GetQueueUrlOutput, error)
-	SendMessage(*sqs.SendMessageInput) (*sqs.SendMessageOutput, error)
-	ReceiveMessage(*sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error)
-	DeleteMessage(*sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error)
+// ReceivedMessage - represents a message received from the queue, independently of the queue backend.
+type ReceivedMessage struct {
+	ID          string
+	MessageType MessageType
+	// Attributes - the attributes sent with the message, the message type included.
+	Attributes map[string]string
+	Body       []byte
 }
 
 // QueueHandlerFunc is used to define the Handler that is run on for each message.
-type QueueHandlerFunc func(ctx context.Context, msg *sqs.Message) error
+type QueueHandlerFunc func(ctx context.Context, msg *ReceivedMessage) error
 
-// HandleMessage wraps a function for handling sqs messages.
-func (f QueueHandlerFunc) HandleMessage(ctx context.Context, msg *sqs.Message) error {
+// HandleMessage wraps a function for handling queue messages.
+func (f QueueHandlerFunc) HandleMessage(ctx context.Context, msg *ReceivedMessage) error {
 	return f(ctx, msg)
 }
 
 // QueueHandler interface.
 type QueueHandler interface {
-	HandleMessage(ctx context.Context, msg *sqs.Message) error
+	HandleMessage(ctx context.Context, msg *ReceivedMessage) error
 }
 
 // QueueService - high-level service that provides access to the queue.
@@ -34,3 +32,11 @@ type QueueService interface {
 	// SendMessage - sends a message to the queue.
 	SendMessage(ctx context.Context, queueName string, message QueueMessage) (messageID string, err error)
 }
+
+// QueueConsumer - high-level service that receives messages from the queue.
+type QueueConsumer interface {
+
+	// Consume - receives messages from the queue and passes them to the handler until the context is done.
+	// A message is acknowledged only when the handler returns no error.
+	Consume(ctx context.Context, queueName string, h QueueHandler) error
+}
@@ -12,11 +12,16 @@ type QueueMessage interface {
 
 	// Validate - validates message type.
 	Validate() error
+
+	// GetAttributes - returns the attributes sent with the message, the filter policies match them.
+	GetAttributes() map[string]string
 }
 
 // AnswerEventMessage - event message.
 type AnswerEventMessage struct {
 	Event *AnswerEvent
+	// EventID - the ID the answer service reported for the event captured from the change stream, empty otherwise.
+	EventID string `json:",omitempty"`
 }
 
 // GetMessageType - returns AnswerEventMessageType.
@@ -24,6 +29,17 @@ func (aem *AnswerEventMessage) GetMessageType() MessageType {
 	return AnswerEventMessageType
 }
 
+// GetAttributes - returns the message type and the event type.
+func (aem *AnswerEventMessage) GetAttributes() map[string]string {
+	attributes := map[string]string{
+		MessageTypeAttributeKey: aem.GetMessageType().String(),
+	}
+	if aem.Event != nil && len(aem.Event.EventType) > 0 {
+		attributes[EventTypeAttributeKey] = string(aem.Event.EventType)
+	}
+	return attributes
+}
+
 // Validate - validates message type.
 func (aem *AnswerEventMessage) Validate() error {
 	if !aem.GetMessageType().IsValid() {
@@ -1,8 +1,9 @@
 package domain
 
-// Message type key.
+// Message attribute keys.
 const (
 	MessageTypeAttributeKey = "MessageType"
+	EventTypeAttributeKey   = "EventType"
 )
 
 // MessageType - message type.
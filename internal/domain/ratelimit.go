@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit JSON fields.
+const (
+	JSONFieldRateLimitKey = "key"
+)
+
+// RateLimit - the token bucket of a client, it holds up to Burst tokens and gets Rate tokens per second.
+// A request takes a token.
+type RateLimit struct {
+	Rate  float64
+	Burst int64
+}
+
+// TokenBucket - the tokens left in the bucket of a client at the time of its last request.
+type TokenBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Take - takes a token from the bucket, a nil bucket is full. It returns the bucket afterwards and,
+// if it is empty, how long until a token is available.
+func (l RateLimit) Take(bucket *TokenBucket, now time.Time) (next TokenBucket, allowed bool, retryAfter time.Duration) {
+	next = TokenBucket{Tokens: float64(l.Burst), UpdatedAt: now}
+	if bucket != nil {
+
+		// The clocks of the replicas may differ, the time does not go back.
+		//
+		next.UpdatedAt = bucket.UpdatedAt
+		if now.After(bucket.UpdatedAt) {
+			next.UpdatedAt = now
+		}
+		refilled := bucket.Tokens + next.UpdatedAt.Sub(bucket.UpdatedAt).Seconds()*l.Rate
+		next.Tokens = math.Min(refilled, float64(l.Burst))
+	}
+	if next.Tokens < 1 {
+		return next, false, time.Duration((1 - next.Tokens) / l.Rate * float64(time.Second))
+	}
+	next.Tokens--
+	return next, true, 0
+}
+
+// FullAt - returns the time the bucket is full again, it may be forgotten afterwards.
+func (l RateLimit) FullAt(bucket TokenBucket) time.Time {
+	missing := float64(l.Burst) - bucket.Tokens
+	return bucket.UpdatedAt.Add(time.Duration(missing / l.Rate * float64(time.Second)))
+}
+
+// RateLimiter - provides access to the token buckets of the clients.
+//
+// Take takes a token from the bucket of the key under the limit, a key without a bucket has a full one.
+// It returns false and the time until a token is available if the bucket is empty. The buckets of
+// several replicas may be shared, so the token must be taken atomically.
+type RateLimiter interface {
+	Take(key string, limit RateLimit, now time.Time) (allowed bool, retryAfter time.Duration, err error)
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+)
+
+// FilterPolicy - selects the messages a subscription queue receives by their attributes.
+//
+// A message matches if every attribute of the policy is sent with one of the listed values,
+// an empty policy matches every message. It is the exact-match subset of the SNS filter policies.
+type FilterPolicy map[string][]string
+
+// Matches - checks if the message attributes satisfy the policy.
+func (p FilterPolicy) Matches(attributes map[string]string) bool {
+	for name, values := range p {
+		value, ok := attributes[name]
+		if !ok || !containsString(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// WithErasures - returns the policy which also matches the erase events, they reach every subscription queue
+// so every consumer forgets the erased answers. A policy without an event type attribute matches them already.
+func (p FilterPolicy) WithErasures() FilterPolicy {
+	eventTypes, ok := p[EventTypeAttributeKey]
+	if !ok || containsString(eventTypes, string(EraseAnswerEventType)) {
+		return p
+	}
+	policy := make(FilterPolicy, len(p))
+	for name, values := range p {
+		policy[name] = values
+	}
+	policy[EventTypeAttributeKey] = append(append([]string(nil), eventTypes...), string(EraseAnswerEventType))
+	return policy
+}
+
+// TopicSubscription - a queue receiving a copy of the topic messages matching its filter policy.
+type TopicSubscription struct {
+	QueueName    string
+	FilterPolicy FilterPolicy
+}
+
+// TopicService - publishes messages to a topic, which fans them out to its subscription queues.
+type TopicService interface {
+
+	// Publish - publishes a message to the topic.
+	Publish(ctx context.Context, topicName string, message QueueMessage) (messageID string, err error)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
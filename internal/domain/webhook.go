@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Webhook JSON fields.
+const (
+	JSONFieldWebhookID             = "id"
+	JSONFieldWebhookSubscriptionID = "subscriptionId"
+	JSONFieldWebhookAttemptedAt    = "attemptedAt"
+)
+
+// WebhookSubscription - a receiver of the answer events, the payloads are signed by its secret.
+type WebhookSubscription struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// EventTypes - the delivered event types, all of them if empty.
+	EventTypes []AnswerEventType `json:"eventTypes"`
+	Secret     string            `json:"secret"`
+	CreatedAt  time.Time         `json:"createdAt"`
+}
+
+// Validate - validates struct.
+func (s *WebhookSubscription) Validate() error {
+	parsed, err := url.Parse(s.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || len(parsed.Host) == 0 {
+		return errors.New("URL must be an absolute http or https URL")
+	}
+	for _, eventType := range s.EventTypes {
+		if !eventType.IsValid() {
+			return fmt.Errorf("EventType %q is not valid", eventType)
+		}
+	}
+	return nil
+}
+
+// Matches - checks if the events of the type are delivered to the subscription.
+func (s *WebhookSubscription) Matches(eventType AnswerEventType) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery - an attempt to deliver an event to a subscription, it holds no answer value.
+type WebhookDelivery struct {
+	// ID - identifies the event delivered to the subscription, it is the same for all the attempts.
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscriptionId"`
+	EventType      AnswerEventType `json:"eventType"`
+	// KeyHash - the AnswerKeyHasher hash of the answer key, the key is not stored, so an erasure leaves nothing behind.
+	KeyHash string `json:"keyHash"`
+	// OccurredAt - the occurrence time of the event.
+	OccurredAt  time.Time `json:"occurredAt"`
+	Attempt     int       `json:"attempt"`
+	AttemptedAt time.Time `json:"attemptedAt"`
+	// StatusCode - the response status, zero if no response was received.
+	StatusCode int    `json:"statusCode"`
+	Error      string `json:"error"`
+	Succeeded  bool   `json:"succeeded"`
+}
+
+// Retryable - checks if the failed attempt may be retried, that is if no response was received
+// or the status is 408, 429 or 5xx.
+func (d *WebhookDelivery) Retryable() bool {
+	if d.Succeeded {
+		return false
+	}
+	return d.StatusCode == 0 || d.StatusCode >= 500 ||
+		d.StatusCode == http.StatusTooManyRequests || d.StatusCode == http.StatusRequestTimeout
+}
+
+// WebhookRepository - provides access to a storage of the subscriptions and their delivery log.
+//
+// CreateSubscription returns ErrAlreadyExist if the id is in use, GetSubscription and
+// DeleteSubscription return ErrNotFound if it is not. ListSubscriptions lists them in
+// a backend-specific order. DeleteSubscription removes the delivery log of the
+// subscription too. ListDeliveries lists the attempts in the order of their time,
+// an attempt is identified by its subscription and time. A page may hold fewer items
+// than requested and a listing ends with an empty token. ListDeliveryAttempts lists
+// the attempts of a delivery made since the time in the order of their time.
+type WebhookRepository interface {
+	CreateSubscription(subscription *WebhookSubscription) error
+	GetSubscription(id string) (*WebhookSubscription, error)
+	ListSubscriptions(page PageRequest) (subscriptions []*WebhookSubscription, nextPageToken string, err error)
+	DeleteSubscription(id string) error
+	CreateDelivery(delivery *WebhookDelivery) error
+	ListDeliveries(subscriptionID string, page PageRequest) (deliveries []*WebhookDelivery, nextPageToken string, err error)
+	ListDeliveryAttempts(subscriptionID, deliveryID string, since time.Time) ([]*WebhookDelivery, error)
+}
+
+// WebhookService - manages the webhook subscriptions.
+type WebhookService interface {
+
+	// CreateWebhookSubscription - registers a subscription, a secret is generated if none is given.
+	// The returned subscription is the only one holding the secret.
+	CreateWebhookSubscription(ctx context.Context, subscription *WebhookSubscription) (*WebhookSubscription, error)
+
+	// GetWebhookSubscription - returns the subscription without its secret.
+	GetWebhookSubscription(ctx context.Context, id string) (*WebhookSubscription, error)
+
+	// ListWebhookSubscriptions - returns a page of subscriptions without their secrets and the token of the next page.
+	ListWebhookSubscriptions(ctx context.Context, page PageRequest) (subscriptions []*WebhookSubscription, nextPageToken string, err error)
+
+	// DeleteWebhookSubscription - stops the deliveries to the subscription and removes its delivery log.
+	DeleteWebhookSubscription(ctx context.Context, id string) error
+
+	// ListWebhookDeliveries - returns a page of the delivery log of the subscription and the token of the next page.
+	ListWebhookDeliveries(ctx context.Context, subscriptionID string, page PageRequest) (deliveries []*WebhookDelivery, nextPageToken string, err error)
+}
+
+// AnswerEventNotifier - tells the interested parties about an answer event, an error fails the event,
+// so it is delivered again.
+type AnswerEventNotifier interface {
+	NotifyAnswerEvent(ctx context.Context, event *AnswerEvent) error
+}
@@ -0,0 +1,297 @@
+package dynamodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsSession "github.com/aws/aws-sdk-go/aws/session"
+	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodbstreams"
+	"github.com/go-kit/log"
+)
+
+// DefaultStreamPollInterval - the delay between the reads of the stream shards.
+const DefaultStreamPollInterval = time.Second
+
+// maxStreamRetryDelay - the longest delay before a failed read of the stream is retried,
+// the delay starts at the poll interval and doubles with every failure in a row.
+const maxStreamRetryDelay = time.Minute
+
+type answerChangeStream struct {
+	streams      *dynamodbstreams.DynamoDBStreams
+	streamARN    string
+	pollInterval time.Duration
+	logger       log.Logger
+}
+
+// NewAnswerChangeStream creates a reader of the DynamoDB stream of the answer table.
+// An error will be returned if the stream of the table is not enabled with the new and old images.
+func NewAnswerChangeStream(session *awsSession.Session, tableName string, logger log.Logger) (domain.AnswerChangeStream, error) {
+
+	// The stream is enabled by the provision command.
+	//
+	streamARN, err := VerifyStream(awsDynamodb.New(session), tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &answerChangeStream{
+		streams:      dynamodbstreams.New(session),
+		streamARN:    streamARN,
+		pollInterval: DefaultStreamPollInterval,
+		logger:       logger,
+	}, nil
+}
+
+// shardReader - the position of the reader in a shard.
+type shardReader struct {
+	parentID string
+	// iterator - the next records of the shard, empty before the first read.
+	iterator string
+	// lastSequenceNumber - the last handled record, the reading resumes after it when the iterator expires.
+	lastSequenceNumber string
+	// pending - the records read but not yet handled.
+	pending []*dynamodbstreams.Record
+	closed  bool
+}
+
+// errChangesLost - the changes of a shard are no longer retained or the shard expired before they were read.
+type errChangesLost struct {
+	shardID string
+	err     error
+}
+
+func (e *errChangesLost) Error() string {
+	return fmt.Sprintf("Changes of the shard %s were lost before they were read: %s", e.shardID, e.err)
+}
+
+// Read - reads the shards from the oldest retained records, so a restarted reader passes the retained changes again.
+// A child shard is read once its parent is finished, so the changes of an answer are not reordered.
+// A failed read is logged and retried after a delay doubled with every failure in a row, an error is only returned
+// if changes were lost, e.g. the retained records were trimmed before they were read.
+func (s *answerChangeStream) Read(ctx context.Context, h domain.AnswerChangeHandler) error {
+	shards := make(map[string]*shardReader)
+	finished := make(map[string]bool)
+	delay := s.pollInterval
+	for {
+		err := s.poll(ctx, shards, finished, h)
+		if _, ok := err.(*errChangesLost); ok {
+			return err
+		}
+
+		// Wait for the next poll, a failed poll is retried after the delay.
+		//
+		if err != nil {
+			_ = s.logger.Log("stream", s.streamARN, "retryIn", delay.String(), "err", err.Error())
+		} else {
+			delay = s.pollInterval
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		if err != nil && delay < maxStreamRetryDelay {
+			delay *= 2
+			if delay > maxStreamRetryDelay {
+				delay = maxStreamRetryDelay
+			}
+		}
+	}
+}
+
+// poll - discovers the new shards and reads the shards whose parent is finished or no longer retained.
+func (s *answerChangeStream) poll(ctx context.Context, shards map[string]*shardReader, finished map[string]bool, h domain.AnswerChangeHandler) error {
+	if err := s.discoverShards(shards, finished); err != nil {
+		return err
+	}
+	for shardID, shard := range shards {
+		if _, ok := shards[shard.parentID]; ok {
+			continue
+		}
+		if err := s.readShard(ctx, shardID, shard, h); err != nil {
+			return err
+		}
+		if shard.closed && len(shard.pending) == 0 {
+			finished[shardID] = true
+			delete(shards, shardID)
+		}
+	}
+	return nil
+}
+
+// discoverShards - adds the shards of the stream which are neither read nor finished.
+func (s *answerChangeStream) discoverShards(shards map[string]*shardReader, finished map[string]bool) error {
+	input := &dynamodbstreams.DescribeStreamInput{
+		StreamArn: aws.String(s.streamARN),
+	}
+	for {
+		output, err := s.streams.DescribeStream(input)
+		if err != nil {
+			return err
+		}
+		for _, shard := range output.StreamDescription.Shards {
+			shardID := aws.StringValue(shard.ShardId)
+			if _, ok := shards[shardID]; ok || finished[shardID] {
+				continue
+			}
+			shards[shardID] = &shardReader{parentID: aws.StringValue(shard.ParentShardId)}
+		}
+		if output.StreamDescription.LastEvaluatedShardId == nil {
+			return nil
+		}
+		input.ExclusiveStartShardId = output.StreamDescription.LastEvaluatedShardId
+	}
+}
+
+// readShard - reads the next records of the shard unless some are pending and passes them to the handler.
+// The records failed by the handler stay pending, so they are passed again by the next poll.
+func (s *answerChangeStream) readShard(ctx context.Context, shardID string, shard *shardReader, h domain.AnswerChangeHandler) error {
+	if len(shard.pending) == 0 && !shard.closed {
+		if err := s.getRecords(shardID, shard); err != nil {
+			return err
+		}
+	}
+	for len(shard.pending) > 0 {
+		record := shard.pending[0]
+		change, err := answerChange(record)
+		if err != nil {
+			return err
+		}
+		if err := h.HandleChange(ctx, change); err != nil {
+			return nil
+		}
+		shard.lastSequenceNumber = aws.StringValue(record.Dynamodb.SequenceNumber)
+		shard.pending = shard.pending[1:]
+	}
+	return nil
+}
+
+// getRecords - reads the next records of the shard, an expired iterator is replaced.
+func (s *answerChangeStream) getRecords(shardID string, shard *shardReader) error {
+	if len(shard.iterator) == 0 {
+		if err := s.getShardIterator(shardID, shard); err != nil {
+			return err
+		}
+	}
+	output, err := s.streams.GetRecords(&dynamodbstreams.GetRecordsInput{
+		ShardIterator: aws.String(shard.iterator),
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodbstreams.ErrCodeExpiredIteratorException {
+		shard.iterator = ""
+		return nil
+	}
+	if isShardLost(err) {
+		return &errChangesLost{shardID: shardID, err: err}
+	}
+	if err != nil {
+		return err
+	}
+	shard.pending = output.Records
+	shard.iterator = aws.StringValue(output.NextShardIterator)
+	shard.closed = output.NextShardIterator == nil
+	return nil
+}
+
+// getShardIterator - positions the reader after the last handled record, or at the oldest retained record
+// if none was handled. The changes were lost if the last handled record or the shard are no longer retained.
+func (s *answerChangeStream) getShardIterator(shardID string, shard *shardReader) error {
+	input := &dynamodbstreams.GetShardIteratorInput{
+		StreamArn:         aws.String(s.streamARN),
+		ShardId:           aws.String(shardID),
+		ShardIteratorType: aws.String(dynamodbstreams.ShardIteratorTypeTrimHorizon),
+	}
+	if len(shard.lastSequenceNumber) > 0 {
+		input.ShardIteratorType = aws.String(dynamodbstreams.ShardIteratorTypeAfterSequenceNumber)
+		input.SequenceNumber = aws.String(shard.lastSequenceNumber)
+	}
+	output, err := s.streams.GetShardIterator(input)
+	if isShardLost(err) {
+		return &errChangesLost{shardID: shardID, err: err}
+	}
+	if err != nil {
+		return err
+	}
+	shard.iterator = aws.StringValue(output.ShardIterator)
+	return nil
+}
+
+// isShardLost - checks if the error tells that the records or the shard are no longer retained.
+func isShardLost(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && (aerr.Code() == dynamodbstreams.ErrCodeTrimmedDataAccessException ||
+		aerr.Code() == dynamodbstreams.ErrCodeResourceNotFoundException)
+}
+
+// answerChangeRecord - represents an image of an answer item, the times are in nanoseconds.
+type answerChangeRecord struct {
+	Key       domain.AnswerKey   `dynamodbav:"key"`
+	Value     domain.AnswerValue `dynamodbav:"value"`
+	Version   int                `dynamodbav:"version"`
+	DeletedAt int64              `dynamodbav:"deletedAt"`
+	ChangedAt int64              `dynamodbav:"changedAt"`
+	EventType string             `dynamodbav:"eventType"`
+	EventID   string             `dynamodbav:"eventId"`
+}
+
+// answerChange - converts the stream record. The time of the change is taken from the item if the repository wrote it,
+// the approximate time of the record is only precise to the second.
+func answerChange(record *dynamodbstreams.Record) (*domain.AnswerChange, error) {
+	streamRecord := record.Dynamodb
+	oldRecord, err := unmarshalImage(streamRecord.OldImage)
+	if err != nil {
+		return nil, err
+	}
+	newRecord, err := unmarshalImage(streamRecord.NewImage)
+	if err != nil {
+		return nil, err
+	}
+	change := &domain.AnswerChange{
+		ID:        aws.StringValue(streamRecord.SequenceNumber),
+		Old:       answerImage(oldRecord),
+		New:       answerImage(newRecord),
+		ChangedAt: aws.TimeValue(streamRecord.ApproximateCreationDateTime).UTC(),
+	}
+	switch {
+	case change.New == nil:
+	case change.New.Deleted && change.Old.IsLive():
+		change.ChangedAt = time.Unix(0, newRecord.DeletedAt).UTC()
+	case newRecord.ChangedAt > 0:
+		change.ChangedAt = time.Unix(0, newRecord.ChangedAt).UTC()
+	}
+	return change, nil
+}
+
+// unmarshalImage - returns nil if the item did not exist.
+func unmarshalImage(image map[string]*awsDynamodb.AttributeValue) (*answerChangeRecord, error) {
+	if len(image) == 0 {
+		return nil, nil
+	}
+	record := &answerChangeRecord{}
+	if err := dynamodbattribute.UnmarshalMap(image, record); err != nil {
+		return nil, fmt.Errorf("Got error unmarshalling stream image: %s", err)
+	}
+	return record, nil
+}
+
+func answerImage(record *answerChangeRecord) *domain.AnswerImage {
+	if record == nil {
+		return nil
+	}
+	return &domain.AnswerImage{
+		Answer: domain.Answer{
+			Key:     record.Key,
+			Value:   record.Value,
+			Version: record.Version,
+		},
+		Deleted:   record.DeletedAt > 0,
+		EventType: domain.AnswerEventType(record.EventType),
+		EventID:   record.EventID,
+	}
+}
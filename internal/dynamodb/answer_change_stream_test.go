@@ -0,0 +1,108 @@
+package dynamodb
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/go-kit/log"
+	"github.com/go-test/deep"
+)
+
+func TestAnswerChangeStream(t *testing.T) {
+	db := awsDynamodb.New(testAwsSession)
+	tableName := provisionTestTableWithTimeToLive(t, AnswerTableSchema(nextTestTableName(testAnswerTableName)))
+
+	// The stream must be enabled by the provision command.
+	//
+	_, err := NewAnswerChangeStream(testAwsSession, tableName, log.NewNopLogger())
+	if err == nil || !strings.Contains(err.Error(), "run the provision command") {
+		t.Fatalf("expected stream not enabled err, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := ProvisionStream(db, tableName); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+	stream, err := NewAnswerChangeStream(testAwsSession, tableName, log.NewNopLogger())
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	stream.(*answerChangeStream).pollInterval = 10 * time.Millisecond
+	repository, err := NewAnswerRepository(testAwsSession, tableName)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// Write the answer, the erasure removes the item without an event.
+	//
+	deletedAt := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+	writes := []func() error{
+		func() error { return repository.Create(&domain.Answer{Key: "name", Value: "John"}) },
+		func() error { return repository.Update(&domain.Answer{Key: "name", Value: "Sam"}) },
+		func() error {
+			_, err := repository.Delete("name", domain.NewTombstone(deletedAt, time.Hour))
+			return err
+		},
+		func() error { return repository.Erase("name") },
+		func() error { return repository.Create(&domain.Answer{Key: "name", Value: "Ann"}) },
+		func() error {
+			return repository.(domain.TaggedAnswerRepository).UpdateTagged(&domain.Answer{Key: "name", Value: "John"},
+				domain.AnswerChangeTag{EventType: domain.RestoreAnswerEventType, EventID: "event-1", OccurredAt: deletedAt.Add(time.Hour)})
+		},
+	}
+	for i, write := range writes {
+		if err := write(); err != nil {
+			t.Fatalf("write %d: unexpected err: %v", i, err)
+		}
+	}
+
+	// Read the events of the changes.
+	//
+	want := []domain.AnswerEvent{
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John", Version: 1}},
+		{EventType: domain.UpdateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam", Version: 2}},
+		{EventType: domain.DeleteAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam", Version: 3}, OccurredAt: deletedAt},
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Ann", Version: 1}},
+		{EventType: domain.RestoreAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John", Version: 2}},
+	}
+	var got []domain.AnswerEvent
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err = stream.Read(ctx, domain.AnswerChangeHandlerFunc(func(ctx context.Context, change *domain.AnswerChange) error {
+		event, eventID, ok := change.Event()
+		if !ok {
+			return nil
+		}
+
+		// The tag of the restore is written with the item.
+		//
+		if (event.EventType == domain.RestoreAnswerEventType) != (eventID == "event-1") {
+			t.Errorf("%v event has the ID %q", event.EventType, eventID)
+		}
+
+		// The repository writes the exact time of the other changes.
+		//
+		if event.EventType != domain.DeleteAnswerEventType {
+			if event.OccurredAt.Nanosecond() == 0 {
+				t.Errorf("%v event has no precise occurrence time", event.EventType)
+			}
+			event.OccurredAt = time.Time{}
+		}
+		got = append(got, *event)
+		if len(got) == len(want) {
+			cancel()
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -1,10 +1,15 @@
 package dynamodb
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
 	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	awsSession "github.com/aws/aws-sdk-go/aws/session"
 	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
@@ -17,150 +22,251 @@ type answerEventRepo struct {
 }
 
 // NewAnswerEventRepository creates a new repository.
-func NewAnswerEventRepository(session *awsSession.Session, tableName string) domain.AnswerEventRepository {
+// An error will be returned if the table does not exist or does not match the schema.
+func NewAnswerEventRepository(session *awsSession.Session, tableName string) (domain.AnswerEventRepository, error) {
 
 	// Create a new dynamodb client.
 	//
 	db := awsDynamodb.New(session)
 
-	// Ensure is table exist.
+	// The table is created by the provision command.
 	//
-	answerEventTableMustExist(db, tableName)
+	if err := VerifyTable(db, AnswerEventTableSchema(tableName)); err != nil {
+
+		// A table keyed by the event type must be migrated to a new table, see the README upgrade note.
+		//
+		if VerifyTable(db, LegacyAnswerEventTableSchema(tableName)) == nil {
+			return nil, fmt.Errorf("table %s is keyed by the event type, migrate it to a new table with %s", tableName, "LEGACY_ANSWER_EVENT_TABLE_NAME")
+		}
+		return nil, err
+	}
 
 	return &answerEventRepo{
 		db:        db,
 		tableName: tableName,
-	}
+	}, nil
 }
 
-func answerEventTableMustExist(db *awsDynamodb.DynamoDB, tableName string) {
-	// Check if the table exist.
-	//
-	listTablesOutput, err := db.ListTables(&awsDynamodb.ListTablesInput{})
-
-	// Returned internal server error.
-	// The application does not know if the table exists or not.
-	// Thus, it cannot query the server, so we panic this error.
-	//
-	if err != nil {
-		panic(err)
-	}
-	for _, table := range listTablesOutput.TableNames {
-		// the table already exists and there is no reason to continue.
-		if *table == tableName {
-			return
-		}
-	}
+// answerEventItem - represents an answer event stored in the table.
+// The occurrence time is stored in nanoseconds to be the sort key of the answer history.
+type answerEventItem struct {
+	Key        domain.AnswerKey       `json:"key"`
+	OccurredAt int64                  `json:"occurredAt"`
+	EventType  domain.AnswerEventType `json:"eventType"`
+	Data       *domain.Answer         `json:"data"`
+}
 
-	// Create table.
-	//
-	_, err = db.CreateTable(&awsDynamodb.CreateTableInput{
-		AttributeDefinitions: []*awsDynamodb.AttributeDefinition{
-			{
-				AttributeName: aws.String(domain.JSONFieldAnswerKey),
-				AttributeType: aws.String("S"),
-			},
-			{
-				AttributeName: aws.String(domain.JSONFieldEventType),
-				AttributeType: aws.String("S"),
-			},
-		},
-		KeySchema: []*awsDynamodb.KeySchemaElement{
-			{
-				AttributeName: aws.String(domain.JSONFieldAnswerKey),
-				KeyType:       aws.String("HASH"),
-			},
-			{
-				AttributeName: aws.String(domain.JSONFieldEventType),
-				KeyType:       aws.String("RANGE"),
-			},
-		},
-		ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
-			ReadCapacityUnits:  aws.Int64(10),
-			WriteCapacityUnits: aws.Int64(10),
-		},
-		TableName: aws.String(tableName),
-	})
-	if aerr, ok := err.(awserr.Error); ok {
-		if aerr.Code() != awsErrorResourceInUse {
-			panic(aerr)
-		}
+func (item *answerEventItem) toAnswerEvent() *domain.AnswerEvent {
+	return &domain.AnswerEvent{
+		EventType:  item.EventType,
+		Data:       item.Data,
+		OccurredAt: time.Unix(0, item.OccurredAt).UTC(),
 	}
-
-	// Wait for table.
-	_ = db.WaitUntilTableExists(&awsDynamodb.DescribeTableInput{
-		TableName: aws.String(tableName),
-	})
 }
 
 func (r *answerEventRepo) Create(answerEvent *domain.AnswerEvent) error {
 
 	// Marshal Go value type to a map of AttributeValues.
 	//
-	attributes, err := dynamodbattribute.MarshalMap(answerEvent)
+	attributes, err := dynamodbattribute.MarshalMap(&answerEventItem{
+		Key:        answerEvent.Data.Key,
+		OccurredAt: answerEvent.OccurredAt.UnixNano(),
+		EventType:  answerEvent.EventType,
+		Data:       answerEvent.Data,
+	})
 	if err != nil {
 		return err
 	}
 
-	// Add missing attributes.
-	//
-	attributes[domain.JSONFieldAnswerKey] = &awsDynamodb.AttributeValue{
-		S: aws.String(string(answerEvent.Data.Key)),
-	}
-
-	// Put input.
+	// Put input, the event must not be stored yet.
 	//
 	input := &awsDynamodb.PutItemInput{
-		Item:      attributes,
-		TableName: aws.String(r.tableName),
+		Item:                attributes,
+		TableName:           aws.String(r.tableName),
+		ConditionExpression: aws.String("attribute_not_exists(#key)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#key": aws.String(domain.JSONFieldAnswerKey),
+		},
 	}
 
 	// Put item in dynamodb storage.
 	//
 	_, err = r.db.PutItem(input)
+	if isConditionalCheckFailed(err) {
+		return errors.NewErrAlreadyExist("Answer event already exists")
+	}
 
 	return err
 }
 
 func (r *answerEventRepo) ListEvents(key domain.AnswerKey) ([]*domain.AnswerEvent, error) {
+	var (
+		items []*domain.AnswerEvent
+		page  = domain.PageRequest{}
+	)
+	for {
+		events, nextPageToken, err := r.ListEventsPage(key, page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, events...)
+		if len(nextPageToken) == 0 {
+			return items, nil
+		}
+		page.PageToken = nextPageToken
+	}
+}
+
+func (r *answerEventRepo) ListEventsPage(key domain.AnswerKey, page domain.PageRequest) ([]*domain.AnswerEvent, string, error) {
 
 	// Build expression.
 	//
-	filter := expression.Name(domain.JSONFieldAnswerKey).Equal(expression.Value(key))
+	keyCondition := expression.Key(domain.JSONFieldAnswerKey).Equal(expression.Value(key))
 
-	expr, err := expression.NewBuilder().WithProjection(r.getProjection()).WithFilter(filter).Build()
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	params := &awsDynamodb.ScanInput{
+	// Events are sorted by the occurrence time,
+	// the token holds the occurrence time of the last event of the previous page.
+	//
+	params := &awsDynamodb.QueryInput{
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
-		FilterExpression:          expr.Filter(),
-		ProjectionExpression:      expr.Projection(),
+		KeyConditionExpression:    expr.KeyCondition(),
 		TableName:                 aws.String(r.tableName),
+		Limit:                     aws.Int64(int64(page.Size())),
+		ScanIndexForward:          aws.Bool(true),
+		ConsistentRead:            aws.Bool(true),
+	}
+	if len(page.PageToken) > 0 {
+		lastOccurredAt, err := domain.DecodePageToken(page.PageToken)
+		if err != nil {
+			return nil, "", errors.NewErrInvalidArgument(err.Error())
+		}
+		if _, err := strconv.ParseInt(lastOccurredAt, 10, 64); err != nil {
+			return nil, "", errors.NewErrInvalidArgument("Page token is not valid")
+		}
+		params.ExclusiveStartKey = map[string]*awsDynamodb.AttributeValue{
+			domain.JSONFieldAnswerKey:  {S: aws.String(string(key))},
+			domain.JSONFieldOccurredAt: {N: aws.String(lastOccurredAt)},
+		}
 	}
 
-	// Make the DynamoDB Query API call
-	result, err := r.db.Scan(params)
+	// Make the DynamoDB Query API call.
+	//
+	result, err := r.db.Query(params)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	var items []*domain.AnswerEvent
 	for _, i := range result.Items {
-		item := &domain.AnswerEvent{}
+		item := &answerEventItem{}
 		err = dynamodbattribute.UnmarshalMap(i, item)
 		if err != nil {
-			return nil, err
+			return nil, "", err
+		}
+		items = append(items, item.toAnswerEvent())
+	}
+
+	// DynamoDB returns the last evaluated key while the partition may have more items.
+	//
+	var nextPageToken string
+	if lastOccurredAt, ok := result.LastEvaluatedKey[domain.JSONFieldOccurredAt]; ok {
+		nextPageToken = domain.EncodePageToken(aws.StringValue(lastOccurredAt.N))
+	}
+	return items, nextPageToken, nil
+}
+
+func (r *answerEventRepo) ListKeys(page domain.PageRequest) ([]domain.AnswerKey, string, error) {
+
+	// The events of a key are scanned together, the token holds the last key of the previous page
+	// and the scan continues after its latest possible event, so a key is never listed twice.
+	//
+	scanInput := &awsDynamodb.ScanInput{
+		TableName:            aws.String(r.tableName),
+		Limit:                aws.Int64(int64(page.Size())),
+		ProjectionExpression: aws.String("#key"),
+		ExpressionAttributeNames: map[string]*string{
+			"#key": aws.String(domain.JSONFieldAnswerKey),
+		},
+	}
+	if len(page.PageToken) > 0 {
+		lastKey, err := domain.DecodePageToken(page.PageToken)
+		if err != nil {
+			return nil, "", errors.NewErrInvalidArgument(err.Error())
+		}
+		scanInput.ExclusiveStartKey = map[string]*awsDynamodb.AttributeValue{
+			domain.JSONFieldAnswerKey:  {S: aws.String(lastKey)},
+			domain.JSONFieldOccurredAt: {N: aws.String(strconv.FormatInt(math.MaxInt64, 10))},
+		}
+	}
+
+	// Make the DynamoDB Scan API call.
+	//
+	result, err := r.db.Scan(scanInput)
+	if err != nil {
+		return nil, "", err
+	}
+	var keys []domain.AnswerKey
+	for _, i := range result.Items {
+		key := domain.AnswerKey(aws.StringValue(i[domain.JSONFieldAnswerKey].S))
+		if len(keys) == 0 || keys[len(keys)-1] != key {
+			keys = append(keys, key)
 		}
-		items = append(items, item)
 	}
-	return items, nil
+
+	// DynamoDB returns the last evaluated key while the table may have more items.
+	//
+	var nextPageToken string
+	if lastKey, ok := result.LastEvaluatedKey[domain.JSONFieldAnswerKey]; ok {
+		nextPageToken = domain.EncodePageToken(aws.StringValue(lastKey.S))
+	}
+	return keys, nextPageToken, nil
 }
 
-func (r *answerEventRepo) getProjection() expression.ProjectionBuilder {
-	return expression.NamesList(
-		expression.Name(domain.JSONFieldAnswerKey),
-		expression.Name(domain.JSONFieldEventType),
-		expression.Name(domain.JSONFieldData))
+func (r *answerEventRepo) EraseEvents(key domain.AnswerKey, before time.Time) (int, error) {
+
+	// Build expression, only the keys of the events are read.
+	//
+	keyCondition := expression.Key(domain.JSONFieldAnswerKey).Equal(expression.Value(key)).
+		And(expression.Key(domain.JSONFieldOccurredAt).LessThan(expression.Value(before.UnixNano())))
+	projection := expression.NamesList(expression.Name(domain.JSONFieldAnswerKey), expression.Name(domain.JSONFieldOccurredAt))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithProjection(projection).Build()
+	if err != nil {
+		return 0, err
+	}
+	params := &awsDynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(r.tableName),
+		ConsistentRead:            aws.Bool(true),
+	}
+
+	// Delete the events page by page.
+	//
+	var erased int
+	for {
+		result, err := r.db.Query(params)
+		if err != nil {
+			return erased, err
+		}
+		for _, item := range result.Items {
+			_, err := r.db.DeleteItem(&awsDynamodb.DeleteItemInput{
+				Key:       item,
+				TableName: aws.String(r.tableName),
+			})
+			if err != nil {
+				return erased, err
+			}
+			erased++
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			return erased, nil
+		}
+		params.ExclusiveStartKey = result.LastEvaluatedKey
+	}
 }
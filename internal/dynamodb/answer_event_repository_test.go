@@ -0,0 +1,19 @@
+package dynamodb
+
+import (
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/repositorytest"
+)
+
+func TestAnswerEventRepository(t *testing.T) {
+	repositorytest.TestAnswerEventRepository(t, func(t *testing.T) domain.AnswerEventRepository {
+		tableName := provisionTestTable(t, AnswerEventTableSchema(nextTestTableName(testAnswerEventTableName)))
+		repository, err := NewAnswerEventRepository(testAwsSession, tableName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repository
+	})
+}
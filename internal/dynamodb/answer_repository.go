@@ -2,12 +2,15 @@ package dynamodb
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"dochq.co.uk.answerservice/internal/domain"
 	errors "dochq.co.uk.answerservice/internal/error"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	awsSession "github.com/aws/aws-sdk-go/aws/session"
 	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
@@ -19,138 +22,159 @@ type answerRepo struct {
 }
 
 // NewAnswerRepository creates a new repository.
-func NewAnswerRepository(session *awsSession.Session, tableName string) domain.AnswerRepository {
+// An error will be returned if the table does not exist, does not match the schema or its items do not expire.
+func NewAnswerRepository(session *awsSession.Session, tableName string) (domain.AnswerRepository, error) {
 
 	// Create a new dynamodb client.
 	//
 	db := awsDynamodb.New(session)
 
-	// Ensure is table exist.
+	// The table is created by the provision command.
 	//
-	answerTableMustExist(db, tableName)
+	if err := VerifyTable(db, AnswerTableSchema(tableName)); err != nil {
+		return nil, err
+	}
+	if err := VerifyTimeToLive(db, tableName, AttributeExpiresAt); err != nil {
+		return nil, err
+	}
 
 	return &answerRepo{
 		db:        db,
 		tableName: tableName,
-	}
+	}, nil
 }
 
-func answerTableMustExist(db *awsDynamodb.DynamoDB, tableName string) {
-	// Check if the table exist.
-	//
-	listTablesOutput, err := db.ListTables(&awsDynamodb.ListTablesInput{})
+func (r *answerRepo) Create(answer *domain.Answer) error {
+	return r.CreateTagged(answer, domain.AnswerChangeTag{})
+}
+
+func (r *answerRepo) CreateTagged(answer *domain.Answer, tag domain.AnswerChangeTag) error {
 
-	// Returned internal server error.
-	// The application does not know if the table exists or not.
-	// Thus, it cannot query the server, so we panic this error.
+	// Update input, the key must not be in use, a deleted answer is replaced.
 	//
+	input, err := r.writeInput(answer, answer.Version, tag)
 	if err != nil {
-		panic(err)
-	}
-	for _, table := range listTablesOutput.TableNames {
-		// the table already exists and there is no reason to continue.
-		if *table == tableName {
-			return
-		}
+		return err
 	}
+	input.ConditionExpression = aws.String("attribute_not_exists(#key) OR attribute_exists(#deletedAt)")
+	input.ExpressionAttributeNames["#key"] = aws.String(domain.JSONFieldAnswerKey)
+	input.ReturnValues = aws.String(awsDynamodb.ReturnValueUpdatedNew)
 
-	// Create table.
+	// Update item in dynamodb storage.
 	//
-	_, err = db.CreateTable(&awsDynamodb.CreateTableInput{
-		AttributeDefinitions: []*awsDynamodb.AttributeDefinition{
-			{
-				AttributeName: aws.String(domain.JSONFieldAnswerKey),
-				AttributeType: aws.String("S"),
-			},
-		},
-		KeySchema: []*awsDynamodb.KeySchemaElement{
-			{
-				AttributeName: aws.String(domain.JSONFieldAnswerKey),
-				KeyType:       aws.String("HASH"),
-			},
-		},
-		ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
-			ReadCapacityUnits:  aws.Int64(10),
-			WriteCapacityUnits: aws.Int64(10),
-		},
-		TableName: aws.String(tableName),
-	})
-	if aerr, ok := err.(awserr.Error); ok {
-		if aerr.Code() != awsErrorResourceInUse {
-			panic(aerr)
-		}
+	output, err := r.db.UpdateItem(input)
+	if isConditionalCheckFailed(err) {
+		return errors.NewErrAlreadyExist("Answer already exists")
 	}
+	if err != nil {
+		return err
+	}
+	answer.Version, err = versionOf(output.Attributes)
+	return err
+}
 
-	// Wait for table.
-	_ = db.WaitUntilTableExists(&awsDynamodb.DescribeTableInput{
-		TableName: aws.String(tableName),
-	})
+func (r *answerRepo) Update(answer *domain.Answer) error {
+	return r.UpdateTagged(answer, domain.AnswerChangeTag{})
 }
 
-func (r *answerRepo) Create(answer *domain.Answer) error {
+func (r *answerRepo) UpdateTagged(answer *domain.Answer, tag domain.AnswerChangeTag) error {
 
-	// Marshal Go value type to a map of AttributeValues.
+	// Update input, the key must be in use.
 	//
-	attributes, err := dynamodbattribute.MarshalMap(answer)
+	input, err := r.writeInput(answer, 0, tag)
 	if err != nil {
 		return err
 	}
+	input.ConditionExpression = aws.String("attribute_exists(#key) AND attribute_not_exists(#deletedAt)")
+	input.ExpressionAttributeNames["#key"] = aws.String(domain.JSONFieldAnswerKey)
+	input.ReturnValues = aws.String(awsDynamodb.ReturnValueUpdatedNew)
 
-	// Put input.
+	// Update item in dynamodb storage.
 	//
-	input := &awsDynamodb.PutItemInput{
-		Item:      attributes,
-		TableName: aws.String(r.tableName),
+	output, err := r.db.UpdateItem(input)
+	if isConditionalCheckFailed(err) {
+		return errors.NewErrNotFound("Answer not found")
 	}
-
-	// Put item in dynamodb storage.
-	//
-	_, err = r.db.PutItem(input)
-
+	if err != nil {
+		return err
+	}
+	answer.Version, err = versionOf(output.Attributes)
 	return err
 }
 
-func (r *answerRepo) Update(answer *domain.Answer) error {
+func (r *answerRepo) Upsert(answer *domain.Answer) (bool, error) {
+	return r.UpsertTagged(answer, domain.AnswerChangeTag{})
+}
 
-	// Marshal Go value type to a map of AttributeValues.
+func (r *answerRepo) UpsertTagged(answer *domain.Answer, tag domain.AnswerChangeTag) (bool, error) {
+
+	// Update input without a condition, the replaced item is returned.
 	//
-	attributes, err := dynamodbattribute.MarshalMap(answer)
+	input, err := r.writeInput(answer, answer.Version, tag)
 	if err != nil {
-		return err
+		return false, err
 	}
+	input.ReturnValues = aws.String(awsDynamodb.ReturnValueAllOld)
 
-	// Put input.
+	// Update item in dynamodb storage.
 	//
-	input := &awsDynamodb.PutItemInput{
-		Item:      attributes,
-		TableName: aws.String(r.tableName),
+	output, err := r.db.UpdateItem(input)
+	if err != nil {
+		return false, err
 	}
 
-	// Put item in dynamodb storage.
+	// The version counts on from the replaced item, the answer is created if there was no item or the item was deleted.
 	//
-	_, err = r.db.PutItem(input)
+	version, err := versionOf(output.Attributes)
+	if err != nil {
+		return false, err
+	}
+	if version == 0 {
+		version = answer.Version
+	}
+	answer.Version = version + 1
+	_, deleted := output.Attributes[AttributeDeletedAt]
+	return len(output.Attributes) == 0 || deleted, nil
+}
 
-	return err
+func (r *answerRepo) Delete(key domain.AnswerKey, tombstone domain.Tombstone) (int, error) {
+	return r.DeleteTagged(key, tombstone, domain.AnswerChangeTag{})
 }
 
-func (r *answerRepo) Delete(key domain.AnswerKey) error {
+func (r *answerRepo) DeleteTagged(key domain.AnswerKey, tombstone domain.Tombstone, tag domain.AnswerChangeTag) (int, error) {
 
-	// Delete input.
+	// Update input, the key must be in use. DynamoDB removes the item
+	// by itself some time after its expiry, the purge command does it on time.
 	//
-	input := &awsDynamodb.DeleteItemInput{
-		Key: map[string]*awsDynamodb.AttributeValue{
-			domain.JSONFieldAnswerKey: {
-				S: aws.String(string(key)),
-			},
+	set := map[string]*awsDynamodb.AttributeValue{
+		AttributeDeletedAt: numberAttribute(tombstone.DeletedAt.UnixNano()),
+		AttributePurgeAt:   numberAttribute(tombstone.PurgeAt.UnixNano()),
+		AttributeExpiresAt: numberAttribute(tombstone.PurgeAt.Unix()),
+	}
+	removed := tagAttributes(tag, set, nil)
+	input := &awsDynamodb.UpdateItemInput{
+		Key:                 r.itemKey(key),
+		TableName:           aws.String(r.tableName),
+		ConditionExpression: aws.String("attribute_exists(#key) AND attribute_not_exists(#deletedAt)"),
+		ExpressionAttributeValues: map[string]*awsDynamodb.AttributeValue{
+			":zero": numberAttribute(0),
+			":one":  numberAttribute(1),
 		},
-		TableName: aws.String(r.tableName),
+		ReturnValues: aws.String(awsDynamodb.ReturnValueUpdatedNew),
 	}
+	updateExpression(input, set, "#version = if_not_exists(#version, :zero) + :one", removed)
+	input.ExpressionAttributeNames["#key"] = aws.String(domain.JSONFieldAnswerKey)
 
-	// Delete item.
+	// Update item.
 	//
-	_, err := r.db.DeleteItem(input)
-
-	return err
+	output, err := r.db.UpdateItem(input)
+	if isConditionalCheckFailed(err) {
+		return 0, errors.NewErrNotFound("Answer not found")
+	}
+	if err != nil {
+		return 0, err
+	}
+	return versionOf(output.Attributes)
 }
 
 func (r *answerRepo) Get(key domain.AnswerKey) (*domain.Answer, error) {
@@ -166,6 +190,9 @@ func (r *answerRepo) Get(key domain.AnswerKey) (*domain.Answer, error) {
 					{S: aws.String(string(key))},
 				},
 			},
+			AttributeDeletedAt: {
+				ComparisonOperator: aws.String("NULL"),
+			},
 		},
 		ConsistentRead: aws.Bool(true),
 	}
@@ -195,3 +222,242 @@ func (r *answerRepo) Get(key domain.AnswerKey) (*domain.Answer, error) {
 	//
 	return answer, nil
 }
+
+func (r *answerRepo) List(page domain.PageRequest) ([]*domain.Answer, string, error) {
+
+	// The token holds the last evaluated key of the previous page.
+	//
+	// Deleted answers are filtered out, so a page may hold fewer items than requested.
+	//
+	result, nextPageToken, err := r.scan(page, "attribute_not_exists(#deletedAt)")
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Unmarshal entities.
+	//
+	var items []*domain.Answer
+	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &items)
+	if err != nil {
+		return nil, "", errors.NewErrInternal(fmt.Sprintf("Got error unmarshalling: %s", err))
+	}
+	return items, nextPageToken, nil
+}
+
+func (r *answerRepo) ListDeleted(page domain.PageRequest) ([]*domain.DeletedAnswer, string, error) {
+	result, nextPageToken, err := r.scan(page, "attribute_exists(#deletedAt)")
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Unmarshal entities.
+	//
+	var records []deletedAnswerRecord
+	err = dynamodbattribute.UnmarshalListOfMaps(result.Items, &records)
+	if err != nil {
+		return nil, "", errors.NewErrInternal(fmt.Sprintf("Got error unmarshalling: %s", err))
+	}
+	items := make([]*domain.DeletedAnswer, len(records))
+	for i, record := range records {
+		items[i] = &domain.DeletedAnswer{
+			Answer: domain.Answer{
+				Key:     record.Key,
+				Value:   record.Value,
+				Version: record.Version,
+			},
+			Tombstone: domain.Tombstone{
+				DeletedAt: time.Unix(0, record.DeletedAt).UTC(),
+				PurgeAt:   time.Unix(0, record.PurgeAt).UTC(),
+			},
+		}
+	}
+	return items, nextPageToken, nil
+}
+
+func (r *answerRepo) Purge(key domain.AnswerKey, now time.Time) error {
+
+	// Delete input, the answer must be deleted and due.
+	//
+	input := &awsDynamodb.DeleteItemInput{
+		Key:                      r.itemKey(key),
+		TableName:                aws.String(r.tableName),
+		ConditionExpression:      aws.String("attribute_exists(#deletedAt) AND #purgeAt <= :now"),
+		ExpressionAttributeNames: expressionAttributeNames(AttributeDeletedAt, AttributePurgeAt),
+		ExpressionAttributeValues: map[string]*awsDynamodb.AttributeValue{
+			":now": numberAttribute(now.UnixNano()),
+		},
+	}
+
+	// Delete item.
+	//
+	_, err := r.db.DeleteItem(input)
+	if isConditionalCheckFailed(err) {
+		return errors.NewErrNotFound("Deleted answer not found")
+	}
+	return err
+}
+
+func (r *answerRepo) Erase(key domain.AnswerKey) error {
+
+	// Delete input, the key must be in use or deleted.
+	//
+	input := &awsDynamodb.DeleteItemInput{
+		Key:                      r.itemKey(key),
+		TableName:                aws.String(r.tableName),
+		ConditionExpression:      aws.String("attribute_exists(#key)"),
+		ExpressionAttributeNames: expressionAttributeNames(domain.JSONFieldAnswerKey),
+	}
+
+	// Delete item.
+	//
+	_, err := r.db.DeleteItem(input)
+	if isConditionalCheckFailed(err) {
+		return errors.NewErrNotFound("Answer not found")
+	}
+	return err
+}
+
+// scan - returns a page of the items matching the filter, the token holds the last evaluated key of the previous page.
+func (r *answerRepo) scan(page domain.PageRequest, filter string) (*awsDynamodb.ScanOutput, string, error) {
+	scanInput := &awsDynamodb.ScanInput{
+		TableName:                aws.String(r.tableName),
+		Limit:                    aws.Int64(int64(page.Size())),
+		FilterExpression:         aws.String(filter),
+		ExpressionAttributeNames: expressionAttributeNames(AttributeDeletedAt),
+		ConsistentRead:           aws.Bool(true),
+	}
+	if len(page.PageToken) > 0 {
+		lastKey, err := domain.DecodePageToken(page.PageToken)
+		if err != nil {
+			return nil, "", errors.NewErrInvalidArgument(err.Error())
+		}
+		scanInput.ExclusiveStartKey = r.itemKey(domain.AnswerKey(lastKey))
+	}
+
+	// Make the DynamoDB Scan API call.
+	//
+	result, err := r.db.Scan(scanInput)
+	if err != nil {
+		return nil, "", errors.NewErrInternal(fmt.Sprintf("Scan API call failed: %s", err))
+	}
+
+	// DynamoDB returns the last evaluated key while the table may have more items.
+	//
+	var nextPageToken string
+	if lastKey, ok := result.LastEvaluatedKey[domain.JSONFieldAnswerKey]; ok {
+		nextPageToken = domain.AnswerPageToken(domain.AnswerKey(aws.StringValue(lastKey.S)))
+	}
+	return result, nextPageToken, nil
+}
+
+func (r *answerRepo) itemKey(key domain.AnswerKey) map[string]*awsDynamodb.AttributeValue {
+	return map[string]*awsDynamodb.AttributeValue{
+		domain.JSONFieldAnswerKey: {
+			S: aws.String(string(key)),
+		},
+	}
+}
+
+// writeInput - returns the update which writes the attributes of the answer and the tag, removes the tombstone and
+// increments the version. The version counts on from the given one if the item has none.
+func (r *answerRepo) writeInput(answer *domain.Answer, version int, tag domain.AnswerChangeTag) (*awsDynamodb.UpdateItemInput, error) {
+
+	// Marshal Go value type to a map of AttributeValues, the key and the version are not set.
+	//
+	attributes, err := dynamodbattribute.MarshalMap(answer)
+	if err != nil {
+		return nil, err
+	}
+	delete(attributes, domain.JSONFieldAnswerKey)
+	delete(attributes, domain.JSONFieldAnswerVersion)
+	changedAt := tag.OccurredAt
+	if changedAt.IsZero() {
+		changedAt = time.Now()
+	}
+	attributes[AttributeChangedAt] = numberAttribute(changedAt.UnixNano())
+	removed := tagAttributes(tag, attributes, []string{AttributeDeletedAt, AttributePurgeAt, AttributeExpiresAt})
+
+	input := &awsDynamodb.UpdateItemInput{
+		Key:       r.itemKey(answer.Key),
+		TableName: aws.String(r.tableName),
+		ExpressionAttributeValues: map[string]*awsDynamodb.AttributeValue{
+			":version": numberAttribute(int64(version)),
+			":one":     numberAttribute(1),
+		},
+	}
+	updateExpression(input, attributes, "#version = if_not_exists(#version, :version) + :one", removed)
+	return input, nil
+}
+
+// tagAttributes - adds the fields of the tag to the set attributes and returns the removed attributes
+// with the empty ones, so the tag of an earlier write is not kept.
+func tagAttributes(tag domain.AnswerChangeTag, set map[string]*awsDynamodb.AttributeValue, removed []string) []string {
+	if len(tag.EventType) > 0 {
+		set[AttributeEventType] = &awsDynamodb.AttributeValue{S: aws.String(string(tag.EventType))}
+	} else {
+		removed = append(removed, AttributeEventType)
+	}
+	if len(tag.EventID) > 0 {
+		set[AttributeEventID] = &awsDynamodb.AttributeValue{S: aws.String(tag.EventID)}
+	} else {
+		removed = append(removed, AttributeEventID)
+	}
+	return removed
+}
+
+// updateExpression - sets the update expression of the input, the attributes are set in the order of their names,
+// so the expression is stable, then the version is set by its clause and the removed attributes are removed.
+func updateExpression(input *awsDynamodb.UpdateItemInput, set map[string]*awsDynamodb.AttributeValue, versionClause string, removed []string) {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	input.ExpressionAttributeNames = expressionAttributeNames(append(append(names, domain.JSONFieldAnswerVersion), removed...)...)
+	var sets []string
+	for _, name := range names {
+		sets = append(sets, fmt.Sprintf("#%s = :%s", name, name))
+		input.ExpressionAttributeValues[":"+name] = set[name]
+	}
+	sets = append(sets, versionClause)
+	expression := "SET " + strings.Join(sets, ", ")
+	if len(removed) > 0 {
+		expression += " REMOVE #" + strings.Join(removed, ", #")
+	}
+	input.UpdateExpression = aws.String(expression)
+}
+
+// versionOf - returns the version of the item attributes, zero if it has none.
+func versionOf(attributes map[string]*awsDynamodb.AttributeValue) (int, error) {
+	attribute, ok := attributes[domain.JSONFieldAnswerVersion]
+	if !ok {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(aws.StringValue(attribute.N))
+	if err != nil {
+		return 0, errors.NewErrInternal(fmt.Sprintf("Answer version is malformed: %s", err))
+	}
+	return version, nil
+}
+
+// expressionAttributeNames - returns the placeholders of the attributes, e.g. "#key" for "key".
+func expressionAttributeNames(attributes ...string) map[string]*string {
+	names := make(map[string]*string, len(attributes))
+	for _, attribute := range attributes {
+		names["#"+attribute] = aws.String(attribute)
+	}
+	return names
+}
+
+// deletedAnswerRecord - represents a deleted answer item, the times are in nanoseconds.
+type deletedAnswerRecord struct {
+	Key       domain.AnswerKey   `dynamodbav:"key"`
+	Value     domain.AnswerValue `dynamodbav:"value"`
+	Version   int                `dynamodbav:"version"`
+	DeletedAt int64              `dynamodbav:"deletedAt"`
+	PurgeAt   int64              `dynamodbav:"purgeAt"`
+}
+
+func numberAttribute(n int64) *awsDynamodb.AttributeValue {
+	return &awsDynamodb.AttributeValue{N: aws.String(strconv.FormatInt(n, 10))}
+}
@@ -1,6 +1,17 @@
 package dynamodb
 
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
 const (
-	awsErrorResourceInUse    = "ResourceInUseException"
-	awsErrorResourceNotFound = "ResourceNotFoundException"
+	awsErrorResourceInUse          = "ResourceInUseException"
+	awsErrorResourceNotFound       = "ResourceNotFoundException"
+	awsErrorConditionalCheckFailed = "ConditionalCheckFailedException"
 )
+
+// isConditionalCheckFailed - checks if the error is caused by a failed condition expression.
+func isConditionalCheckFailed(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == awsErrorConditionalCheckFailed
+}
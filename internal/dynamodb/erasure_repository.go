@@ -0,0 +1,97 @@
+package dynamodb
+
+import (
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsSession "github.com/aws/aws-sdk-go/aws/session"
+	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// AttributeErasedAt - the time an answer key was erased in nanoseconds.
+const AttributeErasedAt = "erasedAt"
+
+type erasureRepo struct {
+	db        *awsDynamodb.DynamoDB
+	tableName string
+}
+
+// NewErasureRepository creates a new repository.
+// An error will be returned if the table does not exist or does not match the schema.
+func NewErasureRepository(session *awsSession.Session, tableName string) (domain.ErasureRepository, error) {
+
+	// Create a new dynamodb client.
+	//
+	db := awsDynamodb.New(session)
+
+	// The table is created by the provision command.
+	//
+	if err := VerifyTable(db, ErasureTableSchema(tableName)); err != nil {
+		return nil, err
+	}
+
+	return &erasureRepo{
+		db:        db,
+		tableName: tableName,
+	}, nil
+}
+
+// erasureItem - represents an erasure stored in the table.
+type erasureItem struct {
+	KeyHash  string `json:"keyHash"`
+	ErasedAt int64  `json:"erasedAt"`
+}
+
+func (r *erasureRepo) Record(erasure *domain.Erasure) error {
+	attributes, err := dynamodbattribute.MarshalMap(&erasureItem{
+		KeyHash:  erasure.KeyHash,
+		ErasedAt: erasure.ErasedAt.UnixNano(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// A later erasure of the key is kept.
+	//
+	_, err = r.db.PutItem(&awsDynamodb.PutItemInput{
+		Item:                     attributes,
+		TableName:                aws.String(r.tableName),
+		ConditionExpression:      aws.String("attribute_not_exists(#keyHash) OR #erasedAt < :erasedAt"),
+		ExpressionAttributeNames: expressionAttributeNames(domain.JSONFieldErasureKeyHash, AttributeErasedAt),
+		ExpressionAttributeValues: map[string]*awsDynamodb.AttributeValue{
+			":erasedAt": numberAttribute(erasure.ErasedAt.UnixNano()),
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *erasureRepo) Get(keyHash string) (*domain.Erasure, error) {
+	result, err := r.db.GetItem(&awsDynamodb.GetItemInput{
+		TableName: aws.String(r.tableName),
+		Key: map[string]*awsDynamodb.AttributeValue{
+			domain.JSONFieldErasureKeyHash: {S: aws.String(keyHash)},
+		},
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, errors.NewErrNotFound("Erasure not found")
+	}
+	item := &erasureItem{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, item); err != nil {
+		return nil, err
+	}
+	return &domain.Erasure{
+		KeyHash:  item.KeyHash,
+		ErasedAt: time.Unix(0, item.ErasedAt).UTC(),
+	}, nil
+}
@@ -0,0 +1,19 @@
+package dynamodb
+
+import (
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/repositorytest"
+)
+
+func TestErasureRepository(t *testing.T) {
+	repositorytest.TestErasureRepository(t, func(t *testing.T) domain.ErasureRepository {
+		tableName := provisionTestTable(t, ErasureTableSchema(nextTestTableName(testErasureTableName)))
+		repository, err := NewErasureRepository(testAwsSession, tableName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repository
+	})
+}
@@ -0,0 +1,228 @@
+package dynamodb
+
+import (
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsSession "github.com/aws/aws-sdk-go/aws/session"
+	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+type idempotencyRepo struct {
+	db        *awsDynamodb.DynamoDB
+	tableName string
+}
+
+// NewIdempotencyRepository creates a new repository.
+// An error will be returned if the table does not exist, does not match the schema or its items do not expire.
+func NewIdempotencyRepository(session *awsSession.Session, tableName string) (domain.IdempotencyRepository, error) {
+
+	// Create a new dynamodb client.
+	//
+	db := awsDynamodb.New(session)
+
+	// The table is created by the provision command.
+	//
+	if err := VerifyTable(db, IdempotencyTableSchema(tableName)); err != nil {
+		return nil, err
+	}
+	if err := VerifyTimeToLive(db, tableName, AttributeExpiresAt); err != nil {
+		return nil, err
+	}
+
+	return &idempotencyRepo{
+		db:        db,
+		tableName: tableName,
+	}, nil
+}
+
+// idempotencyItem - represents a record stored in the table, the expiry time is in seconds,
+// which DynamoDB uses to remove the expired items. The answer key hash is omitted if empty,
+// an index key must not be. The lease is omitted once the record is complete, so it cannot expire.
+type idempotencyItem struct {
+	Key            string `json:"key"`
+	Fingerprint    string `json:"fingerprint"`
+	Response       string `json:"response"`
+	ExpiresAt      int64  `json:"expiresAt"`
+	AnswerKeyHash  string `json:"answerKeyHash,omitempty"`
+	ReservationID  string `json:"reservationId,omitempty"`
+	LeaseExpiresAt int64  `json:"leaseExpiresAt,omitempty"`
+}
+
+func (item *idempotencyItem) toRecord() *domain.IdempotencyRecord {
+	record := &domain.IdempotencyRecord{
+		Key:           item.Key,
+		Fingerprint:   item.Fingerprint,
+		Response:      item.Response,
+		ExpiresAt:     time.Unix(item.ExpiresAt, 0).UTC(),
+		AnswerKeyHash: item.AnswerKeyHash,
+		ReservationID: item.ReservationID,
+	}
+	if item.LeaseExpiresAt != 0 {
+		record.LeaseExpiresAt = time.Unix(0, item.LeaseExpiresAt).UTC()
+	}
+	return record
+}
+
+func (r *idempotencyRepo) Reserve(record *domain.IdempotencyRecord, now time.Time) error {
+	attributes, err := r.marshal(record)
+	if err != nil {
+		return err
+	}
+
+	// The key must not be in use, an expired record not removed yet is replaced and so is a record
+	// in progress whose lease has expired.
+	//
+	_, err = r.db.PutItem(&awsDynamodb.PutItemInput{
+		Item:                     attributes,
+		TableName:                aws.String(r.tableName),
+		ConditionExpression:      aws.String("attribute_not_exists(#key) OR #expiresAt <= :now OR #leaseExpiresAt <= :nowNanos"),
+		ExpressionAttributeNames: expressionAttributeNames(domain.JSONFieldIdempotencyKey, AttributeExpiresAt, AttributeLeaseExpiresAt),
+		ExpressionAttributeValues: map[string]*awsDynamodb.AttributeValue{
+			":now":      numberAttribute(now.Unix()),
+			":nowNanos": numberAttribute(now.UnixNano()),
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return errors.NewErrAlreadyExist("Idempotency key already exists")
+	}
+	return err
+}
+
+func (r *idempotencyRepo) Get(key string, now time.Time) (*domain.IdempotencyRecord, error) {
+	result, err := r.db.GetItem(&awsDynamodb.GetItemInput{
+		TableName:      aws.String(r.tableName),
+		Key:            idempotencyItemKey(key),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, errors.NewErrNotFound("Idempotency key not found")
+	}
+	item := &idempotencyItem{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, item); err != nil {
+		return nil, err
+	}
+
+	// DynamoDB removes an expired item some time after its expiry.
+	//
+	if item.ExpiresAt <= now.Unix() {
+		return nil, errors.NewErrNotFound("Idempotency key not found")
+	}
+	return item.toRecord(), nil
+}
+
+func (r *idempotencyRepo) Complete(record *domain.IdempotencyRecord) error {
+	attributes, err := r.marshal(record)
+	if err != nil {
+		return err
+	}
+
+	// The record must be reserved by the same reservation.
+	//
+	_, err = r.db.PutItem(&awsDynamodb.PutItemInput{
+		Item:                      attributes,
+		TableName:                 aws.String(r.tableName),
+		ConditionExpression:       aws.String("#reservationId = :reservationId"),
+		ExpressionAttributeNames:  expressionAttributeNames(AttributeReservationID),
+		ExpressionAttributeValues: reservationIDValues(record.ReservationID),
+	})
+	if isConditionalCheckFailed(err) {
+		return errors.NewErrNotFound("Idempotency key not found")
+	}
+	return err
+}
+
+func (r *idempotencyRepo) Release(key, reservationID string) error {
+
+	// A record reserved by another reservation since is kept.
+	//
+	_, err := r.db.DeleteItem(&awsDynamodb.DeleteItemInput{
+		TableName:                 aws.String(r.tableName),
+		Key:                       idempotencyItemKey(key),
+		ConditionExpression:       aws.String("#reservationId = :reservationId"),
+		ExpressionAttributeNames:  expressionAttributeNames(AttributeReservationID),
+		ExpressionAttributeValues: reservationIDValues(reservationID),
+	})
+	if isConditionalCheckFailed(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *idempotencyRepo) EraseAnswer(answerKeyHash string) (int, error) {
+	if len(answerKeyHash) == 0 {
+		return 0, nil
+	}
+
+	// The index is eventually consistent, a record written just before may be missed,
+	// so the erasure removes the records it sees and may be repeated.
+	//
+	var (
+		erased  int
+		failure error
+	)
+	queryInput := &awsDynamodb.QueryInput{
+		TableName:                aws.String(r.tableName),
+		IndexName:                aws.String(IdempotencyAnswerKeyHashIndex),
+		KeyConditionExpression:   aws.String("#answerKeyHash = :answerKeyHash"),
+		ExpressionAttributeNames: expressionAttributeNames(domain.JSONFieldIdempotencyAnswerKeyHash),
+		ExpressionAttributeValues: map[string]*awsDynamodb.AttributeValue{
+			":answerKeyHash": {S: aws.String(answerKeyHash)},
+		},
+	}
+	err := r.db.QueryPages(queryInput, func(output *awsDynamodb.QueryOutput, lastPage bool) bool {
+		for _, item := range output.Items {
+			_, failure = r.db.DeleteItem(&awsDynamodb.DeleteItemInput{
+				TableName: aws.String(r.tableName),
+				Key:       idempotencyItemKey(aws.StringValue(item[domain.JSONFieldIdempotencyKey].S)),
+			})
+			if failure != nil {
+				return false
+			}
+			erased++
+		}
+		return true
+	})
+	if err != nil {
+		return erased, err
+	}
+	return erased, failure
+}
+
+func (r *idempotencyRepo) marshal(record *domain.IdempotencyRecord) (map[string]*awsDynamodb.AttributeValue, error) {
+	item := &idempotencyItem{
+		Key:           record.Key,
+		Fingerprint:   record.Fingerprint,
+		Response:      record.Response,
+		ExpiresAt:     record.ExpiresAt.Unix(),
+		AnswerKeyHash: record.AnswerKeyHash,
+		ReservationID: record.ReservationID,
+	}
+	if !record.LeaseExpiresAt.IsZero() {
+		item.LeaseExpiresAt = record.LeaseExpiresAt.UnixNano()
+	}
+	return dynamodbattribute.MarshalMap(item)
+}
+
+func reservationIDValues(reservationID string) map[string]*awsDynamodb.AttributeValue {
+	return map[string]*awsDynamodb.AttributeValue{
+		":reservationId": {
+			S: aws.String(reservationID),
+		},
+	}
+}
+
+func idempotencyItemKey(key string) map[string]*awsDynamodb.AttributeValue {
+	return map[string]*awsDynamodb.AttributeValue{
+		domain.JSONFieldIdempotencyKey: {
+			S: aws.String(key),
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package dynamodb
+
+import (
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/repositorytest"
+)
+
+func TestIdempotencyRepository(t *testing.T) {
+	repositorytest.TestIdempotencyRepository(t, func(t *testing.T) domain.IdempotencyRepository {
+		tableName := provisionTestTableWithTimeToLive(t, IdempotencyTableSchema(nextTestTableName(testIdempotencyTableName)))
+		repository, err := NewIdempotencyRepository(testAwsSession, tableName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repository
+	})
+}
@@ -0,0 +1,124 @@
+package dynamodb
+
+import (
+	"fmt"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// legacyAnswerEventOrder - the order of the events of an answer in the legacy table, it kept the latest
+// event of every type, so the answer was created before it was updated and updated before it was deleted.
+var legacyAnswerEventOrder = map[domain.AnswerEventType]int64{
+	domain.CreateAnswerEventType: 1,
+	domain.UpdateAnswerEventType: 2,
+	domain.DeleteAnswerEventType: 3,
+}
+
+// LegacyAnswerEventTableSchema - returns the definition of the answer event table before the events were sorted
+// by their occurrence time, it kept an event per type of an answer.
+func LegacyAnswerEventTableSchema(tableName string) *awsDynamodb.CreateTableInput {
+	return &awsDynamodb.CreateTableInput{
+		AttributeDefinitions: []*awsDynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String(domain.JSONFieldAnswerKey),
+				AttributeType: aws.String("S"),
+			},
+			{
+				AttributeName: aws.String(domain.JSONFieldEventType),
+				AttributeType: aws.String("S"),
+			},
+		},
+		KeySchema: []*awsDynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String(domain.JSONFieldAnswerKey),
+				KeyType:       aws.String("HASH"),
+			},
+			{
+				AttributeName: aws.String(domain.JSONFieldEventType),
+				KeyType:       aws.String("RANGE"),
+			},
+		},
+		ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(tableName),
+	}
+}
+
+// legacyAnswerEventItem - represents an answer event stored in the legacy table.
+type legacyAnswerEventItem struct {
+	Key       domain.AnswerKey       `json:"key"`
+	EventType domain.AnswerEventType `json:"eventType"`
+	Data      *domain.Answer         `json:"data"`
+}
+
+// MigrateLegacyAnswerEvents - copies the events of the legacy table to the answer event table and returns
+// how many were copied. The occurrence time of the legacy events is unknown, so they get the first nanoseconds
+// of the Unix epoch in the order of their types and come before every event recorded afterwards.
+// Events copied before are skipped, so the migration can be run again.
+func MigrateLegacyAnswerEvents(db *awsDynamodb.DynamoDB, legacyTableName, tableName string) (int, error) {
+	if err := VerifyTable(db, LegacyAnswerEventTableSchema(legacyTableName)); err != nil {
+		return 0, err
+	}
+	if err := VerifyTable(db, AnswerEventTableSchema(tableName)); err != nil {
+		return 0, err
+	}
+
+	var (
+		migrated int
+		failure  error
+	)
+	scanInput := &awsDynamodb.ScanInput{
+		TableName:      aws.String(legacyTableName),
+		ConsistentRead: aws.Bool(true),
+	}
+	err := db.ScanPages(scanInput, func(output *awsDynamodb.ScanOutput, lastPage bool) bool {
+		for _, attributes := range output.Items {
+			var legacy legacyAnswerEventItem
+			if failure = dynamodbattribute.UnmarshalMap(attributes, &legacy); failure != nil {
+				return false
+			}
+			order, ok := legacyAnswerEventOrder[legacy.EventType]
+			if !ok || legacy.Data == nil {
+				failure = fmt.Errorf("legacy event %q of answer %q is malformed", legacy.EventType, legacy.Key)
+				return false
+			}
+			item, err := dynamodbattribute.MarshalMap(&answerEventItem{
+				Key:        legacy.Key,
+				OccurredAt: order,
+				EventType:  legacy.EventType,
+				Data:       legacy.Data,
+			})
+			if err != nil {
+				failure = err
+				return false
+			}
+			_, err = db.PutItem(&awsDynamodb.PutItemInput{
+				Item:                item,
+				TableName:           aws.String(tableName),
+				ConditionExpression: aws.String("attribute_not_exists(#key)"),
+				ExpressionAttributeNames: map[string]*string{
+					"#key": aws.String(domain.JSONFieldAnswerKey),
+				},
+			})
+			if isConditionalCheckFailed(err) {
+				continue
+			}
+			if err != nil {
+				failure = err
+				return false
+			}
+			migrated++
+		}
+		return true
+	})
+	if err != nil {
+		return migrated, err
+	}
+	return migrated, failure
+}
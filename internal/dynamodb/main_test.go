@@ -5,21 +5,27 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync/atomic"
 	"testing"
 
-	"dochq.co.uk.answerservice/internal/domain"
-
 	aws "github.com/aws/aws-sdk-go/aws"
 	awsSession "github.com/aws/aws-sdk-go/aws/session"
+	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/docker/go-connections/nat"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 var (
-	testAwsSession       *awsSession.Session
-	testAnswerRepository domain.AnswerRepository
-	testAnswerTableName  = "testAnswer"
+	testAwsSession                   *awsSession.Session
+	testAnswerTableName              = "testAnswer"
+	testAnswerEventTableName         = "testAnswerEvent"
+	testWebhookSubscriptionTableName = "testWebhookSubscription"
+	testWebhookDeliveryTableName     = "testWebhookDelivery"
+	testIdempotencyTableName         = "testIdempotency"
+	testRateLimitTableName           = "testRateLimit"
+	testErasureTableName             = "testErasure"
+	testTableSequence                int64
 )
 
 func TestMain(m *testing.M) {
@@ -34,7 +40,7 @@ func TestMain(m *testing.M) {
 		WaitingFor:   wait.ForListeningPort(nat.Port(exposedPort)),
 		Env: map[string]string{
 			"DEBUG":    "1",
-			"SERVICES": "dynamodb",
+			"SERVICES": "dynamodb,dynamodbstreams",
 		},
 	}
 
@@ -71,10 +77,31 @@ func TestMain(m *testing.M) {
 		log.Fatalf("Failed to start elasticsearch client %v", err)
 	}
 
-	// Init repositories.
-	//
-	testAnswerRepository = NewAnswerRepository(testAwsSession, testAnswerTableName)
-
 	exitVal := m.Run()
 	os.Exit(exitVal)
 }
+
+// nextTestTableName - returns a new table name, so every test starts with an empty table.
+func nextTestTableName(prefix string) string {
+	return fmt.Sprintf("%s%d", prefix, atomic.AddInt64(&testTableSequence, 1))
+}
+
+// provisionTestTable - creates the table described by the schema and returns its name.
+func provisionTestTable(t *testing.T, schema *awsDynamodb.CreateTableInput) string {
+	t.Helper()
+	if err := ProvisionTable(awsDynamodb.New(testAwsSession), schema); err != nil {
+		t.Fatal(err)
+	}
+	return aws.StringValue(schema.TableName)
+}
+
+// provisionTestTableWithTimeToLive - creates the table described by the schema with the expiring items
+// and returns its name.
+func provisionTestTableWithTimeToLive(t *testing.T, schema *awsDynamodb.CreateTableInput) string {
+	t.Helper()
+	tableName := provisionTestTable(t, schema)
+	if err := ProvisionTimeToLive(awsDynamodb.New(testAwsSession), tableName, AttributeExpiresAt); err != nil {
+		t.Fatal(err)
+	}
+	return tableName
+}
@@ -0,0 +1,140 @@
+package dynamodb
+
+import (
+	"strconv"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsSession "github.com/aws/aws-sdk-go/aws/session"
+	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// AttributeArrivalAt - the attribute of a rate limit bucket holding its theoretical arrival time in nanoseconds,
+// the time the bucket would be full again if no token were taken afterwards.
+const AttributeArrivalAt = "arrivalAt"
+
+type rateLimiter struct {
+	db        *awsDynamodb.DynamoDB
+	tableName string
+}
+
+// NewRateLimiter creates a new rate limiter, its buckets are shared by the replicas.
+// An error will be returned if the table does not exist, does not match the schema or its items do not expire.
+func NewRateLimiter(session *awsSession.Session, tableName string) (domain.RateLimiter, error) {
+
+	// Create a new dynamodb client.
+	//
+	db := awsDynamodb.New(session)
+
+	// The table is created by the provision command.
+	//
+	if err := VerifyTable(db, RateLimitTableSchema(tableName)); err != nil {
+		return nil, err
+	}
+	if err := VerifyTimeToLive(db, tableName, AttributeExpiresAt); err != nil {
+		return nil, err
+	}
+
+	return &rateLimiter{
+		db:        db,
+		tableName: tableName,
+	}, nil
+}
+
+// rateLimitItem - represents a bucket stored in the table, the arrival time is in nanoseconds
+// and the expiry time, when the bucket is full again, in seconds.
+type rateLimitItem struct {
+	Key       string `json:"key"`
+	ArrivalAt int64  `json:"arrivalAt"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
+
+// Take - the bucket is kept as its theoretical arrival time, which every taken token moves on by the emission
+// interval, the time a token takes to be added. A bucket holds a token while its arrival time is at most the burst
+// less one interval ahead. So a token is taken by a single conditional update, without reading the bucket first,
+// and a denied take writes nothing.
+func (r *rateLimiter) Take(key string, limit domain.RateLimit, now time.Time) (bool, time.Duration, error) {
+	interval := time.Duration(float64(time.Second) / limit.Rate)
+	latestArrivalAt := now.Add(time.Duration(limit.Burst-1) * interval)
+
+	// A new bucket or one which is full again starts over from now.
+	//
+	allowed, err := r.update(key, "attribute_not_exists(#arrivalAt) OR #arrivalAt < :now",
+		"SET #arrivalAt = :arrivalAt, #expiresAt = :expiresAt", map[string]*awsDynamodb.AttributeValue{
+			":now":       nanosecondsValue(now),
+			":arrivalAt": nanosecondsValue(now.Add(interval)),
+			":expiresAt": secondsValue(now.Add(interval)),
+		})
+	if err != nil || allowed {
+		return allowed, 0, err
+	}
+
+	// The arrival time is not before now by then, nor does it go back, so a failed condition
+	// means the bucket is empty.
+	//
+	allowed, err = r.update(key, "#arrivalAt <= :latestArrivalAt",
+		"SET #arrivalAt = #arrivalAt + :interval, #expiresAt = :expiresAt", map[string]*awsDynamodb.AttributeValue{
+			":latestArrivalAt": nanosecondsValue(latestArrivalAt),
+			":interval":        {N: aws.String(strconv.FormatInt(int64(interval), 10))},
+			":expiresAt":       secondsValue(latestArrivalAt.Add(interval)),
+		})
+	if err != nil || allowed {
+		return allowed, 0, err
+	}
+
+	// The denied take only reads the bucket to tell when a token is available.
+	//
+	result, err := r.db.GetItem(&awsDynamodb.GetItemInput{
+		TableName:      aws.String(r.tableName),
+		Key:            rateLimitItemKey(key),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return false, 0, err
+	}
+	item := &rateLimitItem{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, item); err != nil {
+		return false, 0, err
+	}
+	retryAfter := time.Unix(0, item.ArrivalAt).Sub(latestArrivalAt)
+	if retryAfter <= 0 || retryAfter > interval {
+		retryAfter = interval
+	}
+	return false, retryAfter, nil
+}
+
+// update - updates the bucket if it meets the condition, it returns false if the bucket does not.
+func (r *rateLimiter) update(key, condition, update string, values map[string]*awsDynamodb.AttributeValue) (bool, error) {
+	_, err := r.db.UpdateItem(&awsDynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.tableName),
+		Key:                       rateLimitItemKey(key),
+		ConditionExpression:       aws.String(condition),
+		UpdateExpression:          aws.String(update),
+		ExpressionAttributeNames:  expressionAttributeNames(AttributeArrivalAt, AttributeExpiresAt),
+		ExpressionAttributeValues: values,
+	})
+	if isConditionalCheckFailed(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func nanosecondsValue(t time.Time) *awsDynamodb.AttributeValue {
+	return &awsDynamodb.AttributeValue{N: aws.String(strconv.FormatInt(t.UnixNano(), 10))}
+}
+
+// secondsValue - returns the expiry time of the bucket full at the time, DynamoDB expires the items by the second.
+func secondsValue(t time.Time) *awsDynamodb.AttributeValue {
+	return &awsDynamodb.AttributeValue{N: aws.String(strconv.FormatInt(t.Unix()+1, 10))}
+}
+
+func rateLimitItemKey(key string) map[string]*awsDynamodb.AttributeValue {
+	return map[string]*awsDynamodb.AttributeValue{
+		domain.JSONFieldRateLimitKey: {
+			S: aws.String(key),
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package dynamodb
+
+import (
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/repositorytest"
+)
+
+func TestRateLimiter(t *testing.T) {
+	repositorytest.TestRateLimiter(t, func(t *testing.T) domain.RateLimiter {
+		tableName := provisionTestTableWithTimeToLive(t, RateLimitTableSchema(nextTestTableName(testRateLimitTableName)))
+		limiter, err := NewRateLimiter(testAwsSession, tableName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return limiter
+	})
+}
@@ -0,0 +1,419 @@
+package dynamodb
+
+import (
+	"fmt"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// Attributes of a deleted answer, the times are in nanoseconds except the expiry time in seconds,
+// which DynamoDB uses to remove the expired items.
+const (
+	AttributeDeletedAt = "deletedAt"
+	AttributePurgeAt   = "purgeAt"
+	AttributeExpiresAt = "expiresAt"
+)
+
+// AttributeChangedAt - the time an answer was created or updated in nanoseconds,
+// it is the time of the event captured from the stream of the answer table.
+const AttributeChangedAt = "changedAt"
+
+// Attributes of the tag of the last write of an answer, they are removed by the untagged writes,
+// so the event captured from the stream of the answer table has the type and the ID the service reported.
+const (
+	AttributeEventType = "eventType"
+	AttributeEventID   = "eventId"
+)
+
+// Attributes of the reservation of an idempotency record in progress, the lease expiry time is in nanoseconds
+// and removed once the record is complete.
+const (
+	AttributeReservationID  = "reservationId"
+	AttributeLeaseExpiresAt = "leaseExpiresAt"
+)
+
+// AnswerTableSchema - returns the definition of the answer table.
+func AnswerTableSchema(tableName string) *awsDynamodb.CreateTableInput {
+	return &awsDynamodb.CreateTableInput{
+		AttributeDefinitions: []*awsDynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String(domain.JSONFieldAnswerKey),
+				AttributeType: aws.String("S"),
+			},
+		},
+		KeySchema: []*awsDynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String(domain.JSONFieldAnswerKey),
+				KeyType:       aws.String("HASH"),
+			},
+		},
+		ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(tableName),
+	}
+}
+
+// AnswerEventTableSchema - returns the definition of the answer event table.
+// The events of an answer are sorted by their occurrence time.
+func AnswerEventTableSchema(tableName string) *awsDynamodb.CreateTableInput {
+	return &awsDynamodb.CreateTableInput{
+		AttributeDefinitions: []*awsDynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String(domain.JSONFieldAnswerKey),
+				AttributeType: aws.String("S"),
+			},
+			{
+				AttributeName: aws.String(domain.JSONFieldOccurredAt),
+				AttributeType: aws.String("N"),
+			},
+		},
+		KeySchema: []*awsDynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String(domain.JSONFieldAnswerKey),
+				KeyType:       aws.String("HASH"),
+			},
+			{
+				AttributeName: aws.String(domain.JSONFieldOccurredAt),
+				KeyType:       aws.String("RANGE"),
+			},
+		},
+		ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(tableName),
+	}
+}
+
+// WebhookSubscriptionTableSchema - returns the definition of the webhook subscription table.
+func WebhookSubscriptionTableSchema(tableName string) *awsDynamodb.CreateTableInput {
+	return &awsDynamodb.CreateTableInput{
+		AttributeDefinitions: []*awsDynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String(domain.JSONFieldWebhookID),
+				AttributeType: aws.String("S"),
+			},
+		},
+		KeySchema: []*awsDynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String(domain.JSONFieldWebhookID),
+				KeyType:       aws.String("HASH"),
+			},
+		},
+		ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(tableName),
+	}
+}
+
+// WebhookDeliveryTableSchema - returns the definition of the webhook delivery table.
+// The deliveries of a subscription are sorted by their attempt time.
+func WebhookDeliveryTableSchema(tableName string) *awsDynamodb.CreateTableInput {
+	return &awsDynamodb.CreateTableInput{
+		AttributeDefinitions: []*awsDynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String(domain.JSONFieldWebhookSubscriptionID),
+				AttributeType: aws.String("S"),
+			},
+			{
+				AttributeName: aws.String(domain.JSONFieldWebhookAttemptedAt),
+				AttributeType: aws.String("N"),
+			},
+		},
+		KeySchema: []*awsDynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String(domain.JSONFieldWebhookSubscriptionID),
+				KeyType:       aws.String("HASH"),
+			},
+			{
+				AttributeName: aws.String(domain.JSONFieldWebhookAttemptedAt),
+				KeyType:       aws.String("RANGE"),
+			},
+		},
+		ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(tableName),
+	}
+}
+
+// ErasureTableSchema - returns the definition of the erasure table.
+func ErasureTableSchema(tableName string) *awsDynamodb.CreateTableInput {
+	return &awsDynamodb.CreateTableInput{
+		AttributeDefinitions: []*awsDynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String(domain.JSONFieldErasureKeyHash),
+				AttributeType: aws.String("S"),
+			},
+		},
+		KeySchema: []*awsDynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String(domain.JSONFieldErasureKeyHash),
+				KeyType:       aws.String("HASH"),
+			},
+		},
+		ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(tableName),
+	}
+}
+
+// IdempotencyAnswerKeyHashIndex - the index of the idempotency records by the hash of the changed answer key,
+// the records without an answer key are not indexed.
+const IdempotencyAnswerKeyHashIndex = "answerKeyHash-index"
+
+// IdempotencyTableSchema - returns the definition of the idempotency table.
+func IdempotencyTableSchema(tableName string) *awsDynamodb.CreateTableInput {
+	return &awsDynamodb.CreateTableInput{
+		AttributeDefinitions: []*awsDynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String(domain.JSONFieldIdempotencyKey),
+				AttributeType: aws.String("S"),
+			},
+			{
+				AttributeName: aws.String(domain.JSONFieldIdempotencyAnswerKeyHash),
+				AttributeType: aws.String("S"),
+			},
+		},
+		KeySchema: []*awsDynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String(domain.JSONFieldIdempotencyKey),
+				KeyType:       aws.String("HASH"),
+			},
+		},
+		GlobalSecondaryIndexes: []*awsDynamodb.GlobalSecondaryIndex{
+			{
+				IndexName: aws.String(IdempotencyAnswerKeyHashIndex),
+				KeySchema: []*awsDynamodb.KeySchemaElement{
+					{
+						AttributeName: aws.String(domain.JSONFieldIdempotencyAnswerKeyHash),
+						KeyType:       aws.String("HASH"),
+					},
+				},
+				Projection: &awsDynamodb.Projection{
+					ProjectionType: aws.String(awsDynamodb.ProjectionTypeKeysOnly),
+				},
+				ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(10),
+					WriteCapacityUnits: aws.Int64(10),
+				},
+			},
+		},
+		ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(tableName),
+	}
+}
+
+// RateLimitTableSchema - returns the definition of the rate limit table.
+func RateLimitTableSchema(tableName string) *awsDynamodb.CreateTableInput {
+	return &awsDynamodb.CreateTableInput{
+		AttributeDefinitions: []*awsDynamodb.AttributeDefinition{
+			{
+				AttributeName: aws.String(domain.JSONFieldRateLimitKey),
+				AttributeType: aws.String("S"),
+			},
+		},
+		KeySchema: []*awsDynamodb.KeySchemaElement{
+			{
+				AttributeName: aws.String(domain.JSONFieldRateLimitKey),
+				KeyType:       aws.String("HASH"),
+			},
+		},
+		ProvisionedThroughput: &awsDynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+		TableName: aws.String(tableName),
+	}
+}
+
+// ProvisionTable - creates the table if it does not exist and verifies it matches the schema.
+func ProvisionTable(db *awsDynamodb.DynamoDB, schema *awsDynamodb.CreateTableInput) error {
+
+	// Create table, it may be created concurrently by another provisioner.
+	//
+	_, err := db.CreateTable(schema)
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() != awsErrorResourceInUse {
+		return err
+	}
+
+	// Wait for table.
+	//
+	err = db.WaitUntilTableExists(&awsDynamodb.DescribeTableInput{
+		TableName: schema.TableName,
+	})
+	if err != nil {
+		return err
+	}
+	return VerifyTable(db, schema)
+}
+
+// ProvisionTimeToLive - makes DynamoDB remove the items of the table once the time in the attribute has passed.
+func ProvisionTimeToLive(db *awsDynamodb.DynamoDB, tableName, attribute string) error {
+	output, err := db.DescribeTimeToLive(&awsDynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Enabling the time to live twice is an error.
+	//
+	description := output.TimeToLiveDescription
+	if description != nil && aws.StringValue(description.AttributeName) == attribute {
+		switch aws.StringValue(description.TimeToLiveStatus) {
+		case awsDynamodb.TimeToLiveStatusEnabled, awsDynamodb.TimeToLiveStatusEnabling:
+			return nil
+		}
+	}
+	_, err = db.UpdateTimeToLive(&awsDynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String(tableName),
+		TimeToLiveSpecification: &awsDynamodb.TimeToLiveSpecification{
+			AttributeName: aws.String(attribute),
+			Enabled:       aws.Bool(true),
+		},
+	})
+	return err
+}
+
+// ProvisionStream - enables the stream of the table with the new and old images of the items.
+func ProvisionStream(db *awsDynamodb.DynamoDB, tableName string) error {
+	output, err := db.DescribeTable(&awsDynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The view type of an enabled stream cannot be changed, it must be disabled first.
+	//
+	specification := output.Table.StreamSpecification
+	if specification != nil && aws.BoolValue(specification.StreamEnabled) {
+		if viewType := aws.StringValue(specification.StreamViewType); viewType != awsDynamodb.StreamViewTypeNewAndOldImages {
+			return fmt.Errorf("stream of table %s has view type %s, expected %s", tableName, viewType, awsDynamodb.StreamViewTypeNewAndOldImages)
+		}
+		return nil
+	}
+	_, err = db.UpdateTable(&awsDynamodb.UpdateTableInput{
+		TableName: aws.String(tableName),
+		StreamSpecification: &awsDynamodb.StreamSpecification{
+			StreamEnabled:  aws.Bool(true),
+			StreamViewType: aws.String(awsDynamodb.StreamViewTypeNewAndOldImages),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return db.WaitUntilTableExists(&awsDynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	})
+}
+
+// VerifyTable - checks that the table exists and its keys and indexes match the schema.
+func VerifyTable(db *awsDynamodb.DynamoDB, schema *awsDynamodb.CreateTableInput) error {
+	tableName := aws.StringValue(schema.TableName)
+	output, err := db.DescribeTable(&awsDynamodb.DescribeTableInput{
+		TableName: schema.TableName,
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == awsErrorResourceNotFound {
+		return fmt.Errorf("table %s does not exist, run the provision command", tableName)
+	}
+	if err != nil {
+		return err
+	}
+	table := output.Table
+
+	// Compare keys.
+	//
+	want := keySchemaString(schema.KeySchema, schema.AttributeDefinitions)
+	if got := keySchemaString(table.KeySchema, table.AttributeDefinitions); got != want {
+		return fmt.Errorf("table %s has key schema %s, expected %s", tableName, got, want)
+	}
+
+	// Compare global secondary indexes.
+	//
+	indexes := make(map[string]string)
+	for _, index := range table.GlobalSecondaryIndexes {
+		indexes[aws.StringValue(index.IndexName)] = keySchemaString(index.KeySchema, table.AttributeDefinitions)
+	}
+	for _, index := range schema.GlobalSecondaryIndexes {
+		indexName := aws.StringValue(index.IndexName)
+		want := keySchemaString(index.KeySchema, schema.AttributeDefinitions)
+		got, ok := indexes[indexName]
+		if !ok {
+			return fmt.Errorf("table %s has no index %s", tableName, indexName)
+		}
+		if got != want {
+			return fmt.Errorf("index %s of table %s has key schema %s, expected %s", indexName, tableName, got, want)
+		}
+	}
+	return nil
+}
+
+// VerifyTimeToLive - checks that the time to live of the table is enabled on the attribute.
+func VerifyTimeToLive(db *awsDynamodb.DynamoDB, tableName, attribute string) error {
+	output, err := db.DescribeTimeToLive(&awsDynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return err
+	}
+	description := output.TimeToLiveDescription
+	if description == nil || aws.StringValue(description.AttributeName) != attribute {
+		return fmt.Errorf("time to live of table %s is not enabled on %s, run the provision command", tableName, attribute)
+	}
+	switch aws.StringValue(description.TimeToLiveStatus) {
+	case awsDynamodb.TimeToLiveStatusEnabled, awsDynamodb.TimeToLiveStatusEnabling:
+		return nil
+	}
+	return fmt.Errorf("time to live of table %s is %s, run the provision command", tableName, aws.StringValue(description.TimeToLiveStatus))
+}
+
+// VerifyStream - checks that the stream of the table is enabled with the new and old images of the items
+// and returns its ARN.
+func VerifyStream(db *awsDynamodb.DynamoDB, tableName string) (string, error) {
+	output, err := db.DescribeTable(&awsDynamodb.DescribeTableInput{
+		TableName: aws.String(tableName),
+	})
+	if err != nil {
+		return "", err
+	}
+	specification := output.Table.StreamSpecification
+	if specification == nil || !aws.BoolValue(specification.StreamEnabled) ||
+		aws.StringValue(specification.StreamViewType) != awsDynamodb.StreamViewTypeNewAndOldImages {
+		return "", fmt.Errorf("stream of table %s is not enabled with %s, run the provision command",
+			tableName, awsDynamodb.StreamViewTypeNewAndOldImages)
+	}
+	return aws.StringValue(output.Table.LatestStreamArn), nil
+}
+
+// keySchemaString - describes the key schema, e.g. "key:S:HASH,occurredAt:N:RANGE".
+func keySchemaString(keySchema []*awsDynamodb.KeySchemaElement, attributes []*awsDynamodb.AttributeDefinition) string {
+	attributeTypes := make(map[string]string)
+	for _, attribute := range attributes {
+		attributeTypes[aws.StringValue(attribute.AttributeName)] = aws.StringValue(attribute.AttributeType)
+	}
+	var s string
+	for i, element := range keySchema {
+		if i > 0 {
+			s += ","
+		}
+		name := aws.StringValue(element.AttributeName)
+		s += name + ":" + attributeTypes[name] + ":" + aws.StringValue(element.KeyType)
+	}
+	return s
+}
@@ -0,0 +1,123 @@
+package dynamodb
+
+import (
+	"strings"
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/go-kit/log"
+	"github.com/go-test/deep"
+)
+
+func TestProvisionTableIsIdempotent(t *testing.T) {
+	schema := AnswerTableSchema(nextTestTableName(testAnswerTableName))
+	provisionTestTable(t, schema)
+	if err := ProvisionTable(awsDynamodb.New(testAwsSession), schema); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+}
+
+func TestRepositoryRequiresTable(t *testing.T) {
+	tableName := nextTestTableName(testAnswerTableName)
+	_, err := NewAnswerRepository(testAwsSession, tableName)
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Errorf("expected missing table err, got %v", err)
+	}
+}
+
+func TestRepositoryRequiresMatchingSchema(t *testing.T) {
+	tableName := provisionTestTable(t, AnswerTableSchema(nextTestTableName(testAnswerEventTableName)))
+	_, err := NewAnswerEventRepository(testAwsSession, tableName)
+	if err == nil || !strings.Contains(err.Error(), "expected key:S:HASH,occurredAt:N:RANGE") {
+		t.Errorf("expected key schema err, got %v", err)
+	}
+}
+
+func TestProvisionTimeToLiveIsIdempotent(t *testing.T) {
+	db := awsDynamodb.New(testAwsSession)
+	tableName := provisionTestTable(t, AnswerTableSchema(nextTestTableName(testAnswerTableName)))
+	for i := 0; i < 2; i++ {
+		if err := ProvisionTimeToLive(db, tableName, AttributeExpiresAt); err != nil {
+			t.Errorf("unexpected err: %v", err)
+		}
+	}
+}
+
+func TestRepositoryReportsLegacySchema(t *testing.T) {
+	tableName := provisionTestTable(t, LegacyAnswerEventTableSchema(nextTestTableName(testAnswerEventTableName)))
+	_, err := NewAnswerEventRepository(testAwsSession, tableName)
+	if err == nil || !strings.Contains(err.Error(), "LEGACY_ANSWER_EVENT_TABLE_NAME") {
+		t.Errorf("expected legacy schema err, got %v", err)
+	}
+}
+
+func TestMigrateLegacyAnswerEvents(t *testing.T) {
+	db := awsDynamodb.New(testAwsSession)
+	legacyTableName := provisionTestTable(t, LegacyAnswerEventTableSchema(nextTestTableName(testAnswerEventTableName)))
+	tableName := provisionTestTable(t, AnswerEventTableSchema(nextTestTableName(testAnswerEventTableName)))
+
+	// The legacy table holds the latest event of every type of an answer.
+	//
+	for _, item := range []legacyAnswerEventItem{
+		{Key: "key-1", EventType: domain.DeleteAnswerEventType, Data: &domain.Answer{Key: "key-1", Value: "value-2"}},
+		{Key: "key-1", EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "key-1", Value: "value-1"}},
+		{Key: "key-1", EventType: domain.UpdateAnswerEventType, Data: &domain.Answer{Key: "key-1", Value: "value-2"}},
+	} {
+		attributes, err := dynamodbattribute.MarshalMap(&item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.PutItem(&awsDynamodb.PutItemInput{Item: attributes, TableName: aws.String(legacyTableName)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A second run copies nothing.
+	//
+	for i, want := range []int{3, 0} {
+		migrated, err := MigrateLegacyAnswerEvents(db, legacyTableName, tableName)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if migrated != want {
+			t.Errorf("run %d: expected %d migrated events, got %d", i, want, migrated)
+		}
+	}
+
+	repository, err := NewAnswerEventRepository(testAwsSession, tableName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := repository.ListEvents("key-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []domain.AnswerEventType
+	for _, event := range events {
+		got = append(got, event.EventType)
+	}
+	want := []domain.AnswerEventType{domain.CreateAnswerEventType, domain.UpdateAnswerEventType, domain.DeleteAnswerEventType}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRepositoryRequiresTimeToLive(t *testing.T) {
+	tableName := provisionTestTable(t, IdempotencyTableSchema(nextTestTableName(testIdempotencyTableName)))
+	_, err := NewIdempotencyRepository(testAwsSession, tableName)
+	if err == nil || !strings.Contains(err.Error(), "time to live") {
+		t.Errorf("expected time to live err, got %v", err)
+	}
+}
+
+func TestChangeStreamRequiresStream(t *testing.T) {
+	tableName := provisionTestTableWithTimeToLive(t, AnswerTableSchema(nextTestTableName(testAnswerTableName)))
+	_, err := NewAnswerChangeStream(testAwsSession, tableName, log.NewNopLogger())
+	if err == nil || !strings.Contains(err.Error(), "stream of table") {
+		t.Errorf("expected stream err, got %v", err)
+	}
+}
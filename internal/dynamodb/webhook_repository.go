@@ -0,0 +1,372 @@
+package dynamodb
+
+import (
+	"strconv"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsSession "github.com/aws/aws-sdk-go/aws/session"
+	awsDynamodb "github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+)
+
+type webhookRepo struct {
+	db                    *awsDynamodb.DynamoDB
+	subscriptionTableName string
+	deliveryTableName     string
+}
+
+// NewWebhookRepository creates a new repository.
+// An error will be returned if the tables do not exist or do not match the schema.
+func NewWebhookRepository(session *awsSession.Session, subscriptionTableName, deliveryTableName string) (domain.WebhookRepository, error) {
+
+	// Create a new dynamodb client.
+	//
+	db := awsDynamodb.New(session)
+
+	// The tables are created by the provision command.
+	//
+	if err := VerifyTable(db, WebhookSubscriptionTableSchema(subscriptionTableName)); err != nil {
+		return nil, err
+	}
+	if err := VerifyTable(db, WebhookDeliveryTableSchema(deliveryTableName)); err != nil {
+		return nil, err
+	}
+
+	return &webhookRepo{
+		db:                    db,
+		subscriptionTableName: subscriptionTableName,
+		deliveryTableName:     deliveryTableName,
+	}, nil
+}
+
+// webhookSubscriptionItem - represents a subscription stored in the table, the time is in nanoseconds.
+type webhookSubscriptionItem struct {
+	ID         string                   `json:"id"`
+	URL        string                   `json:"url"`
+	EventTypes []domain.AnswerEventType `json:"eventTypes"`
+	Secret     string                   `json:"secret"`
+	CreatedAt  int64                    `json:"createdAt"`
+}
+
+func (item *webhookSubscriptionItem) toSubscription() *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{
+		ID:         item.ID,
+		URL:        item.URL,
+		EventTypes: item.EventTypes,
+		Secret:     item.Secret,
+		CreatedAt:  time.Unix(0, item.CreatedAt).UTC(),
+	}
+}
+
+// webhookDeliveryItem - represents a delivery stored in the table, the times are in nanoseconds.
+type webhookDeliveryItem struct {
+	SubscriptionID string                 `json:"subscriptionId"`
+	AttemptedAt    int64                  `json:"attemptedAt"`
+	ID             string                 `json:"id"`
+	EventType      domain.AnswerEventType `json:"eventType"`
+	KeyHash        string                 `json:"keyHash"`
+	OccurredAt     int64                  `json:"occurredAt"`
+	Attempt        int                    `json:"attempt"`
+	StatusCode     int                    `json:"statusCode"`
+	Error          string                 `json:"error"`
+	Succeeded      bool                   `json:"succeeded"`
+}
+
+func (item *webhookDeliveryItem) toDelivery() *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             item.ID,
+		SubscriptionID: item.SubscriptionID,
+		EventType:      item.EventType,
+		KeyHash:        item.KeyHash,
+		OccurredAt:     time.Unix(0, item.OccurredAt).UTC(),
+		Attempt:        item.Attempt,
+		AttemptedAt:    time.Unix(0, item.AttemptedAt).UTC(),
+		StatusCode:     item.StatusCode,
+		Error:          item.Error,
+		Succeeded:      item.Succeeded,
+	}
+}
+
+func (r *webhookRepo) CreateSubscription(subscription *domain.WebhookSubscription) error {
+	attributes, err := dynamodbattribute.MarshalMap(&webhookSubscriptionItem{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Secret:     subscription.Secret,
+		CreatedAt:  subscription.CreatedAt.UnixNano(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The subscription must not be stored yet.
+	//
+	_, err = r.db.PutItem(&awsDynamodb.PutItemInput{
+		Item:                attributes,
+		TableName:           aws.String(r.subscriptionTableName),
+		ConditionExpression: aws.String("attribute_not_exists(#id)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#id": aws.String(domain.JSONFieldWebhookID),
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return errors.NewErrAlreadyExist("Webhook subscription already exists")
+	}
+	return err
+}
+
+func (r *webhookRepo) GetSubscription(id string) (*domain.WebhookSubscription, error) {
+	result, err := r.db.GetItem(&awsDynamodb.GetItemInput{
+		TableName:      aws.String(r.subscriptionTableName),
+		Key:            subscriptionItemKey(id),
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Item == nil {
+		return nil, errors.NewErrNotFound("Webhook subscription not found")
+	}
+	item := &webhookSubscriptionItem{}
+	if err := dynamodbattribute.UnmarshalMap(result.Item, item); err != nil {
+		return nil, err
+	}
+	return item.toSubscription(), nil
+}
+
+func (r *webhookRepo) ListSubscriptions(page domain.PageRequest) ([]*domain.WebhookSubscription, string, error) {
+
+	// Subscriptions are scanned, the token holds the last id of the previous page.
+	//
+	scanInput := &awsDynamodb.ScanInput{
+		TableName:      aws.String(r.subscriptionTableName),
+		Limit:          aws.Int64(int64(page.Size())),
+		ConsistentRead: aws.Bool(true),
+	}
+	if len(page.PageToken) > 0 {
+		lastID, err := domain.DecodePageToken(page.PageToken)
+		if err != nil {
+			return nil, "", errors.NewErrInvalidArgument(err.Error())
+		}
+		scanInput.ExclusiveStartKey = subscriptionItemKey(lastID)
+	}
+	result, err := r.db.Scan(scanInput)
+	if err != nil {
+		return nil, "", err
+	}
+	var items []*domain.WebhookSubscription
+	for _, i := range result.Items {
+		item := &webhookSubscriptionItem{}
+		if err := dynamodbattribute.UnmarshalMap(i, item); err != nil {
+			return nil, "", err
+		}
+		items = append(items, item.toSubscription())
+	}
+
+	// DynamoDB returns the last evaluated key while the table may have more items.
+	//
+	var nextPageToken string
+	if lastID, ok := result.LastEvaluatedKey[domain.JSONFieldWebhookID]; ok {
+		nextPageToken = domain.EncodePageToken(aws.StringValue(lastID.S))
+	}
+	return items, nextPageToken, nil
+}
+
+func (r *webhookRepo) DeleteSubscription(id string) error {
+	_, err := r.db.DeleteItem(&awsDynamodb.DeleteItemInput{
+		TableName:           aws.String(r.subscriptionTableName),
+		Key:                 subscriptionItemKey(id),
+		ConditionExpression: aws.String("attribute_exists(#id)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#id": aws.String(domain.JSONFieldWebhookID),
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return errors.NewErrNotFound("Webhook subscription not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	// Delete the delivery log page by page, only the keys of the deliveries are read.
+	//
+	keyCondition := expression.Key(domain.JSONFieldWebhookSubscriptionID).Equal(expression.Value(id))
+	projection := expression.NamesList(expression.Name(domain.JSONFieldWebhookSubscriptionID), expression.Name(domain.JSONFieldWebhookAttemptedAt))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithProjection(projection).Build()
+	if err != nil {
+		return err
+	}
+	params := &awsDynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		TableName:                 aws.String(r.deliveryTableName),
+		ConsistentRead:            aws.Bool(true),
+	}
+	for {
+		result, err := r.db.Query(params)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Items {
+			_, err := r.db.DeleteItem(&awsDynamodb.DeleteItemInput{
+				Key:       item,
+				TableName: aws.String(r.deliveryTableName),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		params.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
+
+func (r *webhookRepo) CreateDelivery(delivery *domain.WebhookDelivery) error {
+	attributes, err := dynamodbattribute.MarshalMap(&webhookDeliveryItem{
+		SubscriptionID: delivery.SubscriptionID,
+		AttemptedAt:    delivery.AttemptedAt.UnixNano(),
+		ID:             delivery.ID,
+		EventType:      delivery.EventType,
+		KeyHash:        delivery.KeyHash,
+		OccurredAt:     delivery.OccurredAt.UnixNano(),
+		Attempt:        delivery.Attempt,
+		StatusCode:     delivery.StatusCode,
+		Error:          delivery.Error,
+		Succeeded:      delivery.Succeeded,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The delivery must not be stored yet.
+	//
+	_, err = r.db.PutItem(&awsDynamodb.PutItemInput{
+		Item:                attributes,
+		TableName:           aws.String(r.deliveryTableName),
+		ConditionExpression: aws.String("attribute_not_exists(#subscriptionId)"),
+		ExpressionAttributeNames: map[string]*string{
+			"#subscriptionId": aws.String(domain.JSONFieldWebhookSubscriptionID),
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return errors.NewErrAlreadyExist("Webhook delivery already exists")
+	}
+	return err
+}
+
+func (r *webhookRepo) ListDeliveries(subscriptionID string, page domain.PageRequest) ([]*domain.WebhookDelivery, string, error) {
+
+	// Build expression.
+	//
+	keyCondition := expression.Key(domain.JSONFieldWebhookSubscriptionID).Equal(expression.Value(subscriptionID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Deliveries are sorted by the attempt time,
+	// the token holds the attempt time of the last delivery of the previous page.
+	//
+	params := &awsDynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(r.deliveryTableName),
+		Limit:                     aws.Int64(int64(page.Size())),
+		ScanIndexForward:          aws.Bool(true),
+		ConsistentRead:            aws.Bool(true),
+	}
+	if len(page.PageToken) > 0 {
+		lastAttemptedAt, err := domain.DecodePageToken(page.PageToken)
+		if err != nil {
+			return nil, "", errors.NewErrInvalidArgument(err.Error())
+		}
+		if _, err := strconv.ParseInt(lastAttemptedAt, 10, 64); err != nil {
+			return nil, "", errors.NewErrInvalidArgument("Page token is not valid")
+		}
+		params.ExclusiveStartKey = map[string]*awsDynamodb.AttributeValue{
+			domain.JSONFieldWebhookSubscriptionID: {S: aws.String(subscriptionID)},
+			domain.JSONFieldWebhookAttemptedAt:    {N: aws.String(lastAttemptedAt)},
+		}
+	}
+	result, err := r.db.Query(params)
+	if err != nil {
+		return nil, "", err
+	}
+	var items []*domain.WebhookDelivery
+	for _, i := range result.Items {
+		item := &webhookDeliveryItem{}
+		if err := dynamodbattribute.UnmarshalMap(i, item); err != nil {
+			return nil, "", err
+		}
+		items = append(items, item.toDelivery())
+	}
+
+	// DynamoDB returns the last evaluated key while the partition may have more items.
+	//
+	var nextPageToken string
+	if lastAttemptedAt, ok := result.LastEvaluatedKey[domain.JSONFieldWebhookAttemptedAt]; ok {
+		nextPageToken = domain.EncodePageToken(aws.StringValue(lastAttemptedAt.N))
+	}
+	return items, nextPageToken, nil
+}
+
+func (r *webhookRepo) ListDeliveryAttempts(subscriptionID, deliveryID string, since time.Time) ([]*domain.WebhookDelivery, error) {
+
+	// Build expression, the attempts since the time are read and those of other deliveries filtered out.
+	//
+	keyCondition := expression.Key(domain.JSONFieldWebhookSubscriptionID).Equal(expression.Value(subscriptionID)).
+		And(expression.Key(domain.JSONFieldWebhookAttemptedAt).GreaterThanEqual(expression.Value(since.UnixNano())))
+	filter := expression.Name(domain.JSONFieldWebhookID).Equal(expression.Value(deliveryID))
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).WithFilter(filter).Build()
+	if err != nil {
+		return nil, err
+	}
+	params := &awsDynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(r.deliveryTableName),
+		ScanIndexForward:          aws.Bool(true),
+		ConsistentRead:            aws.Bool(true),
+	}
+
+	// Query every page, the filter is applied after the limit of a page.
+	//
+	var items []*domain.WebhookDelivery
+	for {
+		result, err := r.db.Query(params)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range result.Items {
+			item := &webhookDeliveryItem{}
+			if err := dynamodbattribute.UnmarshalMap(i, item); err != nil {
+				return nil, err
+			}
+			items = append(items, item.toDelivery())
+		}
+		if len(result.LastEvaluatedKey) == 0 {
+			return items, nil
+		}
+		params.ExclusiveStartKey = result.LastEvaluatedKey
+	}
+}
+
+func subscriptionItemKey(id string) map[string]*awsDynamodb.AttributeValue {
+	return map[string]*awsDynamodb.AttributeValue{
+		domain.JSONFieldWebhookID: {
+			S: aws.String(id),
+		},
+	}
+}
@@ -0,0 +1,20 @@
+package dynamodb
+
+import (
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/repositorytest"
+)
+
+func TestWebhookRepository(t *testing.T) {
+	repositorytest.TestWebhookRepository(t, func(t *testing.T) domain.WebhookRepository {
+		subscriptionTableName := provisionTestTable(t, WebhookSubscriptionTableSchema(nextTestTableName(testWebhookSubscriptionTableName)))
+		deliveryTableName := provisionTestTable(t, WebhookDeliveryTableSchema(nextTestTableName(testWebhookDeliveryTableName)))
+		repository, err := NewWebhookRepository(testAwsSession, subscriptionTableName, deliveryTableName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repository
+	})
+}
@@ -0,0 +1,49 @@
+package encryption
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+type answerChangeStream struct {
+	next   domain.AnswerChangeStream
+	cipher *ValueCipher
+}
+
+// NewAnswerChangeStream creates a stream which decrypts the values of the changes read from the next stream.
+func NewAnswerChangeStream(next domain.AnswerChangeStream, cipher *ValueCipher) domain.AnswerChangeStream {
+	return &answerChangeStream{
+		next:   next,
+		cipher: cipher,
+	}
+}
+
+func (s *answerChangeStream) Read(ctx context.Context, h domain.AnswerChangeHandler) error {
+	return s.next.Read(ctx, domain.AnswerChangeHandlerFunc(func(ctx context.Context, change *domain.AnswerChange) error {
+
+		// Decrypt a copy, the change is passed again if the handler fails.
+		//
+		decryptedChange := *change
+		var err error
+		if decryptedChange.Old, err = s.decryptImage(ctx, change.Old); err != nil {
+			return err
+		}
+		if decryptedChange.New, err = s.decryptImage(ctx, change.New); err != nil {
+			return err
+		}
+		return h.HandleChange(ctx, &decryptedChange)
+	}))
+}
+
+// decryptImage - returns a copy of the image with the decrypted value.
+func (s *answerChangeStream) decryptImage(ctx context.Context, image *domain.AnswerImage) (*domain.AnswerImage, error) {
+	if image == nil {
+		return nil, nil
+	}
+	decryptedImage := *image
+	if err := s.cipher.DecryptAnswer(ctx, &decryptedImage.Answer); err != nil {
+		return nil, err
+	}
+	return &decryptedImage, nil
+}
@@ -0,0 +1,78 @@
+package encryption
+
+import (
+	"context"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+// idempotencyResponseKeyPrefix - the responses are authenticated with the idempotency key prefixed by it,
+// so an encrypted response cannot be replayed for another key or mistaken for an answer value.
+const idempotencyResponseKeyPrefix = "idempotency:"
+
+type idempotencyRepository struct {
+	next   domain.IdempotencyRepository
+	cipher *ValueCipher
+}
+
+// NewIdempotencyRepository creates a repository which stores the responses encrypted in the next repository,
+// they hold the answer values.
+func NewIdempotencyRepository(next domain.IdempotencyRepository, cipher *ValueCipher) domain.IdempotencyRepository {
+	return &idempotencyRepository{
+		next:   next,
+		cipher: cipher,
+	}
+}
+
+func (r *idempotencyRepository) Reserve(record *domain.IdempotencyRecord, now time.Time) error {
+	encryptedRecord, err := r.encryptResponse(record)
+	if err != nil {
+		return err
+	}
+	return r.next.Reserve(encryptedRecord, now)
+}
+
+func (r *idempotencyRepository) Get(key string, now time.Time) (*domain.IdempotencyRecord, error) {
+	record, err := r.next.Get(key, now)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.cipher.Decrypt(context.Background(), responseKey(record.Key), domain.AnswerValue(record.Response))
+	if err != nil {
+		return nil, err
+	}
+	record.Response = string(response)
+	return record, nil
+}
+
+func (r *idempotencyRepository) Complete(record *domain.IdempotencyRecord) error {
+	encryptedRecord, err := r.encryptResponse(record)
+	if err != nil {
+		return err
+	}
+	return r.next.Complete(encryptedRecord)
+}
+
+func (r *idempotencyRepository) Release(key, reservationID string) error {
+	return r.next.Release(key, reservationID)
+}
+
+func (r *idempotencyRepository) EraseAnswer(answerKeyHash string) (int, error) {
+	return r.next.EraseAnswer(answerKeyHash)
+}
+
+// encryptResponse - returns a copy of the record with the encrypted response, an empty response stays empty.
+func (r *idempotencyRepository) encryptResponse(record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, error) {
+	response, err := r.cipher.Encrypt(context.Background(), responseKey(record.Key), domain.AnswerValue(record.Response))
+	if err != nil {
+		return nil, err
+	}
+	encryptedRecord := *record
+	encryptedRecord.Response = string(response)
+	return &encryptedRecord, nil
+}
+
+func responseKey(key string) domain.AnswerKey {
+	return domain.AnswerKey(idempotencyResponseKeyPrefix + key)
+}
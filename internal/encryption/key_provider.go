@@ -0,0 +1,27 @@
+package encryption
+
+import (
+	"context"
+)
+
+// DataKey - a data key of the envelope encryption, the plaintext encrypts the values
+// and the wrapped copy, encrypted by a master key, is stored next to them.
+type DataKey struct {
+	// KeyID - identifies the master key which wrapped the data key.
+	KeyID     string
+	Plaintext []byte
+	Wrapped   []byte
+}
+
+// KeyProvider - generates the data keys and unwraps them by the master keys.
+//
+// GenerateDataKey wraps the new data keys by the current master key, DecryptDataKey
+// must still unwrap the data keys of the master keys which were rotated out.
+type KeyProvider interface {
+
+	// GenerateDataKey - returns a new 256-bit data key wrapped by the current master key.
+	GenerateDataKey(ctx context.Context) (*DataKey, error)
+
+	// DecryptDataKey - returns the plaintext of a data key wrapped by the master key of the id.
+	DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
@@ -0,0 +1,56 @@
+package encryption
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+// KMSAPI - is the minimum interface required from a KMS client.
+type KMSAPI interface {
+	GenerateDataKeyWithContext(aws.Context, *kms.GenerateDataKeyInput, ...request.Option) (*kms.GenerateDataKeyOutput, error)
+	DecryptWithContext(aws.Context, *kms.DecryptInput, ...request.Option) (*kms.DecryptOutput, error)
+}
+
+type kmsKeyProvider struct {
+	kmsAPI KMSAPI
+	keyID  string
+}
+
+// NewKMSKeyProvider creates a key provider which wraps the data keys by the KMS key of the id, alias or ARN.
+// The data keys remember the ARN of the key which wrapped them, so the key may be replaced by another one
+// as long as the old one is not deleted. KMS rotates the key material by itself.
+func NewKMSKeyProvider(kmsAPI KMSAPI, keyID string) KeyProvider {
+	return &kmsKeyProvider{
+		kmsAPI: kmsAPI,
+		keyID:  keyID,
+	}
+}
+
+func (p *kmsKeyProvider) GenerateDataKey(ctx context.Context) (*DataKey, error) {
+	resp, err := p.kmsAPI.GenerateDataKeyWithContext(ctx, &kms.GenerateDataKeyInput{
+		KeyId:   aws.String(p.keyID),
+		KeySpec: aws.String(kms.DataKeySpecAes256),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DataKey{
+		KeyID:     aws.StringValue(resp.KeyId),
+		Plaintext: resp.Plaintext,
+		Wrapped:   resp.CiphertextBlob,
+	}, nil
+}
+
+func (p *kmsKeyProvider) DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	resp, err := p.kmsAPI.DecryptWithContext(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: wrapped,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Plaintext, nil
+}
@@ -0,0 +1,124 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"gopkg.in/yaml.v2"
+)
+
+// dataKeySize - the data keys and the local master keys are AES-256 keys.
+const dataKeySize = 32
+
+// LocalKeyFile - the master keys of the local key provider, the keys are base64 encoded.
+// Rotating a key means adding a new one and making it primary, the old one must be kept
+// while values encrypted with its data keys are stored.
+type LocalKeyFile struct {
+	PrimaryKeyID string            `yaml:"primaryKeyID"`
+	Keys         map[string]string `yaml:"keys"`
+}
+
+type localKeyProvider struct {
+	primaryKeyID string
+	masterKeys   map[string]cipher.AEAD
+}
+
+// NewLocalKeyProvider creates a key provider which wraps the data keys by master keys held in memory.
+// It must only be used in development and tests, the master keys belong to a key management service.
+func NewLocalKeyProvider(keyFile *LocalKeyFile) (KeyProvider, error) {
+	if _, ok := keyFile.Keys[keyFile.PrimaryKeyID]; !ok {
+		return nil, fmt.Errorf("primary key %q not found", keyFile.PrimaryKeyID)
+	}
+	p := &localKeyProvider{
+		primaryKeyID: keyFile.PrimaryKeyID,
+		masterKeys:   make(map[string]cipher.AEAD, len(keyFile.Keys)),
+	}
+	for keyID, encodedKey := range keyFile.Keys {
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("decode key %q: %w", keyID, err)
+		}
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("key %q must be %d bytes long", keyID, dataKeySize)
+		}
+		p.masterKeys[keyID], err = newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// LoadLocalKeyProvider creates a local key provider with the master keys of the YAML file.
+func LoadLocalKeyProvider(fileName string) (KeyProvider, error) {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("read key file: %w", err)
+	}
+	keyFile := &LocalKeyFile{}
+	if err := yaml.UnmarshalStrict(content, keyFile); err != nil {
+		return nil, fmt.Errorf("parse key file %v: %w", fileName, err)
+	}
+	return NewLocalKeyProvider(keyFile)
+}
+
+func (p *localKeyProvider) GenerateDataKey(ctx context.Context) (*DataKey, error) {
+	plaintext := make([]byte, dataKeySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, err
+	}
+	wrapped, err := seal(p.masterKeys[p.primaryKeyID], plaintext, []byte(p.primaryKeyID))
+	if err != nil {
+		return nil, err
+	}
+	return &DataKey{
+		KeyID:     p.primaryKeyID,
+		Plaintext: plaintext,
+		Wrapped:   wrapped,
+	}, nil
+}
+
+func (p *localKeyProvider) DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	masterKey, ok := p.masterKeys[keyID]
+	if !ok {
+		return nil, errors.NewErrInternal(fmt.Sprintf("Master key %q not found", keyID))
+	}
+	return open(masterKey, wrapped, []byte(keyID))
+}
+
+// newAEAD - returns AES-GCM with the key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal - encrypts the plaintext with a random nonce, the nonce prefixes the result.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open - decrypts the result of seal.
+func open(aead cipher.AEAD, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.NewErrInternal("Encrypted data is too short")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, errors.NewErrInternal("Encrypted data cannot be decrypted")
+	}
+	return plaintext, nil
+}
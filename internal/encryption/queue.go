@@ -0,0 +1,77 @@
+package encryption
+
+import (
+	"context"
+	"encoding/json"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+type queueService struct {
+	next   domain.QueueService
+	cipher *ValueCipher
+}
+
+// NewQueueService creates a queue service which sends the answer values encrypted by the next queue service.
+func NewQueueService(next domain.QueueService, cipher *ValueCipher) domain.QueueService {
+	return &queueService{
+		next:   next,
+		cipher: cipher,
+	}
+}
+
+func (s *queueService) SendMessage(ctx context.Context, queueName string, message domain.QueueMessage) (string, error) {
+	if m, ok := message.(*domain.AnswerEventMessage); ok && m != nil {
+		encryptedEvent, err := s.cipher.EncryptEvent(ctx, m.Event)
+		if err != nil {
+			return "", err
+		}
+		message = &domain.AnswerEventMessage{Event: encryptedEvent}
+	}
+	return s.next.SendMessage(ctx, queueName, message)
+}
+
+type queueConsumer struct {
+	next   domain.QueueConsumer
+	cipher *ValueCipher
+}
+
+// NewQueueConsumer creates a queue consumer which decrypts the answer values received by the next queue consumer.
+func NewQueueConsumer(next domain.QueueConsumer, cipher *ValueCipher) domain.QueueConsumer {
+	return &queueConsumer{
+		next:   next,
+		cipher: cipher,
+	}
+}
+
+func (c *queueConsumer) Consume(ctx context.Context, queueName string, h domain.QueueHandler) error {
+	return c.next.Consume(ctx, queueName, domain.QueueHandlerFunc(func(ctx context.Context, msg *domain.ReceivedMessage) error {
+		decryptedMessage, err := c.decryptMessage(ctx, msg)
+		if err != nil {
+			return err
+		}
+		return h.HandleMessage(ctx, decryptedMessage)
+	}))
+}
+
+// decryptMessage - returns a copy of the message with the decrypted body.
+// Messages of other types and malformed bodies are left to the handler.
+func (c *queueConsumer) decryptMessage(ctx context.Context, msg *domain.ReceivedMessage) (*domain.ReceivedMessage, error) {
+	if msg.MessageType != domain.AnswerEventMessageType {
+		return msg, nil
+	}
+	payload := &domain.AnswerEventMessage{}
+	if err := json.Unmarshal(msg.Body, payload); err != nil || payload.Event == nil {
+		return msg, nil
+	}
+	if err := c.cipher.DecryptEvent(ctx, payload.Event); err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	decryptedMessage := *msg
+	decryptedMessage.Body = body
+	return &decryptedMessage, nil
+}
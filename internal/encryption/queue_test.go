@@ -0,0 +1,75 @@
+package encryption
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/queue"
+
+	"github.com/go-test/deep"
+)
+
+// fakeQueue - keeps the sent messages and delivers them when consumed.
+type fakeQueue struct {
+	messages []*domain.ReceivedMessage
+}
+
+func (q *fakeQueue) SendMessage(ctx context.Context, queueName string, message domain.QueueMessage) (string, error) {
+	body, err := queue.EncodeMessage(queueName, message)
+	if err != nil {
+		return "", err
+	}
+	q.messages = append(q.messages, &domain.ReceivedMessage{ID: "1", MessageType: message.GetMessageType(), Body: body})
+	return "1", nil
+}
+
+func (q *fakeQueue) Consume(ctx context.Context, queueName string, h domain.QueueHandler) error {
+	for _, msg := range q.messages {
+		if err := h.HandleMessage(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestQueue(t *testing.T) {
+	ctx := context.Background()
+	q := &fakeQueue{}
+	cipher := newTestValueCipher(t)
+	message := &domain.AnswerEventMessage{
+		Event: &domain.AnswerEvent{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John"}},
+	}
+	if _, err := NewQueueService(q, cipher).SendMessage(ctx, "events", message); err != nil {
+		t.Fatal(err)
+	}
+
+	// The queue only carries the encrypted value.
+	//
+	if strings.Contains(string(q.messages[0].Body), "John") {
+		t.Errorf("value sent in plaintext: %s", q.messages[0].Body)
+	}
+	if message.Event.Data.Value != "John" {
+		t.Errorf("message modified: %v", message.Event.Data.Value)
+	}
+
+	// The handler receives the decrypted value.
+	//
+	var received []*domain.AnswerEventMessage
+	err := NewQueueConsumer(q, cipher).Consume(ctx, "events", domain.QueueHandlerFunc(func(ctx context.Context, msg *domain.ReceivedMessage) error {
+		payload := &domain.AnswerEventMessage{}
+		if err := json.Unmarshal(msg.Body, payload); err != nil {
+			return err
+		}
+		received = append(received, payload)
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(received, []*domain.AnswerEventMessage{message}); diff != nil {
+		t.Error(diff)
+	}
+}
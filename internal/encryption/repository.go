@@ -0,0 +1,184 @@
+package encryption
+
+import (
+	"context"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+type answerRepository struct {
+	next   domain.TaggedAnswerRepository
+	cipher *ValueCipher
+}
+
+// NewAnswerRepository creates a repository which stores the values encrypted in the next repository.
+// The tags of the writes are passed on if the next repository stores them.
+func NewAnswerRepository(next domain.AnswerRepository, cipher *ValueCipher) domain.AnswerRepository {
+	return &answerRepository{
+		next:   domain.NewTaggedAnswerRepository(next),
+		cipher: cipher,
+	}
+}
+
+func (r *answerRepository) Create(answer *domain.Answer) error {
+	return r.CreateTagged(answer, domain.AnswerChangeTag{})
+}
+
+func (r *answerRepository) CreateTagged(answer *domain.Answer, tag domain.AnswerChangeTag) error {
+	encryptedAnswer, err := r.cipher.EncryptAnswer(context.Background(), answer)
+	if err != nil {
+		return err
+	}
+	if err := r.next.CreateTagged(encryptedAnswer, tag); err != nil {
+		return err
+	}
+	answer.Version = encryptedAnswer.Version
+	return nil
+}
+
+func (r *answerRepository) Update(answer *domain.Answer) error {
+	return r.UpdateTagged(answer, domain.AnswerChangeTag{})
+}
+
+func (r *answerRepository) UpdateTagged(answer *domain.Answer, tag domain.AnswerChangeTag) error {
+	encryptedAnswer, err := r.cipher.EncryptAnswer(context.Background(), answer)
+	if err != nil {
+		return err
+	}
+	if err := r.next.UpdateTagged(encryptedAnswer, tag); err != nil {
+		return err
+	}
+	answer.Version = encryptedAnswer.Version
+	return nil
+}
+
+func (r *answerRepository) Upsert(answer *domain.Answer) (bool, error) {
+	return r.UpsertTagged(answer, domain.AnswerChangeTag{})
+}
+
+func (r *answerRepository) UpsertTagged(answer *domain.Answer, tag domain.AnswerChangeTag) (bool, error) {
+	encryptedAnswer, err := r.cipher.EncryptAnswer(context.Background(), answer)
+	if err != nil {
+		return false, err
+	}
+	created, err := r.next.UpsertTagged(encryptedAnswer, tag)
+	if err != nil {
+		return false, err
+	}
+	answer.Version = encryptedAnswer.Version
+	return created, nil
+}
+
+func (r *answerRepository) Delete(key domain.AnswerKey, tombstone domain.Tombstone) (int, error) {
+	return r.next.Delete(key, tombstone)
+}
+
+func (r *answerRepository) DeleteTagged(key domain.AnswerKey, tombstone domain.Tombstone, tag domain.AnswerChangeTag) (int, error) {
+	return r.next.DeleteTagged(key, tombstone, tag)
+}
+
+func (r *answerRepository) Get(key domain.AnswerKey) (*domain.Answer, error) {
+	answer, err := r.next.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.cipher.DecryptAnswer(context.Background(), answer); err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+func (r *answerRepository) List(page domain.PageRequest) ([]*domain.Answer, string, error) {
+	answers, nextPageToken, err := r.next.List(page)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, answer := range answers {
+		if err := r.cipher.DecryptAnswer(context.Background(), answer); err != nil {
+			return nil, "", err
+		}
+	}
+	return answers, nextPageToken, nil
+}
+
+func (r *answerRepository) ListDeleted(page domain.PageRequest) ([]*domain.DeletedAnswer, string, error) {
+	answers, nextPageToken, err := r.next.ListDeleted(page)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, answer := range answers {
+		if err := r.cipher.DecryptAnswer(context.Background(), &answer.Answer); err != nil {
+			return nil, "", err
+		}
+	}
+	return answers, nextPageToken, nil
+}
+
+func (r *answerRepository) Purge(key domain.AnswerKey, now time.Time) error {
+	return r.next.Purge(key, now)
+}
+
+func (r *answerRepository) Erase(key domain.AnswerKey) error {
+	return r.next.Erase(key)
+}
+
+type answerEventRepository struct {
+	next   domain.AnswerEventRepository
+	cipher *ValueCipher
+}
+
+// NewAnswerEventRepository creates a repository which stores the event values encrypted in the next repository.
+func NewAnswerEventRepository(next domain.AnswerEventRepository, cipher *ValueCipher) domain.AnswerEventRepository {
+	return &answerEventRepository{
+		next:   next,
+		cipher: cipher,
+	}
+}
+
+func (r *answerEventRepository) Create(answerEvent *domain.AnswerEvent) error {
+	encryptedEvent, err := r.cipher.EncryptEvent(context.Background(), answerEvent)
+	if err != nil {
+		return err
+	}
+	return r.next.Create(encryptedEvent)
+}
+
+func (r *answerEventRepository) ListEvents(key domain.AnswerKey) ([]*domain.AnswerEvent, error) {
+	events, err := r.next.ListEvents(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptEvents(events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *answerEventRepository) ListEventsPage(key domain.AnswerKey, page domain.PageRequest) ([]*domain.AnswerEvent, string, error) {
+	events, nextPageToken, err := r.next.ListEventsPage(key, page)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := r.decryptEvents(events); err != nil {
+		return nil, "", err
+	}
+	return events, nextPageToken, nil
+}
+
+func (r *answerEventRepository) ListKeys(page domain.PageRequest) ([]domain.AnswerKey, string, error) {
+	return r.next.ListKeys(page)
+}
+
+func (r *answerEventRepository) EraseEvents(key domain.AnswerKey, before time.Time) (int, error) {
+	return r.next.EraseEvents(key, before)
+}
+
+func (r *answerEventRepository) decryptEvents(events []*domain.AnswerEvent) error {
+	for _, event := range events {
+		if err := r.cipher.DecryptEvent(context.Background(), event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
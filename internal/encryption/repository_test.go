@@ -0,0 +1,105 @@
+package encryption
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/inmemory"
+	"dochq.co.uk.answerservice/internal/repositorytest"
+)
+
+func TestAnswerRepository(t *testing.T) {
+	repositorytest.TestAnswerRepository(t, func(t *testing.T) domain.AnswerRepository {
+		return NewAnswerRepository(inmemory.NewAnswerRepository(), newTestValueCipher(t))
+	})
+}
+
+func TestAnswerEventRepository(t *testing.T) {
+	repositorytest.TestAnswerEventRepository(t, func(t *testing.T) domain.AnswerEventRepository {
+		return NewAnswerEventRepository(inmemory.NewAnswerEventRepository(), newTestValueCipher(t))
+	})
+}
+
+func TestWebhookRepository(t *testing.T) {
+	repositorytest.TestWebhookRepository(t, func(t *testing.T) domain.WebhookRepository {
+		return NewWebhookRepository(inmemory.NewWebhookRepository(), newTestValueCipher(t))
+	})
+}
+
+func TestIdempotencyRepository(t *testing.T) {
+	repositorytest.TestIdempotencyRepository(t, func(t *testing.T) domain.IdempotencyRepository {
+		return NewIdempotencyRepository(inmemory.NewIdempotencyRepository(), newTestValueCipher(t))
+	})
+}
+
+func TestValuesStoredEncrypted(t *testing.T) {
+	answers := inmemory.NewAnswerRepository()
+	events := inmemory.NewAnswerEventRepository()
+	cipher := newTestValueCipher(t)
+	answer := &domain.Answer{Key: "name", Value: "John"}
+	if err := NewAnswerRepository(answers, cipher).Create(answer); err != nil {
+		t.Fatal(err)
+	}
+	event := &domain.AnswerEvent{EventType: domain.CreateAnswerEventType, Data: answer, OccurredAt: time.Now().UTC()}
+	if err := NewAnswerEventRepository(events, cipher).Create(event); err != nil {
+		t.Fatal(err)
+	}
+
+	// The next repositories only see the encrypted values, the arguments are not modified.
+	//
+	storedAnswer, err := answers.Get("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	storedEvents, err := events.ListEvents("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []domain.AnswerValue{storedAnswer.Value, storedEvents[0].Data.Value} {
+		if !strings.HasPrefix(string(value), encryptedValuePrefix) {
+			t.Errorf("value stored in plaintext: %q", value)
+		}
+	}
+	if answer.Value != "John" {
+		t.Errorf("answer modified: %v", answer.Value)
+	}
+}
+
+func TestWebhookSecretStoredEncrypted(t *testing.T) {
+	webhooks := inmemory.NewWebhookRepository()
+	subscription := &domain.WebhookSubscription{ID: "subscription-1", URL: "https://example.com", Secret: "secret"}
+	if err := NewWebhookRepository(webhooks, newTestValueCipher(t)).CreateSubscription(subscription); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := webhooks.GetSubscription("subscription-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(stored.Secret, encryptedValuePrefix) {
+		t.Errorf("secret stored in plaintext: %q", stored.Secret)
+	}
+	if subscription.Secret != "secret" {
+		t.Errorf("subscription modified: %v", subscription.Secret)
+	}
+}
+
+func TestIdempotencyResponseStoredEncrypted(t *testing.T) {
+	records := inmemory.NewIdempotencyRepository()
+	now := time.Now()
+	record := &domain.IdempotencyRecord{Key: "key-1", Response: `{"value":"John"}`, ExpiresAt: now.Add(time.Hour)}
+	if err := NewIdempotencyRepository(records, newTestValueCipher(t)).Reserve(record, now); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := records.Get("key-1", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(stored.Response, encryptedValuePrefix) {
+		t.Errorf("response stored in plaintext: %q", stored.Response)
+	}
+	if record.Response != `{"value":"John"}` {
+		t.Errorf("record modified: %v", record.Response)
+	}
+}
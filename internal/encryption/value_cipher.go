@@ -0,0 +1,189 @@
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+// encryptedValuePrefix - marks the encrypted values and the version of their format,
+// the key id, the wrapped data key and the sealed value follow separated by dots.
+const encryptedValuePrefix = "enc:v1."
+
+// maxDecryptedDataKeys - the decrypted data keys are forgotten once the cache holds this many.
+const maxDecryptedDataKeys = 1024
+
+var encoding = base64.RawURLEncoding
+
+// ValueCipher - encrypts the answer values by envelope encryption.
+//
+// A data key encrypts the values for the data key lifetime, then a new one is generated,
+// so the key provider is not called for every value. The answer key is authenticated with
+// the value, an encrypted value cannot be moved to another answer. Values without the
+// prefix of the encrypted values are returned as they are by Decrypt, so the values stored
+// before the encryption was enabled stay readable.
+type ValueCipher struct {
+	keyProvider     KeyProvider
+	dataKeyLifetime time.Duration
+	now             func() time.Time
+
+	mu                sync.Mutex
+	dataKey           *DataKey
+	dataKeyAEAD       cipher.AEAD
+	dataKeyExpiresAt  time.Time
+	decryptedDataKeys map[string]cipher.AEAD
+}
+
+// NewValueCipher creates a cipher with the key provider of the master keys.
+func NewValueCipher(keyProvider KeyProvider, dataKeyLifetime time.Duration) *ValueCipher {
+	return &ValueCipher{
+		keyProvider:       keyProvider,
+		dataKeyLifetime:   dataKeyLifetime,
+		now:               time.Now,
+		decryptedDataKeys: make(map[string]cipher.AEAD),
+	}
+}
+
+// Encrypt - returns the encrypted value, an empty value stays empty.
+func (c *ValueCipher) Encrypt(ctx context.Context, key domain.AnswerKey, value domain.AnswerValue) (domain.AnswerValue, error) {
+	if len(value) == 0 {
+		return value, nil
+	}
+	dataKey, aead, err := c.currentDataKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(aead, []byte(value), []byte(key))
+	if err != nil {
+		return "", err
+	}
+	return domain.AnswerValue(encryptedValuePrefix +
+		encoding.EncodeToString([]byte(dataKey.KeyID)) + "." +
+		encoding.EncodeToString(dataKey.Wrapped) + "." +
+		encoding.EncodeToString(sealed)), nil
+}
+
+// Decrypt - returns the plaintext of an encrypted value or the value itself if it is not encrypted.
+func (c *ValueCipher) Decrypt(ctx context.Context, key domain.AnswerKey, value domain.AnswerValue) (domain.AnswerValue, error) {
+	if !strings.HasPrefix(string(value), encryptedValuePrefix) {
+		return value, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(string(value), encryptedValuePrefix), ".")
+	if len(parts) != 3 {
+		return "", errors.NewErrInternal("Encrypted value is malformed")
+	}
+	var decoded [3][]byte
+	for i, part := range parts {
+		var err error
+		if decoded[i], err = encoding.DecodeString(part); err != nil {
+			return "", errors.NewErrInternal("Encrypted value is malformed")
+		}
+	}
+	aead, err := c.decryptDataKey(ctx, string(decoded[0]), decoded[1])
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, decoded[2], []byte(key))
+	if err != nil {
+		return "", err
+	}
+	return domain.AnswerValue(plaintext), nil
+}
+
+// EncryptAnswer - returns a copy of the answer with the encrypted value.
+func (c *ValueCipher) EncryptAnswer(ctx context.Context, answer *domain.Answer) (*domain.Answer, error) {
+	if answer == nil {
+		return nil, nil
+	}
+	value, err := c.Encrypt(ctx, answer.Key, answer.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Answer{Key: answer.Key, Value: value, Version: answer.Version}, nil
+}
+
+// DecryptAnswer - decrypts the value of the answer in place.
+func (c *ValueCipher) DecryptAnswer(ctx context.Context, answer *domain.Answer) error {
+	if answer == nil {
+		return nil
+	}
+	value, err := c.Decrypt(ctx, answer.Key, answer.Value)
+	if err != nil {
+		return err
+	}
+	answer.Value = value
+	return nil
+}
+
+// EncryptEvent - returns a copy of the event with the encrypted value.
+func (c *ValueCipher) EncryptEvent(ctx context.Context, event *domain.AnswerEvent) (*domain.AnswerEvent, error) {
+	if event == nil {
+		return nil, nil
+	}
+	data, err := c.EncryptAnswer(ctx, event.Data)
+	if err != nil {
+		return nil, err
+	}
+	encryptedEvent := *event
+	encryptedEvent.Data = data
+	return &encryptedEvent, nil
+}
+
+// DecryptEvent - decrypts the value of the event in place.
+func (c *ValueCipher) DecryptEvent(ctx context.Context, event *domain.AnswerEvent) error {
+	if event == nil {
+		return nil
+	}
+	return c.DecryptAnswer(ctx, event.Data)
+}
+
+// currentDataKey - returns the data key of the encryption, a new one is generated when it expires.
+func (c *ValueCipher) currentDataKey(ctx context.Context) (*DataKey, cipher.AEAD, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if c.dataKey != nil && now.Before(c.dataKeyExpiresAt) {
+		return c.dataKey, c.dataKeyAEAD, nil
+	}
+	dataKey, err := c.keyProvider.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := newAEAD(dataKey.Plaintext)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.dataKey = dataKey
+	c.dataKeyAEAD = aead
+	c.dataKeyExpiresAt = now.Add(c.dataKeyLifetime)
+	return dataKey, aead, nil
+}
+
+// decryptDataKey - returns the wrapped data key unwrapped by the key provider, the result is cached.
+func (c *ValueCipher) decryptDataKey(ctx context.Context, keyID string, wrapped []byte) (cipher.AEAD, error) {
+	cacheKey := keyID + "." + string(wrapped)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if aead, ok := c.decryptedDataKeys[cacheKey]; ok {
+		return aead, nil
+	}
+	plaintext, err := c.keyProvider.DecryptDataKey(ctx, keyID, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.decryptedDataKeys) >= maxDecryptedDataKeys {
+		c.decryptedDataKeys = make(map[string]cipher.AEAD)
+	}
+	c.decryptedDataKeys[cacheKey] = aead
+	return aead, nil
+}
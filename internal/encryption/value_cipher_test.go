@@ -0,0 +1,262 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/go-test/deep"
+)
+
+var (
+	testMasterKey1 = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	testMasterKey2 = base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210"))
+)
+
+// countingKeyProvider - counts the calls of the key provider.
+type countingKeyProvider struct {
+	KeyProvider
+	generated int
+	decrypted int
+}
+
+func (p *countingKeyProvider) GenerateDataKey(ctx context.Context) (*DataKey, error) {
+	p.generated++
+	return p.KeyProvider.GenerateDataKey(ctx)
+}
+
+func (p *countingKeyProvider) DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	p.decrypted++
+	return p.KeyProvider.DecryptDataKey(ctx, keyID, wrapped)
+}
+
+func newTestKeyProvider(t *testing.T, primaryKeyID string) KeyProvider {
+	t.Helper()
+	keyProvider, err := NewLocalKeyProvider(&LocalKeyFile{
+		PrimaryKeyID: primaryKeyID,
+		Keys:         map[string]string{"k1": testMasterKey1, "k2": testMasterKey2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyProvider
+}
+
+// newTestValueCipher - returns a cipher with a local key provider.
+func newTestValueCipher(t *testing.T) *ValueCipher {
+	t.Helper()
+	return NewValueCipher(newTestKeyProvider(t, "k1"), time.Hour)
+}
+
+func TestValueCipher(t *testing.T) {
+	ctx := context.Background()
+	c := newTestValueCipher(t)
+	encrypted, err := c.Encrypt(ctx, "name", "John")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(encrypted), encryptedValuePrefix) || strings.Contains(string(encrypted), "John") {
+		t.Errorf("unexpected encrypted value %q", encrypted)
+	}
+
+	tampered := []byte(encrypted)
+	if tampered[len(tampered)-10] == 'A' {
+		tampered[len(tampered)-10] = 'B'
+	} else {
+		tampered[len(tampered)-10] = 'A'
+	}
+
+	tests := []struct {
+		name    string
+		key     domain.AnswerKey
+		value   domain.AnswerValue
+		want    domain.AnswerValue
+		wantErr bool
+	}{
+		{name: "encrypted value", key: "name", value: encrypted, want: "John"},
+		{name: "plaintext value", key: "name", value: "Sam", want: "Sam"},
+		{name: "value of another key", key: "city", value: encrypted, wantErr: true},
+		{name: "malformed value", key: "name", value: encryptedValuePrefix + "a.b", wantErr: true},
+		{name: "tampered value", key: "name", value: domain.AnswerValue(tampered), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.Decrypt(ctx, tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	// An empty value, e.g. of an erase event, stays empty.
+	//
+	empty, err := c.Encrypt(ctx, "name", "")
+	if err != nil || len(empty) > 0 {
+		t.Errorf("unexpected empty value %q, err: %v", empty, err)
+	}
+}
+
+func TestValueCipherDataKeys(t *testing.T) {
+	ctx := context.Background()
+	keyProvider := &countingKeyProvider{KeyProvider: newTestKeyProvider(t, "k1")}
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	c := NewValueCipher(keyProvider, time.Hour)
+	c.now = func() time.Time { return now }
+
+	// A data key is reused till it expires.
+	//
+	var values []domain.AnswerValue
+	for _, d := range []time.Duration{0, time.Minute, time.Hour} {
+		now = now.Add(d)
+		value, err := c.Encrypt(ctx, "name", "John")
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, value)
+	}
+	if keyProvider.generated != 2 {
+		t.Errorf("expected 2 data keys, got %v", keyProvider.generated)
+	}
+
+	// The unwrapped data keys are cached.
+	//
+	decrypter := &countingKeyProvider{KeyProvider: newTestKeyProvider(t, "k1")}
+	d := NewValueCipher(decrypter, time.Hour)
+	for _, value := range append(values, values...) {
+		if _, err := d.Decrypt(ctx, "name", value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if decrypter.decrypted != 2 {
+		t.Errorf("expected 2 unwrapped data keys, got %v", decrypter.decrypted)
+	}
+}
+
+func TestValueCipherKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	old, err := NewValueCipher(newTestKeyProvider(t, "k1"), time.Hour).Encrypt(ctx, "name", "John")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// After the rotation new values use the new master key, the old values stay readable.
+	//
+	keyProvider := newTestKeyProvider(t, "k2")
+	c := NewValueCipher(keyProvider, time.Hour)
+	if _, err := c.Encrypt(ctx, "name", "Sam"); err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(c.dataKey.KeyID, "k2"); diff != nil {
+		t.Error(diff)
+	}
+	value, err := c.Decrypt(ctx, "name", old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(value, domain.AnswerValue("John")); diff != nil {
+		t.Error(diff)
+	}
+
+	// But not once the old master key is removed.
+	//
+	retired, err := NewLocalKeyProvider(&LocalKeyFile{PrimaryKeyID: "k2", Keys: map[string]string{"k2": testMasterKey2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewValueCipher(retired, time.Hour).Decrypt(ctx, "name", old); err == nil {
+		t.Error("expected an error without the old master key")
+	}
+}
+
+func TestLoadLocalKeyProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "valid", content: "primaryKeyID: k1\nkeys:\n  k1: " + testMasterKey1 + "\n"},
+		{name: "missing primary key", content: "primaryKeyID: k2\nkeys:\n  k1: " + testMasterKey1 + "\n", wantErr: `primary key "k2" not found`},
+		{name: "short key", content: "primaryKeyID: k1\nkeys:\n  k1: c2hvcnQ=\n", wantErr: `key "k1" must be 32 bytes long`},
+		{name: "unknown field", content: "primary: k1\n", wantErr: "field primary not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), "keys.yaml")
+			if err := ioutil.WriteFile(fileName, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadLocalKeyProvider(fileName)
+			if len(tt.wantErr) == 0 && err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if len(tt.wantErr) > 0 && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("expected err containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// fakeKMS - wraps the data keys by a local key provider.
+type fakeKMS struct {
+	keyProvider KeyProvider
+	keyIDs      []string
+}
+
+func (k *fakeKMS) GenerateDataKeyWithContext(ctx aws.Context, input *kms.GenerateDataKeyInput, opts ...request.Option) (*kms.GenerateDataKeyOutput, error) {
+	k.keyIDs = append(k.keyIDs, aws.StringValue(input.KeyId))
+	dataKey, err := k.keyProvider.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &kms.GenerateDataKeyOutput{
+		KeyId:          aws.String("arn:aws:kms:eu-west-2:111122223333:key/" + dataKey.KeyID),
+		Plaintext:      dataKey.Plaintext,
+		CiphertextBlob: dataKey.Wrapped,
+	}, nil
+}
+
+func (k *fakeKMS) DecryptWithContext(ctx aws.Context, input *kms.DecryptInput, opts ...request.Option) (*kms.DecryptOutput, error) {
+	k.keyIDs = append(k.keyIDs, aws.StringValue(input.KeyId))
+	keyID := strings.TrimPrefix(aws.StringValue(input.KeyId), "arn:aws:kms:eu-west-2:111122223333:key/")
+	plaintext, err := k.keyProvider.DecryptDataKey(ctx, keyID, input.CiphertextBlob)
+	if err != nil {
+		return nil, err
+	}
+	return &kms.DecryptOutput{Plaintext: plaintext}, nil
+}
+
+func TestKMSKeyProvider(t *testing.T) {
+	ctx := context.Background()
+	kmsAPI := &fakeKMS{keyProvider: newTestKeyProvider(t, "k1")}
+	c := NewValueCipher(NewKMSKeyProvider(kmsAPI, "alias/answers"), time.Hour)
+	encrypted, err := c.Encrypt(ctx, "name", "John")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The data key is unwrapped by the key ARN returned with it.
+	//
+	value, err := NewValueCipher(NewKMSKeyProvider(kmsAPI, "alias/other"), time.Hour).Decrypt(ctx, "name", encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(value, domain.AnswerValue("John")); diff != nil {
+		t.Error(diff)
+	}
+	expected := []string{"alias/answers", "arn:aws:kms:eu-west-2:111122223333:key/k1"}
+	if diff := deep.Equal(kmsAPI.keyIDs, expected); diff != nil {
+		t.Error(diff)
+	}
+}
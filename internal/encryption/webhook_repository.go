@@ -0,0 +1,88 @@
+package encryption
+
+import (
+	"context"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+// webhookSecretKeyPrefix - the secrets are authenticated with the subscription id prefixed by it,
+// so an encrypted secret cannot be moved to another subscription or mistaken for an answer value.
+const webhookSecretKeyPrefix = "webhook:"
+
+type webhookRepository struct {
+	next   domain.WebhookRepository
+	cipher *ValueCipher
+}
+
+// NewWebhookRepository creates a repository which stores the subscription secrets encrypted in the next repository.
+func NewWebhookRepository(next domain.WebhookRepository, cipher *ValueCipher) domain.WebhookRepository {
+	return &webhookRepository{
+		next:   next,
+		cipher: cipher,
+	}
+}
+
+func (r *webhookRepository) CreateSubscription(subscription *domain.WebhookSubscription) error {
+	secret, err := r.cipher.Encrypt(context.Background(), secretKey(subscription.ID), domain.AnswerValue(subscription.Secret))
+	if err != nil {
+		return err
+	}
+	encryptedSubscription := *subscription
+	encryptedSubscription.Secret = string(secret)
+	return r.next.CreateSubscription(&encryptedSubscription)
+}
+
+func (r *webhookRepository) GetSubscription(id string) (*domain.WebhookSubscription, error) {
+	subscription, err := r.next.GetSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decryptSecret(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (r *webhookRepository) ListSubscriptions(page domain.PageRequest) ([]*domain.WebhookSubscription, string, error) {
+	subscriptions, nextPageToken, err := r.next.ListSubscriptions(page)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, subscription := range subscriptions {
+		if err := r.decryptSecret(subscription); err != nil {
+			return nil, "", err
+		}
+	}
+	return subscriptions, nextPageToken, nil
+}
+
+func (r *webhookRepository) DeleteSubscription(id string) error {
+	return r.next.DeleteSubscription(id)
+}
+
+func (r *webhookRepository) CreateDelivery(delivery *domain.WebhookDelivery) error {
+	return r.next.CreateDelivery(delivery)
+}
+
+func (r *webhookRepository) ListDeliveries(subscriptionID string, page domain.PageRequest) ([]*domain.WebhookDelivery, string, error) {
+	return r.next.ListDeliveries(subscriptionID, page)
+}
+
+func (r *webhookRepository) ListDeliveryAttempts(subscriptionID, deliveryID string, since time.Time) ([]*domain.WebhookDelivery, error) {
+	return r.next.ListDeliveryAttempts(subscriptionID, deliveryID, since)
+}
+
+func (r *webhookRepository) decryptSecret(subscription *domain.WebhookSubscription) error {
+	secret, err := r.cipher.Decrypt(context.Background(), secretKey(subscription.ID), domain.AnswerValue(subscription.Secret))
+	if err != nil {
+		return err
+	}
+	subscription.Secret = string(secret)
+	return nil
+}
+
+func secretKey(subscriptionID string) domain.AnswerKey {
+	return domain.AnswerKey(webhookSecretKeyPrefix + subscriptionID)
+}
@@ -0,0 +1,147 @@
+package erasure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-kit/log"
+)
+
+type service struct {
+	repository            domain.AnswerRepository
+	eventRepository       domain.AnswerEventRepository
+	erasureRepository     domain.ErasureRepository
+	idempotencyRepository domain.IdempotencyRepository
+	queueService          domain.QueueService
+	eventQueueName        string
+	keyHasher             *domain.AnswerKeyHasher
+	now                   func() time.Time
+}
+
+// NewService creates a new service with necessary dependencies.
+// The erase events are sent to the event queue even if the answer events are captured from the change stream
+// of the answer table, the removal of an erased answer is not an event of the stream.
+func NewService(repository domain.AnswerRepository,
+	eventRepository domain.AnswerEventRepository,
+	erasureRepository domain.ErasureRepository,
+	idempotencyRepository domain.IdempotencyRepository,
+	queueService domain.QueueService,
+	eventQueueName string,
+	keyHasher *domain.AnswerKeyHasher,
+	logger log.Logger) domain.AnswerErasureService {
+	var service domain.AnswerErasureService
+	{
+		service = newBasicService(repository, eventRepository, erasureRepository, idempotencyRepository, queueService, eventQueueName, keyHasher)
+		service = LoggingServiceMiddleware(logger)(service)
+	}
+	return service
+}
+
+// Returns a naive, stateless implementation of service.
+func newBasicService(repository domain.AnswerRepository,
+	eventRepository domain.AnswerEventRepository,
+	erasureRepository domain.ErasureRepository,
+	idempotencyRepository domain.IdempotencyRepository,
+	queueService domain.QueueService,
+	eventQueueName string,
+	keyHasher *domain.AnswerKeyHasher) domain.AnswerErasureService {
+	return &service{
+		repository:            repository,
+		eventRepository:       eventRepository,
+		erasureRepository:     erasureRepository,
+		idempotencyRepository: idempotencyRepository,
+		queueService:          queueService,
+		eventQueueName:        eventQueueName,
+		keyHasher:             keyHasher,
+		now:                   time.Now,
+	}
+}
+
+func (s *service) EraseAnswers(ctx context.Context, keys []domain.AnswerKey, requestedBy string) ([]*domain.ErasureReceipt, error) {
+
+	// Check keys.
+	//
+	if len(keys) == 0 {
+		return nil, errors.NewErrInvalidArgument("AnswerKey required")
+	}
+	if len(keys) > domain.MaxPageSize {
+		return nil, errors.NewErrInvalidArgument(fmt.Sprintf("At most %d keys can be erased at once", domain.MaxPageSize))
+	}
+	for _, key := range keys {
+		if len(key) == 0 {
+			return nil, errors.NewErrInvalidArgument("AnswerKey required")
+		}
+	}
+
+	// Erase the keys one by one, an interrupted erasure may be repeated.
+	//
+	var receipts []*domain.ErasureReceipt
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return receipts, err
+		}
+		receipt, err := s.eraseAnswer(ctx, key, requestedBy)
+		if err != nil {
+			return receipts, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
+
+// eraseAnswer - records the tombstone first, so the consumers drop the earlier events still in the queues,
+// then erases the answer, the history before the tombstone and the stored responses. The erase event is sent
+// last, it tells the other consumers, e.g. the search index and the webhooks, to forget the answer.
+func (s *service) eraseAnswer(ctx context.Context, key domain.AnswerKey, requestedBy string) (*domain.ErasureReceipt, error) {
+	receipt := &domain.ErasureReceipt{
+		KeyHash:     s.keyHasher.Hash(key),
+		ErasedAt:    s.now().UTC(),
+		RequestedBy: requestedBy,
+	}
+	err := s.erasureRepository.Record(&domain.Erasure{
+		KeyHash:  receipt.KeyHash,
+		ErasedAt: receipt.ErasedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Erase answer.
+	//
+	err = s.repository.Erase(key)
+	if _, ok := err.(*errors.ErrNotFound); !ok && err != nil {
+		return nil, err
+	}
+	receipt.AnswerErased = err == nil
+
+	// Erase history.
+	//
+	receipt.EventsErased, err = s.eventRepository.EraseEvents(key, receipt.ErasedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Erase the responses replayed for the retried requests, they hold the answer.
+	//
+	if _, err := s.idempotencyRepository.EraseAnswer(receipt.KeyHash); err != nil {
+		return nil, err
+	}
+
+	// Send erase event.
+	//
+	_, err = s.queueService.SendMessage(ctx, s.eventQueueName, &domain.AnswerEventMessage{
+		Event: &domain.AnswerEvent{
+			EventType:  domain.EraseAnswerEventType,
+			Data:       &domain.Answer{Key: key},
+			OccurredAt: receipt.ErasedAt,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
@@ -0,0 +1,210 @@
+package erasure
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errs "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/servicetest"
+
+	"github.com/go-test/deep"
+)
+
+const testRequestedBy = "admin"
+
+var testKeyHasher = domain.NewAnswerKeyHasher("test-secret")
+
+// failingEventRepository - fails the erasure of the history with an error.
+type failingEventRepository struct {
+	domain.AnswerEventRepository
+	eraseErr error
+}
+
+func (r *failingEventRepository) EraseEvents(key domain.AnswerKey, before time.Time) (int, error) {
+	if r.eraseErr != nil {
+		return 0, r.eraseErr
+	}
+	return r.AnswerEventRepository.EraseEvents(key, before)
+}
+
+// serviceFixture - the service under test together with its repositories.
+type serviceFixture struct {
+	service               *service
+	repository            domain.AnswerRepository
+	eventRepository       *failingEventRepository
+	erasureRepository     domain.ErasureRepository
+	idempotencyRepository domain.IdempotencyRepository
+	queueService          *servicetest.QueueService
+}
+
+// newServiceFixture - stores "name" with a history of two events and a stored response,
+// and "city" deleted with a history of one event.
+func newServiceFixture(t *testing.T) *serviceFixture {
+	t.Helper()
+	store := servicetest.NewStore()
+	f := &serviceFixture{
+		repository:            store.Answers,
+		eventRepository:       &failingEventRepository{AnswerEventRepository: store.Events},
+		erasureRepository:     store.Erasures,
+		idempotencyRepository: store.Idempotency,
+		queueService:          &servicetest.QueueService{},
+	}
+	events := []*domain.AnswerEvent{
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John"}},
+		{EventType: domain.UpdateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam"}},
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "city", Value: "NY"}},
+	}
+	for i, event := range events {
+		event.OccurredAt = servicetest.Now.Add(time.Duration(i-10) * time.Second)
+	}
+	store.CreateEvents(t, events...)
+	store.CreateAnswers(t, &domain.Answer{Key: "name", Value: "Sam"}, &domain.Answer{Key: "city", Value: "NY"})
+	store.DeleteAnswers(t, servicetest.Now, time.Hour, "city")
+	err := f.idempotencyRepository.Reserve(&domain.IdempotencyRecord{
+		Key:           "client/CreateAnswer/1",
+		ExpiresAt:     servicetest.Now.Add(time.Hour),
+		AnswerKeyHash: testKeyHasher.Hash("name"),
+	}, servicetest.Now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.service = newBasicService(f.repository, f.eventRepository, f.erasureRepository, f.idempotencyRepository,
+		f.queueService, servicetest.EventQueueName, testKeyHasher).(*service)
+	f.service.now = servicetest.Clock
+	return f
+}
+
+func TestEraseAnswers(t *testing.T) {
+	tests := []struct {
+		name         string
+		keys         []domain.AnswerKey
+		eraseErr     error
+		wantErr      error
+		wantReceipts []*domain.ErasureReceipt
+	}{
+		{
+			name: "answers erased",
+			keys: []domain.AnswerKey{"name", "city", "country"},
+			wantReceipts: []*domain.ErasureReceipt{
+				{KeyHash: testKeyHasher.Hash("name"), ErasedAt: servicetest.Now, AnswerErased: true, EventsErased: 2, RequestedBy: testRequestedBy},
+				{KeyHash: testKeyHasher.Hash("city"), ErasedAt: servicetest.Now, AnswerErased: true, EventsErased: 1, RequestedBy: testRequestedBy},
+				{KeyHash: testKeyHasher.Hash("country"), ErasedAt: servicetest.Now, RequestedBy: testRequestedBy},
+			},
+		},
+		{
+			name:    "no keys",
+			wantErr: errs.NewErrInvalidArgument("AnswerKey required"),
+		},
+		{
+			name:    "empty key",
+			keys:    []domain.AnswerKey{"name", ""},
+			wantErr: errs.NewErrInvalidArgument("AnswerKey required"),
+		},
+		{
+			name:    "too many keys",
+			keys:    make([]domain.AnswerKey, domain.MaxPageSize+1),
+			wantErr: errs.NewErrInvalidArgument(fmt.Sprintf("At most %d keys can be erased at once", domain.MaxPageSize)),
+		},
+		{
+			name:     "history erasure failed",
+			keys:     []domain.AnswerKey{"name"},
+			eraseErr: servicetest.ErrStorage,
+			wantErr:  servicetest.ErrStorage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t)
+			f.eventRepository.eraseErr = tt.eraseErr
+			receipts, err := f.service.EraseAnswers(context.Background(), tt.keys, testRequestedBy)
+			if diff := deep.Equal(err, tt.wantErr); diff != nil {
+				t.Error(diff)
+			}
+			if diff := deep.Equal(receipts, tt.wantReceipts); diff != nil {
+				t.Error(diff)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			// No history remains, a tombstone holding the key hash is recorded and an erase event is sent.
+			//
+			var expectedMessages []servicetest.SentMessage
+			for _, key := range tt.keys {
+				history, err := f.eventRepository.ListEvents(key)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(history) != 0 {
+					t.Errorf("%v: unexpected history %v", key, history)
+				}
+				erasure, err := f.erasureRepository.Get(testKeyHasher.Hash(key))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := deep.Equal(erasure, &domain.Erasure{KeyHash: testKeyHasher.Hash(key), ErasedAt: servicetest.Now}); diff != nil {
+					t.Errorf("%v: %v", key, diff)
+				}
+				expectedMessages = append(expectedMessages, servicetest.SentMessage{
+					QueueName: servicetest.EventQueueName,
+					Message: &domain.AnswerEventMessage{
+						Event: &domain.AnswerEvent{EventType: domain.EraseAnswerEventType, Data: &domain.Answer{Key: key}, OccurredAt: servicetest.Now},
+					},
+				})
+			}
+			if diff := deep.Equal(f.queueService.Messages, expectedMessages); diff != nil {
+				t.Error(diff)
+			}
+			erased, err := f.idempotencyRepository.EraseAnswer(testKeyHasher.Hash("name"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if erased != 0 {
+				t.Errorf("%d stored responses of the answer were not erased", erased)
+			}
+
+			// The answers are gone even from the deleted ones.
+			//
+			answers, _, err := f.repository.List(domain.PageRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			deletedAnswers, _, err := f.repository.ListDeleted(domain.PageRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(answers) != 0 || len(deletedAnswers) != 0 {
+				t.Errorf("unexpected answers %v and deleted answers %v", answers, deletedAnswers)
+			}
+		})
+	}
+}
+
+func TestEraseAnswersIsRepeatable(t *testing.T) {
+	f := newServiceFixture(t)
+	if _, err := f.service.EraseAnswers(context.Background(), []domain.AnswerKey{"name"}, testRequestedBy); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing is left to erase, the later tombstone replaces the earlier one.
+	//
+	f.service.now = func() time.Time { return servicetest.Now.Add(time.Second) }
+	receipts, err := f.service.EraseAnswers(context.Background(), []domain.AnswerKey{"name"}, testRequestedBy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*domain.ErasureReceipt{{KeyHash: testKeyHasher.Hash("name"), ErasedAt: servicetest.Now.Add(time.Second), RequestedBy: testRequestedBy}}
+	if diff := deep.Equal(receipts, expected); diff != nil {
+		t.Error(diff)
+	}
+	erasure, err := f.erasureRepository.Get(testKeyHasher.Hash("name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !erasure.ErasedAt.Equal(servicetest.Now.Add(time.Second)) {
+		t.Errorf("unexpected erasure time %v", erasure.ErasedAt)
+	}
+}
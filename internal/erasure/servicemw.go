@@ -0,0 +1,48 @@
+package erasure
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+)
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(domain.AnswerErasureService) domain.AnswerErasureService
+
+// LoggingServiceMiddleware takes a logger as a dependency
+// and returns a service Middleware.
+func LoggingServiceMiddleware(logger log.Logger) Middleware {
+	return func(next domain.AnswerErasureService) domain.AnswerErasureService {
+		return loggingMiddleware{logger, next}
+	}
+}
+
+type loggingMiddleware struct {
+	logger log.Logger
+	next   domain.AnswerErasureService
+}
+
+// EraseAnswers - logs an audit record, the key hashes and their counts but never the keys or the values.
+func (mw loggingMiddleware) EraseAnswers(ctx context.Context, keys []domain.AnswerKey, requestedBy string) (receipts []*domain.ErasureReceipt, err error) {
+	defer func() {
+		for _, receipt := range receipts {
+			_ = mw.logger.Log("method", "EraseAnswers",
+				"audit", true,
+				"keyHash", receipt.KeyHash,
+				"erasedAt", receipt.ErasedAt,
+				"answerErased", receipt.AnswerErased,
+				"eventsErased", receipt.EventsErased,
+				"requestedBy", receipt.RequestedBy,
+			)
+		}
+		_ = mw.logger.Log("method", "EraseAnswers",
+			"requestedBy", requestedBy,
+			"keys", len(keys),
+			"erased", len(receipts),
+			"err", err,
+		)
+	}()
+	return mw.next.EraseAnswers(ctx, keys, requestedBy)
+}
@@ -1,8 +1,10 @@
 package error
 
 import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // GRPCErrorEncoder error encoder
@@ -10,7 +12,7 @@ func GRPCErrorEncoder(err error) error {
 	if err == nil {
 		return err
 	}
-	switch err.(type) {
+	switch e := err.(type) {
 	case *ErrInvalidArgument:
 		return status.Error(codes.InvalidArgument, err.Error())
 	case *ErrAlreadyExist:
@@ -25,6 +27,19 @@ func GRPCErrorEncoder(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case *ErrPermissionDenied:
 		return status.Error(codes.PermissionDenied, err.Error())
+	case *ErrAborted:
+		return status.Error(codes.Aborted, err.Error())
+	case *ErrResourceExhausted:
+
+		// The clients learn when to retry from the details.
+		//
+		st, detailsErr := status.New(codes.ResourceExhausted, err.Error()).WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(e.RetryAfter),
+		})
+		if detailsErr != nil {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return st.Err()
 	default:
 		return status.Error(codes.Unknown, err.Error())
 	}
@@ -1,5 +1,7 @@
 package error
 
+import "time"
+
 // ErrInvalidArgument - invalid argument
 type ErrInvalidArgument struct {
 	Msg string
@@ -35,6 +37,17 @@ type ErrPermissionDenied struct {
 	Msg string
 }
 
+// ErrAborted - aborted operation, it may be retried.
+type ErrAborted struct {
+	Msg string
+}
+
+// ErrResourceExhausted - too many requests, they may be retried after a while.
+type ErrResourceExhausted struct {
+	Msg        string
+	RetryAfter time.Duration
+}
+
 // NewErrInvalidArgument creates a new error.
 func NewErrInvalidArgument(msg string) error {
 	return &ErrInvalidArgument{msg}
@@ -70,6 +83,16 @@ func NewErrPermissionDenied(msg string) error {
 	return &ErrPermissionDenied{msg}
 }
 
+// NewErrAborted aborted operation.
+func NewErrAborted(msg string) error {
+	return &ErrAborted{msg}
+}
+
+// NewErrResourceExhausted too many requests.
+func NewErrResourceExhausted(msg string, retryAfter time.Duration) error {
+	return &ErrResourceExhausted{msg, retryAfter}
+}
+
 func (e *ErrInvalidArgument) Error() string {
 	return e.Msg
 }
@@ -97,3 +120,11 @@ func (e *ErrUnauthorized) Error() string {
 func (e *ErrPermissionDenied) Error() string {
 	return e.Msg
 }
+
+func (e *ErrAborted) Error() string {
+	return e.Msg
+}
+
+func (e *ErrResourceExhausted) Error() string {
+	return e.Msg
+}
@@ -0,0 +1,101 @@
+package helpers
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/config"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	kitjwt "github.com/go-kit/kit/auth/jwt"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Claims - the claims of the token of a client, the subject identifies the client.
+type Claims struct {
+	jwt.StandardClaims
+	// Admin - the client may call the methods of the admin API.
+	Admin bool `json:"admin,omitempty"`
+}
+
+// Authentication - verifies the bearer tokens of the clients, the claims of a verified token are passed on
+// in the context. A nil Authentication leaves the endpoints as they are, the clients are then anonymous.
+type Authentication struct {
+	parser endpoint.Middleware
+}
+
+// NewAuthentication - returns the authentication of the configured secret, or nil if the tokens are not verified.
+func NewAuthentication(cfg *config.Config) *Authentication {
+	if len(cfg.Auth.JWTSecret) == 0 {
+		return nil
+	}
+	secret := []byte(cfg.Auth.JWTSecret)
+	keyFunc := func(*jwt.Token) (interface{}, error) { return secret, nil }
+	return &Authentication{
+		parser: kitjwt.NewParser(keyFunc, jwt.SigningMethodHS256, func() jwt.Claims { return &Claims{} }),
+	}
+}
+
+// Middleware - returns the middleware verifying the token of a request, a request without a token is anonymous.
+// A token which cannot be verified or has no subject fails with ErrUnauthorized.
+func (a *Authentication) Middleware() endpoint.Middleware {
+	if a == nil {
+		return func(next endpoint.Endpoint) endpoint.Endpoint { return next }
+	}
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if token, _ := ctx.Value(kitjwt.JWTContextKey).(string); len(token) == 0 {
+				return next(ctx, request)
+			}
+
+			// The parser only returns the errors of the token, the endpoint is called with the context
+			// of the verified claims afterwards.
+			//
+			var verified context.Context
+			_, err := a.parser(func(ctx context.Context, _ interface{}) (interface{}, error) {
+				verified = ctx
+				return nil, nil
+			})(ctx, request)
+			if err != nil {
+				return nil, errors.NewErrUnauthorized("Token cannot be verified")
+			}
+			if len(VerifiedSubject(verified)) == 0 {
+				return nil, errors.NewErrUnauthorized("Token has no subject")
+			}
+			return next(verified, request)
+		}
+	}
+}
+
+// RequireAdmin - returns the middleware of the admin methods, they must be called by a verified client with the admin
+// claim. It runs after the middleware of the authentication, an anonymous client fails with ErrUnauthorized and a client
+// without the claim with ErrPermissionDenied.
+func RequireAdmin() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			claims := verifiedClaims(ctx)
+			if claims == nil {
+				return nil, errors.NewErrUnauthorized("Admin methods require an authenticated client")
+			}
+			if !claims.Admin {
+				return nil, errors.NewErrPermissionDenied("Admin methods require the admin claim")
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// VerifiedSubject - returns the subject of the verified token of the request, empty if the client is anonymous.
+func VerifiedSubject(ctx context.Context) string {
+	claims := verifiedClaims(ctx)
+	if claims == nil {
+		return ""
+	}
+	return claims.Subject
+}
+
+// verifiedClaims - returns the claims of the verified token of the request, nil if the client is anonymous.
+func verifiedClaims(ctx context.Context) *Claims {
+	claims, _ := ctx.Value(kitjwt.JWTClaimsContextKey).(*Claims)
+	return claims
+}
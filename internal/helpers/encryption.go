@@ -0,0 +1,31 @@
+package helpers
+
+import (
+	"fmt"
+
+	"dochq.co.uk.answerservice/internal/config"
+	"dochq.co.uk.answerservice/internal/encryption"
+
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+// NewValueCipher - sets up the answer value encryption with the configured key provider.
+// It returns nil if the values are not encrypted.
+func NewValueCipher(cfg *config.Config) (*encryption.ValueCipher, error) {
+	var keyProvider encryption.KeyProvider
+	switch cfg.Encryption.KeyProvider {
+	case config.NoKeyProvider:
+		return nil, nil
+	case config.LocalKeyProvider:
+		var err error
+		keyProvider, err = encryption.LoadLocalKeyProvider(cfg.Encryption.LocalKeyFile)
+		if err != nil {
+			return nil, err
+		}
+	case config.KMSKeyProvider:
+		keyProvider = encryption.NewKMSKeyProvider(kms.New(GetAwsSession(cfg.AWS)), cfg.Encryption.KMSKeyID)
+	default:
+		return nil, fmt.Errorf("unsupported key provider %q", cfg.Encryption.KeyProvider)
+	}
+	return encryption.NewValueCipher(keyProvider, cfg.Encryption.DataKeyLifetimePeriod()), nil
+}
@@ -26,9 +26,12 @@ func MethodLogger(logger log.Logger, s string) endpoint.Middleware {
 	return LoggingEndpointMiddleware(log.With(logger, "method", s))
 }
 
-// SetupEndpoint - setup endpoint.
-func SetupEndpoint(handler endpoint.Endpoint, logger log.Logger, serviceName, methodName string) endpoint.Endpoint {
+// SetupEndpoint - setup endpoint, the tokens of the requests are verified unless authentication is nil and
+// the requests of the method are rate limited unless rateLimit is nil.
+func SetupEndpoint(handler endpoint.Endpoint, authentication *Authentication, rateLimit *RateLimit, logger log.Logger, serviceName, methodName string) endpoint.Endpoint {
 	result := createMiddleware()(handler)
+	result = rateLimit.Middleware(methodName)(result)
+	result = authentication.Middleware()(result)
 	result = MethodLogger(logger, methodName)(result)
 	return result
 }
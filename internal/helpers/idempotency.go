@@ -0,0 +1,197 @@
+package helpers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"github.com/nats-io/nuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// IdempotencyKeyHeader - the HTTP header of the idempotency key, the gRPC metadata is its lower case name.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyContextKey struct{}
+
+// IdempotencyKeyToContext - moves the idempotency key of the gRPC metadata to the context.
+// The gateway forwards the HTTP header as the metadata, see IncomingHeaderMatcher.
+func IdempotencyKeyToContext() func(ctx context.Context, md metadata.MD) context.Context {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		values := md.Get(IdempotencyKeyHeader)
+		if len(values) == 0 {
+			return ctx
+		}
+		return context.WithValue(ctx, idempotencyKeyContextKey{}, values[0])
+	}
+}
+
+// answerKeyRequest - a request changing an answer, its stored response is erased with the answer.
+type answerKeyRequest interface {
+	AnswerKey() domain.AnswerKey
+}
+
+// Idempotency - replays the response of a request retried with the same idempotency key.
+// A nil Idempotency leaves the endpoints as they are.
+type Idempotency struct {
+	repository       domain.IdempotencyRepository
+	retention        time.Duration
+	lease            time.Duration
+	keyHasher        *domain.AnswerKeyHasher
+	verified         bool
+	logger           log.Logger
+	now              func() time.Time
+	newReservationID func() string
+}
+
+// NewIdempotency - returns the idempotency of the endpoints, the keys are kept for the retention period
+// and the key of a request in progress is reserved for the lease period. The records hold the hash of the changed answer key.
+// The keys are scoped by the verified clients of the authentication, or shared by all the clients if authentication is nil.
+func NewIdempotency(repository domain.IdempotencyRepository, retention, lease time.Duration, keyHasher *domain.AnswerKeyHasher,
+	authentication *Authentication, logger log.Logger) *Idempotency {
+	return &Idempotency{
+		repository:       repository,
+		retention:        retention,
+		lease:            lease,
+		keyHasher:        keyHasher,
+		verified:         authentication != nil,
+		logger:           logger,
+		now:              time.Now,
+		newReservationID: nuid.Next,
+	}
+}
+
+// Middleware - returns the middleware of the method, the response is a value of the type returned by the endpoint.
+//
+// The keys are scoped by the method and the verified subject of the client, an anonymous client cannot send a key.
+// Without the authentication, the clients are not told apart and the keys are only scoped by the method.
+// The first request with a key reserves it, a successful response is stored and replayed for a retry of
+// the same request by the same client. A failed request releases the key, so it may be retried. A retry with
+// another request fails with an invalid argument and one made while the request is in progress is aborted,
+// unless the lease of the reservation has expired, then the retry takes it over and makes the request again.
+func (i *Idempotency) Middleware(methodName string, response interface{}) endpoint.Middleware {
+	if i == nil {
+		return func(next endpoint.Endpoint) endpoint.Endpoint { return next }
+	}
+	responseType := reflect.TypeOf(response)
+	logger := log.With(i.logger, "method", methodName)
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+			if len(key) == 0 {
+				return next(ctx, request)
+			}
+			if len(key) > domain.MaxIdempotencyKeyLength {
+				return nil, errors.NewErrInvalidArgument(fmt.Sprintf("%s must be at most %d characters", IdempotencyKeyHeader, domain.MaxIdempotencyKeyLength))
+			}
+			subject := VerifiedSubject(ctx)
+			if i.verified && len(subject) == 0 {
+				return nil, errors.NewErrUnauthorized(fmt.Sprintf("%s requires an authenticated client", IdempotencyKeyHeader))
+			}
+			fingerprint, err := idempotencyFingerprint(request)
+			if err != nil {
+				return nil, err
+			}
+
+			// Reserve the key, a retry finds the record of the first request.
+			//
+			now := i.now()
+			record := &domain.IdempotencyRecord{
+				Key:            idempotencyRecordKey(methodName, subject, key),
+				Fingerprint:    fingerprint,
+				ExpiresAt:      now.Add(i.retention),
+				ReservationID:  i.newReservationID(),
+				LeaseExpiresAt: now.Add(i.lease),
+			}
+			if req, ok := request.(answerKeyRequest); ok {
+				record.AnswerKeyHash = i.keyHasher.Hash(req.AnswerKey())
+			}
+			err = i.repository.Reserve(record, now)
+			if _, ok := err.(*errors.ErrAlreadyExist); ok {
+				return i.replay(record, now, responseType)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			// Call the endpoint, the key is released if it fails.
+			//
+			resp, err := next(ctx, request)
+			if err == nil {
+				if failer, ok := resp.(endpoint.Failer); ok {
+					err = failer.Failed()
+				}
+			}
+			if err != nil {
+				if releaseErr := i.repository.Release(record.Key, record.ReservationID); releaseErr != nil {
+					_ = logger.Log("idempotency_release_error", releaseErr)
+				}
+				return resp, err
+			}
+
+			// Store the response. If it cannot be stored, the response is still returned as the change was made,
+			// a retry is then aborted until the lease expires and takes the key over.
+			//
+			encoded, err := json.Marshal(resp)
+			if err != nil {
+				_ = logger.Log("idempotency_complete_error", err)
+				return resp, nil
+			}
+			record.Response = string(encoded)
+			record.LeaseExpiresAt = time.Time{}
+			if err := i.repository.Complete(record); err != nil {
+				_ = logger.Log("idempotency_complete_error", err)
+			}
+			return resp, nil
+		}
+	}
+}
+
+// replay - returns the stored response of the first request with the key.
+func (i *Idempotency) replay(record *domain.IdempotencyRecord, now time.Time, responseType reflect.Type) (interface{}, error) {
+	stored, err := i.repository.Get(record.Key, now)
+	if _, ok := err.(*errors.ErrNotFound); ok {
+		return nil, errors.NewErrAborted("Request with the idempotency key is in progress")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stored.Fingerprint != record.Fingerprint {
+		return nil, errors.NewErrInvalidArgument("Idempotency key was used with another request")
+	}
+	if !stored.IsComplete() {
+		return nil, errors.NewErrAborted("Request with the idempotency key is in progress")
+	}
+	resp := reflect.New(responseType)
+	if err := json.Unmarshal([]byte(stored.Response), resp.Interface()); err != nil {
+		return nil, errors.NewErrInternal(fmt.Sprintf("Stored response is malformed: %s", err))
+	}
+	return resp.Elem().Interface(), nil
+}
+
+// idempotencyRecordKey - scopes the key by the method and the verified subject of the client, empty without the
+// authentication. The parts are hashed, so the subjects are not stored.
+func idempotencyRecordKey(methodName, subject, key string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{methodName, subject, key}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// idempotencyFingerprint - returns the hash of the request.
+func idempotencyFingerprint(request interface{}) (string, error) {
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return "", errors.NewErrInternal(fmt.Sprintf("Request cannot be encoded: %s", err))
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,179 @@
+package helpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/inmemory"
+
+	kitjwt "github.com/go-kit/kit/auth/jwt"
+	"github.com/go-kit/log"
+	"github.com/go-test/deep"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	testIdempotencyRetention = time.Hour
+	testIdempotencyLease     = time.Minute
+)
+
+type testIdempotencyRequest struct {
+	Value string
+}
+
+type testIdempotencyResponse struct {
+	Call int
+}
+
+// newTestIdempotency - returns the idempotency of an in-memory repository and the clock it reads.
+func newTestIdempotency() (*Idempotency, *time.Time) {
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	idempotency := NewIdempotency(inmemory.NewIdempotencyRepository(), testIdempotencyRetention, testIdempotencyLease,
+		domain.NewAnswerKeyHasher(""), &Authentication{}, log.NewNopLogger())
+	idempotency.now = func() time.Time { return now }
+	return idempotency, &now
+}
+
+// newTestIdempotencyContext - returns the context of a request of the verified client with the idempotency key.
+func newTestIdempotencyContext(key string) context.Context {
+	ctx := context.WithValue(context.Background(), kitjwt.JWTClaimsContextKey, &Claims{StandardClaims: jwt.StandardClaims{Subject: "client"}})
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+func TestIdempotencyAbortsRetryInProgress(t *testing.T) {
+	idempotency, _ := newTestIdempotency()
+	ctx := newTestIdempotencyContext("key-1")
+	request := testIdempotencyRequest{Value: "John"}
+
+	// The retry is made while the first request is in progress.
+	//
+	var calls int
+	var endpoint func(ctx context.Context, request interface{}) (interface{}, error)
+	var retryErr error
+	endpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			_, retryErr = idempotency.Middleware("Create", testIdempotencyResponse{})(endpoint)(ctx, request)
+		}
+		return testIdempotencyResponse{Call: calls}, nil
+	}
+	resp, err := idempotency.Middleware("Create", testIdempotencyResponse{})(endpoint)(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if _, ok := retryErr.(*errors.ErrAborted); !ok {
+		t.Errorf("expected aborted retry, got %v", retryErr)
+	}
+
+	// A retry after the first request replays its response.
+	//
+	replayed, err := idempotency.Middleware("Create", testIdempotencyResponse{})(endpoint)(ctx, request)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(replayed, resp); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal(calls, 1); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestIdempotencyTakesOverExpiredLease(t *testing.T) {
+	tests := []struct {
+		name      string
+		retryIn   time.Duration
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "lease not expired", retryIn: testIdempotencyLease - time.Second, wantCalls: 1, wantErr: true},
+		{name: "lease expired", retryIn: testIdempotencyLease, wantCalls: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idempotency, now := newTestIdempotency()
+			ctx := newTestIdempotencyContext("key-1")
+			request := testIdempotencyRequest{Value: "John"}
+
+			// The first request neither fails nor completes while the retry is made, as if its replica stopped.
+			//
+			var calls int
+			var endpoint func(ctx context.Context, request interface{}) (interface{}, error)
+			var retried interface{}
+			var retryErr error
+			endpoint = func(ctx context.Context, request interface{}) (interface{}, error) {
+				calls++
+				if calls == 1 {
+					*now = now.Add(tt.retryIn)
+					retried, retryErr = idempotency.Middleware("Create", testIdempotencyResponse{})(endpoint)(ctx, request)
+				}
+				return testIdempotencyResponse{Call: calls}, nil
+			}
+			if _, err := idempotency.Middleware("Create", testIdempotencyResponse{})(endpoint)(ctx, request); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if _, aborted := retryErr.(*errors.ErrAborted); aborted != tt.wantErr {
+				t.Errorf("expected aborted retry %v, got %v", tt.wantErr, retryErr)
+			}
+			if diff := deep.Equal(calls, tt.wantCalls); diff != nil {
+				t.Error(diff)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// The response of the retry which took the key over is replayed, the first request lost its reservation.
+			//
+			replayed, err := idempotency.Middleware("Create", testIdempotencyResponse{})(endpoint)(ctx, request)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if diff := deep.Equal(replayed, retried); diff != nil {
+				t.Error(diff)
+			}
+			if diff := deep.Equal(replayed, testIdempotencyResponse{Call: 2}); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestIdempotencyWithoutAuthentication(t *testing.T) {
+	tests := []struct {
+		name           string
+		authentication *Authentication
+		wantCalls      int
+		wantErr        bool
+	}{
+		{name: "anonymous client is rejected", authentication: &Authentication{}, wantErr: true},
+		{name: "key scoped by the method", wantCalls: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idempotency := NewIdempotency(inmemory.NewIdempotencyRepository(), testIdempotencyRetention, testIdempotencyLease,
+				domain.NewAnswerKeyHasher(""), tt.authentication, log.NewNopLogger())
+			ctx := context.WithValue(context.Background(), idempotencyKeyContextKey{}, "key-1")
+			var calls int
+			endpoint := func(ctx context.Context, request interface{}) (interface{}, error) {
+				calls++
+				return testIdempotencyResponse{Call: calls}, nil
+			}
+
+			// The request is retried once.
+			//
+			var err error
+			for n := 0; n < 2 && err == nil; n++ {
+				_, err = idempotency.Middleware("Create", testIdempotencyResponse{})(endpoint)(ctx, testIdempotencyRequest{Value: "John"})
+			}
+			if _, unauthorized := err.(*errors.ErrUnauthorized); unauthorized != tt.wantErr {
+				t.Errorf("expected unauthorized %v, got %v", tt.wantErr, err)
+			}
+			if diff := deep.Equal(calls, tt.wantCalls); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package helpers
+
+import (
+	"dochq.co.uk.answerservice/internal/config"
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/redaction"
+
+	kitzapadapter "github.com/go-kit/kit/log/zap"
+	"github.com/go-kit/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewLogger - returns the logger of the applications, the answers are redacted if a redactor is given.
+func NewLogger(zapLogger *zap.Logger, redactor *redaction.Redactor) log.Logger {
+	var logger log.Logger
+	logger = kitzapadapter.NewZapSugarLogger(zapLogger, zapcore.InfoLevel)
+	if redactor != nil {
+		logger = redaction.NewLogger(logger, redactor)
+	}
+	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
+	logger = log.With(logger, "caller", log.DefaultCaller)
+	return logger
+}
+
+// NewRedactor - returns the redactor of the configured policies.
+func NewRedactor(cfg *config.Config) (*redaction.Redactor, error) {
+	return redaction.NewRedactor(
+		redaction.Policy(cfg.Redaction.ValuePolicy),
+		redaction.Policy(cfg.Redaction.KeyPolicy),
+		cfg.Redaction.SensitiveKeyPatterns,
+		cfg.Redaction.HashSalt,
+	)
+}
+
+// NewAnswerKeyHasher - returns the hasher of the answer keys, it is keyed by the salt of the redaction.
+func NewAnswerKeyHasher(cfg *config.Config) *domain.AnswerKeyHasher {
+	return domain.NewAnswerKeyHasher(cfg.Redaction.HashSalt)
+}
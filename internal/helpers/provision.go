@@ -0,0 +1,113 @@
+package helpers
+
+import (
+	"database/sql"
+
+	"dochq.co.uk.answerservice/internal/config"
+	pkgDynamodb "dochq.co.uk.answerservice/internal/dynamodb"
+	"dochq.co.uk.answerservice/internal/postgres"
+	"dochq.co.uk.answerservice/internal/snstopic"
+	"dochq.co.uk.answerservice/internal/sqsqueue"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/go-kit/log"
+)
+
+// Provision - creates or updates the tables and the queues required by the configured backends.
+// It is idempotent, so it can be run on every deployment.
+func Provision(cfg *config.Config, logger log.Logger) error {
+
+	// Provision storage.
+	//
+	switch cfg.Storage.Backend {
+	case config.DynamoDBStorageBackend:
+		db := dynamodb.New(GetAwsSession(cfg.AWS))
+		schemas := []*dynamodb.CreateTableInput{
+			pkgDynamodb.AnswerTableSchema(cfg.Storage.AnswerTableName),
+			pkgDynamodb.AnswerEventTableSchema(cfg.Storage.AnswerEventTableName),
+			pkgDynamodb.WebhookSubscriptionTableSchema(cfg.Storage.WebhookSubscriptionTableName),
+			pkgDynamodb.WebhookDeliveryTableSchema(cfg.Storage.WebhookDeliveryTableName),
+			pkgDynamodb.IdempotencyTableSchema(cfg.Storage.IdempotencyTableName),
+			pkgDynamodb.ErasureTableSchema(cfg.Storage.ErasureTableName),
+		}
+		ttlTableNames := []string{cfg.Storage.AnswerTableName, cfg.Storage.IdempotencyTableName}
+		if cfg.RateLimit.Backend == config.StorageRateLimitBackend {
+			schemas = append(schemas, pkgDynamodb.RateLimitTableSchema(cfg.Storage.RateLimitTableName))
+			ttlTableNames = append(ttlTableNames, cfg.Storage.RateLimitTableName)
+		}
+		for _, schema := range schemas {
+			if err := pkgDynamodb.ProvisionTable(db, schema); err != nil {
+				return err
+			}
+			_ = logger.Log("storage", cfg.Storage.Backend, "table", *schema.TableName, "status", "provisioned")
+		}
+		for _, tableName := range ttlTableNames {
+			if err := pkgDynamodb.ProvisionTimeToLive(db, tableName, pkgDynamodb.AttributeExpiresAt); err != nil {
+				return err
+			}
+			_ = logger.Log("storage", cfg.Storage.Backend, "table", tableName, "ttl", pkgDynamodb.AttributeExpiresAt)
+		}
+		if legacyTableName := cfg.Storage.LegacyAnswerEventTableName; len(legacyTableName) > 0 {
+			migrated, err := pkgDynamodb.MigrateLegacyAnswerEvents(db, legacyTableName, cfg.Storage.AnswerEventTableName)
+			if err != nil {
+				return err
+			}
+			_ = logger.Log("storage", cfg.Storage.Backend, "table", cfg.Storage.AnswerEventTableName, "legacyTable", legacyTableName, "migrated", migrated)
+		}
+		if cfg.Queue.AnswerEventSource == config.StreamEventSource {
+			if err := pkgDynamodb.ProvisionStream(db, cfg.Storage.AnswerTableName); err != nil {
+				return err
+			}
+			_ = logger.Log("storage", cfg.Storage.Backend, "table", cfg.Storage.AnswerTableName, "stream", dynamodb.StreamViewTypeNewAndOldImages)
+		}
+	case config.PostgresStorageBackend:
+		db, err := sql.Open(postgres.DriverName, cfg.Storage.PostgresDSN)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		if err := postgres.Migrate(db); err != nil {
+			return err
+		}
+		_ = logger.Log("storage", cfg.Storage.Backend, "status", "migrated")
+	default:
+		_ = logger.Log("storage", cfg.Storage.Backend, "status", "nothing to provision")
+	}
+
+	// Provision queue.
+	//
+	switch cfg.Queue.Backend {
+	case config.SQSQueueBackend:
+		sqsClient := sqs.New(GetAwsSession(cfg.AWS))
+		topicName := cfg.Queue.AnswerEventTopicName
+		if len(topicName) == 0 {
+			_, err := sqsqueue.ProvisionSQSQueue(sqsClient, cfg.Queue.AnswerEventQueueName, cfg.Queue.SQS.VisibilityTimeout)
+			if err != nil {
+				return err
+			}
+			_ = logger.Log("queue", cfg.Queue.Backend, "name", cfg.Queue.AnswerEventQueueName, "status", "provisioned")
+			break
+		}
+
+		// Provision the topic and its subscription queues.
+		//
+		snsClient := sns.New(GetAwsSession(cfg.AWS))
+		topicARN, err := snstopic.ProvisionSNSTopic(snsClient, topicName)
+		if err != nil {
+			return err
+		}
+		_ = logger.Log("topic", topicName, "status", "provisioned")
+		for _, subscription := range TopicSubscriptions(cfg) {
+			_, err := snstopic.ProvisionSNSSubscription(snsClient, sqsClient, topicARN, subscription, cfg.Queue.SQS.VisibilityTimeout)
+			if err != nil {
+				return err
+			}
+			_ = logger.Log("queue", cfg.Queue.Backend, "name", subscription.QueueName, "topic", topicName, "status", "provisioned")
+		}
+	default:
+		_ = logger.Log("queue", cfg.Queue.Backend, "status", "nothing to provision")
+	}
+	return nil
+}
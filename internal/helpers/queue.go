@@ -0,0 +1,108 @@
+package helpers
+
+import (
+	"fmt"
+
+	"dochq.co.uk.answerservice/internal/config"
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/encryption"
+	"dochq.co.uk.answerservice/internal/memqueue"
+	"dochq.co.uk.answerservice/internal/natsqueue"
+	"dochq.co.uk.answerservice/internal/queue"
+	"dochq.co.uk.answerservice/internal/snstopic"
+	"dochq.co.uk.answerservice/internal/sqsqueue"
+	"dochq.co.uk.answerservice/internal/topic"
+
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/go-kit/log"
+	"github.com/nats-io/nats.go"
+)
+
+// Queue - provides access to the configured queue backend.
+type Queue struct {
+	Backend  config.QueueBackend
+	Service  domain.QueueService
+	Consumer domain.QueueConsumer
+	close    func()
+}
+
+// Close - releases the backend connections.
+func (q *Queue) Close() {
+	if q.close != nil {
+		q.close()
+	}
+}
+
+// NewQueue - sets up the queue service and the queue consumer of the configured backend.
+// If the answer event topic is configured, the queue service publishes to the topic named as the queue:
+// SNS fans the messages out with the sqs backend, the queue service itself with the other backends.
+// The answer values of the messages are encrypted if a key provider is configured.
+func NewQueue(cfg *config.Config, logger log.Logger) (*Queue, error) {
+	cipher, err := NewValueCipher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	q := &Queue{Backend: cfg.Queue.Backend}
+	switch q.Backend {
+	case config.SQSQueueBackend:
+		// The queue is created by the provision command.
+		//
+		sqsClient := sqs.New(GetAwsSession(cfg.AWS))
+		if _, err := sqsqueue.GetSQSQueueURL(sqsClient, cfg.Queue.AnswerEventQueueName); err != nil {
+			return nil, err
+		}
+		q.Service = sqsqueue.NewQueueService(sqsClient)
+		q.Consumer = sqsqueue.NewQueueConsumer(sqsClient, cfg.Queue.SQS.MaxNumberOfMessages, logger)
+	case config.MemoryQueueBackend:
+		broker := memqueue.NewBroker(logger)
+		q.Service = broker
+		q.Consumer = broker
+	case config.NATSQueueBackend:
+		conn, err := nats.Connect(cfg.Queue.NATSURL)
+		if err != nil {
+			return nil, err
+		}
+		q.Service = natsqueue.NewQueueService(conn)
+		q.Consumer = natsqueue.NewQueueConsumer(conn, logger)
+		q.close = conn.Close
+	default:
+		return nil, fmt.Errorf("unsupported queue backend %q", q.Backend)
+	}
+	if topicName := cfg.Queue.AnswerEventTopicName; len(topicName) > 0 {
+		var topicService domain.TopicService
+		if q.Backend == config.SQSQueueBackend {
+			// The topic is created by the provision command.
+			//
+			snsClient := sns.New(GetAwsSession(cfg.AWS))
+			if _, err := snstopic.GetSNSTopicARN(snsClient, topicName); err != nil {
+				q.Close()
+				return nil, err
+			}
+			topicService = snstopic.NewTopicService(snsClient)
+		} else {
+			topicService = topic.NewFanOutService(q.Service, map[string][]domain.TopicSubscription{
+				topicName: TopicSubscriptions(cfg),
+			})
+		}
+		q.Service = topic.NewQueueService(topicService)
+	}
+	if cipher != nil {
+		q.Service = encryption.NewQueueService(q.Service, cipher)
+		q.Consumer = encryption.NewQueueConsumer(q.Consumer, cipher)
+	}
+	q.Service = queue.LoggingServiceMiddleware(logger)(q.Service)
+	return q, nil
+}
+
+// TopicSubscriptions - returns the configured subscription queues of the answer event topic.
+func TopicSubscriptions(cfg *config.Config) []domain.TopicSubscription {
+	var subscriptions []domain.TopicSubscription
+	for _, subscription := range cfg.Queue.TopicSubscriptions() {
+		subscriptions = append(subscriptions, domain.TopicSubscription{
+			QueueName:    subscription.QueueName,
+			FilterPolicy: domain.FilterPolicy(subscription.FilterPolicy),
+		})
+	}
+	return subscriptions
+}
@@ -0,0 +1,162 @@
+package helpers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/config"
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/inmemory"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// RetryAfterHeader - the HTTP header of the seconds a rate limited client should wait, the gRPC metadata
+// is its lower case name.
+const RetryAfterHeader = "Retry-After"
+
+// forwardedForMetadata - the addresses the request was forwarded from, the gateway appends the HTTP client address.
+const forwardedForMetadata = "x-forwarded-for"
+
+type clientAddressesContextKey struct{}
+
+// ClientAddressesToContext - moves the addresses the request passed through to the context, the first
+// is the address of the origin and the last one the address of the gRPC peer.
+func ClientAddressesToContext() func(ctx context.Context, md metadata.MD) context.Context {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		var addresses []string
+		for _, forwarded := range md.Get(forwardedForMetadata) {
+			for _, address := range strings.Split(forwarded, ",") {
+				addresses = append(addresses, strings.TrimSpace(address))
+			}
+		}
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			address := p.Addr.String()
+			if host, _, err := net.SplitHostPort(address); err == nil {
+				address = host
+			}
+			addresses = append(addresses, address)
+		}
+		return context.WithValue(ctx, clientAddressesContextKey{}, addresses)
+	}
+}
+
+// RateLimit - limits the requests of every client to each method by a token bucket.
+// A nil RateLimit leaves the endpoints as they are.
+type RateLimit struct {
+	limiter        domain.RateLimiter
+	defaultLimit   domain.RateLimit
+	methodLimits   map[string]*domain.RateLimit
+	trustedProxies int
+	logger         log.Logger
+	now            func() time.Time
+}
+
+// NewRateLimit - returns the rate limit of the configured backend, or nil if the requests are not limited.
+// The storage provides the buckets shared by the replicas.
+func NewRateLimit(cfg *config.Config, storage *Storage, logger log.Logger) *RateLimit {
+	var limiter domain.RateLimiter
+	switch cfg.RateLimit.Backend {
+	case config.MemoryRateLimitBackend:
+		limiter = inmemory.NewRateLimiter()
+	case config.StorageRateLimitBackend:
+		limiter = storage.RateLimiter
+	default:
+		return nil
+	}
+	r := &RateLimit{
+		limiter:        limiter,
+		defaultLimit:   requestsPerMinute(cfg.RateLimit.RequestsPerMinute, cfg.RateLimit.Burst),
+		methodLimits:   make(map[string]*domain.RateLimit),
+		trustedProxies: int(cfg.RateLimit.TrustedProxies),
+		logger:         logger,
+		now:            time.Now,
+	}
+	for _, method := range cfg.RateLimit.Methods {
+		if method.RequestsPerMinute == 0 {
+			r.methodLimits[method.Method] = nil
+			continue
+		}
+		burst := method.Burst
+		if burst == 0 {
+			burst = cfg.RateLimit.Burst
+		}
+		limit := requestsPerMinute(method.RequestsPerMinute, burst)
+		r.methodLimits[method.Method] = &limit
+	}
+	return r
+}
+
+// Middleware - returns the middleware of the method, a client whose bucket is empty gets ErrResourceExhausted.
+// The limiter fails open: if the buckets cannot be reached, the error is logged and the requests are let through,
+// so an outage of the storage backend of the buckets does not stop the APIs, though it lifts the limits meanwhile.
+func (r *RateLimit) Middleware(methodName string) endpoint.Middleware {
+	limit, ok := r.limit(methodName)
+	if !ok {
+		return func(next endpoint.Endpoint) endpoint.Endpoint { return next }
+	}
+	logger := log.With(r.logger, "method", methodName)
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			allowed, retryAfter, err := r.limiter.Take(r.bucketKey(ctx, methodName), limit, r.now())
+			if err != nil {
+				_ = logger.Log("rate_limit_error", err)
+				return next(ctx, request)
+			}
+			if !allowed {
+				return nil, errors.NewErrResourceExhausted(fmt.Sprintf("Rate limit of %s exceeded", methodName), retryAfter)
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// limit - returns the limit of the method, false if it is not limited.
+func (r *RateLimit) limit(methodName string) (domain.RateLimit, bool) {
+	if r == nil {
+		return domain.RateLimit{}, false
+	}
+	limit, ok := r.methodLimits[methodName]
+	if !ok {
+		return r.defaultLimit, true
+	}
+	if limit == nil {
+		return domain.RateLimit{}, false
+	}
+	return *limit, true
+}
+
+// bucketKey - returns the bucket of the client for the method. A client is identified by the verified subject of its
+// token, or else by the address the nearest untrusted hop sent the request from. A token which is not verified is
+// ignored, a client could get a new bucket with every made-up token otherwise. The parts are hashed, so the subjects
+// are not stored.
+func (r *RateLimit) bucketKey(ctx context.Context, methodName string) string {
+	client := ""
+	if subject := VerifiedSubject(ctx); len(subject) > 0 {
+		client = "subject:" + subject
+	} else {
+		addresses, _ := ctx.Value(clientAddressesContextKey{}).([]string)
+		if i := len(addresses) - 1 - r.trustedProxies; len(addresses) > 0 {
+			if i < 0 {
+				i = 0
+			}
+			client = "address:" + addresses[i]
+		}
+	}
+	sum := sha256.Sum256([]byte(methodName + "\x00" + client))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestsPerMinute - returns the token bucket of the requests per minute.
+func requestsPerMinute(requests, burst int64) domain.RateLimit {
+	return domain.RateLimit{Rate: float64(requests) / 60, Burst: burst}
+}
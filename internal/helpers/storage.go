@@ -0,0 +1,126 @@
+package helpers
+
+import (
+	"database/sql"
+	"fmt"
+
+	"dochq.co.uk.answerservice/internal/config"
+	"dochq.co.uk.answerservice/internal/domain"
+	pkgDynamodb "dochq.co.uk.answerservice/internal/dynamodb"
+	"dochq.co.uk.answerservice/internal/encryption"
+	"dochq.co.uk.answerservice/internal/inmemory"
+	"dochq.co.uk.answerservice/internal/postgres"
+
+	"github.com/go-kit/log"
+)
+
+// Storage - provides access to the repositories of the configured storage backend.
+type Storage struct {
+	Backend               config.StorageBackend
+	AnswerRepository      domain.AnswerRepository
+	AnswerEventRepository domain.AnswerEventRepository
+	WebhookRepository     domain.WebhookRepository
+	// IdempotencyRepository - the requests made with an idempotency key and their responses.
+	IdempotencyRepository domain.IdempotencyRepository
+	// ErasureRepository - the tombstones of the erased answer keys.
+	ErasureRepository domain.ErasureRepository
+	// RateLimiter - the rate limit buckets shared by the replicas, only set when the storage keeps them.
+	RateLimiter domain.RateLimiter
+	// AnswerChangeStream - the changes of the answer table, only set when the answer events are captured from it.
+	AnswerChangeStream domain.AnswerChangeStream
+	close              func()
+}
+
+// Close - releases the backend connections.
+func (s *Storage) Close() {
+	if s.close != nil {
+		s.close()
+	}
+}
+
+// NewStorage - sets up the repositories of the configured backend.
+// The tables are created by the provision command, an error will be returned if they are missing.
+// The answer values, the webhook secrets and the stored responses are encrypted if a key provider is configured.
+// The change stream of the answer table is only set up when the answer events are captured from it,
+// the rate limit table when the storage keeps the rate limit buckets. The failed reads of the change stream are logged.
+func NewStorage(cfg *config.Config, logger log.Logger) (*Storage, error) {
+	cipher, err := NewValueCipher(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s := &Storage{Backend: cfg.Storage.Backend}
+	switch s.Backend {
+	case config.DynamoDBStorageBackend:
+		awsSession := GetAwsSession(cfg.AWS)
+		s.AnswerRepository, err = pkgDynamodb.NewAnswerRepository(awsSession, cfg.Storage.AnswerTableName)
+		if err != nil {
+			return nil, err
+		}
+		s.AnswerEventRepository, err = pkgDynamodb.NewAnswerEventRepository(awsSession, cfg.Storage.AnswerEventTableName)
+		if err != nil {
+			return nil, err
+		}
+		s.WebhookRepository, err = pkgDynamodb.NewWebhookRepository(awsSession, cfg.Storage.WebhookSubscriptionTableName, cfg.Storage.WebhookDeliveryTableName)
+		if err != nil {
+			return nil, err
+		}
+		s.IdempotencyRepository, err = pkgDynamodb.NewIdempotencyRepository(awsSession, cfg.Storage.IdempotencyTableName)
+		if err != nil {
+			return nil, err
+		}
+		s.ErasureRepository, err = pkgDynamodb.NewErasureRepository(awsSession, cfg.Storage.ErasureTableName)
+		if err != nil {
+			return nil, err
+		}
+		if cfg.RateLimit.Backend == config.StorageRateLimitBackend {
+			s.RateLimiter, err = pkgDynamodb.NewRateLimiter(awsSession, cfg.Storage.RateLimitTableName)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if cfg.Queue.AnswerEventSource == config.StreamEventSource {
+			s.AnswerChangeStream, err = pkgDynamodb.NewAnswerChangeStream(awsSession, cfg.Storage.AnswerTableName, logger)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case config.MemoryStorageBackend:
+		s.AnswerRepository = inmemory.NewAnswerRepository()
+		if cfg.Queue.AnswerEventSource == config.StreamEventSource {
+			s.AnswerRepository, s.AnswerChangeStream = inmemory.NewAnswerRepositoryWithChangeStream()
+		}
+		s.AnswerEventRepository = inmemory.NewAnswerEventRepository()
+		s.WebhookRepository = inmemory.NewWebhookRepository()
+		s.IdempotencyRepository = inmemory.NewIdempotencyRepository()
+		s.ErasureRepository = inmemory.NewErasureRepository()
+		s.RateLimiter = inmemory.NewRateLimiter()
+	case config.PostgresStorageBackend:
+		db, err := sql.Open(postgres.DriverName, cfg.Storage.PostgresDSN)
+		if err != nil {
+			return nil, err
+		}
+		if err := postgres.CheckMigrations(db); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+		s.AnswerRepository = postgres.NewAnswerRepository(db)
+		s.AnswerEventRepository = postgres.NewAnswerEventRepository(db)
+		s.WebhookRepository = postgres.NewWebhookRepository(db)
+		s.IdempotencyRepository = postgres.NewIdempotencyRepository(db)
+		s.ErasureRepository = postgres.NewErasureRepository(db)
+		s.RateLimiter = postgres.NewRateLimiter(db)
+		s.close = func() { _ = db.Close() }
+	default:
+		return nil, fmt.Errorf("unsupported storage backend %q", s.Backend)
+	}
+	if cipher != nil {
+		s.AnswerRepository = encryption.NewAnswerRepository(s.AnswerRepository, cipher)
+		s.AnswerEventRepository = encryption.NewAnswerEventRepository(s.AnswerEventRepository, cipher)
+		s.WebhookRepository = encryption.NewWebhookRepository(s.WebhookRepository, cipher)
+		s.IdempotencyRepository = encryption.NewIdempotencyRepository(s.IdempotencyRepository, cipher)
+		if s.AnswerChangeStream != nil {
+			s.AnswerChangeStream = encryption.NewAnswerChangeStream(s.AnswerChangeStream, cipher)
+		}
+	}
+	return s, nil
+}
@@ -12,31 +12,28 @@ import (
 var swaggerMap = sync.Map{}
 
 const (
-	swaggerKey         = "swagger"
-	defaultSwaggerPath = "api/generated/app.swagger.json"
+	swaggerKey = "swagger"
 )
 
-// ServeSwagger - serves swagger specification.
-func ServeSwagger(w http.ResponseWriter, r *http.Request) {
-	_, ok := swaggerMap.Load(swaggerKey)
-	if !ok {
-		swaggerPath := defaultSwaggerPath
-		if overridedPath, ok := os.LookupEnv("SWAGGER_PATH"); ok {
-			swaggerPath = overridedPath
+// ServeSwagger - returns a handler serving the swagger specification from the provided path.
+func ServeSwagger(swaggerPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, ok := swaggerMap.Load(swaggerKey)
+		if !ok {
+			m, err := getSwaggerMap(swaggerPath)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+			swaggerMap.Store(swaggerKey, m)
 		}
-		m, err := getSwaggerMap(swaggerPath)
+		// serving a swagger file
+		m, _ := swaggerMap.Load("swagger")
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(m)
 		if err != nil {
 			fmt.Println(err)
-			return
 		}
-		swaggerMap.Store(swaggerKey, m)
-	}
-	// serving a swagger file
-	m, _ := swaggerMap.Load("swagger")
-	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(m)
-	if err != nil {
-		fmt.Println(err)
 	}
 }
 
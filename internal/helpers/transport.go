@@ -2,6 +2,10 @@ package helpers
 
 import (
 	"context"
+	"math"
+	"net/textproto"
+	"strconv"
+	"strings"
 
 	errors "dochq.co.uk.answerservice/internal/error"
 
@@ -9,18 +13,64 @@ import (
 	"github.com/go-kit/kit/transport"
 	"github.com/go-kit/kit/transport/grpc"
 	"github.com/go-kit/log"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 // ServeGrpc - wraps the error
 func ServeGrpc(ctx context.Context, req interface{}, handler grpc.Handler) (interface{}, error) {
 	_, resp, err := handler.ServeGRPC(ctx, req)
+
+	// A rate limited client is told when to retry, the gateway forwards the header, see OutgoingHeaderMatcher.
+	//
+	if exhausted, ok := err.(*errors.ErrResourceExhausted); ok {
+		seconds := int64(math.Ceil(exhausted.RetryAfter.Seconds()))
+		if seconds < 1 {
+			seconds = 1
+		}
+		_ = googlegrpc.SetHeader(ctx, metadata.Pairs(RetryAfterHeader, strconv.FormatInt(seconds, 10)))
+	}
 	return resp, errors.GRPCErrorEncoder(err)
 }
 
+// IncomingHeaderMatcher - forwards the idempotency key header to the gRPC metadata in addition to the
+// headers forwarded by default.
+func IncomingHeaderMatcher(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == IdempotencyKeyHeader {
+		return strings.ToLower(IdempotencyKeyHeader), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// OutgoingHeaderMatcher - forwards the retry after metadata as the HTTP header, the other metadata is forwarded
+// with the default prefix.
+func OutgoingHeaderMatcher(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == RetryAfterHeader {
+		return RetryAfterHeader, true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
 // SetupServerOptions - setups server options.
 func SetupServerOptions(logger log.Logger) []grpc.ServerOption {
 	return []grpc.ServerOption{
 		grpc.ServerErrorHandler(transport.NewLogErrorHandler(logger)),
-		grpc.ServerBefore(jwt.GRPCToContext()),
+		grpc.ServerBefore(serverBefore()...),
+	}
+}
+
+// StreamContext - moves the metadata of a streaming call to the context as the server options do for the unary calls,
+// go-kit transports only handle unary calls.
+func StreamContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, before := range serverBefore() {
+		ctx = before(ctx, md)
 	}
+	return ctx
+}
+
+// serverBefore - returns the functions moving the metadata of a call to the context.
+func serverBefore() []grpc.ServerRequestFunc {
+	return []grpc.ServerRequestFunc{jwt.GRPCToContext(), IdempotencyKeyToContext(), ClientAddressesToContext()}
 }
@@ -0,0 +1,81 @@
+package inmemory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+// changeRetryDelay - the delay before a change failed by the handler is passed again.
+const changeRetryDelay = 100 * time.Millisecond
+
+type answerChangeStream struct {
+	mu      sync.Mutex
+	changes []*domain.AnswerChange
+	// appended - closed and replaced when a change is appended, so readers wake up.
+	appended chan struct{}
+}
+
+// NewAnswerRepositoryWithChangeStream creates a new repository with the stream of its changes,
+// it stands in for DynamoDB Streams, so the change capture can run offline.
+func NewAnswerRepositoryWithChangeStream() (domain.AnswerRepository, domain.AnswerChangeStream) {
+	stream := &answerChangeStream{
+		appended: make(chan struct{}),
+	}
+	repository := newAnswerRepository()
+	repository.changes = stream
+	return repository, stream
+}
+
+func (s *answerChangeStream) Read(ctx context.Context, h domain.AnswerChangeHandler) error {
+	for position := 0; ; {
+
+		// Wait for the next change.
+		//
+		s.mu.Lock()
+		appended := s.appended
+		var change *domain.AnswerChange
+		if position < len(s.changes) {
+			change = s.changes[position]
+		}
+		s.mu.Unlock()
+		if change == nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-appended:
+			}
+			continue
+		}
+
+		// Pass the change again until it is handled.
+		//
+		if err := h.HandleChange(ctx, change); err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(changeRetryDelay):
+			}
+			continue
+		}
+		position++
+	}
+}
+
+// append - records a change of the answer, the caller must hold the lock of the repository,
+// so the changes are appended in the order they were made.
+func (s *answerChangeStream) append(old, new *domain.AnswerImage, changedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changes = append(s.changes, &domain.AnswerChange{
+		ID:        strconv.Itoa(len(s.changes) + 1),
+		Old:       old,
+		New:       new,
+		ChangedAt: changedAt.UTC(),
+	})
+	close(s.appended)
+	s.appended = make(chan struct{})
+}
@@ -0,0 +1,152 @@
+package inmemory
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+type answerEventRepo struct {
+	mu     sync.RWMutex
+	events map[domain.AnswerKey][]domain.AnswerEvent
+}
+
+// NewAnswerEventRepository creates a new repository.
+func NewAnswerEventRepository() domain.AnswerEventRepository {
+	return &answerEventRepo{
+		events: make(map[domain.AnswerKey][]domain.AnswerEvent),
+	}
+}
+
+func (r *answerEventRepo) Create(answerEvent *domain.AnswerEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Copy the event, so the caller cannot change the stored one.
+	//
+	event := *answerEvent
+	data := *answerEvent.Data
+	event.Data = &data
+
+	// Keep the events sorted by occurrence.
+	//
+	events := r.events[data.Key]
+	i := sort.Search(len(events), func(i int) bool {
+		return !events[i].OccurredAt.Before(event.OccurredAt)
+	})
+	if i < len(events) && events[i].OccurredAt.Equal(event.OccurredAt) {
+		return errors.NewErrAlreadyExist("Answer event already exists")
+	}
+	events = append(events, domain.AnswerEvent{})
+	copy(events[i+1:], events[i:])
+	events[i] = event
+	r.events[data.Key] = events
+	return nil
+}
+
+func (r *answerEventRepo) ListEvents(key domain.AnswerKey) ([]*domain.AnswerEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return copyEvents(r.events[key]), nil
+}
+
+func (r *answerEventRepo) ListEventsPage(key domain.AnswerKey, page domain.PageRequest) ([]*domain.AnswerEvent, string, error) {
+
+	// The token holds the occurrence time of the last event of the previous page.
+	//
+	after, err := decodeOccurredAt(page.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	events := r.events[key]
+	start := 0
+	if len(page.PageToken) > 0 {
+		start = sort.Search(len(events), func(i int) bool {
+			return events[i].OccurredAt.After(after)
+		})
+	}
+	end := start + page.Size()
+	if end >= len(events) {
+		return copyEvents(events[start:]), "", nil
+	}
+	return copyEvents(events[start:end]), domain.EncodePageToken(strconv.FormatInt(events[end-1].OccurredAt.UnixNano(), 10)), nil
+}
+
+func (r *answerEventRepo) ListKeys(page domain.PageRequest) ([]domain.AnswerKey, string, error) {
+
+	// Keys are listed in order, the token holds the last key of the previous page.
+	//
+	lastKey, err := domain.DecodePageToken(page.PageToken)
+	if err != nil {
+		return nil, "", errors.NewErrInvalidArgument(err.Error())
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var keys []domain.AnswerKey
+	for key, events := range r.events {
+		if len(events) > 0 && (len(page.PageToken) == 0 || string(key) > lastKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	// Cut the page.
+	//
+	if len(keys) <= page.Size() {
+		return keys, "", nil
+	}
+	keys = keys[:page.Size()]
+	return keys, domain.EncodePageToken(string(keys[len(keys)-1])), nil
+}
+
+func (r *answerEventRepo) EraseEvents(key domain.AnswerKey, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Events are sorted, so the erased ones are the leading events.
+	//
+	events := r.events[key]
+	i := sort.Search(len(events), func(i int) bool {
+		return !events[i].OccurredAt.Before(before)
+	})
+	if i == len(events) {
+		delete(r.events, key)
+	} else {
+		r.events[key] = append([]domain.AnswerEvent(nil), events[i:]...)
+	}
+	return i, nil
+}
+
+func decodeOccurredAt(token string) (time.Time, error) {
+	if len(token) == 0 {
+		return time.Time{}, nil
+	}
+	cursor, err := domain.DecodePageToken(token)
+	if err != nil {
+		return time.Time{}, errors.NewErrInvalidArgument(err.Error())
+	}
+	nanos, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.NewErrInvalidArgument("Page token is not valid")
+	}
+	return time.Unix(0, nanos), nil
+}
+
+func copyEvents(events []domain.AnswerEvent) []*domain.AnswerEvent {
+	var items []*domain.AnswerEvent
+	for _, e := range events {
+		event := e
+		data := *e.Data
+		event.Data = &data
+		items = append(items, &event)
+	}
+	return items
+}
@@ -0,0 +1,246 @@
+package inmemory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+type answerRepo struct {
+	mu      sync.RWMutex
+	answers map[domain.AnswerKey]domain.Answer
+	deleted map[domain.AnswerKey]domain.DeletedAnswer
+	// tags - the tags of the last writes of the answers, the untagged writes remove them.
+	tags    map[domain.AnswerKey]domain.AnswerChangeTag
+	changes *answerChangeStream
+}
+
+// NewAnswerRepository creates a new repository.
+func NewAnswerRepository() domain.AnswerRepository {
+	return newAnswerRepository()
+}
+
+func newAnswerRepository() *answerRepo {
+	return &answerRepo{
+		answers: make(map[domain.AnswerKey]domain.Answer),
+		deleted: make(map[domain.AnswerKey]domain.DeletedAnswer),
+		tags:    make(map[domain.AnswerKey]domain.AnswerChangeTag),
+	}
+}
+
+func (r *answerRepo) Create(answer *domain.Answer) error {
+	return r.CreateTagged(answer, domain.AnswerChangeTag{})
+}
+
+func (r *answerRepo) CreateTagged(answer *domain.Answer, tag domain.AnswerChangeTag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.answers[answer.Key]; ok {
+		return errors.NewErrAlreadyExist("Answer already exists")
+	}
+	old := r.image(answer.Key)
+	answer.Version = r.nextVersion(answer.Key, answer.Version)
+	delete(r.deleted, answer.Key)
+	r.answers[answer.Key] = *answer
+	r.recordChange(old, answer.Key, tag, time.Now())
+	return nil
+}
+
+func (r *answerRepo) Update(answer *domain.Answer) error {
+	return r.UpdateTagged(answer, domain.AnswerChangeTag{})
+}
+
+func (r *answerRepo) UpdateTagged(answer *domain.Answer, tag domain.AnswerChangeTag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.answers[answer.Key]; !ok {
+		return errors.NewErrNotFound("Answer not found")
+	}
+	old := r.image(answer.Key)
+	answer.Version = r.nextVersion(answer.Key, 0)
+	r.answers[answer.Key] = *answer
+	r.recordChange(old, answer.Key, tag, time.Now())
+	return nil
+}
+
+func (r *answerRepo) Upsert(answer *domain.Answer) (bool, error) {
+	return r.UpsertTagged(answer, domain.AnswerChangeTag{})
+}
+
+func (r *answerRepo) UpsertTagged(answer *domain.Answer, tag domain.AnswerChangeTag) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.answers[answer.Key]
+	old := r.image(answer.Key)
+	answer.Version = r.nextVersion(answer.Key, answer.Version)
+	delete(r.deleted, answer.Key)
+	r.answers[answer.Key] = *answer
+	r.recordChange(old, answer.Key, tag, time.Now())
+	return !ok, nil
+}
+
+func (r *answerRepo) Delete(key domain.AnswerKey, tombstone domain.Tombstone) (int, error) {
+	return r.DeleteTagged(key, tombstone, domain.AnswerChangeTag{})
+}
+
+func (r *answerRepo) DeleteTagged(key domain.AnswerKey, tombstone domain.Tombstone, tag domain.AnswerChangeTag) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	answer, ok := r.answers[key]
+	if !ok {
+		return 0, errors.NewErrNotFound("Answer not found")
+	}
+	old := r.image(key)
+	answer.Version = r.nextVersion(key, 0)
+	delete(r.answers, key)
+	r.deleted[key] = domain.DeletedAnswer{
+		Answer:    answer,
+		Tombstone: tombstone,
+	}
+	r.recordChange(old, key, tag, tombstone.DeletedAt)
+	return answer.Version, nil
+}
+
+func (r *answerRepo) Get(key domain.AnswerKey) (*domain.Answer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	answer, ok := r.answers[key]
+	if !ok {
+		return nil, errors.NewErrNotFound("Answer not found")
+	}
+	return &answer, nil
+}
+
+func (r *answerRepo) List(page domain.PageRequest) ([]*domain.Answer, string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]domain.AnswerKey, 0, len(r.answers))
+	for key := range r.answers {
+		keys = append(keys, key)
+	}
+	keys, nextPageToken, err := pageKeys(page, keys)
+	if err != nil {
+		return nil, "", err
+	}
+	items := make([]*domain.Answer, len(keys))
+	for i, key := range keys {
+		answer := r.answers[key]
+		items[i] = &answer
+	}
+	return items, nextPageToken, nil
+}
+
+func (r *answerRepo) ListDeleted(page domain.PageRequest) ([]*domain.DeletedAnswer, string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]domain.AnswerKey, 0, len(r.deleted))
+	for key := range r.deleted {
+		keys = append(keys, key)
+	}
+	keys, nextPageToken, err := pageKeys(page, keys)
+	if err != nil {
+		return nil, "", err
+	}
+	items := make([]*domain.DeletedAnswer, len(keys))
+	for i, key := range keys {
+		answer := r.deleted[key]
+		items[i] = &answer
+	}
+	return items, nextPageToken, nil
+}
+
+func (r *answerRepo) Purge(key domain.AnswerKey, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	answer, ok := r.deleted[key]
+	if !ok || !answer.IsDue(now) {
+		return errors.NewErrNotFound("Deleted answer not found")
+	}
+	old := r.image(key)
+	delete(r.deleted, key)
+	r.recordChange(old, key, domain.AnswerChangeTag{}, now)
+	return nil
+}
+
+func (r *answerRepo) Erase(key domain.AnswerKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.answers[key]
+	_, deleted := r.deleted[key]
+	if !ok && !deleted {
+		return errors.NewErrNotFound("Answer not found")
+	}
+	old := r.image(key)
+	delete(r.answers, key)
+	delete(r.deleted, key)
+	r.recordChange(old, key, domain.AnswerChangeTag{}, time.Now())
+	return nil
+}
+
+// nextVersion - returns the version of the next change of the answer, it counts on from the given version
+// if the answer has none stored. The caller must hold the lock.
+func (r *answerRepo) nextVersion(key domain.AnswerKey, version int) int {
+	if answer, ok := r.answers[key]; ok && answer.Version > 0 {
+		version = answer.Version
+	} else if answer, ok := r.deleted[key]; ok && answer.Version > 0 {
+		version = answer.Version
+	}
+	return version + 1
+}
+
+// image - returns the state of the answer item or nil if it does not exist, the caller must hold the lock.
+func (r *answerRepo) image(key domain.AnswerKey) *domain.AnswerImage {
+	tag := r.tags[key]
+	if answer, ok := r.answers[key]; ok {
+		return &domain.AnswerImage{Answer: answer, EventType: tag.EventType, EventID: tag.EventID}
+	}
+	if answer, ok := r.deleted[key]; ok {
+		return &domain.AnswerImage{Answer: answer.Answer, Deleted: true, EventType: tag.EventType, EventID: tag.EventID}
+	}
+	return nil
+}
+
+// recordChange - keeps the tag of the write and appends the change of the answer item to the change stream
+// if the repository has one, the time of the tag is the time of the change unless it is zero.
+// The caller must hold the lock.
+func (r *answerRepo) recordChange(old *domain.AnswerImage, key domain.AnswerKey, tag domain.AnswerChangeTag, changedAt time.Time) {
+	_, live := r.answers[key]
+	_, deleted := r.deleted[key]
+	if live || deleted {
+		r.tags[key] = tag
+	} else {
+		delete(r.tags, key)
+	}
+	if !tag.OccurredAt.IsZero() {
+		changedAt = tag.OccurredAt
+	}
+	if r.changes != nil {
+		r.changes.append(old, r.image(key), changedAt)
+	}
+}
+
+// pageKeys - returns a page of the keys in order, the token holds the last key of the previous page.
+func pageKeys(page domain.PageRequest, all []domain.AnswerKey) ([]domain.AnswerKey, string, error) {
+	lastKey, err := domain.DecodePageToken(page.PageToken)
+	if err != nil {
+		return nil, "", errors.NewErrInvalidArgument(err.Error())
+	}
+	var keys []domain.AnswerKey
+	for _, key := range all {
+		if len(page.PageToken) == 0 || string(key) > lastKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	// Cut the page.
+	//
+	if len(keys) <= page.Size() {
+		return keys, "", nil
+	}
+	keys = keys[:page.Size()]
+	return keys, domain.AnswerPageToken(keys[len(keys)-1]), nil
+}
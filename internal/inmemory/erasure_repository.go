@@ -0,0 +1,40 @@
+package inmemory
+
+import (
+	"sync"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+type erasureRepo struct {
+	mu       sync.Mutex
+	erasures map[string]domain.Erasure
+}
+
+// NewErasureRepository creates a new repository.
+func NewErasureRepository() domain.ErasureRepository {
+	return &erasureRepo{
+		erasures: make(map[string]domain.Erasure),
+	}
+}
+
+func (r *erasureRepo) Record(erasure *domain.Erasure) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.erasures[erasure.KeyHash]; ok && stored.ErasedAt.After(erasure.ErasedAt) {
+		return nil
+	}
+	r.erasures[erasure.KeyHash] = *erasure
+	return nil
+}
+
+func (r *erasureRepo) Get(keyHash string) (*domain.Erasure, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	erasure, ok := r.erasures[keyHash]
+	if !ok {
+		return nil, errors.NewErrNotFound("Erasure not found")
+	}
+	return &erasure, nil
+}
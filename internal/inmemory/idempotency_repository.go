@@ -0,0 +1,86 @@
+package inmemory
+
+import (
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+type idempotencyRepo struct {
+	mu      sync.Mutex
+	records map[string]domain.IdempotencyRecord
+}
+
+// NewIdempotencyRepository creates a new repository.
+func NewIdempotencyRepository() domain.IdempotencyRepository {
+	return &idempotencyRepo{
+		records: make(map[string]domain.IdempotencyRecord),
+	}
+}
+
+func (r *idempotencyRepo) Reserve(record *domain.IdempotencyRecord, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Remove the expired records, nothing else does in memory.
+	//
+	for key, stored := range r.records {
+		if !stored.ExpiresAt.After(now) {
+			delete(r.records, key)
+		}
+	}
+	if stored, ok := r.records[record.Key]; ok && !isLeaseExpired(&stored, now) {
+		return errors.NewErrAlreadyExist("Idempotency key already exists")
+	}
+	r.records[record.Key] = *record
+	return nil
+}
+
+func (r *idempotencyRepo) Get(key string, now time.Time) (*domain.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record, ok := r.records[key]
+	if !ok || !record.ExpiresAt.After(now) {
+		return nil, errors.NewErrNotFound("Idempotency key not found")
+	}
+	return &record, nil
+}
+
+func (r *idempotencyRepo) Complete(record *domain.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.records[record.Key]; !ok || stored.ReservationID != record.ReservationID {
+		return errors.NewErrNotFound("Idempotency key not found")
+	}
+	r.records[record.Key] = *record
+	return nil
+}
+
+func (r *idempotencyRepo) Release(key, reservationID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.records[key]; ok && stored.ReservationID == reservationID {
+		delete(r.records, key)
+	}
+	return nil
+}
+
+func (r *idempotencyRepo) EraseAnswer(answerKeyHash string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var erased int
+	for key, record := range r.records {
+		if record.AnswerKeyHash == answerKeyHash {
+			delete(r.records, key)
+			erased++
+		}
+	}
+	return erased, nil
+}
+
+// isLeaseExpired - checks if the record is in progress and its reservation may be taken over.
+func isLeaseExpired(record *domain.IdempotencyRecord, now time.Time) bool {
+	return !record.IsComplete() && !record.LeaseExpiresAt.IsZero() && !record.LeaseExpiresAt.After(now)
+}
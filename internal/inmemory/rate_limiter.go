@@ -0,0 +1,53 @@
+package inmemory
+
+import (
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+// rateLimiterSweepInterval - how often the full buckets are forgotten.
+const rateLimiterSweepInterval = time.Minute
+
+type rateLimitBucket struct {
+	bucket domain.TokenBucket
+	fullAt time.Time
+}
+
+type rateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]rateLimitBucket
+	lastSweep time.Time
+}
+
+// NewRateLimiter creates a new rate limiter, its buckets are only seen by the current process.
+func NewRateLimiter() domain.RateLimiter {
+	return &rateLimiter{
+		buckets: make(map[string]rateLimitBucket),
+	}
+}
+
+func (r *rateLimiter) Take(key string, limit domain.RateLimit, now time.Time) (bool, time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Forget the full buckets, a new bucket is full anyway.
+	//
+	if now.Sub(r.lastSweep) >= rateLimiterSweepInterval {
+		for key, stored := range r.buckets {
+			if !stored.fullAt.After(now) {
+				delete(r.buckets, key)
+			}
+		}
+		r.lastSweep = now
+	}
+
+	var bucket *domain.TokenBucket
+	if stored, ok := r.buckets[key]; ok {
+		bucket = &stored.bucket
+	}
+	next, allowed, retryAfter := limit.Take(bucket, now)
+	r.buckets[key] = rateLimitBucket{bucket: next, fullAt: limit.FullAt(next)}
+	return allowed, retryAfter, nil
+}
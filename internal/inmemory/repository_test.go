@@ -0,0 +1,44 @@
+package inmemory
+
+import (
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/repositorytest"
+)
+
+func TestAnswerRepository(t *testing.T) {
+	repositorytest.TestAnswerRepository(t, func(t *testing.T) domain.AnswerRepository {
+		return NewAnswerRepository()
+	})
+}
+
+func TestAnswerEventRepository(t *testing.T) {
+	repositorytest.TestAnswerEventRepository(t, func(t *testing.T) domain.AnswerEventRepository {
+		return NewAnswerEventRepository()
+	})
+}
+
+func TestWebhookRepository(t *testing.T) {
+	repositorytest.TestWebhookRepository(t, func(t *testing.T) domain.WebhookRepository {
+		return NewWebhookRepository()
+	})
+}
+
+func TestIdempotencyRepository(t *testing.T) {
+	repositorytest.TestIdempotencyRepository(t, func(t *testing.T) domain.IdempotencyRepository {
+		return NewIdempotencyRepository()
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	repositorytest.TestRateLimiter(t, func(t *testing.T) domain.RateLimiter {
+		return NewRateLimiter()
+	})
+}
+
+func TestErasureRepository(t *testing.T) {
+	repositorytest.TestErasureRepository(t, func(t *testing.T) domain.ErasureRepository {
+		return NewErasureRepository()
+	})
+}
@@ -0,0 +1,165 @@
+package inmemory
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+type webhookRepo struct {
+	mu            sync.RWMutex
+	subscriptions map[string]domain.WebhookSubscription
+	deliveries    map[string][]domain.WebhookDelivery
+}
+
+// NewWebhookRepository creates a new repository.
+func NewWebhookRepository() domain.WebhookRepository {
+	return &webhookRepo{
+		subscriptions: make(map[string]domain.WebhookSubscription),
+		deliveries:    make(map[string][]domain.WebhookDelivery),
+	}
+}
+
+func (r *webhookRepo) CreateSubscription(subscription *domain.WebhookSubscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subscriptions[subscription.ID]; ok {
+		return errors.NewErrAlreadyExist("Webhook subscription already exists")
+	}
+	r.subscriptions[subscription.ID] = copySubscription(subscription)
+	return nil
+}
+
+func (r *webhookRepo) GetSubscription(id string) (*domain.WebhookSubscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	subscription, ok := r.subscriptions[id]
+	if !ok {
+		return nil, errors.NewErrNotFound("Webhook subscription not found")
+	}
+	subscription = copySubscription(&subscription)
+	return &subscription, nil
+}
+
+func (r *webhookRepo) ListSubscriptions(page domain.PageRequest) ([]*domain.WebhookSubscription, string, error) {
+
+	// Subscriptions are listed by id, the token holds the last id of the previous page.
+	//
+	lastID, err := domain.DecodePageToken(page.PageToken)
+	if err != nil {
+		return nil, "", errors.NewErrInvalidArgument(err.Error())
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var ids []string
+	for id := range r.subscriptions {
+		if len(page.PageToken) == 0 || id > lastID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	var nextPageToken string
+	if len(ids) > page.Size() {
+		ids = ids[:page.Size()]
+		nextPageToken = domain.EncodePageToken(ids[len(ids)-1])
+	}
+	items := make([]*domain.WebhookSubscription, len(ids))
+	for i, id := range ids {
+		subscription := r.subscriptions[id]
+		subscription = copySubscription(&subscription)
+		items[i] = &subscription
+	}
+	return items, nextPageToken, nil
+}
+
+func (r *webhookRepo) DeleteSubscription(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.subscriptions[id]; !ok {
+		return errors.NewErrNotFound("Webhook subscription not found")
+	}
+	delete(r.subscriptions, id)
+	delete(r.deliveries, id)
+	return nil
+}
+
+func (r *webhookRepo) CreateDelivery(delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Keep the deliveries sorted by attempt time.
+	//
+	deliveries := r.deliveries[delivery.SubscriptionID]
+	i := sort.Search(len(deliveries), func(i int) bool {
+		return !deliveries[i].AttemptedAt.Before(delivery.AttemptedAt)
+	})
+	if i < len(deliveries) && deliveries[i].AttemptedAt.Equal(delivery.AttemptedAt) {
+		return errors.NewErrAlreadyExist("Webhook delivery already exists")
+	}
+	deliveries = append(deliveries, domain.WebhookDelivery{})
+	copy(deliveries[i+1:], deliveries[i:])
+	deliveries[i] = *delivery
+	r.deliveries[delivery.SubscriptionID] = deliveries
+	return nil
+}
+
+func (r *webhookRepo) ListDeliveries(subscriptionID string, page domain.PageRequest) ([]*domain.WebhookDelivery, string, error) {
+
+	// The token holds the attempt time of the last delivery of the previous page.
+	//
+	after, err := decodeOccurredAt(page.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	deliveries := r.deliveries[subscriptionID]
+	start := 0
+	if len(page.PageToken) > 0 {
+		start = sort.Search(len(deliveries), func(i int) bool {
+			return deliveries[i].AttemptedAt.After(after)
+		})
+	}
+	end := start + page.Size()
+	var nextPageToken string
+	if end < len(deliveries) {
+		nextPageToken = domain.EncodePageToken(strconv.FormatInt(deliveries[end-1].AttemptedAt.UnixNano(), 10))
+	} else {
+		end = len(deliveries)
+	}
+	var items []*domain.WebhookDelivery
+	for _, d := range deliveries[start:end] {
+		delivery := d
+		items = append(items, &delivery)
+	}
+	return items, nextPageToken, nil
+}
+
+func (r *webhookRepo) ListDeliveryAttempts(subscriptionID, deliveryID string, since time.Time) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	deliveries := r.deliveries[subscriptionID]
+	start := sort.Search(len(deliveries), func(i int) bool {
+		return !deliveries[i].AttemptedAt.Before(since)
+	})
+	var items []*domain.WebhookDelivery
+	for _, d := range deliveries[start:] {
+		if d.ID == deliveryID {
+			delivery := d
+			items = append(items, &delivery)
+		}
+	}
+	return items, nil
+}
+
+func copySubscription(subscription *domain.WebhookSubscription) domain.WebhookSubscription {
+	copied := *subscription
+	copied.EventTypes = append([]domain.AnswerEventType(nil), subscription.EventTypes...)
+	return copied
+}
@@ -0,0 +1,138 @@
+package memqueue
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/queue"
+
+	"github.com/go-kit/log"
+)
+
+// DefaultRedeliveryDelay - delay before a message which failed to be handled is delivered again.
+const DefaultRedeliveryDelay = time.Second
+
+// Broker - in-memory message broker.
+// It is both a queue service and a queue consumer, so the publisher and the consumer
+// must share the same broker instance, i.e. run in the same process.
+type Broker struct {
+	logger          log.Logger
+	redeliveryDelay time.Duration
+	sequence        uint64
+	mu              sync.Mutex
+	queues          map[string]*messageQueue
+}
+
+// NewBroker creates a new broker.
+func NewBroker(logger log.Logger) *Broker {
+	return &Broker{
+		logger:          logger,
+		redeliveryDelay: DefaultRedeliveryDelay,
+		queues:          make(map[string]*messageQueue),
+	}
+}
+
+// SetRedeliveryDelay - overrides the delay before a failed message is delivered again.
+func (b *Broker) SetRedeliveryDelay(delay time.Duration) {
+	b.redeliveryDelay = delay
+}
+
+// SendMessage - sends a message to the queue.
+func (b *Broker) SendMessage(
+	ctx context.Context,
+	queueName string,
+	message domain.QueueMessage,
+) (emptyMessageID string, err error) {
+
+	// Validate and marshal message body.
+	//
+	messageBody, err := queue.EncodeMessage(queueName, message)
+	if err != nil {
+		return emptyMessageID, err
+	}
+
+	// Enqueue message.
+	//
+	messageID := strconv.FormatUint(atomic.AddUint64(&b.sequence, 1), 10)
+	b.getQueue(queueName).push(&domain.ReceivedMessage{
+		ID:          messageID,
+		MessageType: message.GetMessageType(),
+		Attributes:  message.GetAttributes(),
+		Body:        messageBody,
+	})
+	return messageID, nil
+}
+
+// Consume - passes queued messages to the handler till the context is done.
+// A message is delivered again after the redelivery delay if the handler fails.
+func (b *Broker) Consume(ctx context.Context, queueName string, h domain.QueueHandler) error {
+	q := b.getQueue(queueName)
+	for {
+		msg, ok := q.pop()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-q.notify:
+				continue
+			}
+		}
+		if err := h.HandleMessage(ctx, msg); err != nil {
+			_ = b.logger.Log("Failed to handle message", "err", err)
+			time.AfterFunc(b.redeliveryDelay, func() {
+				q.push(msg)
+			})
+		}
+	}
+}
+
+func (b *Broker) getQueue(queueName string) *messageQueue {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	q, ok := b.queues[queueName]
+	if !ok {
+		q = &messageQueue{notify: make(chan struct{}, 1)}
+		b.queues[queueName] = q
+	}
+	return q
+}
+
+// messageQueue - unbounded FIFO queue of messages.
+type messageQueue struct {
+	mu       sync.Mutex
+	messages []*domain.ReceivedMessage
+	notify   chan struct{}
+}
+
+func (q *messageQueue) push(msg *domain.ReceivedMessage) {
+	q.mu.Lock()
+	q.messages = append(q.messages, msg)
+	q.mu.Unlock()
+
+	// Wake up a waiting consumer.
+	//
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *messageQueue) pop() (*domain.ReceivedMessage, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.messages) == 0 {
+		return nil, false
+	}
+	msg := q.messages[0]
+	q.messages = q.messages[1:]
+	return msg, true
+}
+
+var (
+	_ domain.QueueService  = &Broker{}
+	_ domain.QueueConsumer = &Broker{}
+)
@@ -0,0 +1,90 @@
+package memqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+	"github.com/go-test/deep"
+)
+
+func TestBroker(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	broker := NewBroker(log.NewNopLogger())
+	broker.SetRedeliveryDelay(10 * time.Millisecond)
+
+	// Invalid messages must be rejected.
+	//
+	if _, err := broker.SendMessage(ctx, "", &domain.AnswerEventMessage{}); err == nil {
+		t.Error("expected error for empty queue name")
+	}
+	if _, err := broker.SendMessage(ctx, "events", nil); err == nil {
+		t.Error("expected error for nil message")
+	}
+
+	// Send messages.
+	//
+	messages := []*domain.AnswerEventMessage{
+		{Event: &domain.AnswerEvent{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John"}}},
+		{Event: &domain.AnswerEvent{EventType: domain.UpdateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam"}}},
+	}
+	for _, m := range messages {
+		if _, err := broker.SendMessage(ctx, "events", m); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+
+	// Consume messages, the first delivery of every message fails.
+	//
+	var (
+		attempts = map[string]int{}
+		received []*domain.AnswerEventMessage
+		done     = make(chan struct{})
+	)
+	go func() {
+		_ = broker.Consume(ctx, "events", domain.QueueHandlerFunc(func(ctx context.Context, msg *domain.ReceivedMessage) error {
+			if msg.MessageType != domain.AnswerEventMessageType {
+				t.Errorf("unexpected message type: %v", msg.MessageType)
+			}
+			attempts[msg.ID]++
+			if attempts[msg.ID] == 1 {
+				return errors.New("temporary failure")
+			}
+			payload := &domain.AnswerEventMessage{}
+			if err := json.Unmarshal(msg.Body, payload); err != nil {
+				t.Errorf("unexpected err: %v", err)
+			}
+			received = append(received, payload)
+			if len(received) == len(messages) {
+				close(done)
+			}
+			return nil
+		}))
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		t.Fatal("messages were not redelivered")
+	}
+	// Redelivery may change the order of messages.
+	//
+	for _, m := range messages {
+		found := false
+		for _, r := range received {
+			if deep.Equal(r, m) == nil {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("message not received: %v", m.Event)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package natsqueue
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+	"github.com/nats-io/nats.go"
+)
+
+type consumer struct {
+	conn   *nats.Conn
+	logger log.Logger
+}
+
+// NewQueueConsumer creates a consumer with necessary dependencies.
+// Consumers of the same queue name form a NATS queue group, so every message
+// is delivered to only one of them. Core NATS delivers messages at most once:
+// a message the handler fails to process is logged and dropped.
+func NewQueueConsumer(conn *nats.Conn, logger log.Logger) domain.QueueConsumer {
+	return &consumer{
+		conn:   conn,
+		logger: logger,
+	}
+}
+
+// Consume - subscribes to the queue and waits till the context is done.
+func (c *consumer) Consume(ctx context.Context, queueName string, h domain.QueueHandler) error {
+	sub, err := c.conn.QueueSubscribe(queueName, queueName, func(m *nats.Msg) {
+		if err := h.HandleMessage(ctx, decodeMessage(m)); err != nil {
+			_ = c.logger.Log("Failed to handle message", "err", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	<-ctx.Done()
+	_ = c.logger.Log("Stopping subscription because a context kill signal was sent")
+	return sub.Unsubscribe()
+}
+
+// decodeMessage - converts a NATS message to a backend-neutral message.
+// The headers other than the message id are the message attributes.
+func decodeMessage(m *nats.Msg) *domain.ReceivedMessage {
+	attributes := make(map[string]string)
+	for name := range m.Header {
+		if name != headerMessageID {
+			attributes[name] = m.Header.Get(name)
+		}
+	}
+	return &domain.ReceivedMessage{
+		ID:          m.Header.Get(headerMessageID),
+		MessageType: domain.MessageType(attributes[domain.MessageTypeAttributeKey]),
+		Attributes:  attributes,
+		Body:        m.Data,
+	}
+}
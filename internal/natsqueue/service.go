@@ -0,0 +1,59 @@
+package natsqueue
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/queue"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nuid"
+)
+
+// Message headers.
+const (
+	headerMessageID = "Nats-Msg-Id"
+)
+
+type service struct {
+	conn *nats.Conn
+}
+
+// NewQueueService creates a service with necessary dependencies.
+// Queue names are used as NATS subjects.
+func NewQueueService(conn *nats.Conn) domain.QueueService {
+	return &service{
+		conn: conn,
+	}
+}
+
+func (s *service) SendMessage(
+	ctx context.Context,
+	queueName string,
+	message domain.QueueMessage,
+) (emptyMessageID string, err error) {
+
+	// Validate and marshal message body.
+	//
+	messageBody, err := queue.EncodeMessage(queueName, message)
+	if err != nil {
+		return emptyMessageID, err
+	}
+
+	// Publish message.
+	//
+	messageID := nuid.Next()
+	msg := nats.NewMsg(queueName)
+	msg.Header.Set(headerMessageID, messageID)
+	for name, value := range message.GetAttributes() {
+		msg.Header.Set(name, value)
+	}
+	msg.Data = messageBody
+	if err := s.conn.PublishMsg(msg); err != nil {
+		return emptyMessageID, err
+	}
+
+	// Return result.
+	//
+	return messageID, nil
+}
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/lib/pq"
+)
+
+type answerEventRepo struct {
+	db *sql.DB
+}
+
+// NewAnswerEventRepository creates a new repository.
+// The schema must be migrated with Migrate beforehand.
+func NewAnswerEventRepository(db *sql.DB) domain.AnswerEventRepository {
+	return &answerEventRepo{
+		db: db,
+	}
+}
+
+func (r *answerEventRepo) Create(answerEvent *domain.AnswerEvent) error {
+
+	// Marshal event data.
+	//
+	data, err := json.Marshal(answerEvent.Data)
+	if err != nil {
+		return err
+	}
+
+	// Insert event.
+	// The occurrence time is stored in nanoseconds, TIMESTAMPTZ would truncate it to microseconds.
+	//
+	_, err = r.db.Exec(`INSERT INTO answer_events (key, event_type, data, occurred_at) VALUES ($1, $2, $3, $4)`,
+		answerEvent.Data.Key, answerEvent.EventType, data, answerEvent.OccurredAt.UnixNano())
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == pqErrorUniqueViolation {
+		return errors.NewErrAlreadyExist("Answer event already exists")
+	}
+	return err
+}
+
+func (r *answerEventRepo) ListEvents(key domain.AnswerKey) ([]*domain.AnswerEvent, error) {
+	rows, err := r.db.Query(`SELECT event_type, data, occurred_at FROM answer_events WHERE key = $1 ORDER BY occurred_at`, key)
+	if err != nil {
+		return nil, err
+	}
+	return scanEvents(rows)
+}
+
+func (r *answerEventRepo) ListEventsPage(key domain.AnswerKey, page domain.PageRequest) ([]*domain.AnswerEvent, string, error) {
+
+	// The token holds the occurrence time of the last event of the previous page.
+	//
+	var after int64
+	if len(page.PageToken) > 0 {
+		cursor, err := domain.DecodePageToken(page.PageToken)
+		if err != nil {
+			return nil, "", errors.NewErrInvalidArgument(err.Error())
+		}
+		if after, err = strconv.ParseInt(cursor, 10, 64); err != nil {
+			return nil, "", errors.NewErrInvalidArgument("Page token is not valid")
+		}
+	}
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if len(page.PageToken) == 0 {
+		rows, err = r.db.Query(`SELECT event_type, data, occurred_at FROM answer_events
+			WHERE key = $1 ORDER BY occurred_at LIMIT $2`, key, page.Size()+1)
+	} else {
+		rows, err = r.db.Query(`SELECT event_type, data, occurred_at FROM answer_events
+			WHERE key = $1 AND occurred_at > $2 ORDER BY occurred_at LIMIT $3`, key, after, page.Size()+1)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	items, err := scanEvents(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// One more row than requested means there is a next page.
+	//
+	if len(items) <= page.Size() {
+		return items, "", nil
+	}
+	items = items[:page.Size()]
+	lastOccurredAt := items[len(items)-1].OccurredAt.UnixNano()
+	return items, domain.EncodePageToken(strconv.FormatInt(lastOccurredAt, 10)), nil
+}
+
+func (r *answerEventRepo) ListKeys(page domain.PageRequest) ([]domain.AnswerKey, string, error) {
+
+	// Keys are listed in order, the token holds the last key of the previous page.
+	//
+	lastKey, err := domain.DecodePageToken(page.PageToken)
+	if err != nil {
+		return nil, "", errors.NewErrInvalidArgument(err.Error())
+	}
+	var rows *sql.Rows
+	if len(page.PageToken) == 0 {
+		rows, err = r.db.Query(`SELECT DISTINCT key FROM answer_events ORDER BY key LIMIT $1`, page.Size()+1)
+	} else {
+		rows, err = r.db.Query(`SELECT DISTINCT key FROM answer_events WHERE key > $1 ORDER BY key LIMIT $2`, lastKey, page.Size()+1)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var keys []domain.AnswerKey
+	for rows.Next() {
+		var key domain.AnswerKey
+		if err := rows.Scan(&key); err != nil {
+			return nil, "", err
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	// One more row than requested means there is a next page.
+	//
+	if len(keys) <= page.Size() {
+		return keys, "", nil
+	}
+	keys = keys[:page.Size()]
+	return keys, domain.EncodePageToken(string(keys[len(keys)-1])), nil
+}
+
+func (r *answerEventRepo) EraseEvents(key domain.AnswerKey, before time.Time) (int, error) {
+	result, err := r.db.Exec(`DELETE FROM answer_events WHERE key = $1 AND occurred_at < $2`, key, before.UnixNano())
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
+
+func scanEvents(rows *sql.Rows) ([]*domain.AnswerEvent, error) {
+	defer rows.Close()
+
+	var items []*domain.AnswerEvent
+	for rows.Next() {
+		var (
+			item       = &domain.AnswerEvent{}
+			data       []byte
+			occurredAt int64
+		)
+		if err := rows.Scan(&item.EventType, &data, &occurredAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &item.Data); err != nil {
+			return nil, err
+		}
+		item.OccurredAt = time.Unix(0, occurredAt).UTC()
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
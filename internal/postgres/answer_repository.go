@@ -0,0 +1,230 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+// nextVersion - the version of a replaced row, it counts on from the given version
+// if the row was stored before the changes were counted.
+const nextVersion = `CASE WHEN answers.version > 0 THEN answers.version ELSE EXCLUDED.version - 1 END + 1`
+
+type answerRepo struct {
+	db *sql.DB
+}
+
+// NewAnswerRepository creates a new repository.
+// The schema must be migrated with Migrate beforehand.
+func NewAnswerRepository(db *sql.DB) domain.AnswerRepository {
+	return &answerRepo{
+		db: db,
+	}
+}
+
+func (r *answerRepo) Create(answer *domain.Answer) error {
+
+	// A deleted answer is replaced, a conflict with an answer in use returns no row.
+	//
+	err := r.db.QueryRow(`INSERT INTO answers (key, value, version) VALUES ($1, $2, $3 + 1)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, deleted_at = NULL, purge_at = NULL,
+			version = `+nextVersion+`
+		WHERE answers.deleted_at IS NOT NULL
+		RETURNING version`, answer.Key, answer.Value, answer.Version).Scan(&answer.Version)
+	if err == sql.ErrNoRows {
+		return errors.NewErrAlreadyExist("Answer already exists")
+	}
+	return err
+}
+
+func (r *answerRepo) Update(answer *domain.Answer) error {
+	err := r.db.QueryRow(`UPDATE answers SET value = $2, version = version + 1 WHERE key = $1 AND deleted_at IS NULL
+		RETURNING version`, answer.Key, answer.Value).Scan(&answer.Version)
+	if err == sql.ErrNoRows {
+		return errors.NewErrNotFound("Answer not found")
+	}
+	return err
+}
+
+func (r *answerRepo) Upsert(answer *domain.Answer) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Lock the row if it exists, a deleted answer is replaced like a missing one.
+	//
+	var deletedAt sql.NullInt64
+	err = tx.QueryRow(`SELECT deleted_at FROM answers WHERE key = $1 FOR UPDATE`, answer.Key).Scan(&deletedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	replacesDeleted := err == nil && deletedAt.Valid
+
+	// A row inserted by a concurrent upsert after the lookup is updated, xmax is zero only for an inserted row.
+	//
+	var inserted bool
+	err = tx.QueryRow(`INSERT INTO answers (key, value, version) VALUES ($1, $2, $3 + 1)
+		ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, deleted_at = NULL, purge_at = NULL,
+			version = `+nextVersion+`
+		RETURNING xmax = 0, version`, answer.Key, answer.Value, answer.Version).Scan(&inserted, &answer.Version)
+	if err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return inserted || replacesDeleted, nil
+}
+
+func (r *answerRepo) Delete(key domain.AnswerKey, tombstone domain.Tombstone) (int, error) {
+	var version int
+	err := r.db.QueryRow(`UPDATE answers SET deleted_at = $2, purge_at = $3, version = version + 1
+		WHERE key = $1 AND deleted_at IS NULL RETURNING version`,
+		key, tombstone.DeletedAt.UnixNano(), tombstone.PurgeAt.UnixNano()).Scan(&version)
+	if err == sql.ErrNoRows {
+		return 0, errors.NewErrNotFound("Answer not found")
+	}
+	return version, err
+}
+
+func (r *answerRepo) Get(key domain.AnswerKey) (*domain.Answer, error) {
+	answer := &domain.Answer{}
+	err := r.db.QueryRow(`SELECT key, value, version FROM answers WHERE key = $1 AND deleted_at IS NULL`, key).
+		Scan(&answer.Key, &answer.Value, &answer.Version)
+	if err == sql.ErrNoRows {
+		return nil, errors.NewErrNotFound("Answer not found")
+	}
+	if err != nil {
+		return nil, errors.NewErrInternal(fmt.Sprintf("Query failed: %s", err))
+	}
+	return answer, nil
+}
+
+func (r *answerRepo) List(page domain.PageRequest) ([]*domain.Answer, string, error) {
+
+	// Answers are listed in the order of their keys,
+	// the token holds the last key of the previous page.
+	//
+	lastKey, err := domain.DecodePageToken(page.PageToken)
+	if err != nil {
+		return nil, "", errors.NewErrInvalidArgument(err.Error())
+	}
+	var rows *sql.Rows
+	if len(page.PageToken) == 0 {
+		rows, err = r.db.Query(`SELECT key, value, version FROM answers WHERE deleted_at IS NULL ORDER BY key LIMIT $1`, page.Size()+1)
+	} else {
+		rows, err = r.db.Query(`SELECT key, value, version FROM answers WHERE deleted_at IS NULL AND key > $1 ORDER BY key LIMIT $2`, lastKey, page.Size()+1)
+	}
+	if err != nil {
+		return nil, "", errors.NewErrInternal(fmt.Sprintf("Query failed: %s", err))
+	}
+	defer rows.Close()
+
+	var items []*domain.Answer
+	for rows.Next() {
+		item := &domain.Answer{}
+		if err := rows.Scan(&item.Key, &item.Value, &item.Version); err != nil {
+			return nil, "", err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	// One more row than requested means there is a next page.
+	//
+	if len(items) <= page.Size() {
+		return items, "", nil
+	}
+	items = items[:page.Size()]
+	return items, domain.AnswerPageToken(items[len(items)-1].Key), nil
+}
+
+func (r *answerRepo) ListDeleted(page domain.PageRequest) ([]*domain.DeletedAnswer, string, error) {
+
+	// Deleted answers are listed like the answers in use.
+	//
+	lastKey, err := domain.DecodePageToken(page.PageToken)
+	if err != nil {
+		return nil, "", errors.NewErrInvalidArgument(err.Error())
+	}
+	var rows *sql.Rows
+	if len(page.PageToken) == 0 {
+		rows, err = r.db.Query(`SELECT key, value, version, deleted_at, purge_at FROM answers
+			WHERE deleted_at IS NOT NULL ORDER BY key LIMIT $1`, page.Size()+1)
+	} else {
+		rows, err = r.db.Query(`SELECT key, value, version, deleted_at, purge_at FROM answers
+			WHERE deleted_at IS NOT NULL AND key > $1 ORDER BY key LIMIT $2`, lastKey, page.Size()+1)
+	}
+	if err != nil {
+		return nil, "", errors.NewErrInternal(fmt.Sprintf("Query failed: %s", err))
+	}
+	defer rows.Close()
+
+	var items []*domain.DeletedAnswer
+	for rows.Next() {
+		var (
+			item               = &domain.DeletedAnswer{}
+			deletedAt, purgeAt int64
+		)
+		if err := rows.Scan(&item.Key, &item.Value, &item.Version, &deletedAt, &purgeAt); err != nil {
+			return nil, "", err
+		}
+		item.DeletedAt = time.Unix(0, deletedAt).UTC()
+		item.PurgeAt = time.Unix(0, purgeAt).UTC()
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	// One more row than requested means there is a next page.
+	//
+	if len(items) <= page.Size() {
+		return items, "", nil
+	}
+	items = items[:page.Size()]
+	return items, domain.AnswerPageToken(items[len(items)-1].Key), nil
+}
+
+func (r *answerRepo) Purge(key domain.AnswerKey, now time.Time) error {
+	result, err := r.db.Exec(`DELETE FROM answers WHERE key = $1 AND deleted_at IS NOT NULL AND purge_at <= $2`,
+		key, now.UnixNano())
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.NewErrNotFound("Deleted answer not found")
+	}
+	return nil
+}
+
+func (r *answerRepo) Erase(key domain.AnswerKey) error {
+	result, err := r.db.Exec(`DELETE FROM answers WHERE key = $1`, key)
+	if err != nil {
+		return err
+	}
+	return mustAffectRow(result)
+}
+
+// mustAffectRow - returns not found error if the statement did not change any row.
+func mustAffectRow(result sql.Result) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.NewErrNotFound("Answer not found")
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package postgres
+
+const (
+	// DriverName - name of the registered database/sql driver.
+	DriverName = "postgres"
+
+	pqErrorUniqueViolation = "23505"
+)
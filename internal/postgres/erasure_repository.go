@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+type erasureRepo struct {
+	db *sql.DB
+}
+
+// NewErasureRepository creates a new repository.
+// The schema must be migrated with Migrate beforehand.
+func NewErasureRepository(db *sql.DB) domain.ErasureRepository {
+	return &erasureRepo{
+		db: db,
+	}
+}
+
+func (r *erasureRepo) Record(erasure *domain.Erasure) error {
+	_, err := r.db.Exec(`INSERT INTO answer_erasures (key_hash, erased_at) VALUES ($1, $2)
+		ON CONFLICT (key_hash) DO UPDATE SET erased_at = GREATEST(answer_erasures.erased_at, EXCLUDED.erased_at)`,
+		erasure.KeyHash, erasure.ErasedAt.UnixNano())
+	return err
+}
+
+func (r *erasureRepo) Get(keyHash string) (*domain.Erasure, error) {
+	var erasedAt int64
+	err := r.db.QueryRow(`SELECT erased_at FROM answer_erasures WHERE key_hash = $1`, keyHash).Scan(&erasedAt)
+	if err == sql.ErrNoRows {
+		return nil, errors.NewErrNotFound("Erasure not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Erasure{
+		KeyHash:  keyHash,
+		ErasedAt: time.Unix(0, erasedAt).UTC(),
+	}, nil
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+type idempotencyRepo struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates a new repository.
+// The schema must be migrated with Migrate beforehand.
+func NewIdempotencyRepository(db *sql.DB) domain.IdempotencyRepository {
+	return &idempotencyRepo{
+		db: db,
+	}
+}
+
+func (r *idempotencyRepo) Reserve(record *domain.IdempotencyRecord, now time.Time) error {
+
+	// Remove the expired records, postgres does not expire rows by itself.
+	//
+	if _, err := r.db.Exec(`DELETE FROM idempotency_records WHERE expires_at <= $1`, now.UnixNano()); err != nil {
+		return err
+	}
+
+	// A conflict with a record which has not expired leaves no row affected, unless the lease
+	// of the record in progress has expired.
+	//
+	result, err := r.db.Exec(`INSERT INTO idempotency_records (key, fingerprint, response, expires_at, answer_key_hash, reservation_id, lease_expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (key) DO UPDATE SET fingerprint = EXCLUDED.fingerprint, response = EXCLUDED.response, expires_at = EXCLUDED.expires_at,
+		answer_key_hash = EXCLUDED.answer_key_hash, reservation_id = EXCLUDED.reservation_id, lease_expires_at = EXCLUDED.lease_expires_at
+		WHERE idempotency_records.expires_at <= $8
+		OR (idempotency_records.response = '' AND idempotency_records.lease_expires_at <> 0 AND idempotency_records.lease_expires_at <= $8)`,
+		record.Key, record.Fingerprint, record.Response, record.ExpiresAt.UnixNano(), record.AnswerKeyHash,
+		record.ReservationID, leaseNanos(record.LeaseExpiresAt), now.UnixNano())
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.NewErrAlreadyExist("Idempotency key already exists")
+	}
+	return nil
+}
+
+func (r *idempotencyRepo) Get(key string, now time.Time) (*domain.IdempotencyRecord, error) {
+	var (
+		record         = &domain.IdempotencyRecord{}
+		expiresAt      int64
+		leaseExpiresAt int64
+	)
+	err := r.db.QueryRow(`SELECT key, fingerprint, response, expires_at, answer_key_hash, reservation_id, lease_expires_at FROM idempotency_records
+		WHERE key = $1 AND expires_at > $2`,
+		key, now.UnixNano()).Scan(&record.Key, &record.Fingerprint, &record.Response, &expiresAt, &record.AnswerKeyHash,
+		&record.ReservationID, &leaseExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, errors.NewErrNotFound("Idempotency key not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	record.ExpiresAt = time.Unix(0, expiresAt).UTC()
+	if leaseExpiresAt != 0 {
+		record.LeaseExpiresAt = time.Unix(0, leaseExpiresAt).UTC()
+	}
+	return record, nil
+}
+
+func (r *idempotencyRepo) Complete(record *domain.IdempotencyRecord) error {
+	result, err := r.db.Exec(`UPDATE idempotency_records SET fingerprint = $2, response = $3, expires_at = $4, answer_key_hash = $5,
+		lease_expires_at = $7
+		WHERE key = $1 AND reservation_id = $6`,
+		record.Key, record.Fingerprint, record.Response, record.ExpiresAt.UnixNano(), record.AnswerKeyHash,
+		record.ReservationID, leaseNanos(record.LeaseExpiresAt))
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.NewErrNotFound("Idempotency key not found")
+	}
+	return nil
+}
+
+func (r *idempotencyRepo) Release(key, reservationID string) error {
+	_, err := r.db.Exec(`DELETE FROM idempotency_records WHERE key = $1 AND reservation_id = $2`, key, reservationID)
+	return err
+}
+
+func (r *idempotencyRepo) EraseAnswer(answerKeyHash string) (int, error) {
+	result, err := r.db.Exec(`DELETE FROM idempotency_records WHERE answer_key_hash = $1`, answerKeyHash)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
+
+// leaseNanos - returns the lease expiry time in nanoseconds, 0 if the record has no lease.
+func leaseNanos(leaseExpiresAt time.Time) int64 {
+	if leaseExpiresAt.IsZero() {
+		return 0
+	}
+	return leaseExpiresAt.UnixNano()
+}
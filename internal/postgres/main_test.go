@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var (
+	testDB *sql.DB
+)
+
+func TestMain(m *testing.M) {
+
+	// Setup PostgreSQL database.
+	//
+	ctx := context.Background()
+	exposedPort := "5432"
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:13-alpine",
+		ExposedPorts: []string{exposedPort},
+		WaitingFor:   wait.ForListeningPort(nat.Port(exposedPort)),
+		Env: map[string]string{
+			"POSTGRES_USER":     "test",
+			"POSTGRES_PASSWORD": "test",
+			"POSTGRES_DB":       "answers",
+		},
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		log.Fatalf("Failed to start container %v", err)
+	}
+
+	defer func() { _ = container.Terminate(ctx) }()
+
+	// Setup test dependencies.
+	//
+	ip, err := container.Host(ctx)
+	if err != nil {
+		log.Fatalf("Failed to start container host %v", err)
+	}
+	port, err := container.MappedPort(ctx, nat.Port(exposedPort))
+	if err != nil {
+		log.Fatalf("Failed to start container port %v", err)
+	}
+	dsn := fmt.Sprintf("postgres://test:test@%s:%s/answers?sslmode=disable", ip, port.Port())
+	fmt.Printf("Database address: %s\n", dsn)
+
+	// Open database, the server restarts once after the initialization.
+	//
+	testDB, err = sql.Open(DriverName, dsn)
+	if err != nil {
+		log.Fatalf("Failed to open database %v", err)
+	}
+	for i := 0; i < 30; i++ {
+		if err = testDB.Ping(); err == nil {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	if err != nil {
+		log.Fatalf("Failed to connect database %v", err)
+	}
+	if err := Migrate(testDB); err != nil {
+		log.Fatalf("Failed to migrate database %v", err)
+	}
+
+	exitVal := m.Run()
+	os.Exit(exitVal)
+}
+
+// truncate - removes all the rows of the provided tables.
+func truncate(t *testing.T, tables ...string) {
+	for _, table := range tables {
+		if _, err := testDB.Exec(fmt.Sprintf("TRUNCATE %s", table)); err != nil {
+			t.Fatalf("Failed to truncate %s: %v", table, err)
+		}
+	}
+}
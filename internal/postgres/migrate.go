@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Migrations are applied in the lexical order of their file names.
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Migrate - applies the embedded SQL migrations which were not applied yet.
+// Every migration runs in its own transaction together with its bookkeeping record.
+func Migrate(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return err
+	}
+
+	// Apply migrations.
+	//
+	versions, err := migrationVersions()
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if err := applyMigration(db, version); err != nil {
+			return fmt.Errorf("migration %s failed: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// CheckMigrations - checks that all the embedded SQL migrations were applied.
+// The migrations are applied by the provision command.
+func CheckMigrations(db *sql.DB) error {
+	versions, err := migrationVersions()
+	if err != nil {
+		return err
+	}
+	applied := make(map[string]bool)
+	rows, err := db.Query(`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("schema is not migrated, run the provision command: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return err
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	var pending []string
+	for _, version := range versions {
+		if !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("migrations %s are not applied, run the provision command", strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// migrationVersions - returns the names of the embedded migrations in order.
+func migrationVersions() ([]string, error) {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, entry := range entries {
+		versions = append(versions, entry.Name())
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+
+func applyMigration(db *sql.DB, version string) error {
+	script, err := migrations.ReadFile(path.Join("migrations", version))
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Serialize concurrent migrators, e.g. the app and the worker starting together.
+	//
+	if _, err := tx.Exec(`LOCK TABLE schema_migrations IN EXCLUSIVE MODE`); err != nil {
+		return err
+	}
+	var applied bool
+	err = tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version).Scan(&applied)
+	if err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+	if _, err := tx.Exec(string(script)); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version) VALUES ($1)`, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,14 @@
+CREATE TABLE answers (
+    key   TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+);
+
+CREATE TABLE answer_events (
+    id          BIGSERIAL PRIMARY KEY,
+    key         TEXT NOT NULL,
+    occurred_at BIGINT NOT NULL,
+    event_type  TEXT NOT NULL,
+    data        JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX answer_events_key_occurred_at_idx ON answer_events (key, occurred_at);
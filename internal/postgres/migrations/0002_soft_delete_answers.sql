@@ -0,0 +1,6 @@
+ALTER TABLE answers ADD COLUMN deleted_at BIGINT;
+
+ALTER TABLE answers ADD COLUMN purge_at BIGINT;
+
+-- The purge command looks the deleted answers up.
+CREATE INDEX answers_deleted_key_idx ON answers (key) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,22 @@
+CREATE TABLE webhook_subscriptions (
+    id          TEXT PRIMARY KEY,
+    url         TEXT NOT NULL,
+    event_types TEXT[] NOT NULL,
+    secret      TEXT NOT NULL,
+    created_at  BIGINT NOT NULL
+);
+
+-- An attempt is identified by its subscription and time, the times are in nanoseconds.
+CREATE TABLE webhook_deliveries (
+    subscription_id TEXT NOT NULL,
+    attempted_at    BIGINT NOT NULL,
+    id              TEXT NOT NULL,
+    event_type      TEXT NOT NULL,
+    key             TEXT NOT NULL,
+    occurred_at     BIGINT NOT NULL,
+    attempt         INTEGER NOT NULL,
+    status_code     INTEGER NOT NULL,
+    error           TEXT NOT NULL,
+    succeeded       BOOLEAN NOT NULL,
+    PRIMARY KEY (subscription_id, attempted_at)
+);
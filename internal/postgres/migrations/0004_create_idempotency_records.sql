@@ -0,0 +1,14 @@
+-- The responses of the requests made with an idempotency key, the expiry time is in nanoseconds.
+-- The answer key is only stored as its hash, the records are erased with the answer.
+CREATE TABLE idempotency_records (
+    key             TEXT PRIMARY KEY,
+    fingerprint     TEXT NOT NULL,
+    response        TEXT NOT NULL,
+    expires_at      BIGINT NOT NULL,
+    answer_key_hash TEXT NOT NULL DEFAULT ''
+);
+
+-- The expired records are removed when a key is reserved.
+CREATE INDEX idempotency_records_expires_at_idx ON idempotency_records (expires_at);
+
+CREATE INDEX idempotency_records_answer_key_hash_idx ON idempotency_records (answer_key_hash);
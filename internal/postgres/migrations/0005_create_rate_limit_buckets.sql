@@ -0,0 +1,10 @@
+-- The token buckets of the rate limited clients, the times are in nanoseconds.
+CREATE TABLE rate_limit_buckets (
+    key        TEXT PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    updated_at BIGINT NOT NULL,
+    expires_at BIGINT NOT NULL
+);
+
+-- The buckets which are full again are removed from time to time.
+CREATE INDEX rate_limit_buckets_expires_at_idx ON rate_limit_buckets (expires_at);
@@ -0,0 +1,5 @@
+-- The tombstones of the erased answer keys, the key is only stored as its hash and the erasure time is in nanoseconds.
+CREATE TABLE answer_erasures (
+    key_hash  TEXT PRIMARY KEY,
+    erased_at BIGINT NOT NULL
+);
@@ -0,0 +1 @@
+ALTER TABLE answers ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- The reservation of a request in progress, a retry may take it over once the lease has expired.
+-- The lease expiry time is in nanoseconds, 0 once the record is complete.
+ALTER TABLE idempotency_records ADD COLUMN reservation_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE idempotency_records ADD COLUMN lease_expires_at BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- The deliveries hold the hash of the answer key instead of the key, so an erasure leaves no key behind.
+-- The stored keys cannot be hashed here, they are cleared.
+ALTER TABLE webhook_deliveries RENAME COLUMN key TO key_hash;
+UPDATE webhook_deliveries SET key_hash = '';
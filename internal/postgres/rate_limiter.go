@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+// rateLimiterSweepInterval - how often the full buckets are removed.
+const rateLimiterSweepInterval = time.Minute
+
+type rateLimiter struct {
+	db        *sql.DB
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+// NewRateLimiter creates a new rate limiter, its buckets are shared by the replicas.
+// The schema must be migrated with Migrate beforehand.
+func NewRateLimiter(db *sql.DB) domain.RateLimiter {
+	return &rateLimiter{
+		db: db,
+	}
+}
+
+func (r *rateLimiter) Take(key string, limit domain.RateLimit, now time.Time) (bool, time.Duration, error) {
+	if err := r.sweep(now); err != nil {
+		return false, 0, err
+	}
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// A new bucket is full, the row is locked until the take is committed.
+	//
+	_, err = tx.Exec(`INSERT INTO rate_limit_buckets (key, tokens, updated_at, expires_at) VALUES ($1, $2, $3, $3)
+		ON CONFLICT (key) DO NOTHING`, key, float64(limit.Burst), now.UnixNano())
+	if err != nil {
+		return false, 0, err
+	}
+	var (
+		bucket    domain.TokenBucket
+		updatedAt int64
+	)
+	err = tx.QueryRow(`SELECT tokens, updated_at FROM rate_limit_buckets WHERE key = $1 FOR UPDATE`, key).Scan(&bucket.Tokens, &updatedAt)
+	if err != nil {
+		return false, 0, err
+	}
+	bucket.UpdatedAt = time.Unix(0, updatedAt)
+	next, allowed, retryAfter := limit.Take(&bucket, now)
+	_, err = tx.Exec(`UPDATE rate_limit_buckets SET tokens = $2, updated_at = $3, expires_at = $4 WHERE key = $1`,
+		key, next.Tokens, next.UpdatedAt.UnixNano(), limit.FullAt(next).UnixNano())
+	if err != nil {
+		return false, 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, 0, err
+	}
+	return allowed, retryAfter, nil
+}
+
+// sweep - removes the full buckets once in a while, postgres does not expire rows by itself.
+func (r *rateLimiter) sweep(now time.Time) error {
+	r.mu.Lock()
+	if now.Sub(r.lastSweep) < rateLimiterSweepInterval {
+		r.mu.Unlock()
+		return nil
+	}
+	r.lastSweep = now
+	r.mu.Unlock()
+	_, err := r.db.Exec(`DELETE FROM rate_limit_buckets WHERE expires_at <= $1`, now.UnixNano())
+	return err
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/repositorytest"
+)
+
+func TestAnswerRepository(t *testing.T) {
+	repositorytest.TestAnswerRepository(t, func(t *testing.T) domain.AnswerRepository {
+		truncate(t, "answers")
+		return NewAnswerRepository(testDB)
+	})
+}
+
+func TestAnswerEventRepository(t *testing.T) {
+	repositorytest.TestAnswerEventRepository(t, func(t *testing.T) domain.AnswerEventRepository {
+		truncate(t, "answer_events")
+		return NewAnswerEventRepository(testDB)
+	})
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	if err := Migrate(testDB); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+}
+
+func TestCheckMigrations(t *testing.T) {
+	if err := CheckMigrations(testDB); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+
+	// A pending migration must be reported.
+	//
+	if _, err := testDB.Exec(`DELETE FROM schema_migrations WHERE version = '0002_soft_delete_answers.sql'`); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_, _ = testDB.Exec(`INSERT INTO schema_migrations (version) VALUES ('0002_soft_delete_answers.sql')`)
+	}()
+	err := CheckMigrations(testDB)
+	if err == nil || !strings.Contains(err.Error(), "0002_soft_delete_answers.sql") {
+		t.Errorf("expected pending migration err, got %v", err)
+	}
+}
+
+func TestWebhookRepository(t *testing.T) {
+	repositorytest.TestWebhookRepository(t, func(t *testing.T) domain.WebhookRepository {
+		truncate(t, "webhook_subscriptions", "webhook_deliveries")
+		return NewWebhookRepository(testDB)
+	})
+}
+
+func TestIdempotencyRepository(t *testing.T) {
+	repositorytest.TestIdempotencyRepository(t, func(t *testing.T) domain.IdempotencyRepository {
+		truncate(t, "idempotency_records")
+		return NewIdempotencyRepository(testDB)
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	repositorytest.TestRateLimiter(t, func(t *testing.T) domain.RateLimiter {
+		truncate(t, "rate_limit_buckets")
+		return NewRateLimiter(testDB)
+	})
+}
+
+func TestErasureRepository(t *testing.T) {
+	repositorytest.TestErasureRepository(t, func(t *testing.T) domain.ErasureRepository {
+		truncate(t, "answer_erasures")
+		return NewErasureRepository(testDB)
+	})
+}
@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/lib/pq"
+)
+
+type webhookRepo struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository creates a new repository.
+// The schema must be migrated with Migrate beforehand.
+func NewWebhookRepository(db *sql.DB) domain.WebhookRepository {
+	return &webhookRepo{
+		db: db,
+	}
+}
+
+func (r *webhookRepo) CreateSubscription(subscription *domain.WebhookSubscription) error {
+	eventTypes := make([]string, len(subscription.EventTypes))
+	for i, eventType := range subscription.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+	_, err := r.db.Exec(`INSERT INTO webhook_subscriptions (id, url, event_types, secret, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		subscription.ID, subscription.URL, pq.Array(eventTypes), subscription.Secret, subscription.CreatedAt.UnixNano())
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == pqErrorUniqueViolation {
+		return errors.NewErrAlreadyExist("Webhook subscription already exists")
+	}
+	return err
+}
+
+func (r *webhookRepo) GetSubscription(id string) (*domain.WebhookSubscription, error) {
+	rows, err := r.db.Query(`SELECT id, url, event_types, secret, created_at FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	items, err := scanSubscriptions(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errors.NewErrNotFound("Webhook subscription not found")
+	}
+	return items[0], nil
+}
+
+func (r *webhookRepo) ListSubscriptions(page domain.PageRequest) ([]*domain.WebhookSubscription, string, error) {
+
+	// Subscriptions are listed by id, the token holds the last id of the previous page.
+	//
+	lastID, err := domain.DecodePageToken(page.PageToken)
+	if err != nil {
+		return nil, "", errors.NewErrInvalidArgument(err.Error())
+	}
+	var rows *sql.Rows
+	if len(page.PageToken) == 0 {
+		rows, err = r.db.Query(`SELECT id, url, event_types, secret, created_at FROM webhook_subscriptions
+			ORDER BY id LIMIT $1`, page.Size()+1)
+	} else {
+		rows, err = r.db.Query(`SELECT id, url, event_types, secret, created_at FROM webhook_subscriptions
+			WHERE id > $1 ORDER BY id LIMIT $2`, lastID, page.Size()+1)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	items, err := scanSubscriptions(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// One more row than requested means there is a next page.
+	//
+	if len(items) <= page.Size() {
+		return items, "", nil
+	}
+	items = items[:page.Size()]
+	return items, domain.EncodePageToken(items[len(items)-1].ID), nil
+}
+
+func (r *webhookRepo) DeleteSubscription(id string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	result, err := tx.Exec(`DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.NewErrNotFound("Webhook subscription not found")
+	}
+	if _, err := tx.Exec(`DELETE FROM webhook_deliveries WHERE subscription_id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *webhookRepo) CreateDelivery(delivery *domain.WebhookDelivery) error {
+	_, err := r.db.Exec(`INSERT INTO webhook_deliveries
+		(subscription_id, attempted_at, id, event_type, key_hash, occurred_at, attempt, status_code, error, succeeded)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		delivery.SubscriptionID, delivery.AttemptedAt.UnixNano(), delivery.ID, delivery.EventType, delivery.KeyHash,
+		delivery.OccurredAt.UnixNano(), delivery.Attempt, delivery.StatusCode, delivery.Error, delivery.Succeeded)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == pqErrorUniqueViolation {
+		return errors.NewErrAlreadyExist("Webhook delivery already exists")
+	}
+	return err
+}
+
+func (r *webhookRepo) ListDeliveries(subscriptionID string, page domain.PageRequest) ([]*domain.WebhookDelivery, string, error) {
+
+	// The token holds the attempt time of the last delivery of the previous page.
+	//
+	var after int64
+	if len(page.PageToken) > 0 {
+		cursor, err := domain.DecodePageToken(page.PageToken)
+		if err != nil {
+			return nil, "", errors.NewErrInvalidArgument(err.Error())
+		}
+		if after, err = strconv.ParseInt(cursor, 10, 64); err != nil {
+			return nil, "", errors.NewErrInvalidArgument("Page token is not valid")
+		}
+	}
+	rows, err := r.db.Query(`SELECT id, subscription_id, event_type, key_hash, occurred_at, attempt, attempted_at, status_code, error, succeeded
+		FROM webhook_deliveries WHERE subscription_id = $1 AND attempted_at > $2 ORDER BY attempted_at LIMIT $3`,
+		subscriptionID, after, page.Size()+1)
+	if err != nil {
+		return nil, "", err
+	}
+	items, err := scanDeliveries(rows)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// One more row than requested means there is a next page.
+	//
+	if len(items) <= page.Size() {
+		return items, "", nil
+	}
+	items = items[:page.Size()]
+	lastAttemptedAt := items[len(items)-1].AttemptedAt.UnixNano()
+	return items, domain.EncodePageToken(strconv.FormatInt(lastAttemptedAt, 10)), nil
+}
+
+func (r *webhookRepo) ListDeliveryAttempts(subscriptionID, deliveryID string, since time.Time) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.db.Query(`SELECT id, subscription_id, event_type, key_hash, occurred_at, attempt, attempted_at, status_code, error, succeeded
+		FROM webhook_deliveries WHERE subscription_id = $1 AND attempted_at >= $2 AND id = $3 ORDER BY attempted_at`,
+		subscriptionID, since.UnixNano(), deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	return scanDeliveries(rows)
+}
+
+func scanSubscriptions(rows *sql.Rows) ([]*domain.WebhookSubscription, error) {
+	defer rows.Close()
+
+	var items []*domain.WebhookSubscription
+	for rows.Next() {
+		var (
+			item       = &domain.WebhookSubscription{}
+			eventTypes []string
+			createdAt  int64
+		)
+		if err := rows.Scan(&item.ID, &item.URL, pq.Array(&eventTypes), &item.Secret, &createdAt); err != nil {
+			return nil, err
+		}
+		for _, eventType := range eventTypes {
+			item.EventTypes = append(item.EventTypes, domain.AnswerEventType(eventType))
+		}
+		item.CreatedAt = time.Unix(0, createdAt).UTC()
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func scanDeliveries(rows *sql.Rows) ([]*domain.WebhookDelivery, error) {
+	defer rows.Close()
+
+	var items []*domain.WebhookDelivery
+	for rows.Next() {
+		var (
+			item                    = &domain.WebhookDelivery{}
+			occurredAt, attemptedAt int64
+		)
+		err := rows.Scan(&item.ID, &item.SubscriptionID, &item.EventType, &item.KeyHash, &occurredAt, &item.Attempt,
+			&attemptedAt, &item.StatusCode, &item.Error, &item.Succeeded)
+		if err != nil {
+			return nil, err
+		}
+		item.OccurredAt = time.Unix(0, occurredAt).UTC()
+		item.AttemptedAt = time.Unix(0, attemptedAt).UTC()
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,35 @@
+package queue
+
+import (
+	"encoding/json"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+// EncodeMessage - validates the message and marshals it to JSON.
+// It is shared by all queue backends, so they accept exactly the same messages.
+func EncodeMessage(queueName string, message domain.QueueMessage) ([]byte, error) {
+
+	// Check if the queue name defined.
+	//
+	if len(queueName) == 0 {
+		return nil, errors.NewErrInvalidArgument("Queue name required")
+	}
+
+	// Check message for nil.
+	//
+	if message == nil {
+		return nil, errors.NewErrInvalidArgument("Message cannot be nil")
+	}
+
+	// Validate message.
+	//
+	if err := message.Validate(); err != nil {
+		return nil, errors.NewErrInvalidArgument(err.Error())
+	}
+
+	// Marshal message body to JSON.
+	//
+	return json.Marshal(message)
+}
@@ -1,4 +1,4 @@
-package sqsqueue
+package queue
 
 import (
 	"context"
@@ -11,7 +11,9 @@ import (
 // Middleware describes a service (as opposed to endpoint) middleware.
 type Middleware func(domain.QueueService) domain.QueueService
 
-func loggingServiceMiddleware(logger log.Logger) Middleware {
+// LoggingServiceMiddleware takes a logger as a dependency
+// and returns a service Middleware.
+func LoggingServiceMiddleware(logger log.Logger) Middleware {
 	return func(next domain.QueueService) domain.QueueService {
 		return loggingMiddleware{logger, next}
 	}
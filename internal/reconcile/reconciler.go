@@ -0,0 +1,160 @@
+package reconcile
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+// DivergenceKind - the way the stored answer differs from the replayed history.
+type DivergenceKind string
+
+// Divergence kinds.
+const (
+	// MissingDivergence - the history holds the answer, the table does not.
+	MissingDivergence = DivergenceKind("missing")
+	// UnexpectedDivergence - the table holds the answer, the history does not.
+	UnexpectedDivergence = DivergenceKind("unexpected")
+	// ValueDivergence - the table and the history hold different values.
+	ValueDivergence = DivergenceKind("value")
+)
+
+// Divergence - represents a key whose stored answer does not match its replayed history.
+type Divergence struct {
+	Kind DivergenceKind   `json:"kind"`
+	Key  domain.AnswerKey `json:"key"`
+	// Stored - the answer in the table, nil if it is missing.
+	Stored *domain.Answer `json:"stored"`
+	// Replayed - the answer rebuilt from the history, nil if it does not exist.
+	Replayed *domain.Answer `json:"replayed"`
+}
+
+// Summary - the result of a reconciliation.
+type Summary struct {
+	Checked     int
+	Divergences int
+}
+
+// DivergenceFunc - receives a found divergence, an error stops the reconciliation.
+type DivergenceFunc func(divergence *Divergence) error
+
+// Reconciler - compares the answers table with the state rebuilt from the answer history.
+type Reconciler interface {
+
+	// Reconcile - checks every key of the table and of the history and calls fn for each divergence.
+	// Events are recorded asynchronously, so recently written answers may be reported as diverged.
+	Reconcile(ctx context.Context, fn DivergenceFunc) (*Summary, error)
+}
+
+type reconciler struct {
+	repository      domain.AnswerRepository
+	eventRepository domain.AnswerEventRepository
+	pageSize        int
+}
+
+// NewReconciler creates a new reconciler with necessary dependencies.
+func NewReconciler(repository domain.AnswerRepository, eventRepository domain.AnswerEventRepository) Reconciler {
+	return &reconciler{
+		repository:      repository,
+		eventRepository: eventRepository,
+		pageSize:        domain.DefaultPageSize,
+	}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, fn DivergenceFunc) (*Summary, error) {
+	summary := &Summary{}
+	checked := make(map[domain.AnswerKey]bool)
+	check := func(key domain.AnswerKey, stored *domain.Answer) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		checked[key] = true
+		summary.Checked++
+		divergence, err := r.compare(key, stored)
+		if err != nil || divergence == nil {
+			return err
+		}
+		summary.Divergences++
+		return fn(divergence)
+	}
+
+	// Check the answers of the table.
+	//
+	page := domain.PageRequest{PageSize: r.pageSize}
+	for {
+		answers, nextPageToken, err := r.repository.List(page)
+		if err != nil {
+			return summary, err
+		}
+		for _, answer := range answers {
+			if err := check(answer.Key, answer); err != nil {
+				return summary, err
+			}
+		}
+		if len(nextPageToken) == 0 {
+			break
+		}
+		page.PageToken = nextPageToken
+	}
+
+	// Check the keys which only have a history.
+	//
+	page = domain.PageRequest{PageSize: r.pageSize}
+	for {
+		keys, nextPageToken, err := r.eventRepository.ListKeys(page)
+		if err != nil {
+			return summary, err
+		}
+		for _, key := range keys {
+			if checked[key] {
+				continue
+			}
+
+			// The answer may have been created after the table was listed.
+			stored, err := r.findAnswer(key)
+			if err != nil {
+				return summary, err
+			}
+			if err := check(key, stored); err != nil {
+				return summary, err
+			}
+		}
+		if len(nextPageToken) == 0 {
+			return summary, nil
+		}
+		page.PageToken = nextPageToken
+	}
+}
+
+// compare - replays the history of the key and returns the divergence from the stored answer, nil if they match.
+func (r *reconciler) compare(key domain.AnswerKey, stored *domain.Answer) (*Divergence, error) {
+	events, err := r.eventRepository.ListEvents(key)
+	if err != nil {
+		return nil, err
+	}
+	replayed := domain.ReplayAnswerEvents(events).Answer
+	divergence := &Divergence{Key: key, Stored: stored, Replayed: replayed}
+	switch {
+	case stored == nil && replayed == nil:
+		return nil, nil
+	case stored == nil:
+		divergence.Kind = MissingDivergence
+	case replayed == nil:
+		divergence.Kind = UnexpectedDivergence
+	case stored.Value != replayed.Value:
+		divergence.Kind = ValueDivergence
+	default:
+		return nil, nil
+	}
+	return divergence, nil
+}
+
+// findAnswer - returns the answer or nil if it does not exist.
+func (r *reconciler) findAnswer(key domain.AnswerKey) (*domain.Answer, error) {
+	foundAnswer, err := r.repository.Get(key)
+	if _, ok := err.(*errors.ErrNotFound); ok {
+		return nil, nil
+	}
+	return foundAnswer, err
+}
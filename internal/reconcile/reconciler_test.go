@@ -0,0 +1,76 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/inmemory"
+
+	"github.com/go-test/deep"
+)
+
+func TestReconcile(t *testing.T) {
+	repository := inmemory.NewAnswerRepository()
+	eventRepository := inmemory.NewAnswerEventRepository()
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	record := func(eventType domain.AnswerEventType, key domain.AnswerKey, value domain.AnswerValue) {
+		now = now.Add(time.Second)
+		err := eventRepository.Create(&domain.AnswerEvent{EventType: eventType, Data: &domain.Answer{Key: key, Value: value}, OccurredAt: now})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := func(key domain.AnswerKey, value domain.AnswerValue) {
+		if err := repository.Create(&domain.Answer{Key: key, Value: value}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Setup a consistent key and one key per divergence kind.
+	//
+	store("consistent", "1")
+	record(domain.CreateAnswerEventType, "consistent", "0")
+	record(domain.UpdateAnswerEventType, "consistent", "1")
+	record(domain.CreateAnswerEventType, "deleted", "1")
+	record(domain.DeleteAnswerEventType, "deleted", "1")
+	record(domain.CreateAnswerEventType, "missing", "1")
+	store("unexpected", "1")
+	record(domain.CreateAnswerEventType, "value", "1")
+	record(domain.UpdateAnswerEventType, "value", "2")
+	store("value", "1")
+
+	r := NewReconciler(repository, eventRepository).(*reconciler)
+	r.pageSize = 1
+	var got []*Divergence
+	summary, err := r.Reconcile(context.Background(), func(divergence *Divergence) error {
+		got = append(got, divergence)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i].Key < got[j].Key })
+	want := []*Divergence{
+		{Kind: MissingDivergence, Key: "missing", Replayed: &domain.Answer{Key: "missing", Value: "1"}},
+		{Kind: UnexpectedDivergence, Key: "unexpected", Stored: &domain.Answer{Key: "unexpected", Value: "1", Version: 1}},
+		{Kind: ValueDivergence, Key: "value", Stored: &domain.Answer{Key: "value", Value: "1", Version: 1}, Replayed: &domain.Answer{Key: "value", Value: "2"}},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal(summary, &Summary{Checked: 5, Divergences: 3}); diff != nil {
+		t.Error(diff)
+	}
+
+	// The first error of the callback stops the reconciliation.
+	//
+	stop := errors.New("stop")
+	summary, err = r.Reconcile(context.Background(), func(divergence *Divergence) error { return stop })
+	if err != stop || summary.Divergences != 1 {
+		t.Errorf("expected the reconciliation to stop, got err %v after %v divergences", err, summary.Divergences)
+	}
+}
@@ -0,0 +1,30 @@
+package redaction
+
+import (
+	"github.com/go-kit/log"
+)
+
+type logger struct {
+	next     log.Logger
+	redactor *Redactor
+}
+
+// NewLogger creates a logger which redacts the logged values before the next logger writes them.
+// It must wrap the base logger, so the valuers of log.With are resolved with the expected caller depth.
+func NewLogger(next log.Logger, redactor *Redactor) log.Logger {
+	return &logger{
+		next:     next,
+		redactor: redactor,
+	}
+}
+
+func (l *logger) Log(keyvals ...interface{}) error {
+	redacted := make([]interface{}, len(keyvals))
+	for i, value := range keyvals {
+		if i%2 == 1 {
+			value = l.redactor.Redact(value)
+		}
+		redacted[i] = value
+	}
+	return l.next.Log(redacted...)
+}
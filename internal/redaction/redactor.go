@@ -0,0 +1,285 @@
+package redaction
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+// Policy - the way an answer value or key is written to the logs.
+type Policy string
+
+// Policies.
+const (
+	// NoPolicy - writes it as it is, it must only be used in development.
+	NoPolicy = Policy("none")
+	// HashPolicy - writes a salted hash, equal values can still be correlated.
+	HashPolicy = Policy("hash")
+	// MaskPolicy - writes an asterisk per character.
+	MaskPolicy = Policy("mask")
+	// DropPolicy - leaves it out.
+	DropPolicy = Policy("drop")
+)
+
+// hashPrefix - marks the hashed values, a hash is the first 8 bytes of the HMAC-SHA256 of the value.
+const hashPrefix = "hmac:"
+
+// Redactor - redacts the answer values and the sensitive answer keys before they are logged.
+type Redactor struct {
+	valuePolicy   Policy
+	keyPolicy     Policy
+	sensitiveKeys []*regexp.Regexp
+	hashSalt      []byte
+}
+
+// NewRedactor creates a redactor, the value policy applies to every value and the key policy
+// to the keys matching one of the sensitive key patterns.
+func NewRedactor(valuePolicy, keyPolicy Policy, sensitiveKeyPatterns []string, hashSalt string) (*Redactor, error) {
+	r := &Redactor{
+		valuePolicy: valuePolicy,
+		keyPolicy:   keyPolicy,
+		hashSalt:    []byte(hashSalt),
+	}
+	for _, policy := range []Policy{valuePolicy, keyPolicy} {
+		switch policy {
+		case NoPolicy, HashPolicy, MaskPolicy, DropPolicy:
+		default:
+			return nil, fmt.Errorf("unsupported redaction policy %q", policy)
+		}
+	}
+	for _, pattern := range sensitiveKeyPatterns {
+		sensitiveKey, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		r.sensitiveKeys = append(r.sensitiveKeys, sensitiveKey)
+	}
+	return r, nil
+}
+
+// Value - returns the value as it may be logged, empty if it is dropped.
+func (r *Redactor) Value(value domain.AnswerValue) string {
+	return r.apply(r.valuePolicy, string(value))
+}
+
+// Key - returns the key as it may be logged, empty if it is dropped.
+func (r *Redactor) Key(key domain.AnswerKey) string {
+	for _, sensitiveKey := range r.sensitiveKeys {
+		if sensitiveKey.MatchString(string(key)) {
+			return r.apply(r.keyPolicy, string(key))
+		}
+	}
+	return string(key)
+}
+
+// Redact - returns the log value with the answer values and the sensitive keys redacted.
+// Values of other types are returned as they are.
+func (r *Redactor) Redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case domain.AnswerKey:
+		return r.Key(v)
+	case []domain.AnswerKey:
+		keys := make([]string, len(v))
+		for i, key := range v {
+			keys[i] = r.Key(key)
+		}
+		return keys
+	case domain.AnswerValue:
+		return r.Value(v)
+	case domain.Answer:
+		return r.answer(&v)
+	case *domain.Answer:
+		if v == nil {
+			return nil
+		}
+		return r.answer(v)
+	case []*domain.Answer:
+		views := make([]*answerView, len(v))
+		for i, answer := range v {
+			views[i] = r.answer(answer)
+		}
+		return views
+	case *domain.AnswerEvent:
+		if v == nil {
+			return nil
+		}
+		return r.event(v)
+	case []*domain.AnswerEvent:
+		return r.events(v)
+	case *domain.AnswerSnapshot:
+		if v == nil {
+			return nil
+		}
+		return &snapshotView{Answer: r.answer(v.Answer), Version: v.Version, OccurredAt: v.OccurredAt}
+	case *domain.DeletedAnswer:
+		if v == nil {
+			return nil
+		}
+		return r.deletedAnswer(v)
+	case []*domain.DeletedAnswer:
+		views := make([]*deletedAnswerView, len(v))
+		for i, answer := range v {
+			views[i] = r.deletedAnswer(answer)
+		}
+		return views
+	case *domain.AnswerRecord:
+		if v == nil {
+			return nil
+		}
+		return &recordView{Key: r.Key(v.Key), Answer: r.answer(v.Answer), Events: r.events(v.Events)}
+	case *domain.AnswerEventMessage:
+		if v == nil {
+			return nil
+		}
+		return &messageView{Event: r.event(v.Event)}
+	case domain.AnswerSearchQuery:
+		return &searchQueryView{Key: r.searchTerm(v.Key, r.keyText), Value: r.searchTerm(v.Value, r.valueText)}
+	case *domain.ErasureReceipt:
+		if v == nil {
+			return nil
+		}
+		return &receiptView{
+			KeyHash:      v.KeyHash,
+			ErasedAt:     v.ErasedAt,
+			AnswerErased: v.AnswerErased,
+			EventsErased: v.EventsErased,
+			RequestedBy:  v.RequestedBy,
+		}
+	default:
+		return value
+	}
+}
+
+func (r *Redactor) apply(policy Policy, s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	switch policy {
+	case HashPolicy:
+		mac := hmac.New(sha256.New, r.hashSalt)
+		_, _ = mac.Write([]byte(s))
+		return hashPrefix + hex.EncodeToString(mac.Sum(nil)[:8])
+	case MaskPolicy:
+		return strings.Repeat("*", utf8.RuneCountInString(s))
+	case DropPolicy:
+		return ""
+	default:
+		return s
+	}
+}
+
+// answerView - the logged answer.
+type answerView struct {
+	Key   string `json:"key,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// eventView - the logged answer event.
+type eventView struct {
+	EventType  domain.AnswerEventType `json:"eventType"`
+	Data       *answerView            `json:"data,omitempty"`
+	OccurredAt time.Time              `json:"occurredAt"`
+}
+
+// snapshotView - the logged answer snapshot.
+type snapshotView struct {
+	Answer     *answerView `json:"answer,omitempty"`
+	Version    int         `json:"version"`
+	OccurredAt time.Time   `json:"occurredAt"`
+}
+
+// deletedAnswerView - the logged deleted answer.
+type deletedAnswerView struct {
+	answerView
+	DeletedAt time.Time `json:"deletedAt"`
+	PurgeAt   time.Time `json:"purgeAt"`
+}
+
+// recordView - the logged answer record.
+type recordView struct {
+	Key    string       `json:"key,omitempty"`
+	Answer *answerView  `json:"answer,omitempty"`
+	Events []*eventView `json:"events"`
+}
+
+// messageView - the logged answer event message.
+type messageView struct {
+	Event *eventView `json:"event,omitempty"`
+}
+
+// receiptView - the logged erasure receipt.
+type receiptView struct {
+	KeyHash      string    `json:"keyHash"`
+	ErasedAt     time.Time `json:"erasedAt"`
+	AnswerErased bool      `json:"answerErased"`
+	EventsErased int       `json:"eventsErased"`
+	RequestedBy  string    `json:"requestedBy"`
+}
+
+// searchTermView - the logged search term.
+type searchTermView struct {
+	Text      string                 `json:"text,omitempty"`
+	Match     domain.AnswerMatchType `json:"match"`
+	Fuzziness int                    `json:"fuzziness,omitempty"`
+}
+
+// searchQueryView - the logged search query, the texts are redacted as the keys and the values they match.
+type searchQueryView struct {
+	Key   *searchTermView `json:"key,omitempty"`
+	Value *searchTermView `json:"value,omitempty"`
+}
+
+func (r *Redactor) answer(answer *domain.Answer) *answerView {
+	if answer == nil {
+		return nil
+	}
+	return &answerView{Key: r.Key(answer.Key), Value: r.Value(answer.Value)}
+}
+
+func (r *Redactor) event(event *domain.AnswerEvent) *eventView {
+	if event == nil {
+		return nil
+	}
+	return &eventView{EventType: event.EventType, Data: r.answer(event.Data), OccurredAt: event.OccurredAt}
+}
+
+func (r *Redactor) events(events []*domain.AnswerEvent) []*eventView {
+	views := make([]*eventView, len(events))
+	for i, event := range events {
+		views[i] = r.event(event)
+	}
+	return views
+}
+
+func (r *Redactor) deletedAnswer(answer *domain.DeletedAnswer) *deletedAnswerView {
+	if answer == nil {
+		return nil
+	}
+	return &deletedAnswerView{
+		answerView: *r.answer(&answer.Answer),
+		DeletedAt:  answer.DeletedAt,
+		PurgeAt:    answer.PurgeAt,
+	}
+}
+
+func (r *Redactor) searchTerm(term *domain.AnswerSearchTerm, redact func(string) string) *searchTermView {
+	if term == nil {
+		return nil
+	}
+	return &searchTermView{Text: redact(term.Text), Match: term.Match, Fuzziness: term.Fuzziness}
+}
+
+func (r *Redactor) keyText(s string) string {
+	return r.Key(domain.AnswerKey(s))
+}
+
+func (r *Redactor) valueText(s string) string {
+	return r.Value(domain.AnswerValue(s))
+}
@@ -0,0 +1,124 @@
+package redaction
+
+import (
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+	"github.com/go-test/deep"
+)
+
+var testOccurredAt = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func newTestRedactor(t *testing.T, valuePolicy, keyPolicy Policy) *Redactor {
+	t.Helper()
+	r, err := NewRedactor(valuePolicy, keyPolicy, []string{`^patient\.`}, "salt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRedactorPolicies(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    Policy
+		wantValue string
+		wantKey   string
+	}{
+		{name: "none", policy: NoPolicy, wantValue: "Jöhn", wantKey: "patient.name"},
+		{name: "hash", policy: HashPolicy, wantValue: "hmac:7af0a1041ba1daf2", wantKey: "hmac:b7cd816d4b0aa1a8"},
+		{name: "mask", policy: MaskPolicy, wantValue: "****", wantKey: "************"},
+		{name: "drop", policy: DropPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRedactor(t, tt.policy, tt.policy)
+			if diff := deep.Equal(r.Value("Jöhn"), tt.wantValue); diff != nil {
+				t.Errorf("value: %v", diff)
+			}
+			if diff := deep.Equal(r.Key("patient.name"), tt.wantKey); diff != nil {
+				t.Errorf("key: %v", diff)
+			}
+
+			// Only the sensitive keys are redacted.
+			//
+			if diff := deep.Equal(r.Key("city"), "city"); diff != nil {
+				t.Errorf("key: %v", diff)
+			}
+		})
+	}
+}
+
+func TestRedactorHashSalt(t *testing.T) {
+	r, err := NewRedactor(HashPolicy, HashPolicy, nil, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Value("Jöhn") == newTestRedactor(t, HashPolicy, HashPolicy).Value("Jöhn") {
+		t.Error("expected the hash to depend on the salt")
+	}
+}
+
+func TestNewRedactorErrors(t *testing.T) {
+	if _, err := NewRedactor("encrypt", NoPolicy, nil, ""); err == nil {
+		t.Error("expected error for unsupported policy")
+	}
+	if _, err := NewRedactor(MaskPolicy, HashPolicy, []string{"("}, ""); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestLogger(t *testing.T) {
+	answer := &domain.Answer{Key: "patient.name", Value: "John"}
+	event := &domain.AnswerEvent{EventType: domain.CreateAnswerEventType, Data: answer, OccurredAt: testOccurredAt}
+	var message domain.QueueMessage = &domain.AnswerEventMessage{Event: event}
+	var nilAnswer *domain.Answer
+
+	var logged []interface{}
+	logger := NewLogger(log.LoggerFunc(func(keyvals ...interface{}) error {
+		logged = keyvals
+		return nil
+	}), newTestRedactor(t, MaskPolicy, DropPolicy))
+	err := logger.Log(
+		"method", "Test",
+		"key", answer.Key,
+		"answer", answer,
+		"found", nilAnswer,
+		"list", []*domain.AnswerEvent{event},
+		"message", message,
+		"deleted", []*domain.DeletedAnswer{{Answer: domain.Answer{Key: "city", Value: "NY"}}},
+		"query", domain.AnswerSearchQuery{
+			Key:   &domain.AnswerSearchTerm{Text: "patient.", Match: domain.PrefixAnswerMatch},
+			Value: &domain.AnswerSearchTerm{Text: "Jon", Match: domain.FuzzyAnswerMatch, Fuzziness: 1},
+		},
+		"count", 1,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loggedEvent := &eventView{
+		EventType:  domain.CreateAnswerEventType,
+		Data:       &answerView{Value: "****"},
+		OccurredAt: testOccurredAt,
+	}
+	expected := []interface{}{
+		"method", "Test",
+		"key", "",
+		"answer", &answerView{Value: "****"},
+		"found", nil,
+		"list", []*eventView{loggedEvent},
+		"message", &messageView{Event: loggedEvent},
+		"deleted", []*deletedAnswerView{{answerView: answerView{Key: "city", Value: "**"}}},
+		"query", &searchQueryView{
+			Key:   &searchTermView{Match: domain.PrefixAnswerMatch},
+			Value: &searchTermView{Text: "***", Match: domain.FuzzyAnswerMatch, Fuzziness: 1},
+		},
+		"count", 1,
+	}
+	if diff := deep.Equal(logged, expected); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,47 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-test/deep"
+)
+
+// ErasureRepositoryFactory - returns an empty repository under test.
+type ErasureRepositoryFactory func(t *testing.T) domain.ErasureRepository
+
+// testErasedAt - the time of the erasure tests.
+var testErasedAt = time.Date(2022, 3, 1, 10, 0, 0, 1, time.UTC)
+
+// TestErasureRepository - checks that the repository satisfies the domain.ErasureRepository contract.
+// Every subtest asks the factory for a new empty repository.
+func TestErasureRepository(t *testing.T, newRepository ErasureRepositoryFactory) {
+	t.Run("RecordGet", func(t *testing.T) {
+		testErasureRecordGet(t, newRepository(t))
+	})
+}
+
+func testErasureRecordGet(t *testing.T, repository domain.ErasureRepository) {
+	_, err := repository.Get("hash-1")
+	expectNotFound(t, "Get", err)
+
+	// The last erasure of the key is kept, whatever the order of the records.
+	//
+	for _, erasedAt := range []time.Time{testErasedAt, testErasedAt.Add(time.Hour), testErasedAt.Add(time.Minute)} {
+		if err := repository.Record(&domain.Erasure{KeyHash: "hash-1", ErasedAt: erasedAt}); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+	if err := repository.Record(&domain.Erasure{KeyHash: "hash-2", ErasedAt: testErasedAt}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	found, err := repository.Get("hash-1")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(found, &domain.Erasure{KeyHash: "hash-1", ErasedAt: testErasedAt.Add(time.Hour)}); diff != nil {
+		t.Error(diff)
+	}
+}
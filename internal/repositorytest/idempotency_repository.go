@@ -0,0 +1,256 @@
+package repositorytest
+
+import (
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-test/deep"
+)
+
+// IdempotencyRepositoryFactory - returns an empty repository under test.
+type IdempotencyRepositoryFactory func(t *testing.T) domain.IdempotencyRepository
+
+// testIdempotencyNow - the time of the idempotency tests, the expiry times are whole seconds
+// as some backends round them.
+var testIdempotencyNow = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// TestIdempotencyRepository - checks that the repository satisfies the domain.IdempotencyRepository contract.
+// Every subtest asks the factory for a new empty repository.
+func TestIdempotencyRepository(t *testing.T, newRepository IdempotencyRepositoryFactory) {
+	t.Run("ReserveCompleteGet", func(t *testing.T) {
+		testIdempotencyReserveComplete(t, newRepository(t))
+	})
+	t.Run("Release", func(t *testing.T) {
+		testIdempotencyRelease(t, newRepository(t))
+	})
+	t.Run("Expiry", func(t *testing.T) {
+		testIdempotencyExpiry(t, newRepository(t))
+	})
+	t.Run("LeaseTakeover", func(t *testing.T) {
+		testIdempotencyLeaseTakeover(t, newRepository(t))
+	})
+	t.Run("EraseAnswer", func(t *testing.T) {
+		testIdempotencyEraseAnswer(t, newRepository(t))
+	})
+}
+
+func testIdempotencyReserveComplete(t *testing.T, repository domain.IdempotencyRepository) {
+	record := &domain.IdempotencyRecord{
+		Key:            "client/CreateAnswer/key-1",
+		Fingerprint:    "fingerprint",
+		ExpiresAt:      testIdempotencyNow.Add(time.Hour),
+		ReservationID:  "reservation-1",
+		LeaseExpiresAt: testIdempotencyNow.Add(time.Minute),
+	}
+	if err := repository.Reserve(record, testIdempotencyNow); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// A reserved key is in progress and cannot be reserved again.
+	//
+	err := repository.Reserve(record, testIdempotencyNow)
+	if _, ok := err.(*errors.ErrAlreadyExist); !ok {
+		t.Errorf("Reserve: expected ErrAlreadyExist, got %T: %v", err, err)
+	}
+	found, err := repository.Get(record.Key, testIdempotencyNow)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(found, record); diff != nil {
+		t.Errorf("record expected to be in progress: %v", diff)
+	}
+
+	// Only the reservation completes the record, the completed record holds the response and no lease.
+	//
+	completed := *record
+	completed.Response = `{"answer":"John"}`
+	completed.LeaseExpiresAt = time.Time{}
+	other := completed
+	other.ReservationID = "reservation-2"
+	expectNotFound(t, "Complete", repository.Complete(&other))
+	if err := repository.Complete(&completed); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	found, err = repository.Get(record.Key, testIdempotencyNow)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(found, &completed); diff != nil {
+		t.Error(diff)
+	}
+
+	// Only a reserved record can be completed.
+	//
+	missing := completed
+	missing.Key = "client/CreateAnswer/missing"
+	expectNotFound(t, "Complete", repository.Complete(&missing))
+	_, err = repository.Get(missing.Key, testIdempotencyNow)
+	expectNotFound(t, "Get", err)
+}
+
+func testIdempotencyRelease(t *testing.T, repository domain.IdempotencyRepository) {
+	record := &domain.IdempotencyRecord{
+		Key:            "client/UpdateAnswer/key-1",
+		Fingerprint:    "fingerprint",
+		ExpiresAt:      testIdempotencyNow.Add(time.Hour),
+		ReservationID:  "reservation-1",
+		LeaseExpiresAt: testIdempotencyNow.Add(time.Minute),
+	}
+	if err := repository.Reserve(record, testIdempotencyNow); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// Another reservation does not release the key.
+	//
+	if err := repository.Release(record.Key, "reservation-2"); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if _, err := repository.Get(record.Key, testIdempotencyNow); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// A released key may be reserved again, releasing a missing key is harmless.
+	//
+	if err := repository.Release(record.Key, record.ReservationID); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	_, err := repository.Get(record.Key, testIdempotencyNow)
+	expectNotFound(t, "Get", err)
+	if err := repository.Release(record.Key, record.ReservationID); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+	if err := repository.Reserve(record, testIdempotencyNow); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+}
+
+func testIdempotencyExpiry(t *testing.T, repository domain.IdempotencyRepository) {
+	record := &domain.IdempotencyRecord{
+		Key:           "client/DeleteAnswer/key-1",
+		Fingerprint:   "fingerprint",
+		Response:      `{"answer":"John"}`,
+		ExpiresAt:     testIdempotencyNow.Add(time.Hour),
+		ReservationID: "reservation-1",
+	}
+	if err := repository.Reserve(record, testIdempotencyNow); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// The expired record is ignored and its key may be reserved again.
+	//
+	later := record.ExpiresAt
+	_, err := repository.Get(record.Key, later)
+	expectNotFound(t, "Get", err)
+	reused := &domain.IdempotencyRecord{
+		Key:            record.Key,
+		Fingerprint:    "other fingerprint",
+		ExpiresAt:      later.Add(time.Hour),
+		ReservationID:  "reservation-2",
+		LeaseExpiresAt: later.Add(time.Minute),
+	}
+	if err := repository.Reserve(reused, later); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	found, err := repository.Get(record.Key, later)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(found, reused); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func testIdempotencyLeaseTakeover(t *testing.T, repository domain.IdempotencyRepository) {
+	record := &domain.IdempotencyRecord{
+		Key:            "client/CreateAnswer/key-1",
+		Fingerprint:    "fingerprint",
+		ExpiresAt:      testIdempotencyNow.Add(time.Hour),
+		ReservationID:  "reservation-1",
+		LeaseExpiresAt: testIdempotencyNow.Add(time.Minute),
+	}
+	if err := repository.Reserve(record, testIdempotencyNow); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// The reservation is kept during its lease and taken over afterwards.
+	//
+	retried := *record
+	retried.ReservationID = "reservation-2"
+	retried.LeaseExpiresAt = record.LeaseExpiresAt.Add(time.Minute)
+	err := repository.Reserve(&retried, record.LeaseExpiresAt.Add(-time.Second))
+	if _, ok := err.(*errors.ErrAlreadyExist); !ok {
+		t.Errorf("Reserve: expected ErrAlreadyExist, got %T: %v", err, err)
+	}
+	if err := repository.Reserve(&retried, record.LeaseExpiresAt); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// The first reservation can neither complete nor release the record any more.
+	//
+	completed := *record
+	completed.Response = `{"answer":"John"}`
+	completed.LeaseExpiresAt = time.Time{}
+	expectNotFound(t, "Complete", repository.Complete(&completed))
+	if err := repository.Release(record.Key, record.ReservationID); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	found, err := repository.Get(record.Key, record.LeaseExpiresAt)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(found, &retried); diff != nil {
+		t.Error(diff)
+	}
+
+	// A completed record has no lease to take over.
+	//
+	completed.ReservationID = retried.ReservationID
+	if err := repository.Complete(&completed); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	later := retried.LeaseExpiresAt.Add(time.Minute)
+	err = repository.Reserve(&retried, later)
+	if _, ok := err.(*errors.ErrAlreadyExist); !ok {
+		t.Errorf("Reserve: expected ErrAlreadyExist, got %T: %v", err, err)
+	}
+}
+
+func testIdempotencyEraseAnswer(t *testing.T, repository domain.IdempotencyRepository) {
+	records := []*domain.IdempotencyRecord{
+		{Key: "client/CreateAnswer/key-1", Fingerprint: "fingerprint", ReservationID: "reservation-1", AnswerKeyHash: "hash-1"},
+		{Key: "client/UpdateAnswer/key-2", Fingerprint: "fingerprint", ReservationID: "reservation-2", AnswerKeyHash: "hash-1"},
+		{Key: "client/UpdateAnswer/key-3", Fingerprint: "fingerprint", ReservationID: "reservation-3", AnswerKeyHash: "hash-2"},
+		{Key: "client/UpdateAnswer/key-4", Fingerprint: "fingerprint", ReservationID: "reservation-4"},
+	}
+	for _, record := range records {
+		record.ExpiresAt = testIdempotencyNow.Add(time.Hour)
+		if err := repository.Reserve(record, testIdempotencyNow); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+
+	// Only the records of the answer key hash are erased.
+	//
+	erased, err := repository.EraseAnswer("hash-1")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if erased != 2 {
+		t.Errorf("expected 2 erased records, got %d", erased)
+	}
+	for i, record := range records {
+		_, err := repository.Get(record.Key, testIdempotencyNow)
+		if i < 2 {
+			expectNotFound(t, "Get", err)
+		} else if err != nil {
+			t.Errorf("unexpected err: %v", err)
+		}
+	}
+	erased, err = repository.EraseAnswer("hash-1")
+	if err != nil || erased != 0 {
+		t.Errorf("expected nothing erased again, got %d, %v", erased, err)
+	}
+}
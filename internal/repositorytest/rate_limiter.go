@@ -0,0 +1,108 @@
+package repositorytest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-test/deep"
+)
+
+// RateLimiterFactory - returns a rate limiter under test without buckets.
+type RateLimiterFactory func(t *testing.T) domain.RateLimiter
+
+// testRateLimitNow - the time of the rate limiter tests.
+var testRateLimitNow = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// TestRateLimiter - checks that the rate limiter satisfies the domain.RateLimiter contract.
+// Every subtest asks the factory for a new rate limiter.
+func TestRateLimiter(t *testing.T, newRateLimiter RateLimiterFactory) {
+	t.Run("BurstRefill", func(t *testing.T) {
+		testRateLimiterBurstRefill(t, newRateLimiter(t))
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		testRateLimiterConcurrent(t, newRateLimiter(t))
+	})
+}
+
+// rateLimitTake - the outcome of a take.
+type rateLimitTake struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+func testRateLimiterBurstRefill(t *testing.T, limiter domain.RateLimiter) {
+	limit := domain.RateLimit{Rate: 2, Burst: 2}
+	take := func(key string, at time.Duration) rateLimitTake {
+		t.Helper()
+		allowed, retryAfter, err := limiter.Take(key, limit, testRateLimitNow.Add(at))
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		return rateLimitTake{Allowed: allowed, RetryAfter: retryAfter}
+	}
+
+	// The burst is taken at once, then a token is added every half a second. The buckets of the keys are separate.
+	//
+	got := []rateLimitTake{
+		take("client-1", 0),
+		take("client-1", 0),
+		take("client-1", 0),
+		take("client-2", 0),
+		take("client-1", 250*time.Millisecond),
+		take("client-1", 500*time.Millisecond),
+		take("client-1", 500*time.Millisecond),
+		take("client-1", 10*time.Second),
+		take("client-1", 10*time.Second),
+		take("client-1", 10*time.Second),
+	}
+	want := []rateLimitTake{
+		{Allowed: true},
+		{Allowed: true},
+		{RetryAfter: 500 * time.Millisecond},
+		{Allowed: true},
+		{RetryAfter: 250 * time.Millisecond},
+		{Allowed: true},
+		{RetryAfter: 500 * time.Millisecond},
+		{Allowed: true},
+		{Allowed: true},
+		{RetryAfter: 500 * time.Millisecond},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func testRateLimiterConcurrent(t *testing.T, limiter domain.RateLimiter) {
+	limit := domain.RateLimit{Rate: 0.001, Burst: 5}
+
+	// Concurrent takes must not take more than the burst.
+	//
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, _, err := limiter.Take("client-1", limit, testRateLimitNow)
+			if err != nil {
+				t.Errorf("unexpected err: %v", err)
+				return
+			}
+			if ok {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed != int(limit.Burst) {
+		t.Errorf("expected %v allowed takes, got %v", limit.Burst, allowed)
+	}
+}
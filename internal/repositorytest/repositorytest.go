@@ -0,0 +1,963 @@
+package repositorytest
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-test/deep"
+)
+
+// AnswerRepositoryFactory - returns an empty repository under test.
+type AnswerRepositoryFactory func(t *testing.T) domain.AnswerRepository
+
+// AnswerEventRepositoryFactory - returns an empty repository under test.
+type AnswerEventRepositoryFactory func(t *testing.T) domain.AnswerEventRepository
+
+// TestAnswerRepository - checks that the repository satisfies the domain.AnswerRepository contract.
+// Every subtest asks the factory for a new empty repository.
+func TestAnswerRepository(t *testing.T, newRepository AnswerRepositoryFactory) {
+	t.Run("CreateGetUpdateDelete", func(t *testing.T) {
+		testAnswerCRUD(t, newRepository(t))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		testAnswerNotFound(t, newRepository(t))
+	})
+	t.Run("Duplicate", func(t *testing.T) {
+		testAnswerDuplicate(t, newRepository(t))
+	})
+	t.Run("Upsert", func(t *testing.T) {
+		testAnswerUpsert(t, newRepository(t))
+	})
+	t.Run("List", func(t *testing.T) {
+		testAnswerList(t, newRepository(t))
+	})
+	t.Run("SoftDelete", func(t *testing.T) {
+		testAnswerSoftDelete(t, newRepository(t))
+	})
+	t.Run("Purge", func(t *testing.T) {
+		testAnswerPurge(t, newRepository(t))
+	})
+	t.Run("Erase", func(t *testing.T) {
+		testAnswerErase(t, newRepository(t))
+	})
+	t.Run("Versions", func(t *testing.T) {
+		testAnswerVersions(t, newRepository(t))
+	})
+	t.Run("Concurrency", func(t *testing.T) {
+		testAnswerConcurrency(t, newRepository(t))
+	})
+}
+
+// testTombstone - the tombstone of the deleted answers, the times are in UTC as the backends return them.
+var testTombstone = domain.NewTombstone(time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC), 24*time.Hour)
+
+// TestAnswerEventRepository - checks that the repository satisfies the domain.AnswerEventRepository contract.
+// Every subtest asks the factory for a new empty repository.
+func TestAnswerEventRepository(t *testing.T, newRepository AnswerEventRepositoryFactory) {
+	t.Run("CreateList", func(t *testing.T) {
+		testEventCreateList(t, newRepository(t))
+	})
+	t.Run("Ordering", func(t *testing.T) {
+		testEventOrdering(t, newRepository(t))
+	})
+	t.Run("Duplicate", func(t *testing.T) {
+		testEventDuplicate(t, newRepository(t))
+	})
+	t.Run("Pagination", func(t *testing.T) {
+		testEventPagination(t, newRepository(t))
+	})
+	t.Run("ListKeys", func(t *testing.T) {
+		testEventListKeys(t, newRepository(t))
+	})
+	t.Run("EraseEvents", func(t *testing.T) {
+		testEventErase(t, newRepository(t))
+	})
+	t.Run("Concurrency", func(t *testing.T) {
+		testEventConcurrency(t, newRepository(t))
+	})
+}
+
+func testAnswerCRUD(t *testing.T, repository domain.AnswerRepository) {
+
+	// Setup initial dataset.
+	//
+	answers := []*domain.Answer{
+		{
+			Key:   "name",
+			Value: "John",
+		},
+		{
+			Key:   "country",
+			Value: "US",
+		},
+		{
+			Key:   "city",
+			Value: "NY",
+		},
+		{
+			Key:   "address",
+			Value: "street 1",
+		},
+	}
+
+	// Test create operations.
+	//
+	for _, a := range answers {
+		if err := repository.Create(a); err != nil {
+			t.Errorf("unexpected err: %v", err)
+			continue
+		}
+	}
+
+	// Test get & update operations.
+	//
+	for _, a := range answers {
+		foundAnswer, err := repository.Get(a.Key)
+		if err != nil {
+			t.Errorf("unexpected err: %v", err)
+			continue
+		}
+		if diff := deep.Equal(foundAnswer, a); diff != nil {
+			t.Error(diff)
+			continue
+		}
+		var (
+			updatedAnswer = &domain.Answer{
+				Key:   a.Key,
+				Value: domain.AnswerValue(fmt.Sprintf("%v-new", a.Value)),
+			}
+		)
+		if err := repository.Update(updatedAnswer); err != nil {
+			t.Errorf("unexpected err: %v", err)
+			continue
+		}
+		foundAnswer, err = repository.Get(a.Key)
+		if err != nil {
+			t.Errorf("unexpected err: %v", err)
+			continue
+		}
+		if diff := deep.Equal(foundAnswer, updatedAnswer); diff != nil {
+			t.Error(diff)
+			continue
+		}
+	}
+
+	// Test delete operations.
+	//
+	for _, a := range answers {
+		if _, err := repository.Delete(a.Key, testTombstone); err != nil {
+			t.Errorf("unexpected err: %v", err)
+			continue
+		}
+		foundAnswer, _ := repository.Get(a.Key)
+		if foundAnswer != nil {
+			t.Errorf("answer expected to be deleted: %v", a.Key)
+			continue
+		}
+	}
+}
+
+func testAnswerNotFound(t *testing.T, repository domain.AnswerRepository) {
+	answer := &domain.Answer{Key: "missing", Value: "value"}
+
+	foundAnswer, err := repository.Get(answer.Key)
+	expectNotFound(t, "Get", err)
+	if foundAnswer != nil {
+		t.Errorf("Get: unexpected answer: %v", foundAnswer)
+	}
+	expectNotFound(t, "Update", repository.Update(answer))
+	_, err = repository.Delete(answer.Key, testTombstone)
+	expectNotFound(t, "Delete", err)
+	expectNotFound(t, "Purge", repository.Purge(answer.Key, testTombstone.PurgeAt))
+
+	// A failed update must not create the answer.
+	//
+	if foundAnswer, _ := repository.Get(answer.Key); foundAnswer != nil {
+		t.Errorf("answer expected to be missing: %v", foundAnswer)
+	}
+}
+
+func testAnswerDuplicate(t *testing.T, repository domain.AnswerRepository) {
+	original := &domain.Answer{Key: "name", Value: "John"}
+	if err := repository.Create(original); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// A duplicate must be rejected and must not replace the stored answer.
+	//
+	err := repository.Create(&domain.Answer{Key: "name", Value: "Sam"})
+	if _, ok := err.(*errors.ErrAlreadyExist); !ok {
+		t.Errorf("Create: expected ErrAlreadyExist, got %T: %v", err, err)
+	}
+	foundAnswer, err := repository.Get(original.Key)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(foundAnswer, original); diff != nil {
+		t.Error(diff)
+	}
+
+	// A deleted key can be used again.
+	//
+	if _, err := repository.Delete(original.Key, testTombstone); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := repository.Create(original); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+}
+
+func testAnswerUpsert(t *testing.T, repository domain.AnswerRepository) {
+	testCases := []struct {
+		name        string
+		answer      *domain.Answer
+		deleteAfter bool
+		wantCreated bool
+	}{
+		{
+			name:        "missing key is created",
+			answer:      &domain.Answer{Key: "name", Value: "John"},
+			wantCreated: true,
+		},
+		{
+			name:        "key in use is replaced",
+			answer:      &domain.Answer{Key: "name", Value: "Sam"},
+			deleteAfter: true,
+		},
+		{
+			name:        "deleted key is created",
+			answer:      &domain.Answer{Key: "name", Value: "Tom"},
+			wantCreated: true,
+		},
+	}
+	for _, tc := range testCases {
+		created, err := repository.Upsert(tc.answer)
+		if err != nil {
+			t.Fatalf("%s: unexpected err: %v", tc.name, err)
+		}
+		if created != tc.wantCreated {
+			t.Errorf("%s: created = %v, want %v", tc.name, created, tc.wantCreated)
+		}
+		foundAnswer, err := repository.Get(tc.answer.Key)
+		if err != nil {
+			t.Fatalf("%s: unexpected err: %v", tc.name, err)
+		}
+		if diff := deep.Equal(foundAnswer, tc.answer); diff != nil {
+			t.Errorf("%s: %v", tc.name, diff)
+		}
+		if tc.deleteAfter {
+			if _, err := repository.Delete(tc.answer.Key, testTombstone); err != nil {
+				t.Fatalf("%s: unexpected err: %v", tc.name, err)
+			}
+		}
+	}
+
+	// The replaced answer is no longer listed as deleted.
+	//
+	deleted, _, err := repository.ListDeleted(domain.PageRequest{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("unexpected deleted answers: %v", deleted)
+	}
+}
+
+func testAnswerList(t *testing.T, repository domain.AnswerRepository) {
+
+	// An empty repository has a single empty page.
+	//
+	answers, nextPageToken, err := repository.List(domain.PageRequest{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(answers) != 0 || len(nextPageToken) != 0 {
+		t.Errorf("unexpected page: %v, token %q", answers, nextPageToken)
+	}
+
+	// Setup initial dataset.
+	//
+	expected := map[domain.AnswerKey]*domain.Answer{}
+	for i := 0; i < 7; i++ {
+		answer := &domain.Answer{
+			Key:   domain.AnswerKey(fmt.Sprintf("key-%d", i)),
+			Value: domain.AnswerValue(fmt.Sprintf("value-%d", i)),
+		}
+		if err := repository.Create(answer); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		expected[answer.Key] = answer
+	}
+
+	// Walk through all the pages.
+	//
+	listed := map[domain.AnswerKey]*domain.Answer{}
+	page := domain.PageRequest{PageSize: 3}
+	for i := 0; ; i++ {
+		if i > len(expected) {
+			t.Fatal("listing does not end")
+		}
+		answers, nextPageToken, err := repository.List(page)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(answers) > page.PageSize {
+			t.Errorf("page size exceeded: %d", len(answers))
+		}
+		for _, a := range answers {
+			if _, ok := listed[a.Key]; ok {
+				t.Errorf("answer listed twice: %v", a.Key)
+			}
+			listed[a.Key] = a
+		}
+		if len(nextPageToken) == 0 {
+			break
+		}
+		page.PageToken = nextPageToken
+	}
+	if diff := deep.Equal(listed, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	// A malformed token must be rejected.
+	//
+	_, _, err = repository.List(domain.PageRequest{PageToken: "%%%"})
+	if _, ok := err.(*errors.ErrInvalidArgument); !ok {
+		t.Errorf("List: expected ErrInvalidArgument, got %T: %v", err, err)
+	}
+}
+
+func testAnswerSoftDelete(t *testing.T, repository domain.AnswerRepository) {
+	for _, answer := range []*domain.Answer{{Key: "city", Value: "NY"}, {Key: "name", Value: "John"}} {
+		if err := repository.Create(answer); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+	if _, err := repository.Delete("name", testTombstone); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// A deleted answer is hidden.
+	//
+	_, err := repository.Get("name")
+	expectNotFound(t, "Get", err)
+	expectNotFound(t, "Update", repository.Update(&domain.Answer{Key: "name", Value: "Sam"}))
+	_, err = repository.Delete("name", testTombstone)
+	expectNotFound(t, "Delete", err)
+	answers := listAllAnswers(t, repository)
+	if diff := deep.Equal(answers, []*domain.Answer{{Key: "city", Value: "NY", Version: 1}}); diff != nil {
+		t.Error(diff)
+	}
+
+	// But it is listed with its tombstone.
+	//
+	expected := []*domain.DeletedAnswer{
+		{
+			Answer:    domain.Answer{Key: "name", Value: "John", Version: 2},
+			Tombstone: testTombstone,
+		},
+	}
+	if diff := deep.Equal(listAllDeletedAnswers(t, repository), expected); diff != nil {
+		t.Error(diff)
+	}
+
+	// Creating the answer again replaces the tombstone and counts on.
+	//
+	if err := repository.Create(&domain.Answer{Key: "name", Value: "Sam"}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	foundAnswer, err := repository.Get("name")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(foundAnswer, &domain.Answer{Key: "name", Value: "Sam", Version: 3}); diff != nil {
+		t.Error(diff)
+	}
+	if deletedAnswers := listAllDeletedAnswers(t, repository); len(deletedAnswers) != 0 {
+		t.Errorf("unexpected deleted answers: %v", deletedAnswers)
+	}
+}
+
+func testAnswerPurge(t *testing.T, repository domain.AnswerRepository) {
+	for _, answer := range []*domain.Answer{{Key: "city", Value: "NY"}, {Key: "name", Value: "John"}} {
+		if err := repository.Create(answer); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+	if _, err := repository.Delete("name", testTombstone); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// Answers in use and answers before their purge time are kept.
+	//
+	expectNotFound(t, "Purge", repository.Purge("city", testTombstone.PurgeAt))
+	expectNotFound(t, "Purge", repository.Purge("name", testTombstone.PurgeAt.Add(-time.Nanosecond)))
+	if deletedAnswers := listAllDeletedAnswers(t, repository); len(deletedAnswers) != 1 {
+		t.Errorf("expected a deleted answer, got %v", deletedAnswers)
+	}
+
+	// A due answer is gone for good.
+	//
+	if err := repository.Purge("name", testTombstone.PurgeAt); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if deletedAnswers := listAllDeletedAnswers(t, repository); len(deletedAnswers) != 0 {
+		t.Errorf("unexpected deleted answers: %v", deletedAnswers)
+	}
+	expectNotFound(t, "Purge", repository.Purge("name", testTombstone.PurgeAt))
+	if _, err := repository.Get("city"); err != nil {
+		t.Errorf("unexpected err: %v", err)
+	}
+}
+
+func testAnswerErase(t *testing.T, repository domain.AnswerRepository) {
+	for _, answer := range []*domain.Answer{{Key: "city", Value: "NY"}, {Key: "name", Value: "John"}, {Key: "country", Value: "US"}} {
+		if err := repository.Create(answer); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+	if _, err := repository.Delete("name", testTombstone); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// Answers are erased whether they are deleted or not.
+	//
+	for _, key := range []domain.AnswerKey{"city", "name"} {
+		if err := repository.Erase(key); err != nil {
+			t.Errorf("unexpected err: %v", err)
+		}
+		expectNotFound(t, "Erase", repository.Erase(key))
+	}
+	if diff := deep.Equal(listAllAnswers(t, repository), []*domain.Answer{{Key: "country", Value: "US", Version: 1}}); diff != nil {
+		t.Error(diff)
+	}
+	if deletedAnswers := listAllDeletedAnswers(t, repository); len(deletedAnswers) != 0 {
+		t.Errorf("unexpected deleted answers: %v", deletedAnswers)
+	}
+}
+
+func testAnswerVersions(t *testing.T, repository domain.AnswerRepository) {
+	answer := &domain.Answer{Key: "name", Value: "John"}
+	expectVersion := func(action string, version int) {
+		t.Helper()
+		if answer.Version != version {
+			t.Errorf("%s: version = %d, want %d", action, answer.Version, version)
+		}
+	}
+
+	// Every change counts, the given version is ignored while the key has one stored.
+	//
+	if err := repository.Create(answer); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expectVersion("Create", 1)
+	answer.Value = "Sam"
+	if err := repository.Update(answer); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expectVersion("Update", 2)
+	answer.Value, answer.Version = "Tom", 10
+	if _, err := repository.Upsert(answer); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expectVersion("Upsert", 3)
+	version, err := repository.Delete(answer.Key, testTombstone)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if version != 4 {
+		t.Errorf("Delete: version = %d, want 4", version)
+	}
+	answer.Version = 10
+	if err := repository.Create(answer); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expectVersion("Create deleted", 5)
+	foundAnswer, err := repository.Get(answer.Key)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(foundAnswer, answer); diff != nil {
+		t.Error(diff)
+	}
+
+	// A purged key counts on from the given version.
+	//
+	if _, err := repository.Delete(answer.Key, testTombstone); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := repository.Purge(answer.Key, testTombstone.PurgeAt); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	answer.Version = 6
+	if err := repository.Create(answer); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expectVersion("Create purged", 7)
+	if err := repository.Erase(answer.Key); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	answer.Version = 0
+	if _, err := repository.Upsert(answer); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expectVersion("Upsert erased", 1)
+}
+
+func testAnswerConcurrency(t *testing.T, repository domain.AnswerRepository) {
+	const workers = 8
+
+	// Concurrent creates of the same key: exactly one must succeed.
+	//
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		created   int
+		conflicts int
+	)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			err := repository.Create(&domain.Answer{Key: "shared", Value: domain.AnswerValue(fmt.Sprintf("value-%d", i))})
+			mu.Lock()
+			defer mu.Unlock()
+			switch err.(type) {
+			case nil:
+				created++
+			case *errors.ErrAlreadyExist:
+				conflicts++
+			default:
+				t.Errorf("unexpected err: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if created != 1 || conflicts != workers-1 {
+		t.Errorf("expected 1 create and %d conflicts, got %d and %d", workers-1, created, conflicts)
+	}
+
+	// Concurrent updates of the same key get distinct versions.
+	//
+	versions := map[int]bool{}
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			answer := &domain.Answer{Key: "shared", Value: domain.AnswerValue(fmt.Sprintf("updated-%d", i))}
+			err := repository.Update(answer)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				t.Errorf("unexpected err: %v", err)
+				return
+			}
+			versions[answer.Version] = true
+		}(i)
+	}
+	wg.Wait()
+	for version := 2; version <= workers+1; version++ {
+		if !versions[version] {
+			t.Errorf("version %d missing from %v", version, versions)
+		}
+	}
+
+	// Concurrent writes of distinct keys must all succeed.
+	//
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			answer := &domain.Answer{Key: domain.AnswerKey(fmt.Sprintf("key-%d", i)), Value: "created"}
+			if err := repository.Create(answer); err != nil {
+				t.Errorf("unexpected err: %v", err)
+				return
+			}
+			answer.Value = "updated"
+			if err := repository.Update(answer); err != nil {
+				t.Errorf("unexpected err: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i := 0; i < workers; i++ {
+		foundAnswer, err := repository.Get(domain.AnswerKey(fmt.Sprintf("key-%d", i)))
+		if err != nil {
+			t.Errorf("unexpected err: %v", err)
+			continue
+		}
+		if foundAnswer.Value != "updated" {
+			t.Errorf("unexpected value: %v", foundAnswer.Value)
+		}
+	}
+}
+
+func testEventCreateList(t *testing.T, repository domain.AnswerEventRepository) {
+	at := newClock()
+
+	// Setup initial dataset.
+	//
+	events := []*domain.AnswerEvent{
+		{
+			EventType:  domain.CreateAnswerEventType,
+			Data:       &domain.Answer{Key: "name", Value: "John"},
+			OccurredAt: at(),
+		},
+		{
+			EventType:  domain.CreateAnswerEventType,
+			Data:       &domain.Answer{Key: "city", Value: "NY"},
+			OccurredAt: at(),
+		},
+		{
+			EventType:  domain.DeleteAnswerEventType,
+			Data:       &domain.Answer{Key: "name", Value: "John"},
+			OccurredAt: at(),
+		},
+	}
+
+	// Test create operations.
+	//
+	for _, e := range events {
+		if err := repository.Create(e); err != nil {
+			t.Errorf("unexpected err: %v", err)
+		}
+	}
+
+	// Test list operations.
+	//
+	nameEvents, err := repository.ListEvents("name")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(nameEvents, []*domain.AnswerEvent{events[0], events[2]}); diff != nil {
+		t.Error(diff)
+	}
+	unknownEvents, err := repository.ListEvents("unknown")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(unknownEvents) != 0 {
+		t.Errorf("unexpected events: %v", unknownEvents)
+	}
+}
+
+func testEventOrdering(t *testing.T, repository domain.AnswerEventRepository) {
+	at := newClock()
+
+	// Events may be recorded out of order, e.g. by concurrent workers.
+	//
+	events := []*domain.AnswerEvent{
+		{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John"}, OccurredAt: at()},
+		{EventType: domain.UpdateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Sam"}, OccurredAt: at()},
+		{EventType: domain.UpdateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Bob"}, OccurredAt: at()},
+		{EventType: domain.DeleteAnswerEventType, Data: &domain.Answer{Key: "name", Value: "Bob"}, OccurredAt: at()},
+	}
+	for _, i := range []int{2, 0, 3, 1} {
+		if err := repository.Create(events[i]); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+	listed, err := repository.ListEvents("name")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(listed, events); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func testEventDuplicate(t *testing.T, repository domain.AnswerEventRepository) {
+	event := &domain.AnswerEvent{
+		EventType:  domain.CreateAnswerEventType,
+		Data:       &domain.Answer{Key: "name", Value: "John"},
+		OccurredAt: newClock()(),
+	}
+	if err := repository.Create(event); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// A redelivered event must not be recorded twice.
+	//
+	err := repository.Create(event)
+	if _, ok := err.(*errors.ErrAlreadyExist); !ok {
+		t.Errorf("Create: expected ErrAlreadyExist, got %T: %v", err, err)
+	}
+	listed, err := repository.ListEvents("name")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(listed, []*domain.AnswerEvent{event}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func testEventPagination(t *testing.T, repository domain.AnswerEventRepository) {
+	at := newClock()
+
+	// Setup initial dataset.
+	//
+	var events []*domain.AnswerEvent
+	for i := 0; i < 7; i++ {
+		event := &domain.AnswerEvent{
+			EventType:  domain.UpdateAnswerEventType,
+			Data:       &domain.Answer{Key: "name", Value: domain.AnswerValue(fmt.Sprintf("value-%d", i))},
+			OccurredAt: at(),
+		}
+		if err := repository.Create(event); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		events = append(events, event)
+	}
+	other := &domain.AnswerEvent{
+		EventType:  domain.CreateAnswerEventType,
+		Data:       &domain.Answer{Key: "other", Value: "value"},
+		OccurredAt: at(),
+	}
+	if err := repository.Create(other); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	// Walk through all the pages, the concatenation must keep the order.
+	//
+	var listed []*domain.AnswerEvent
+	page := domain.PageRequest{PageSize: 3}
+	for i := 0; ; i++ {
+		if i > len(events) {
+			t.Fatal("listing does not end")
+		}
+		pageEvents, nextPageToken, err := repository.ListEventsPage("name", page)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(pageEvents) > page.PageSize {
+			t.Errorf("page size exceeded: %d", len(pageEvents))
+		}
+		listed = append(listed, pageEvents...)
+		if len(nextPageToken) == 0 {
+			break
+		}
+		page.PageToken = nextPageToken
+	}
+	if diff := deep.Equal(listed, events); diff != nil {
+		t.Error(diff)
+	}
+
+	// A malformed token must be rejected.
+	//
+	_, _, err := repository.ListEventsPage("name", domain.PageRequest{PageToken: "%%%"})
+	if _, ok := err.(*errors.ErrInvalidArgument); !ok {
+		t.Errorf("ListEventsPage: expected ErrInvalidArgument, got %T: %v", err, err)
+	}
+}
+
+func testEventListKeys(t *testing.T, repository domain.AnswerEventRepository) {
+	at := newClock()
+
+	// An empty repository has a single empty page.
+	//
+	keys, nextPageToken, err := repository.ListKeys(domain.PageRequest{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(keys) != 0 || len(nextPageToken) != 0 {
+		t.Errorf("unexpected page: %v, token %q", keys, nextPageToken)
+	}
+
+	// Setup initial dataset, every key has several events.
+	//
+	expected := map[domain.AnswerKey]bool{}
+	for i := 0; i < 5; i++ {
+		key := domain.AnswerKey(fmt.Sprintf("key-%d", i))
+		for _, eventType := range []domain.AnswerEventType{domain.CreateAnswerEventType, domain.UpdateAnswerEventType, domain.DeleteAnswerEventType} {
+			event := &domain.AnswerEvent{
+				EventType:  eventType,
+				Data:       &domain.Answer{Key: key, Value: "value"},
+				OccurredAt: at(),
+			}
+			if err := repository.Create(event); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+		}
+		expected[key] = true
+	}
+
+	// Walk through all the pages, a key must be listed once.
+	//
+	listed := map[domain.AnswerKey]bool{}
+	page := domain.PageRequest{PageSize: 2}
+	for i := 0; ; i++ {
+		if i > 3*len(expected) {
+			t.Fatal("listing does not end")
+		}
+		keys, nextPageToken, err := repository.ListKeys(page)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(keys) > page.PageSize {
+			t.Errorf("page size exceeded: %d", len(keys))
+		}
+		for _, key := range keys {
+			if listed[key] {
+				t.Errorf("key listed twice: %v", key)
+			}
+			listed[key] = true
+		}
+		if len(nextPageToken) == 0 {
+			break
+		}
+		page.PageToken = nextPageToken
+	}
+	if diff := deep.Equal(listed, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	// A malformed token must be rejected.
+	//
+	_, _, err = repository.ListKeys(domain.PageRequest{PageToken: "%%%"})
+	if _, ok := err.(*errors.ErrInvalidArgument); !ok {
+		t.Errorf("ListKeys: expected ErrInvalidArgument, got %T: %v", err, err)
+	}
+}
+
+func testEventConcurrency(t *testing.T, repository domain.AnswerEventRepository) {
+	const workers = 8
+	at := newClock()
+
+	// Concurrent creates of distinct events of the same answer must all be recorded.
+	//
+	events := make([]*domain.AnswerEvent, workers)
+	for i := range events {
+		events[i] = &domain.AnswerEvent{
+			EventType:  domain.UpdateAnswerEventType,
+			Data:       &domain.Answer{Key: "name", Value: domain.AnswerValue(fmt.Sprintf("value-%d", i))},
+			OccurredAt: at(),
+		}
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := range events {
+		go func(event *domain.AnswerEvent) {
+			defer wg.Done()
+			if err := repository.Create(event); err != nil {
+				t.Errorf("unexpected err: %v", err)
+			}
+		}(events[i])
+	}
+	wg.Wait()
+	listed, err := repository.ListEvents("name")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(listed, events); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func testEventErase(t *testing.T, repository domain.AnswerEventRepository) {
+	at := newClock()
+	var events []*domain.AnswerEvent
+	for _, key := range []domain.AnswerKey{"name", "city"} {
+		for _, eventType := range []domain.AnswerEventType{domain.CreateAnswerEventType, domain.UpdateAnswerEventType, domain.DeleteAnswerEventType} {
+			event := &domain.AnswerEvent{
+				EventType:  eventType,
+				Data:       &domain.Answer{Key: key, Value: "value"},
+				OccurredAt: at(),
+			}
+			if err := repository.Create(event); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			events = append(events, event)
+		}
+	}
+
+	// The events before the time are erased, the others and the events of other keys are kept.
+	//
+	erased, err := repository.EraseEvents("name", events[2].OccurredAt)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if erased != 2 {
+		t.Errorf("expected 2 erased events, got %d", erased)
+	}
+	for key, expected := range map[domain.AnswerKey][]*domain.AnswerEvent{"name": events[2:3], "city": events[3:]} {
+		history, err := repository.ListEvents(key)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if diff := deep.Equal(history, expected); diff != nil {
+			t.Errorf("%v: %v", key, diff)
+		}
+	}
+
+	// Erasing again finds nothing.
+	//
+	erased, err = repository.EraseEvents("name", events[2].OccurredAt)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if erased != 0 {
+		t.Errorf("expected no erased events, got %d", erased)
+	}
+}
+
+// listAllAnswers - walks through all the pages of the answers and returns them ordered by key.
+func listAllAnswers(t *testing.T, repository domain.AnswerRepository) []*domain.Answer {
+	t.Helper()
+	var answers []*domain.Answer
+	page := domain.PageRequest{PageSize: 1}
+	for {
+		items, nextPageToken, err := repository.List(page)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		answers = append(answers, items...)
+		if len(nextPageToken) == 0 {
+			break
+		}
+		page.PageToken = nextPageToken
+	}
+	sort.Slice(answers, func(i, j int) bool { return answers[i].Key < answers[j].Key })
+	return answers
+}
+
+// listAllDeletedAnswers - walks through all the pages of the deleted answers and returns them ordered by key.
+func listAllDeletedAnswers(t *testing.T, repository domain.AnswerRepository) []*domain.DeletedAnswer {
+	t.Helper()
+	var answers []*domain.DeletedAnswer
+	page := domain.PageRequest{PageSize: 1}
+	for {
+		items, nextPageToken, err := repository.ListDeleted(page)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		answers = append(answers, items...)
+		if len(nextPageToken) == 0 {
+			break
+		}
+		page.PageToken = nextPageToken
+	}
+	sort.Slice(answers, func(i, j int) bool { return answers[i].Key < answers[j].Key })
+	return answers
+}
+
+func expectNotFound(t *testing.T, method string, err error) {
+	t.Helper()
+	if _, ok := err.(*errors.ErrNotFound); !ok {
+		t.Errorf("%s: expected ErrNotFound, got %T: %v", method, err, err)
+	}
+}
+
+// newClock - returns a function generating strictly increasing occurrence times.
+func newClock() func() time.Time {
+	now := time.Now().UTC()
+	return func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+}
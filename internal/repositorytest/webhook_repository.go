@@ -0,0 +1,237 @@
+package repositorytest
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-test/deep"
+)
+
+// WebhookRepositoryFactory - returns an empty repository under test.
+type WebhookRepositoryFactory func(t *testing.T) domain.WebhookRepository
+
+// TestWebhookRepository - checks that the repository satisfies the domain.WebhookRepository contract.
+// Every subtest asks the factory for a new empty repository.
+func TestWebhookRepository(t *testing.T, newRepository WebhookRepositoryFactory) {
+	t.Run("CreateGetDeleteSubscription", func(t *testing.T) {
+		testWebhookSubscriptionCRUD(t, newRepository(t))
+	})
+	t.Run("ListSubscriptions", func(t *testing.T) {
+		testWebhookSubscriptionList(t, newRepository(t))
+	})
+	t.Run("Deliveries", func(t *testing.T) {
+		testWebhookDeliveries(t, newRepository(t))
+	})
+}
+
+func testWebhookSubscriptionCRUD(t *testing.T, repository domain.WebhookRepository) {
+	subscription := &domain.WebhookSubscription{
+		ID:         "subscription-1",
+		URL:        "https://example.com/hooks",
+		EventTypes: []domain.AnswerEventType{domain.CreateAnswerEventType, domain.DeleteAnswerEventType},
+		Secret:     "secret",
+		CreatedAt:  newClock()(),
+	}
+	if err := repository.CreateSubscription(subscription); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	err := repository.CreateSubscription(subscription)
+	if _, ok := err.(*errors.ErrAlreadyExist); !ok {
+		t.Errorf("CreateSubscription: expected ErrAlreadyExist, got %T: %v", err, err)
+	}
+	got, err := repository.GetSubscription(subscription.ID)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(got, subscription); diff != nil {
+		t.Error(diff)
+	}
+
+	// A subscription of all the event types has none.
+	//
+	all := &domain.WebhookSubscription{ID: "subscription-2", URL: "http://example.com", Secret: "secret", CreatedAt: newClock()()}
+	if err := repository.CreateSubscription(all); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	got, err = repository.GetSubscription(all.ID)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(got.EventTypes) != 0 {
+		t.Errorf("unexpected event types: %v", got.EventTypes)
+	}
+
+	// Test delete, the delivery log goes with the subscription.
+	//
+	delivery := &domain.WebhookDelivery{
+		ID:             "delivery",
+		SubscriptionID: subscription.ID,
+		EventType:      domain.CreateAnswerEventType,
+		KeyHash:        "name-hash",
+		OccurredAt:     newClock()(),
+		Attempt:        1,
+		AttemptedAt:    newClock()(),
+		Succeeded:      true,
+	}
+	if err := repository.CreateDelivery(delivery); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if err := repository.DeleteSubscription(subscription.ID); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	_, err = repository.GetSubscription(subscription.ID)
+	expectNotFound(t, "GetSubscription", err)
+	expectNotFound(t, "DeleteSubscription", repository.DeleteSubscription(subscription.ID))
+	deliveries, _, err := repository.ListDeliveries(subscription.ID, domain.PageRequest{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(deliveries) != 0 {
+		t.Errorf("unexpected deliveries: %v", deliveries)
+	}
+}
+
+func testWebhookSubscriptionList(t *testing.T, repository domain.WebhookRepository) {
+	at := newClock()
+
+	// Setup initial dataset.
+	//
+	var subscriptions []*domain.WebhookSubscription
+	for i := 0; i < 5; i++ {
+		subscription := &domain.WebhookSubscription{
+			ID:        fmt.Sprintf("subscription-%d", i),
+			URL:       fmt.Sprintf("https://example.com/%d", i),
+			Secret:    "secret",
+			CreatedAt: at(),
+		}
+		if err := repository.CreateSubscription(subscription); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	// Walk through all the pages, every subscription is listed once.
+	//
+	var listed []*domain.WebhookSubscription
+	page := domain.PageRequest{PageSize: 2}
+	for i := 0; ; i++ {
+		if i > len(subscriptions) {
+			t.Fatal("listing does not end")
+		}
+		items, nextPageToken, err := repository.ListSubscriptions(page)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(items) > page.PageSize {
+			t.Errorf("page size exceeded: %d", len(items))
+		}
+		listed = append(listed, items...)
+		if len(nextPageToken) == 0 {
+			break
+		}
+		page.PageToken = nextPageToken
+	}
+	sort.Slice(listed, func(i, j int) bool { return listed[i].ID < listed[j].ID })
+	if diff := deep.Equal(listed, subscriptions); diff != nil {
+		t.Error(diff)
+	}
+
+	// A malformed token must be rejected.
+	//
+	_, _, err := repository.ListSubscriptions(domain.PageRequest{PageToken: "%%%"})
+	if _, ok := err.(*errors.ErrInvalidArgument); !ok {
+		t.Errorf("ListSubscriptions: expected ErrInvalidArgument, got %T: %v", err, err)
+	}
+}
+
+func testWebhookDeliveries(t *testing.T, repository domain.WebhookRepository) {
+	at := newClock()
+	occurredAt := at()
+
+	// Attempts may be recorded out of order, e.g. by concurrent workers.
+	//
+	var deliveries []*domain.WebhookDelivery
+	for i := 0; i < 5; i++ {
+		deliveries = append(deliveries, &domain.WebhookDelivery{
+			ID:             "delivery",
+			SubscriptionID: "subscription",
+			EventType:      domain.UpdateAnswerEventType,
+			KeyHash:        "name-hash",
+			OccurredAt:     occurredAt,
+			Attempt:        i + 1,
+			AttemptedAt:    at(),
+			StatusCode:     500,
+			Error:          "Internal Server Error",
+		})
+	}
+	deliveries[4].StatusCode = 204
+	deliveries[4].Error = ""
+	deliveries[4].Succeeded = true
+	for _, i := range []int{2, 0, 4, 3, 1} {
+		if err := repository.CreateDelivery(deliveries[i]); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+	other := &domain.WebhookDelivery{ID: "other", SubscriptionID: "other", EventType: domain.CreateAnswerEventType, KeyHash: "name-hash", OccurredAt: occurredAt, Attempt: 1, AttemptedAt: at()}
+	if err := repository.CreateDelivery(other); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	otherEvent := &domain.WebhookDelivery{ID: "other", SubscriptionID: "subscription", EventType: domain.CreateAnswerEventType, KeyHash: "name-hash", OccurredAt: occurredAt, Attempt: 1, AttemptedAt: at()}
+	if err := repository.CreateDelivery(otherEvent); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	err := repository.CreateDelivery(deliveries[0])
+	if _, ok := err.(*errors.ErrAlreadyExist); !ok {
+		t.Errorf("CreateDelivery: expected ErrAlreadyExist, got %T: %v", err, err)
+	}
+
+	// Walk through all the pages, the concatenation must keep the order.
+	//
+	var listed []*domain.WebhookDelivery
+	page := domain.PageRequest{PageSize: 2}
+	for i := 0; ; i++ {
+		if i > len(deliveries) {
+			t.Fatal("listing does not end")
+		}
+		items, nextPageToken, err := repository.ListDeliveries("subscription", page)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if len(items) > page.PageSize {
+			t.Errorf("page size exceeded: %d", len(items))
+		}
+		listed = append(listed, items...)
+		if len(nextPageToken) == 0 {
+			break
+		}
+		page.PageToken = nextPageToken
+	}
+	if diff := deep.Equal(listed, append(deliveries, otherEvent)); diff != nil {
+		t.Error(diff)
+	}
+
+	// The attempts of a delivery are listed since the time.
+	//
+	attempts, err := repository.ListDeliveryAttempts("subscription", "delivery", deliveries[1].AttemptedAt)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(attempts, deliveries[1:]); diff != nil {
+		t.Error(diff)
+	}
+	attempts, err = repository.ListDeliveryAttempts("subscription", "unknown", occurredAt)
+	if err != nil || len(attempts) > 0 {
+		t.Errorf("ListDeliveryAttempts: expected no attempts, got %v, %v", attempts, err)
+	}
+
+	// A malformed token must be rejected.
+	//
+	_, _, err = repository.ListDeliveries("subscription", domain.PageRequest{PageToken: "%%%"})
+	if _, ok := err.(*errors.ErrInvalidArgument); !ok {
+		t.Errorf("ListDeliveries: expected ErrInvalidArgument, got %T: %v", err, err)
+	}
+}
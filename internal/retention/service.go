@@ -0,0 +1,87 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-kit/log"
+)
+
+type service struct {
+	repository domain.AnswerRepository
+	pageSize   int
+	now        func() time.Time
+}
+
+// NewService creates a new service with necessary dependencies.
+func NewService(repository domain.AnswerRepository, logger log.Logger) domain.AnswerRetentionService {
+	var service domain.AnswerRetentionService
+	{
+		service = newBasicService(repository)
+		service = LoggingServiceMiddleware(logger)(service)
+	}
+	return service
+}
+
+// Returns a naive, stateless implementation of service.
+func newBasicService(repository domain.AnswerRepository) domain.AnswerRetentionService {
+	return &service{
+		repository: repository,
+		pageSize:   domain.DefaultPageSize,
+		now:        time.Now,
+	}
+}
+
+func (s *service) ListDeletedAnswers(ctx context.Context, page domain.PageRequest) ([]*domain.DeletedAnswer, string, error) {
+
+	// Check page size.
+	//
+	if page.PageSize < 0 || page.PageSize > domain.MaxPageSize {
+		return nil, "", errors.NewErrInvalidArgument(fmt.Sprintf("PageSize must be between 0 and %d", domain.MaxPageSize))
+	}
+
+	// Return result.
+	//
+	return s.repository.ListDeleted(page)
+}
+
+func (s *service) PurgeDeletedAnswers(ctx context.Context) (int, error) {
+	var (
+		now    = s.now().UTC()
+		page   = domain.PageRequest{PageSize: s.pageSize}
+		purged int
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+		answers, nextPageToken, err := s.repository.ListDeleted(page)
+		if err != nil {
+			return purged, err
+		}
+
+		// An answer may be created again since it was listed, the repository keeps it then.
+		//
+		for _, answer := range answers {
+			if !answer.IsDue(now) {
+				continue
+			}
+			err := s.repository.Purge(answer.Key, now)
+			if _, ok := err.(*errors.ErrNotFound); ok {
+				continue
+			}
+			if err != nil {
+				return purged, err
+			}
+			purged++
+		}
+		if len(nextPageToken) == 0 {
+			return purged, nil
+		}
+		page.PageToken = nextPageToken
+	}
+}
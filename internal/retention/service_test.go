@@ -0,0 +1,132 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errs "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/servicetest"
+
+	"github.com/go-test/deep"
+)
+
+var testRetention = 24 * time.Hour
+
+// failingRepository - fails the purge of the answers with an error.
+type failingRepository struct {
+	domain.AnswerRepository
+	purgeErrs map[domain.AnswerKey]error
+}
+
+func (r *failingRepository) Purge(key domain.AnswerKey, now time.Time) error {
+	if err, ok := r.purgeErrs[key]; ok {
+		return err
+	}
+	return r.AnswerRepository.Purge(key, now)
+}
+
+// newTestService - returns the service with the answers deleted at the times.
+func newTestService(t *testing.T, deletedAt map[domain.AnswerKey]time.Time) (*service, *failingRepository) {
+	t.Helper()
+	store := servicetest.NewStore()
+	for key, at := range deletedAt {
+		store.CreateAnswers(t, &domain.Answer{Key: key, Value: "value"})
+		store.DeleteAnswers(t, at, testRetention, key)
+	}
+	repository := &failingRepository{
+		AnswerRepository: store.Answers,
+		purgeErrs:        make(map[domain.AnswerKey]error),
+	}
+	s := newBasicService(repository).(*service)
+	s.pageSize = 1
+	s.now = servicetest.Clock
+	return s, repository
+}
+
+func TestPurgeDeletedAnswers(t *testing.T) {
+	deletedAt := map[domain.AnswerKey]time.Time{
+		"due":      servicetest.Now.Add(-testRetention),
+		"overdue":  servicetest.Now.Add(-2 * testRetention),
+		"retained": servicetest.Now.Add(-testRetention + time.Second),
+	}
+	tests := []struct {
+		name       string
+		purgeErrs  map[domain.AnswerKey]error
+		wantPurged int
+		wantErr    error
+		wantKept   []domain.AnswerKey
+	}{
+		{
+			name:       "due answers purged",
+			wantPurged: 2,
+			wantKept:   []domain.AnswerKey{"retained"},
+		},
+		{
+			name:       "recreated answer skipped",
+			purgeErrs:  map[domain.AnswerKey]error{"due": errs.NewErrNotFound("Deleted answer not found")},
+			wantPurged: 1,
+			wantKept:   []domain.AnswerKey{"due", "retained"},
+		},
+		{
+			name:      "purge failed",
+			purgeErrs: map[domain.AnswerKey]error{"due": servicetest.ErrStorage},
+			wantErr:   servicetest.ErrStorage,
+			wantKept:  []domain.AnswerKey{"due", "overdue", "retained"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repository := newTestService(t, deletedAt)
+			for key, err := range tt.purgeErrs {
+				repository.purgeErrs[key] = err
+			}
+			purged, err := s.PurgeDeletedAnswers(context.Background())
+			if diff := deep.Equal(err, tt.wantErr); diff != nil {
+				t.Error(diff)
+			}
+			if purged != tt.wantPurged {
+				t.Errorf("expected %d purged, got %d", tt.wantPurged, purged)
+			}
+			answers, _, err := repository.ListDeleted(domain.PageRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var kept []domain.AnswerKey
+			for _, answer := range answers {
+				kept = append(kept, answer.Key)
+			}
+			if diff := deep.Equal(kept, tt.wantKept); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestListDeletedAnswers(t *testing.T) {
+	s, _ := newTestService(t, map[domain.AnswerKey]time.Time{"name": servicetest.Now})
+	answers, nextPageToken, err := s.ListDeletedAnswers(context.Background(), domain.PageRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*domain.DeletedAnswer{
+		{
+			Answer:    domain.Answer{Key: "name", Value: "value", Version: 2},
+			Tombstone: domain.NewTombstone(servicetest.Now, testRetention),
+		},
+	}
+	if diff := deep.Equal(answers, expected); diff != nil {
+		t.Error(diff)
+	}
+	if len(nextPageToken) != 0 {
+		t.Errorf("unexpected token %q", nextPageToken)
+	}
+
+	// Page size is limited.
+	//
+	_, _, err = s.ListDeletedAnswers(context.Background(), domain.PageRequest{PageSize: domain.MaxPageSize + 1})
+	if _, ok := err.(*errs.ErrInvalidArgument); !ok {
+		t.Errorf("expected ErrInvalidArgument, got %T: %v", err, err)
+	}
+}
@@ -0,0 +1,46 @@
+package retention
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+)
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(domain.AnswerRetentionService) domain.AnswerRetentionService
+
+// LoggingServiceMiddleware takes a logger as a dependency
+// and returns a service Middleware.
+func LoggingServiceMiddleware(logger log.Logger) Middleware {
+	return func(next domain.AnswerRetentionService) domain.AnswerRetentionService {
+		return loggingMiddleware{logger, next}
+	}
+}
+
+type loggingMiddleware struct {
+	logger log.Logger
+	next   domain.AnswerRetentionService
+}
+
+func (mw loggingMiddleware) ListDeletedAnswers(ctx context.Context, page domain.PageRequest) (answers []*domain.DeletedAnswer, nextPageToken string, err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "ListDeletedAnswers",
+			"pageSize", page.PageSize,
+			"count", len(answers),
+			"err", err,
+		)
+	}()
+	return mw.next.ListDeletedAnswers(ctx, page)
+}
+
+func (mw loggingMiddleware) PurgeDeletedAnswers(ctx context.Context) (purged int, err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "PurgeDeletedAnswers",
+			"purged", purged,
+			"err", err,
+		)
+	}()
+	return mw.next.PurgeDeletedAnswers(ctx)
+}
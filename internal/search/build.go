@@ -0,0 +1,49 @@
+package search
+
+import (
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+// BuildIndex - indexes all the stored answers page by page. The answers are indexed with the time of their change
+// read from the history, so the events consumed afterwards replace them unless they are older, including the events of
+// the changes made during the build. An answer without a history of its value is indexed without a change time.
+func BuildIndex(repository domain.AnswerRepository, eventRepository domain.AnswerEventRepository, index domain.AnswerSearchIndex) (indexed int, err error) {
+	page := domain.PageRequest{PageSize: domain.MaxPageSize}
+	for {
+		answers, nextPageToken, err := repository.List(page)
+		if err != nil {
+			return indexed, err
+		}
+		for _, answer := range answers {
+			events, err := eventRepository.ListEvents(answer.Key)
+			if err != nil {
+				return indexed, err
+			}
+			if err := index.Index(answer, answerChangedAt(answer, events)); err != nil {
+				return indexed, err
+			}
+			indexed++
+		}
+		if len(nextPageToken) == 0 {
+			return indexed, nil
+		}
+		page.PageToken = nextPageToken
+	}
+}
+
+// answerChangedAt - returns the time of the event which made the version of the answer. The answers stored before the
+// changes were counted take the time of the last event with their value.
+func answerChangedAt(answer *domain.Answer, events []*domain.AnswerEvent) time.Time {
+	for n := len(events) - 1; n >= 0; n-- {
+		event := events[n]
+		if answer.Version > 0 && event.Version() == answer.Version {
+			return event.OccurredAt
+		}
+		if answer.Version == 0 && event.Data != nil && event.Data.Value == answer.Value {
+			return event.OccurredAt
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,142 @@
+package search
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+)
+
+// removedEntryRetention - how long a removed answer is kept after the last change of the index. An older change
+// delivered later than that adds the answer again, the queues deliver the events well within it.
+const removedEntryRetention = 24 * time.Hour
+
+// indexEntry - the indexed state of an answer, a removed answer is kept with its removal time,
+// so an older change delivered late does not add it again.
+type indexEntry struct {
+	answer    domain.Answer
+	removed   bool
+	changedAt time.Time
+}
+
+type index struct {
+	mu      sync.RWMutex
+	entries map[domain.AnswerKey]*indexEntry
+	// keys - the sorted keys of the indexed answers, the removed ones excluded.
+	keys []domain.AnswerKey
+	// lastChangedAt, prunedAt - the time of the latest change and of the last pruning of the removed answers.
+	lastChangedAt time.Time
+	prunedAt      time.Time
+}
+
+// NewIndex creates an index embedded in the process. The values are only held in memory,
+// so the decrypted values of the answers are never written anywhere by the index.
+// A search scans the answers in the order of their keys from the page token until the page is full,
+// the index serves a single replica of a moderate number of answers.
+func NewIndex() domain.AnswerSearchIndex {
+	return &index{
+		entries: make(map[domain.AnswerKey]*indexEntry),
+	}
+}
+
+func (i *index) Index(answer *domain.Answer, changedAt time.Time) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	entry, ok := i.entries[answer.Key]
+	if ok && entry.changedAt.After(changedAt) {
+		return nil
+	}
+	if !ok || entry.removed {
+		i.insertKey(answer.Key)
+	}
+	i.entries[answer.Key] = &indexEntry{answer: *answer, changedAt: changedAt}
+	i.prune(changedAt)
+	return nil
+}
+
+func (i *index) Remove(key domain.AnswerKey, changedAt time.Time) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	entry, ok := i.entries[key]
+	if ok && entry.changedAt.After(changedAt) {
+		return nil
+	}
+	if ok && !entry.removed {
+		i.deleteKey(key)
+	}
+	i.entries[key] = &indexEntry{answer: domain.Answer{Key: key}, removed: true, changedAt: changedAt}
+	i.prune(changedAt)
+	return nil
+}
+
+func (i *index) Search(query domain.AnswerSearchQuery, page domain.PageRequest) ([]*domain.Answer, string, error) {
+	lastKey, err := domain.DecodePageToken(page.PageToken)
+	if err != nil {
+		return nil, "", errors.NewErrInvalidArgument(err.Error())
+	}
+
+	// Match the answers after the last key of the previous page, one more than the page size tells whether
+	// another page follows.
+	//
+	i.mu.RLock()
+	start := 0
+	if len(page.PageToken) > 0 {
+		start = sort.Search(len(i.keys), func(n int) bool { return string(i.keys[n]) > lastKey })
+	}
+	var answers []*domain.Answer
+	for _, key := range i.keys[start:] {
+		entry := i.entries[key]
+		if query.Matches(&entry.answer) {
+			answer := entry.answer
+			answers = append(answers, &answer)
+			if len(answers) > page.Size() {
+				break
+			}
+		}
+	}
+	i.mu.RUnlock()
+
+	// Cut the page.
+	//
+	if len(answers) <= page.Size() {
+		return answers, "", nil
+	}
+	answers = answers[:page.Size()]
+	return answers, domain.AnswerPageToken(answers[len(answers)-1].Key), nil
+}
+
+// insertKey - adds the key to the sorted keys.
+func (i *index) insertKey(key domain.AnswerKey) {
+	n := sort.Search(len(i.keys), func(n int) bool { return i.keys[n] >= key })
+	i.keys = append(i.keys, "")
+	copy(i.keys[n+1:], i.keys[n:])
+	i.keys[n] = key
+}
+
+// deleteKey - removes the key from the sorted keys.
+func (i *index) deleteKey(key domain.AnswerKey) {
+	n := sort.Search(len(i.keys), func(n int) bool { return i.keys[n] >= key })
+	if n < len(i.keys) && i.keys[n] == key {
+		i.keys = append(i.keys[:n], i.keys[n+1:]...)
+	}
+}
+
+// prune - drops the answers removed longer than the retention before the latest change.
+// The removed answers are looked for once per retention, so a change does not scan the index.
+func (i *index) prune(changedAt time.Time) {
+	if changedAt.After(i.lastChangedAt) {
+		i.lastChangedAt = changedAt
+	}
+	if i.lastChangedAt.Sub(i.prunedAt) < removedEntryRetention {
+		return
+	}
+	before := i.lastChangedAt.Add(-removedEntryRetention)
+	for key, entry := range i.entries {
+		if entry.removed && entry.changedAt.Before(before) {
+			delete(i.entries, key)
+		}
+	}
+	i.prunedAt = i.lastChangedAt
+}
@@ -0,0 +1,265 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/inmemory"
+
+	"github.com/go-test/deep"
+)
+
+var (
+	testChangedAt = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	testAnswers   = []*domain.Answer{
+		{Key: "patient.allergy", Value: "Penicillin"},
+		{Key: "symptom.cough", Value: "Dry cough"},
+		{Key: "symptom.headache", Value: "Severe headache since Monday"},
+		{Key: "symptom.temperature", Value: "38.5"},
+	}
+)
+
+// newTestIndex - returns an index of the test answers.
+func newTestIndex(t *testing.T) domain.AnswerSearchIndex {
+	t.Helper()
+	index := NewIndex()
+	for _, answer := range testAnswers {
+		if err := index.Index(answer, testChangedAt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return index
+}
+
+func term(text string, match domain.AnswerMatchType, fuzziness int) *domain.AnswerSearchTerm {
+	return &domain.AnswerSearchTerm{Text: text, Match: match, Fuzziness: fuzziness}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    domain.AnswerSearchQuery
+		wantKeys []domain.AnswerKey
+	}{
+		{
+			name:     "exact key",
+			query:    domain.AnswerSearchQuery{Key: term("symptom.cough", domain.ExactAnswerMatch, 0)},
+			wantKeys: []domain.AnswerKey{"symptom.cough"},
+		},
+		{
+			name:  "exact match keeps the case",
+			query: domain.AnswerSearchQuery{Value: term("dry cough", domain.ExactAnswerMatch, 0)},
+		},
+		{
+			name:     "key prefix ignores the case",
+			query:    domain.AnswerSearchQuery{Key: term("SYMPTOM.", domain.PrefixAnswerMatch, 0)},
+			wantKeys: []domain.AnswerKey{"symptom.cough", "symptom.headache", "symptom.temperature"},
+		},
+		{
+			name:     "value substring",
+			query:    domain.AnswerSearchQuery{Value: term("cillin", domain.SubstringAnswerMatch, 0)},
+			wantKeys: []domain.AnswerKey{"patient.allergy"},
+		},
+		{
+			name:     "fuzzy word of the value",
+			query:    domain.AnswerSearchQuery{Value: term("hedache", domain.FuzzyAnswerMatch, 0)},
+			wantKeys: []domain.AnswerKey{"symptom.headache"},
+		},
+		{
+			name:     "fuzzy word of the key",
+			query:    domain.AnswerSearchQuery{Key: term("alergy", domain.FuzzyAnswerMatch, 1)},
+			wantKeys: []domain.AnswerKey{"patient.allergy"},
+		},
+		{
+			name:  "fuzziness exceeded",
+			query: domain.AnswerSearchQuery{Value: term("hdch", domain.FuzzyAnswerMatch, 1)},
+		},
+		{
+			name:  "short text is not fuzzy by default",
+			query: domain.AnswerSearchQuery{Value: term("do", domain.FuzzyAnswerMatch, 0)},
+		},
+		{
+			name: "all terms match",
+			query: domain.AnswerSearchQuery{
+				Key:   term("symptom.", domain.PrefixAnswerMatch, 0),
+				Value: term("cough", domain.SubstringAnswerMatch, 0),
+			},
+			wantKeys: []domain.AnswerKey{"symptom.cough"},
+		},
+	}
+	index := newTestIndex(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers, nextPageToken, err := index.Search(tt.query, domain.PageRequest{})
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if diff := deep.Equal(keys(answers), tt.wantKeys); diff != nil {
+				t.Error(diff)
+			}
+			if diff := deep.Equal(nextPageToken, ""); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestSearchPages(t *testing.T) {
+	index := newTestIndex(t)
+	query := domain.AnswerSearchQuery{Key: term("symptom.", domain.PrefixAnswerMatch, 0)}
+	var pages [][]domain.AnswerKey
+	page := domain.PageRequest{PageSize: 2}
+	for {
+		answers, nextPageToken, err := index.Search(query, page)
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		pages = append(pages, keys(answers))
+		if len(nextPageToken) == 0 {
+			break
+		}
+		page.PageToken = nextPageToken
+	}
+	want := [][]domain.AnswerKey{
+		{"symptom.cough", "symptom.headache"},
+		{"symptom.temperature"},
+	}
+	if diff := deep.Equal(pages, want); diff != nil {
+		t.Error(diff)
+	}
+
+	_, _, err := index.Search(query, domain.PageRequest{PageToken: "not a token"})
+	if diff := deep.Equal(err != nil, true); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestIndexIgnoresOlderChanges(t *testing.T) {
+	index := newTestIndex(t)
+	query := domain.AnswerSearchQuery{Key: term("symptom.cough", domain.ExactAnswerMatch, 0)}
+
+	// An older update delivered late does not replace the answer.
+	//
+	if err := index.Index(&domain.Answer{Key: "symptom.cough", Value: "Wet cough"}, testChangedAt.Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	answers, _, _ := index.Search(query, domain.PageRequest{})
+	if diff := deep.Equal(answers, []*domain.Answer{testAnswers[1]}); diff != nil {
+		t.Error(diff)
+	}
+
+	// An older create delivered after the removal does not add the answer again.
+	//
+	if err := index.Remove("symptom.cough", testChangedAt.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := index.Index(testAnswers[1], testChangedAt); err != nil {
+		t.Fatal(err)
+	}
+	answers, _, _ = index.Search(query, domain.PageRequest{})
+	if diff := deep.Equal(len(answers), 0); diff != nil {
+		t.Error(diff)
+	}
+
+	// A newer create adds the answer again.
+	//
+	recreated := &domain.Answer{Key: "symptom.cough", Value: "Chesty cough"}
+	if err := index.Index(recreated, testChangedAt.Add(2*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	answers, _, _ = index.Search(query, domain.PageRequest{})
+	if diff := deep.Equal(answers, []*domain.Answer{recreated}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestIndexPrunesRemovedAnswers(t *testing.T) {
+	searchIndex := newTestIndex(t)
+	if err := searchIndex.Remove("symptom.cough", testChangedAt); err != nil {
+		t.Fatal(err)
+	}
+
+	// The removed answer is kept within the retention, then dropped by a later change.
+	//
+	changes := []struct {
+		changedAt time.Time
+		wantKept  bool
+	}{
+		{changedAt: testChangedAt.Add(removedEntryRetention / 2), wantKept: true},
+		{changedAt: testChangedAt.Add(2 * removedEntryRetention), wantKept: false},
+	}
+	for _, change := range changes {
+		if err := searchIndex.Index(&domain.Answer{Key: "symptom.rash", Value: "Red"}, change.changedAt); err != nil {
+			t.Fatal(err)
+		}
+		_, kept := searchIndex.(*index).entries["symptom.cough"]
+		if diff := deep.Equal(kept, change.wantKept); diff != nil {
+			t.Error(diff)
+		}
+	}
+	answers, _, err := searchIndex.Search(domain.AnswerSearchQuery{Key: term("symptom.", domain.PrefixAnswerMatch, 0)}, domain.PageRequest{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(keys(answers), []domain.AnswerKey{"symptom.headache", "symptom.rash", "symptom.temperature"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestBuildIndex(t *testing.T) {
+	repository := inmemory.NewAnswerRepository()
+	eventRepository := inmemory.NewAnswerEventRepository()
+	for _, testAnswer := range testAnswers {
+		answer := &domain.Answer{Key: testAnswer.Key, Value: testAnswer.Value}
+		if err := repository.Create(answer); err != nil {
+			t.Fatal(err)
+		}
+		event := &domain.AnswerEvent{EventType: domain.CreateAnswerEventType, Data: answer, OccurredAt: testChangedAt}
+		if err := eventRepository.Create(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	index := NewIndex()
+
+	// A newer change consumed during the build is not replaced by the stored answer, an older one is.
+	//
+	if err := index.Remove("patient.allergy", testChangedAt.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err := index.Remove("symptom.cough", testChangedAt.Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	indexed, err := BuildIndex(repository, eventRepository, index)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(indexed, len(testAnswers)); diff != nil {
+		t.Error(diff)
+	}
+
+	// The stored answers carry their change time, so an older change consumed after the build is ignored.
+	//
+	if err := index.Index(&domain.Answer{Key: "symptom.headache", Value: "Mild headache"}, testChangedAt.Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	answers, _, err := index.Search(domain.AnswerSearchQuery{Value: term("c", domain.SubstringAnswerMatch, 0)}, domain.PageRequest{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := []*domain.Answer{
+		{Key: "symptom.cough", Value: "Dry cough", Version: 1},
+		{Key: "symptom.headache", Value: "Severe headache since Monday", Version: 1},
+	}
+	if diff := deep.Equal(answers, want); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func keys(answers []*domain.Answer) []domain.AnswerKey {
+	var keys []domain.AnswerKey
+	for _, answer := range answers {
+		keys = append(keys, answer.Key)
+	}
+	return keys
+}
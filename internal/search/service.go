@@ -0,0 +1,55 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-kit/log"
+)
+
+type service struct {
+	index domain.AnswerSearchIndex
+}
+
+// NewService creates a new service with necessary dependencies.
+// The index is nil if the search is not enabled, the searches then fail.
+func NewService(index domain.AnswerSearchIndex, logger log.Logger) domain.AnswerSearchService {
+	var service domain.AnswerSearchService
+	{
+		service = newBasicService(index)
+		service = LoggingServiceMiddleware(logger)(service)
+	}
+	return service
+}
+
+// Returns a naive, stateless implementation of service.
+func newBasicService(index domain.AnswerSearchIndex) domain.AnswerSearchService {
+	return &service{
+		index: index,
+	}
+}
+
+func (s *service) SearchAnswers(ctx context.Context, query domain.AnswerSearchQuery, page domain.PageRequest) ([]*domain.Answer, string, error) {
+
+	// Check request.
+	//
+	if err := query.Validate(); err != nil {
+		return nil, "", errors.NewErrInvalidArgument(err.Error())
+	}
+	if page.PageSize < 0 || page.PageSize > domain.MaxPageSize {
+		return nil, "", errors.NewErrInvalidArgument(fmt.Sprintf("PageSize must be between 0 and %d", domain.MaxPageSize))
+	}
+
+	// The index is only kept by the applications configured with a search queue.
+	//
+	if s.index == nil {
+		return nil, "", errors.NewErrFailedPrecondition("Answer search is not enabled")
+	}
+
+	// Return result.
+	//
+	return s.index.Search(query, page)
+}
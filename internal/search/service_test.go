@@ -0,0 +1,80 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errs "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-test/deep"
+)
+
+func TestSearchAnswers(t *testing.T) {
+	tests := []struct {
+		name     string
+		disabled bool
+		query    domain.AnswerSearchQuery
+		page     domain.PageRequest
+		wantKeys []domain.AnswerKey
+		wantErr  error
+	}{
+		{
+			name:     "matching answers",
+			query:    domain.AnswerSearchQuery{Value: term("cough", domain.SubstringAnswerMatch, 0)},
+			wantKeys: []domain.AnswerKey{"symptom.cough"},
+		},
+		{
+			name:    "no term",
+			wantErr: errs.NewErrInvalidArgument("Key or value term required"),
+		},
+		{
+			name:    "empty text",
+			query:   domain.AnswerSearchQuery{Key: term("", domain.PrefixAnswerMatch, 0)},
+			wantErr: errs.NewErrInvalidArgument("Key term: Text required"),
+		},
+		{
+			name:    "unknown match type",
+			query:   domain.AnswerSearchQuery{Value: term("cough", "regex", 0)},
+			wantErr: errs.NewErrInvalidArgument(`Value term: Match type "regex" is not valid`),
+		},
+		{
+			name:    "fuzziness without fuzzy match",
+			query:   domain.AnswerSearchQuery{Value: term("cough", domain.PrefixAnswerMatch, 1)},
+			wantErr: errs.NewErrInvalidArgument("Value term: Fuzziness requires the fuzzy match"),
+		},
+		{
+			name:    "fuzziness too high",
+			query:   domain.AnswerSearchQuery{Value: term("cough", domain.FuzzyAnswerMatch, 3)},
+			wantErr: errs.NewErrInvalidArgument("Value term: Fuzziness must be between 0 and 2"),
+		},
+		{
+			name:    "page size too big",
+			query:   domain.AnswerSearchQuery{Value: term("cough", domain.SubstringAnswerMatch, 0)},
+			page:    domain.PageRequest{PageSize: domain.MaxPageSize + 1},
+			wantErr: errs.NewErrInvalidArgument("PageSize must be between 0 and 1000"),
+		},
+		{
+			name:     "search not enabled",
+			disabled: true,
+			query:    domain.AnswerSearchQuery{Value: term("cough", domain.SubstringAnswerMatch, 0)},
+			wantErr:  errs.NewErrFailedPrecondition("Answer search is not enabled"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index := newTestIndex(t)
+			if tt.disabled {
+				index = nil
+			}
+			s := newBasicService(index)
+			answers, _, err := s.SearchAnswers(context.Background(), tt.query, tt.page)
+			if diff := deep.Equal(err, tt.wantErr); diff != nil {
+				t.Error(diff)
+			}
+			if diff := deep.Equal(keys(answers), tt.wantKeys); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
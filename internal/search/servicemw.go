@@ -0,0 +1,39 @@
+package search
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+)
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(domain.AnswerSearchService) domain.AnswerSearchService
+
+// LoggingServiceMiddleware takes a logger as a dependency
+// and returns a service Middleware.
+func LoggingServiceMiddleware(logger log.Logger) Middleware {
+	return func(next domain.AnswerSearchService) domain.AnswerSearchService {
+		return loggingMiddleware{logger, next}
+	}
+}
+
+type loggingMiddleware struct {
+	logger log.Logger
+	next   domain.AnswerSearchService
+}
+
+func (mw loggingMiddleware) SearchAnswers(ctx context.Context, query domain.AnswerSearchQuery, page domain.PageRequest) (list []*domain.Answer, nextPageToken string, err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "SearchAnswers",
+			"query", query,
+			"pageSize", page.PageSize,
+			"pageToken", domain.RedactPageToken(page.PageToken),
+			"count", len(list),
+			"nextPageToken", domain.RedactPageToken(nextPageToken),
+			"err", err,
+		)
+	}()
+	return mw.next.SearchAnswers(ctx, query, page)
+}
@@ -0,0 +1,117 @@
+package servicetest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/inmemory"
+)
+
+// EventQueueName - the queue the services under test send the answer events to.
+const EventQueueName = "answer.events"
+
+var (
+	// Now - the time the clock of the services under test returns.
+	Now = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	// ErrStorage - the error of a failing storage.
+	ErrStorage = errors.NewErrInternal("storage is down")
+)
+
+// Clock - returns Now, it replaces the clock of the services under test.
+func Clock() time.Time {
+	return Now
+}
+
+// Store - the in-memory repositories the services under test read and write.
+type Store struct {
+	Answers     domain.AnswerRepository
+	Events      domain.AnswerEventRepository
+	Erasures    domain.ErasureRepository
+	Idempotency domain.IdempotencyRepository
+	Webhooks    domain.WebhookRepository
+}
+
+// NewStore - returns empty repositories.
+func NewStore() *Store {
+	return &Store{
+		Answers:     inmemory.NewAnswerRepository(),
+		Events:      inmemory.NewAnswerEventRepository(),
+		Erasures:    inmemory.NewErasureRepository(),
+		Idempotency: inmemory.NewIdempotencyRepository(),
+		Webhooks:    inmemory.NewWebhookRepository(),
+	}
+}
+
+// CreateAnswers - stores the answers.
+func (s *Store) CreateAnswers(t *testing.T, answers ...*domain.Answer) {
+	t.Helper()
+	for _, answer := range answers {
+		if err := s.Answers.Create(answer); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// DeleteAnswers - deletes the stored answers of the keys at the time, they are retained for the retention period.
+func (s *Store) DeleteAnswers(t *testing.T, deletedAt time.Time, retention time.Duration, keys ...domain.AnswerKey) {
+	t.Helper()
+	for _, key := range keys {
+		if _, err := s.Answers.Delete(key, domain.NewTombstone(deletedAt, retention)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// CreateEvents - records the events in the history.
+func (s *Store) CreateEvents(t *testing.T, events ...*domain.AnswerEvent) {
+	t.Helper()
+	for _, event := range events {
+		if err := s.Events.Create(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// CreateRecords - stores the answers and records the history of the records.
+func (s *Store) CreateRecords(t *testing.T, records ...*domain.AnswerRecord) {
+	t.Helper()
+	for _, record := range records {
+		if record.Answer != nil {
+			s.CreateAnswers(t, record.Answer)
+		}
+		s.CreateEvents(t, record.Events...)
+	}
+}
+
+// SentMessage - a message recorded by QueueService.
+type SentMessage struct {
+	QueueName string
+	Message   domain.QueueMessage
+}
+
+// QueueService - records the sent messages, or fails with Err if set.
+type QueueService struct {
+	mu        sync.Mutex
+	Messages  []SentMessage
+	MessageID string
+	Err       error
+}
+
+// SendMessage - records the message and returns MessageID.
+func (q *QueueService) SendMessage(ctx context.Context, queueName string, message domain.QueueMessage) (string, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.Err != nil {
+		return "", q.Err
+	}
+	q.Messages = append(q.Messages, SentMessage{QueueName: queueName, Message: message})
+	return q.MessageID, nil
+}
+
+var (
+	_ domain.QueueService = &QueueService{}
+)
@@ -0,0 +1,68 @@
+package snstopic
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/queue"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+)
+
+type service struct {
+	topicAPI TopicAPI
+	topicARNCache
+}
+
+// NewTopicService creates a service with necessary dependencies.
+// The topic fans the messages out to the SQS subscription queues by their filter policies.
+func NewTopicService(topicAPI TopicAPI) domain.TopicService {
+	return &service{
+		topicAPI:      topicAPI,
+		topicARNCache: topicARNCache{topicAPI: topicAPI},
+	}
+}
+
+func (s *service) Publish(
+	ctx context.Context,
+	topicName string,
+	message domain.QueueMessage,
+) (emptyMessageID string, err error) {
+
+	// Validate and marshal message body.
+	//
+	messageBody, err := queue.EncodeMessage(topicName, message)
+	if err != nil {
+		return emptyMessageID, err
+	}
+
+	// Get topic arn.
+	//
+	topicARN, err := s.getTopicARN(topicName)
+	if err != nil {
+		return emptyMessageID, err
+	}
+
+	// Publish message, the attributes are matched by the filter policies.
+	//
+	attributes := make(map[string]*sns.MessageAttributeValue)
+	for name, value := range message.GetAttributes() {
+		attributes[name] = &sns.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
+	}
+	resp, err := s.topicAPI.Publish(&sns.PublishInput{
+		TopicArn:          aws.String(topicARN),
+		Message:           aws.String(string(messageBody)),
+		MessageAttributes: attributes,
+	})
+	if err != nil {
+		return emptyMessageID, err
+	}
+
+	// Return result.
+	//
+	return aws.StringValue(resp.MessageId), nil
+}
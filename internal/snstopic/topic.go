@@ -0,0 +1,175 @@
+package snstopic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/sqsqueue"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+// TopicAPI - is the minimum interface required from a SNS client.
+type TopicAPI interface {
+	ListTopics(*sns.ListTopicsInput) (*sns.ListTopicsOutput, error)
+	Publish(*sns.PublishInput) (*sns.PublishOutput, error)
+}
+
+// TopicAdminAPI - is the minimum interface required from a SNS client to provision topics and their subscriptions.
+type TopicAdminAPI interface {
+	CreateTopic(*sns.CreateTopicInput) (*sns.CreateTopicOutput, error)
+	Subscribe(*sns.SubscribeInput) (*sns.SubscribeOutput, error)
+	SetSubscriptionAttributes(*sns.SetSubscriptionAttributesInput) (*sns.SetSubscriptionAttributesOutput, error)
+}
+
+// SubscriptionQueueAPI - is the minimum interface required from a SQS client to provision subscription queues.
+type SubscriptionQueueAPI interface {
+	sqsqueue.QueueAdminAPI
+	GetQueueAttributes(*sqs.GetQueueAttributesInput) (*sqs.GetQueueAttributesOutput, error)
+}
+
+// GetSNSTopicARN - returns the ARN of an existing Amazon SNS topic.
+// An error will be returned if the topic does not exist.
+func GetSNSTopicARN(topicAPI TopicAPI, topicName string) (arn string, err error) {
+	input := &sns.ListTopicsInput{}
+	for {
+		resp, err := topicAPI.ListTopics(input)
+		if err != nil {
+			return arn, err
+		}
+		for _, topic := range resp.Topics {
+			if strings.HasSuffix(aws.StringValue(topic.TopicArn), ":"+topicName) {
+				return aws.StringValue(topic.TopicArn), nil
+			}
+		}
+		if resp.NextToken == nil {
+			return arn, fmt.Errorf("topic %s does not exist, run the provision command", topicName)
+		}
+		input.NextToken = resp.NextToken
+	}
+}
+
+// ProvisionSNSTopic - creates a standard Amazon SNS topic if it does not exist.
+func ProvisionSNSTopic(topicAPI TopicAdminAPI, topicName string) (arn string, err error) {
+	resp, err := topicAPI.CreateTopic(&sns.CreateTopicInput{
+		Name: aws.String(topicName),
+	})
+	if err != nil {
+		return arn, err
+	}
+	return aws.StringValue(resp.TopicArn), nil
+}
+
+// ProvisionSNSSubscription - creates the subscription queue if it does not exist, allows the topic to send to it
+// and subscribes it to the topic with the filter policy. The messages are delivered raw, so their attributes are
+// the attributes of the queue messages.
+func ProvisionSNSSubscription(
+	topicAPI TopicAdminAPI,
+	queueAPI SubscriptionQueueAPI,
+	topicARN string,
+	subscription domain.TopicSubscription,
+	visibilityTimeout int64,
+) (arn string, err error) {
+
+	// Provision queue.
+	//
+	queueURL, err := sqsqueue.ProvisionSQSQueue(queueAPI, subscription.QueueName, visibilityTimeout)
+	if err != nil {
+		return arn, err
+	}
+	attributesResp, err := queueAPI.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueURL),
+		AttributeNames: []*string{aws.String(sqs.QueueAttributeNameQueueArn)},
+	})
+	if err != nil {
+		return arn, err
+	}
+	queueARN := aws.StringValue(attributesResp.Attributes[sqs.QueueAttributeNameQueueArn])
+
+	// Allow the topic to send to the queue.
+	//
+	policy, err := json.Marshal(map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{{
+			"Effect":    "Allow",
+			"Principal": map[string]string{"Service": "sns.amazonaws.com"},
+			"Action":    "sqs:SendMessage",
+			"Resource":  queueARN,
+			"Condition": map[string]interface{}{"ArnEquals": map[string]string{"aws:SourceArn": topicARN}},
+		}},
+	})
+	if err != nil {
+		return arn, err
+	}
+	_, err = queueAPI.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+		QueueUrl:   aws.String(queueURL),
+		Attributes: map[string]*string{sqs.QueueAttributeNamePolicy: aws.String(string(policy))},
+	})
+	if err != nil {
+		return arn, err
+	}
+
+	// Subscribe the queue, subscribing again returns the existing subscription,
+	// so its attributes are set afterwards to apply the changed filter policies.
+	//
+	subscribeResp, err := topicAPI.Subscribe(&sns.SubscribeInput{
+		TopicArn:              aws.String(topicARN),
+		Protocol:              aws.String("sqs"),
+		Endpoint:              aws.String(queueARN),
+		ReturnSubscriptionArn: aws.Bool(true),
+	})
+	if err != nil {
+		return arn, err
+	}
+	arn = aws.StringValue(subscribeResp.SubscriptionArn)
+	filterPolicy, err := encodeFilterPolicy(subscription.FilterPolicy.WithErasures())
+	if err != nil {
+		return arn, err
+	}
+	for name, value := range map[string]string{
+		"RawMessageDelivery": "true",
+		"FilterPolicy":       filterPolicy,
+	} {
+		_, err := topicAPI.SetSubscriptionAttributes(&sns.SetSubscriptionAttributesInput{
+			SubscriptionArn: aws.String(arn),
+			AttributeName:   aws.String(name),
+			AttributeValue:  aws.String(value),
+		})
+		if err != nil {
+			return arn, err
+		}
+	}
+	return arn, nil
+}
+
+// encodeFilterPolicy - returns the SNS filter policy, an empty policy removes the filter.
+func encodeFilterPolicy(policy domain.FilterPolicy) (string, error) {
+	if len(policy) == 0 {
+		return "{}", nil
+	}
+	encoded, err := json.Marshal(policy)
+	return string(encoded), err
+}
+
+// topicARNCache - caches topic ARNs by topic names.
+type topicARNCache struct {
+	topicAPI    TopicAPI
+	topicARNMap sync.Map
+}
+
+func (c *topicARNCache) getTopicARN(topicName string) (string, error) {
+	if topicARN, ok := c.topicARNMap.Load(topicName); ok {
+		return topicARN.(string), nil
+	}
+	topicARN, err := GetSNSTopicARN(c.topicAPI, topicName)
+	if err != nil {
+		return "", err
+	}
+	c.topicARNMap.Store(topicName, topicARN)
+	return topicARN, nil
+}
@@ -0,0 +1,131 @@
+package sqsqueue
+
+import (
+	"context"
+	"sync"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/go-kit/log"
+)
+
+type consumer struct {
+	queueAPI            QueueAPI
+	maxNumberOfMessages int64
+	logger              log.Logger
+	queueURLCache
+}
+
+// NewQueueConsumer creates a consumer with necessary dependencies.
+func NewQueueConsumer(queueAPI QueueAPI, maxNumberOfMessages int64, logger log.Logger) domain.QueueConsumer {
+	return &consumer{
+		queueAPI:            queueAPI,
+		maxNumberOfMessages: maxNumberOfMessages,
+		logger:              logger,
+		queueURLCache:       queueURLCache{queueAPI: queueAPI},
+	}
+}
+
+// Consume - starts the polling and will continue polling till the context is done.
+func (c *consumer) Consume(ctx context.Context, queueName string, h domain.QueueHandler) error {
+	for {
+		select {
+		case <-ctx.Done():
+			_ = c.logger.Log("Stopping polling because a context kill signal was sent")
+			return nil
+		default:
+
+			// Get queue url.
+			//
+			queueURL, err := c.getQueueURL(queueName)
+			if err != nil {
+				_ = c.logger.Log("err", err.Error())
+				continue
+			}
+
+			// Setup polling parameters.
+			//
+			params := &sqs.ReceiveMessageInput{
+				QueueUrl:            aws.String(queueURL), // Required
+				MaxNumberOfMessages: aws.Int64(c.maxNumberOfMessages),
+				AttributeNames: []*string{
+					aws.String("All"), // Required
+				},
+				MessageAttributeNames: []*string{
+					aws.String("All"), // Required
+				},
+			}
+
+			// Receive message from queue.
+			//
+			resp, err := c.queueAPI.ReceiveMessage(params)
+			if err != nil {
+				_ = c.logger.Log("err", err.Error())
+				continue
+			}
+			if len(resp.Messages) > 0 {
+				c.run(ctx, queueURL, h, resp.Messages)
+			}
+		}
+	}
+}
+
+// run - launches goroutine per received message and wait for all message to be processed.
+func (c *consumer) run(ctx context.Context, queueURL string, h domain.QueueHandler, messages []*sqs.Message) {
+	numMessages := len(messages)
+	_ = c.logger.Log("Received messages", numMessages)
+
+	var wg sync.WaitGroup
+	wg.Add(numMessages)
+	for i := range messages {
+		go func(m *sqs.Message) {
+			// launch goroutine
+			defer wg.Done()
+
+			// Hadle message
+			//
+			err := c.handleMessage(ctx, queueURL, m, h)
+			if err != nil {
+				_ = c.logger.Log("Failed to handle message", "err", err)
+			}
+		}(messages[i])
+	}
+
+	wg.Wait()
+}
+
+func (c *consumer) handleMessage(ctx context.Context, queueURL string, m *sqs.Message, h domain.QueueHandler) error {
+
+	// Handle message.
+	//
+	if err := h.HandleMessage(ctx, decodeMessage(m)); err != nil {
+		return err
+	}
+
+	// Delete message.
+	//
+	params := &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL), // Required
+		ReceiptHandle: m.ReceiptHandle,      // Required
+	}
+	_, err := c.queueAPI.DeleteMessage(params)
+	return err
+}
+
+// decodeMessage - converts a SQS message to a backend-neutral message.
+func decodeMessage(m *sqs.Message) *domain.ReceivedMessage {
+	msg := &domain.ReceivedMessage{
+		ID:         aws.StringValue(m.MessageId),
+		Attributes: make(map[string]string),
+		Body:       []byte(aws.StringValue(m.Body)),
+	}
+	for name, value := range m.MessageAttributes {
+		if value.StringValue != nil {
+			msg.Attributes[name] = aws.StringValue(value.StringValue)
+		}
+	}
+	msg.MessageType = domain.MessageType(msg.Attributes[domain.MessageTypeAttributeKey])
+	return msg
+}
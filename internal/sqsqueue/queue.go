@@ -0,0 +1,100 @@
+package sqsqueue
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+// QueueAPI - is the minimum interface required from a SQS client.
+type QueueAPI interface {
+	GetQueueUrl(*sqs.GetQueueUrlInput) (*sqs.GetQueueUrlOutput, error)
+	SendMessage(*sqs.SendMessageInput) (*sqs.SendMessageOutput, error)
+	ReceiveMessage(*sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error)
+	DeleteMessage(*sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error)
+}
+
+// QueueAdminAPI - is the minimum interface required from a SQS client to provision queues.
+type QueueAdminAPI interface {
+	CreateQueue(*sqs.CreateQueueInput) (*sqs.CreateQueueOutput, error)
+	GetQueueUrl(*sqs.GetQueueUrlInput) (*sqs.GetQueueUrlOutput, error)
+	SetQueueAttributes(*sqs.SetQueueAttributesInput) (*sqs.SetQueueAttributesOutput, error)
+}
+
+// GetSQSQueueURL - returns the URL of an existing Amazon SQS queue.
+// An error will be returned if the queue does not exist.
+func GetSQSQueueURL(queueAPI QueueAPI, queueName string) (url string, err error) {
+	resp, err := queueAPI.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: aws.String(queueName),
+	})
+	if isQueueDoesNotExist(err) {
+		return url, fmt.Errorf("queue %s does not exist, run the provision command", queueName)
+	}
+	if err != nil {
+		return url, err
+	}
+	return aws.StringValue(resp.QueueUrl), nil
+}
+
+// ProvisionSQSQueue - creates a standard Amazon SQS queue if it does not exist
+// and applies the visibility timeout in seconds to it.
+func ProvisionSQSQueue(queueAPI QueueAdminAPI, queueName string, visibilityTimeout int64) (url string, err error) {
+	attributes := map[string]*string{
+		sqs.QueueAttributeNameVisibilityTimeout: aws.String(strconv.FormatInt(visibilityTimeout, 10)),
+	}
+
+	// Update the existing queue.
+	//
+	urlResp, err := queueAPI.GetQueueUrl(&sqs.GetQueueUrlInput{
+		QueueName: aws.String(queueName),
+	})
+	if err == nil {
+		url = aws.StringValue(urlResp.QueueUrl)
+		_, err = queueAPI.SetQueueAttributes(&sqs.SetQueueAttributesInput{
+			QueueUrl:   aws.String(url),
+			Attributes: attributes,
+		})
+		return url, err
+	}
+	if !isQueueDoesNotExist(err) {
+		return url, err
+	}
+
+	// The queue does not exist, create it.
+	//
+	createResp, err := queueAPI.CreateQueue(&sqs.CreateQueueInput{
+		QueueName:  aws.String(queueName),
+		Attributes: attributes,
+	})
+	if err != nil {
+		return url, err
+	}
+	return aws.StringValue(createResp.QueueUrl), nil
+}
+
+func isQueueDoesNotExist(err error) bool {
+	aerr, ok := err.(awserr.Error)
+	return ok && aerr.Code() == sqs.ErrCodeQueueDoesNotExist
+}
+
+// queueURLCache - caches queue urls by queue names.
+type queueURLCache struct {
+	queueAPI    QueueAPI
+	queueURLMap sync.Map
+}
+
+func (c *queueURLCache) getQueueURL(queueName string) (string, error) {
+	if queueURL, ok := c.queueURLMap.Load(queueName); ok {
+		return queueURL.(string), nil
+	}
+	queueURL, err := GetSQSQueueURL(c.queueAPI, queueName)
+	if err != nil {
+		return "", err
+	}
+	c.queueURLMap.Store(queueName, queueURL)
+	return queueURL, nil
+}
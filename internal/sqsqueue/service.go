@@ -2,36 +2,24 @@ package sqsqueue
 
 import (
 	"context"
-	"encoding/json"
-	"sync"
 
 	"dochq.co.uk.answerservice/internal/domain"
-	errors "dochq.co.uk.answerservice/internal/error"
-	"dochq.co.uk.answerservice/internal/helpers"
+	"dochq.co.uk.answerservice/internal/queue"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sqs"
-	"github.com/go-kit/log"
 )
 
 type service struct {
-	queueAPI    domain.QueueAPI
-	queueURLMap sync.Map
+	queueAPI QueueAPI
+	queueURLCache
 }
 
 // NewQueueService creates a service with necessary dependencies.
-func NewQueueService(queueAPI domain.QueueAPI, logger log.Logger) domain.QueueService {
-	var service domain.QueueService
-	{
-		service = newBasicQueueService(queueAPI)
-		service = loggingServiceMiddleware(logger)(service)
-	}
-	return service
-}
-
-func newBasicQueueService(queueAPI domain.QueueAPI) domain.QueueService {
+func NewQueueService(queueAPI QueueAPI) domain.QueueService {
 	return &service{
-		queueAPI: queueAPI,
+		queueAPI:      queueAPI,
+		queueURLCache: queueURLCache{queueAPI: queueAPI},
 	}
 }
 
@@ -41,37 +29,16 @@ func (s *service) SendMessage(
 	message domain.QueueMessage,
 ) (emptyMessageID string, err error) {
 
-	// Check if the queue name defined.
-	//
-	if len(queueName) == 0 {
-		return emptyMessageID,
-			errors.NewErrInvalidArgument("Queue name required")
-	}
-
-	// Check message for nil.
-	//
-	if message == nil {
-		return emptyMessageID,
-			errors.NewErrInvalidArgument("Message cannot be nil")
-	}
-
-	// Validate message.
+	// Validate and marshal message body.
 	//
-	if err := message.Validate(); err != nil {
-		return emptyMessageID,
-			errors.NewErrInvalidArgument(err.Error())
-	}
-
-	// Marshal message body to JSON.
-	//
-	messageBody, err := json.Marshal(message)
+	messageBody, err := queue.EncodeMessage(queueName, message)
 	if err != nil {
 		return emptyMessageID, err
 	}
 
 	// Get queue url.
 	//
-	queueURL, err := s.getOrCreateQueueURL(queueName)
+	queueURL, err := s.getQueueURL(queueName)
 	if err != nil {
 		return emptyMessageID, err
 	}
@@ -79,14 +46,9 @@ func (s *service) SendMessage(
 	// Send message.
 	//
 	resp, err := s.queueAPI.SendMessage(&sqs.SendMessageInput{
-		QueueUrl:    aws.String(queueURL),
-		MessageBody: aws.String(string(messageBody)),
-		MessageAttributes: map[string]*sqs.MessageAttributeValue{
-			domain.MessageTypeAttributeKey: {
-				DataType:    aws.String("String"),
-				StringValue: aws.String(message.GetMessageType().String()),
-			},
-		},
+		QueueUrl:          aws.String(queueURL),
+		MessageBody:       aws.String(string(messageBody)),
+		MessageAttributes: encodeMessageAttributes(message),
 	})
 	if err != nil {
 		return emptyMessageID, err
@@ -97,14 +59,14 @@ func (s *service) SendMessage(
 	return aws.StringValue(resp.MessageId), nil
 }
 
-func (s *service) getOrCreateQueueURL(queueName string) (string, error) {
-	if queueURL, ok := s.queueURLMap.Load(queueName); ok {
-		return queueURL.(string), nil
-	}
-	queueURL, err := helpers.GetOrCreateSQSQueue(s.queueAPI, queueName)
-	if err != nil {
-		return "", err
+// encodeMessageAttributes - converts the message attributes to SQS string attributes.
+func encodeMessageAttributes(message domain.QueueMessage) map[string]*sqs.MessageAttributeValue {
+	attributes := make(map[string]*sqs.MessageAttributeValue)
+	for name, value := range message.GetAttributes() {
+		attributes[name] = &sqs.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
 	}
-	s.queueURLMap.Store(queueName, queueURL)
-	return queueURL, nil
+	return attributes
 }
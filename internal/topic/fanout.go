@@ -0,0 +1,56 @@
+package topic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/queue"
+)
+
+type fanOutService struct {
+	queueService  domain.QueueService
+	subscriptions map[string][]domain.TopicSubscription
+	sequence      uint64
+}
+
+// NewFanOutService creates a topic service which sends a copy of every message to the matching
+// subscription queues by the queue service. It stands in for SNS with the queue backends without topics.
+//
+// The copies are not sent atomically: if sending one fails the error is returned, the copies sent
+// before stay queued, so a publisher retrying the message must be served by idempotent consumers.
+func NewFanOutService(queueService domain.QueueService, subscriptions map[string][]domain.TopicSubscription) domain.TopicService {
+	return &fanOutService{
+		queueService:  queueService,
+		subscriptions: subscriptions,
+	}
+}
+
+func (s *fanOutService) Publish(ctx context.Context, topicName string, message domain.QueueMessage) (emptyMessageID string, err error) {
+
+	// Validate message, so it is rejected whether or not a subscription matches.
+	//
+	if _, err := queue.EncodeMessage(topicName, message); err != nil {
+		return emptyMessageID, err
+	}
+	subscriptions, ok := s.subscriptions[topicName]
+	if !ok {
+		return emptyMessageID, errors.NewErrNotFound(fmt.Sprintf("Topic %v not found", topicName))
+	}
+
+	// Send a copy to every matching subscription queue, as SNS does with the provisioned policies.
+	//
+	attributes := message.GetAttributes()
+	for _, subscription := range subscriptions {
+		if !subscription.FilterPolicy.WithErasures().Matches(attributes) {
+			continue
+		}
+		if _, err := s.queueService.SendMessage(ctx, subscription.QueueName, message); err != nil {
+			return emptyMessageID, err
+		}
+	}
+	return strconv.FormatUint(atomic.AddUint64(&s.sequence, 1), 10), nil
+}
@@ -0,0 +1,87 @@
+package topic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/memqueue"
+
+	"github.com/go-kit/log"
+	"github.com/go-test/deep"
+)
+
+func TestFanOutService(t *testing.T) {
+	broker := memqueue.NewBroker(log.NewNopLogger())
+	service := NewQueueService(NewFanOutService(broker, map[string][]domain.TopicSubscription{
+		"answer.events": {
+			{QueueName: "history"},
+			{QueueName: "analytics", FilterPolicy: domain.FilterPolicy{
+				domain.EventTypeAttributeKey: {string(domain.CreateAnswerEventType), string(domain.DeleteAnswerEventType)},
+			}},
+			{QueueName: "unsubscribed", FilterPolicy: domain.FilterPolicy{"Region": {"eu"}}},
+		},
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Publish an event of every type.
+	//
+	for _, eventType := range []domain.AnswerEventType{domain.CreateAnswerEventType, domain.UpdateAnswerEventType, domain.DeleteAnswerEventType, domain.EraseAnswerEventType} {
+		message := &domain.AnswerEventMessage{Event: &domain.AnswerEvent{EventType: eventType, Data: &domain.Answer{Key: "name", Value: "John"}}}
+		if _, err := service.SendMessage(ctx, "answer.events", message); err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+	}
+	_, err := service.SendMessage(ctx, "unknown", &domain.AnswerEventMessage{})
+	if diff := deep.Equal(err, errors.NewErrNotFound("Topic unknown not found")); diff != nil {
+		t.Error(diff)
+	}
+
+	// Every subscription queue receives the matching events in order, the erase events reach every queue
+	// filtering the event types.
+	//
+	for queueName, want := range map[string][]string{
+		"history":   {"create", "update", "delete", "erase"},
+		"analytics": {"create", "delete", "erase"},
+	} {
+		var got []string
+		consumeCtx, stop := context.WithCancel(ctx)
+		_ = broker.Consume(consumeCtx, queueName, domain.QueueHandlerFunc(func(ctx context.Context, msg *domain.ReceivedMessage) error {
+			got = append(got, msg.Attributes[domain.EventTypeAttributeKey])
+			if len(got) == len(want) {
+				stop()
+			}
+			return nil
+		}))
+		stop()
+		if diff := deep.Equal(got, want); diff != nil {
+			t.Errorf("%v: %v", queueName, diff)
+		}
+	}
+}
+
+func TestFilterPolicy(t *testing.T) {
+	attributes := map[string]string{domain.MessageTypeAttributeKey: "ANSWER_EVENT", domain.EventTypeAttributeKey: "create"}
+	tests := []struct {
+		name   string
+		policy domain.FilterPolicy
+		want   bool
+	}{
+		{name: "empty", want: true},
+		{name: "value listed", policy: domain.FilterPolicy{domain.EventTypeAttributeKey: {"update", "create"}}, want: true},
+		{name: "every attribute", policy: domain.FilterPolicy{domain.EventTypeAttributeKey: {"create"}, domain.MessageTypeAttributeKey: {"ANSWER_EVENT"}}, want: true},
+		{name: "value not listed", policy: domain.FilterPolicy{domain.EventTypeAttributeKey: {"update"}}},
+		{name: "attribute missing", policy: domain.FilterPolicy{"Region": {"eu"}}},
+		{name: "no values", policy: domain.FilterPolicy{domain.EventTypeAttributeKey: {}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Matches(attributes); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
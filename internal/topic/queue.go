@@ -0,0 +1,23 @@
+package topic
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+type queueService struct {
+	topicService domain.TopicService
+}
+
+// NewQueueService creates a queue service which publishes the messages to the topic named as the queue,
+// so the publishers of a queue can publish to a topic instead.
+func NewQueueService(topicService domain.TopicService) domain.QueueService {
+	return &queueService{
+		topicService: topicService,
+	}
+}
+
+func (s *queueService) SendMessage(ctx context.Context, topicName string, message domain.QueueMessage) (string, error) {
+	return s.topicService.Publish(ctx, topicName, message)
+}
@@ -0,0 +1,38 @@
+package transfer
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+// fakeAnswerService - writes to the repository and records the calls.
+type fakeAnswerService struct {
+	domain.AnswerService
+	repository domain.AnswerRepository
+	calls      []string
+}
+
+func (s *fakeAnswerService) CreateAnswer(ctx context.Context, answer *domain.Answer) (*domain.AnswerMutation, error) {
+	s.calls = append(s.calls, "create "+string(answer.Key))
+	return &domain.AnswerMutation{Answer: answer}, s.repository.Create(answer)
+}
+
+func (s *fakeAnswerService) UpdateAnswer(ctx context.Context, answer *domain.Answer) (*domain.AnswerMutation, error) {
+	s.calls = append(s.calls, "update "+string(answer.Key))
+	return &domain.AnswerMutation{Answer: answer}, s.repository.Update(answer)
+}
+
+func (s *fakeAnswerService) DeleteAnswer(ctx context.Context, key domain.AnswerKey) (*domain.AnswerMutation, error) {
+	s.calls = append(s.calls, "delete "+string(key))
+	_, err := s.repository.Delete(key, domain.Tombstone{})
+	return &domain.AnswerMutation{}, err
+}
+
+func (s *fakeAnswerService) GetAnswer(ctx context.Context, key domain.AnswerKey) (*domain.Answer, error) {
+	return s.repository.Get(key)
+}
+
+var (
+	_ domain.AnswerService = &fakeAnswerService{}
+)
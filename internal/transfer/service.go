@@ -0,0 +1,291 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-kit/log"
+)
+
+type service struct {
+	answerService   domain.AnswerService
+	repository      domain.AnswerRepository
+	eventRepository domain.AnswerEventRepository
+	retention       time.Duration
+	pageSize        int
+	now             func() time.Time
+}
+
+// NewService creates a new service with necessary dependencies.
+// The replay import mode writes through the answer service, the preserve mode writes to the repositories.
+func NewService(answerService domain.AnswerService,
+	repository domain.AnswerRepository,
+	eventRepository domain.AnswerEventRepository,
+	deletedAnswerRetention time.Duration,
+	logger log.Logger) domain.AnswerTransferService {
+	var service domain.AnswerTransferService
+	{
+		service = newBasicService(answerService, repository, eventRepository, deletedAnswerRetention)
+		service = LoggingServiceMiddleware(logger)(service)
+	}
+	return service
+}
+
+// Returns a naive, stateless implementation of service.
+func newBasicService(answerService domain.AnswerService,
+	repository domain.AnswerRepository,
+	eventRepository domain.AnswerEventRepository,
+	deletedAnswerRetention time.Duration) domain.AnswerTransferService {
+	return &service{
+		answerService:   answerService,
+		repository:      repository,
+		eventRepository: eventRepository,
+		retention:       deletedAnswerRetention,
+		pageSize:        domain.DefaultPageSize,
+		now:             time.Now,
+	}
+}
+
+// The phases of an export, the checkpoint of a later phase starts with its prefix.
+const (
+	historyCheckpointPrefix = "history:"
+	deletedCheckpointPrefix = "deleted:"
+)
+
+func (s *service) ExportAnswers(ctx context.Context, checkpoint string, fn domain.ExportFunc) error {
+
+	// The live answers are exported first, then the histories of the keys without a live answer,
+	// i.e. of the deleted, purged and erased keys, and last the deleted answers without a history.
+	// The checkpoint is the page token after the last exported key, prefixed by its phase.
+	//
+	switch {
+	case strings.HasPrefix(checkpoint, deletedCheckpointPrefix):
+		return s.exportDeleted(ctx, strings.TrimPrefix(checkpoint, deletedCheckpointPrefix), fn)
+	case strings.HasPrefix(checkpoint, historyCheckpointPrefix):
+		if err := s.exportHistories(ctx, strings.TrimPrefix(checkpoint, historyCheckpointPrefix), fn); err != nil {
+			return err
+		}
+		return s.exportDeleted(ctx, "", fn)
+	}
+	if err := s.exportAnswers(ctx, checkpoint, fn); err != nil {
+		return err
+	}
+	if err := s.exportHistories(ctx, "", fn); err != nil {
+		return err
+	}
+	return s.exportDeleted(ctx, "", fn)
+}
+
+// exportAnswers - exports the live answers with their histories.
+func (s *service) exportAnswers(ctx context.Context, pageToken string, fn domain.ExportFunc) error {
+	page := domain.PageRequest{PageToken: pageToken, PageSize: s.pageSize}
+	for {
+		answers, nextPageToken, err := s.repository.List(page)
+		if err != nil {
+			return err
+		}
+		for _, answer := range answers {
+			if err := s.exportRecord(ctx, answer.Key, answer, "", fn); err != nil {
+				return err
+			}
+		}
+		if len(nextPageToken) == 0 {
+			return nil
+		}
+		page.PageToken = nextPageToken
+	}
+}
+
+// exportHistories - exports the histories of the keys without a live answer, which was exported with its history.
+func (s *service) exportHistories(ctx context.Context, pageToken string, fn domain.ExportFunc) error {
+	page := domain.PageRequest{PageToken: pageToken, PageSize: s.pageSize}
+	for {
+		keys, nextPageToken, err := s.eventRepository.ListKeys(page)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			_, err := s.repository.Get(key)
+			if err == nil {
+				continue
+			}
+			if _, ok := err.(*errors.ErrNotFound); !ok {
+				return err
+			}
+			if err := s.exportRecord(ctx, key, nil, historyCheckpointPrefix, fn); err != nil {
+				return err
+			}
+		}
+		if len(nextPageToken) == 0 {
+			return nil
+		}
+		page.PageToken = nextPageToken
+	}
+}
+
+// exportDeleted - exports the deleted answers without a history, the others were exported with their histories.
+func (s *service) exportDeleted(ctx context.Context, pageToken string, fn domain.ExportFunc) error {
+	page := domain.PageRequest{PageToken: pageToken, PageSize: s.pageSize}
+	for {
+		answers, nextPageToken, err := s.repository.ListDeleted(page)
+		if err != nil {
+			return err
+		}
+		for _, answer := range answers {
+			events, _, err := s.eventRepository.ListEventsPage(answer.Key, domain.PageRequest{PageSize: 1})
+			if err != nil {
+				return err
+			}
+			if len(events) > 0 {
+				continue
+			}
+			if err := s.exportRecord(ctx, answer.Key, nil, deletedCheckpointPrefix, fn); err != nil {
+				return err
+			}
+		}
+		if len(nextPageToken) == 0 {
+			return nil
+		}
+		page.PageToken = nextPageToken
+	}
+}
+
+// exportRecord - passes the record of the key with its history to fn, the answer is nil if the key has no live answer.
+func (s *service) exportRecord(ctx context.Context, key domain.AnswerKey, answer *domain.Answer, checkpointPrefix string, fn domain.ExportFunc) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Attach the history.
+	//
+	events, err := s.eventRepository.ListEvents(key)
+	if err != nil {
+		return err
+	}
+	record := &domain.AnswerRecord{
+		Key:    key,
+		Answer: answer,
+		Events: events,
+	}
+	return fn(record, checkpointPrefix+domain.AnswerPageToken(key))
+}
+
+func (s *service) ImportAnswer(ctx context.Context, record *domain.AnswerRecord, mode domain.ImportMode) error {
+
+	// Validate the record.
+	//
+	if err := validateRecord(record, mode); err != nil {
+		return errors.NewErrInvalidArgument(err.Error())
+	}
+
+	// Write the record.
+	//
+	if mode == domain.ReplayImportMode {
+		return s.replay(ctx, record)
+	}
+	return s.preserve(record)
+}
+
+// replay - brings the answer to the state of the record through the answer service,
+// so the events of the changes are generated as if a client made them.
+func (s *service) replay(ctx context.Context, record *domain.AnswerRecord) error {
+	foundAnswer, err := s.answerService.GetAnswer(ctx, record.Key)
+	if _, ok := err.(*errors.ErrNotFound); ok {
+		foundAnswer, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case record.Answer == nil && foundAnswer != nil:
+		_, err = s.answerService.DeleteAnswer(ctx, record.Key)
+	case record.Answer == nil:
+	case foundAnswer == nil:
+		_, err = s.answerService.CreateAnswer(ctx, record.Answer)
+	case foundAnswer.Value != record.Answer.Value:
+		_, err = s.answerService.UpdateAnswer(ctx, record.Answer)
+	}
+	return err
+}
+
+// preserve - stores the events and the answer of the record as they are.
+// Events which are already stored are skipped, so a repeated import changes nothing.
+func (s *service) preserve(record *domain.AnswerRecord) error {
+
+	// Store the history.
+	//
+	for _, event := range record.Events {
+		err := s.eventRepository.Create(event)
+		if _, ok := err.(*errors.ErrAlreadyExist); ok {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Store the current state, the retention of a deleted answer starts with its last event.
+	//
+	if record.Answer == nil {
+		deletedAt := s.now().UTC()
+		if len(record.Events) > 0 {
+			deletedAt = record.Events[len(record.Events)-1].OccurredAt
+		}
+		_, err := s.repository.Delete(record.Key, domain.NewTombstone(deletedAt, s.retention))
+		if _, ok := err.(*errors.ErrNotFound); ok {
+			return nil
+		}
+		return err
+	}
+	// A created answer takes the version of the last imported event,
+	// the repository counts on from the version before it.
+	//
+	answer := *record.Answer
+	if version := domain.LastAnswerVersion(record.Events); version > 0 {
+		answer.Version = version - 1
+	}
+	err := s.repository.Create(&answer)
+	if _, ok := err.(*errors.ErrAlreadyExist); ok {
+		return s.repository.Update(&answer)
+	}
+	return err
+}
+
+// validateRecord - checks that the record can be imported in the mode.
+func validateRecord(record *domain.AnswerRecord, mode domain.ImportMode) error {
+	if !mode.IsValid() {
+		return fmt.Errorf("Import mode %q is not valid", mode)
+	}
+	if record == nil {
+		return fmt.Errorf("Record required")
+	}
+	if len(record.Key) == 0 {
+		return fmt.Errorf("Key required")
+	}
+	if record.Answer != nil {
+		if err := record.Answer.Validate(); err != nil {
+			return err
+		}
+		if record.Answer.Key != record.Key {
+			return fmt.Errorf("Answer key must match the record key")
+		}
+	}
+	for _, event := range record.Events {
+		switch {
+		case event == nil || event.Data == nil:
+			return fmt.Errorf("Event data required")
+		case !event.EventType.IsValid():
+			return fmt.Errorf("Event type %q is not valid", event.EventType)
+		case event.Data.Key != record.Key:
+			return fmt.Errorf("Event key must match the record key")
+		case mode == domain.PreserveImportMode && event.OccurredAt.IsZero():
+			return fmt.Errorf("Event occurrence time required")
+		}
+	}
+	return nil
+}
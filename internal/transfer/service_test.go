@@ -0,0 +1,336 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errs "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/servicetest"
+
+	"github.com/go-test/deep"
+)
+
+var (
+	testOccurredAt = time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	testRetention  = 24 * time.Hour
+)
+
+// serviceFixture - the service under test and its dependencies.
+type serviceFixture struct {
+	service         *service
+	answerService   *fakeAnswerService
+	repository      domain.AnswerRepository
+	eventRepository domain.AnswerEventRepository
+}
+
+func newServiceFixture(t *testing.T, records ...*domain.AnswerRecord) *serviceFixture {
+	t.Helper()
+	store := servicetest.NewStore()
+	store.CreateRecords(t, records...)
+	answerService := &fakeAnswerService{repository: store.Answers}
+	return &serviceFixture{
+		service:         newBasicService(answerService, store.Answers, store.Events, testRetention).(*service),
+		answerService:   answerService,
+		repository:      store.Answers,
+		eventRepository: store.Events,
+	}
+}
+
+// record - returns a record of the answer with a versioned event per value, the last value is the current one.
+func record(key domain.AnswerKey, values ...domain.AnswerValue) *domain.AnswerRecord {
+	r := &domain.AnswerRecord{Key: key}
+	for i, value := range values {
+		eventType := domain.UpdateAnswerEventType
+		if i == 0 {
+			eventType = domain.CreateAnswerEventType
+		}
+		r.Answer = &domain.Answer{Key: key, Value: value}
+		r.Events = append(r.Events, &domain.AnswerEvent{
+			EventType:  eventType,
+			Data:       &domain.Answer{Key: key, Value: value, Version: i + 1},
+			OccurredAt: testOccurredAt.Add(time.Duration(i) * time.Second),
+		})
+	}
+	return r
+}
+
+func TestExportAnswers(t *testing.T) {
+	records := []*domain.AnswerRecord{record("a", "1"), record("b", "1", "2"), record("c", "1")}
+	f := newServiceFixture(t, records...)
+	f.service.pageSize = 2
+
+	// Export everything and remember the checkpoints.
+	//
+	var got []*domain.AnswerRecord
+	var checkpoints []string
+	err := f.service.ExportAnswers(context.Background(), "", func(record *domain.AnswerRecord, checkpoint string) error {
+		got = append(got, record)
+		checkpoints = append(checkpoints, checkpoint)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(got, records); diff != nil {
+		t.Error(diff)
+	}
+
+	// Resume after the first record.
+	//
+	got = nil
+	err = f.service.ExportAnswers(context.Background(), checkpoints[0], func(record *domain.AnswerRecord, checkpoint string) error {
+		got = append(got, record)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(got, records[1:]); diff != nil {
+		t.Error(diff)
+	}
+
+	// The first error of the callback stops the export.
+	//
+	stop := errors.New("stop")
+	var count int
+	err = f.service.ExportAnswers(context.Background(), "", func(record *domain.AnswerRecord, checkpoint string) error {
+		count++
+		return stop
+	})
+	if err != stop || count != 1 {
+		t.Errorf("expected the export to stop, got err %v after %v records", err, count)
+	}
+}
+
+func TestExportAnswersWithoutLiveAnswer(t *testing.T) {
+	live := record("a", "1")
+	deleted := record("b", "1", "2")
+	purged := record("c", "1")
+	purged.Answer = nil
+	f := newServiceFixture(t, live, deleted, purged)
+	f.service.pageSize = 1
+	if _, err := f.repository.Delete("b", domain.NewTombstone(testOccurredAt.Add(2*time.Second), testRetention)); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.repository.Create(&domain.Answer{Key: "d", Value: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.repository.Delete("d", domain.NewTombstone(testOccurredAt, testRetention)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The deleted and purged keys are exported with their histories only, after the live answers.
+	//
+	var got []*domain.AnswerRecord
+	var checkpoints []string
+	err := f.service.ExportAnswers(context.Background(), "", func(record *domain.AnswerRecord, checkpoint string) error {
+		got = append(got, record)
+		checkpoints = append(checkpoints, checkpoint)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	historyOnly := &domain.AnswerRecord{Key: "b", Events: deleted.Events}
+	withoutHistory := &domain.AnswerRecord{Key: "d"}
+	expected := []*domain.AnswerRecord{live, historyOnly, purged, withoutHistory}
+	if diff := deep.Equal(got, expected); diff != nil {
+		t.Error(diff)
+	}
+
+	// Every checkpoint resumes the export after its record, whatever the phase.
+	//
+	for i, checkpoint := range checkpoints {
+		resumed := []*domain.AnswerRecord{}
+		err := f.service.ExportAnswers(context.Background(), checkpoint, func(record *domain.AnswerRecord, checkpoint string) error {
+			resumed = append(resumed, record)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		if diff := deep.Equal(resumed, expected[i+1:]); diff != nil {
+			t.Errorf("resumed after %v: %v", checkpoint, diff)
+		}
+	}
+
+	// The histories survive the round trip, the deleted answer without a history has nothing to import.
+	//
+	target := newServiceFixture(t)
+	for _, r := range got {
+		if err := target.service.ImportAnswer(context.Background(), r, domain.PreserveImportMode); err != nil {
+			t.Fatalf("import %v: %v", r.Key, err)
+		}
+	}
+	var imported []*domain.AnswerRecord
+	err = target.service.ExportAnswers(context.Background(), "", func(record *domain.AnswerRecord, checkpoint string) error {
+		imported = append(imported, record)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if diff := deep.Equal(imported, expected[:3]); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestImportAnswer(t *testing.T) {
+	deleted := record("a", "1")
+	deleted.Answer = nil
+	tests := []struct {
+		name        string
+		stored      []*domain.AnswerRecord
+		record      *domain.AnswerRecord
+		mode        domain.ImportMode
+		wantErr     error
+		wantAnswer  *domain.Answer
+		wantHistory []*domain.AnswerEvent
+		wantCalls   []string
+	}{
+		{
+			name:        "preserve new answer",
+			record:      record("a", "1", "2"),
+			mode:        domain.PreserveImportMode,
+			wantAnswer:  &domain.Answer{Key: "a", Value: "2", Version: 2},
+			wantHistory: record("a", "1", "2").Events,
+		},
+		{
+			name:        "preserve is repeatable",
+			stored:      []*domain.AnswerRecord{record("a", "1")},
+			record:      record("a", "1", "2"),
+			mode:        domain.PreserveImportMode,
+			wantAnswer:  &domain.Answer{Key: "a", Value: "2", Version: 2},
+			wantHistory: record("a", "1", "2").Events,
+		},
+		{
+			name:        "preserve deleted answer",
+			stored:      []*domain.AnswerRecord{record("a", "1")},
+			record:      deleted,
+			mode:        domain.PreserveImportMode,
+			wantHistory: record("a", "1").Events,
+		},
+		{
+			name:       "replay new answer",
+			record:     record("a", "1", "2"),
+			mode:       domain.ReplayImportMode,
+			wantAnswer: &domain.Answer{Key: "a", Value: "2", Version: 1},
+			wantCalls:  []string{"create a"},
+		},
+		{
+			name:        "replay changed answer",
+			stored:      []*domain.AnswerRecord{record("a", "1")},
+			record:      record("a", "1", "2"),
+			mode:        domain.ReplayImportMode,
+			wantAnswer:  &domain.Answer{Key: "a", Value: "2", Version: 2},
+			wantHistory: record("a", "1").Events,
+			wantCalls:   []string{"update a"},
+		},
+		{
+			name:        "replay unchanged answer",
+			stored:      []*domain.AnswerRecord{record("a", "1")},
+			record:      record("a", "1"),
+			mode:        domain.ReplayImportMode,
+			wantAnswer:  &domain.Answer{Key: "a", Value: "1", Version: 1},
+			wantHistory: record("a", "1").Events,
+		},
+		{
+			name:        "replay deleted answer",
+			stored:      []*domain.AnswerRecord{record("a", "1")},
+			record:      deleted,
+			mode:        domain.ReplayImportMode,
+			wantHistory: record("a", "1").Events,
+			wantCalls:   []string{"delete a"},
+		},
+		{
+			name:    "unknown mode",
+			record:  record("a", "1"),
+			mode:    domain.ImportMode("merge"),
+			wantErr: errs.NewErrInvalidArgument(`Import mode "merge" is not valid`),
+		},
+		{
+			name:    "nil record",
+			mode:    domain.ReplayImportMode,
+			wantErr: errs.NewErrInvalidArgument("Record required"),
+		},
+		{
+			name:    "answer of another key",
+			record:  &domain.AnswerRecord{Key: "a", Answer: &domain.Answer{Key: "b", Value: "1"}},
+			mode:    domain.ReplayImportMode,
+			wantErr: errs.NewErrInvalidArgument("Answer key must match the record key"),
+		},
+		{
+			name:    "event of another key",
+			record:  &domain.AnswerRecord{Key: "b", Events: record("a", "1").Events},
+			mode:    domain.PreserveImportMode,
+			wantErr: errs.NewErrInvalidArgument("Event key must match the record key"),
+		},
+		{
+			name: "preserved event without time",
+			record: &domain.AnswerRecord{Key: "a", Events: []*domain.AnswerEvent{
+				{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "a", Value: "1"}},
+			}},
+			mode:    domain.PreserveImportMode,
+			wantErr: errs.NewErrInvalidArgument("Event occurrence time required"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t, tt.stored...)
+			err := f.service.ImportAnswer(context.Background(), tt.record, tt.mode)
+			if diff := deep.Equal(err, tt.wantErr); diff != nil {
+				t.Fatal(diff)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			key := tt.record.Key
+			answer, err := f.repository.Get(key)
+			if _, ok := err.(*errs.ErrNotFound); !ok && err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(answer, tt.wantAnswer); diff != nil {
+				t.Errorf("answer: %v", diff)
+			}
+			history, err := f.eventRepository.ListEvents(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(history, tt.wantHistory); diff != nil {
+				t.Errorf("history: %v", diff)
+			}
+			if diff := deep.Equal(f.answerService.calls, tt.wantCalls); diff != nil {
+				t.Errorf("calls: %v", diff)
+			}
+		})
+	}
+}
+
+func TestImportAnswerPreservesDeletionTime(t *testing.T) {
+	deleted := record("a", "1", "2")
+	deleted.Answer = nil
+	f := newServiceFixture(t, record("a", "1"))
+	if err := f.service.ImportAnswer(context.Background(), deleted, domain.PreserveImportMode); err != nil {
+		t.Fatal(err)
+	}
+
+	// The retention starts with the last event, not with the import.
+	//
+	deletedAnswers, _, err := f.repository.ListDeleted(domain.PageRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deletedAt := testOccurredAt.Add(time.Second)
+	expected := []*domain.DeletedAnswer{
+		{
+			Answer:    domain.Answer{Key: "a", Value: "1", Version: 2},
+			Tombstone: domain.NewTombstone(deletedAt, testRetention),
+		},
+	}
+	if diff := deep.Equal(deletedAnswers, expected); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,55 @@
+package transfer
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+)
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(domain.AnswerTransferService) domain.AnswerTransferService
+
+// LoggingServiceMiddleware takes a logger as a dependency
+// and returns a service Middleware.
+func LoggingServiceMiddleware(logger log.Logger) Middleware {
+	return func(next domain.AnswerTransferService) domain.AnswerTransferService {
+		return loggingMiddleware{logger, next}
+	}
+}
+
+type loggingMiddleware struct {
+	logger log.Logger
+	next   domain.AnswerTransferService
+}
+
+func (mw loggingMiddleware) ExportAnswers(ctx context.Context, checkpoint string, fn domain.ExportFunc) (err error) {
+	var count int
+	defer func() {
+		_ = mw.logger.Log("method", "ExportAnswers",
+			"checkpoint", checkpoint,
+			"count", count,
+			"err", err,
+		)
+	}()
+	return mw.next.ExportAnswers(ctx, checkpoint, func(record *domain.AnswerRecord, checkpoint string) error {
+		count++
+		return fn(record, checkpoint)
+	})
+}
+
+func (mw loggingMiddleware) ImportAnswer(ctx context.Context, record *domain.AnswerRecord, mode domain.ImportMode) (err error) {
+	defer func() {
+		var key domain.AnswerKey
+		if record != nil {
+			key = record.Key
+		}
+		_ = mw.logger.Log("method", "ImportAnswer",
+			"key", key,
+			"mode", mode,
+			"err", err,
+		)
+	}()
+	return mw.next.ImportAnswer(ctx, record, mode)
+}
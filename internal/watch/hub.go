@@ -0,0 +1,78 @@
+package watch
+
+import (
+	"sync"
+
+	"dochq.co.uk.answerservice/internal/domain"
+)
+
+// DefaultSubscriptionBuffer - number of events a subscriber may fall behind before it is dropped.
+const DefaultSubscriptionBuffer = 256
+
+// Hub - fans the events of the watch queue out to the watches of this process.
+type Hub struct {
+	mu            sync.Mutex
+	subscriptions map[*subscription]struct{}
+	buffer        int
+}
+
+// subscription - the events of a watch, the channel is closed when the watch lags behind.
+type subscription struct {
+	filter domain.AnswerWatchFilter
+	events chan *domain.WatchedAnswerEvent
+}
+
+// NewHub - creates a hub with the default subscription buffer.
+func NewHub() *Hub {
+	return NewHubWithBuffer(DefaultSubscriptionBuffer)
+}
+
+// NewHubWithBuffer - creates a hub which drops the subscribers lagging the buffer size behind.
+func NewHubWithBuffer(buffer int) *Hub {
+	return &Hub{
+		subscriptions: make(map[*subscription]struct{}),
+		buffer:        buffer,
+	}
+}
+
+// Publish - passes the event to the matching subscribers without waiting for them.
+func (h *Hub) Publish(event *domain.WatchedAnswerEvent) {
+	if event.Event == nil || event.Event.Data == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subscriptions {
+		if !s.filter.Matches(event.Event.Data.Key) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			// A slow subscriber must not block the writers, it resumes from its last version.
+			//
+			delete(h.subscriptions, s)
+			close(s.events)
+		}
+	}
+}
+
+func (h *Hub) subscribe(filter domain.AnswerWatchFilter) *subscription {
+	s := &subscription{
+		filter: filter,
+		events: make(chan *domain.WatchedAnswerEvent, h.buffer),
+	}
+	h.mu.Lock()
+	h.subscriptions[s] = struct{}{}
+	h.mu.Unlock()
+	return s
+}
+
+func (h *Hub) unsubscribe(s *subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.subscriptions[s]; ok {
+		delete(h.subscriptions, s)
+		close(s.events)
+	}
+}
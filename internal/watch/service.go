@@ -0,0 +1,135 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-kit/log"
+)
+
+type service struct {
+	eventRepository domain.AnswerEventRepository
+	hub             *Hub
+}
+
+// NewService creates a new service with necessary dependencies.
+func NewService(eventRepository domain.AnswerEventRepository,
+	hub *Hub,
+	logger log.Logger) domain.AnswerWatchService {
+	var service domain.AnswerWatchService
+	{
+		service = newBasicService(eventRepository, hub)
+		service = LoggingServiceMiddleware(logger)(service)
+	}
+	return service
+}
+
+// Returns a naive, stateless implementation of service.
+func newBasicService(eventRepository domain.AnswerEventRepository, hub *Hub) domain.AnswerWatchService {
+	return &service{
+		eventRepository: eventRepository,
+		hub:             hub,
+	}
+}
+
+func (s *service) WatchAnswers(ctx context.Context, filter domain.AnswerWatchFilter, fromVersion int, fn domain.WatchFunc) error {
+
+	// Check request.
+	//
+	if err := filter.Validate(); err != nil {
+		return errors.NewErrInvalidArgument(err.Error())
+	}
+	if fromVersion < 0 {
+		return errors.NewErrInvalidArgument("Version must not be negative")
+	}
+	if fromVersion > 0 && len(filter.Key) == 0 {
+		return errors.NewErrInvalidArgument("Version is only supported by key watches")
+	}
+
+	// Subscribe before reading the history, so no event is missed in between.
+	//
+	sub := s.hub.subscribe(filter)
+	defer s.hub.unsubscribe(sub)
+
+	// Replay the history of the key after the version.
+	//
+	var last int
+	if len(filter.Key) > 0 {
+		events, err := s.eventRepository.ListEvents(filter.Key)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if fromVersion > 0 && event.Version() <= fromVersion {
+				continue
+			}
+			if err := fn(&domain.WatchedAnswerEvent{Event: event, Version: event.Version()}); err != nil {
+				return err
+			}
+		}
+		last = domain.LastAnswerVersion(events)
+		if fromVersion > last {
+			last = fromVersion
+		}
+	}
+
+	// Stream the live events, skipping those replayed or delivered already.
+	//
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.events:
+			if !ok {
+				return errors.NewErrAborted("Watch fell behind the events, resume it from the last version")
+			}
+			if len(filter.Key) > 0 {
+				if event.Version <= last {
+					continue
+				}
+
+				// A live event may come before an earlier one was passed on, e.g. it was sent by another
+				// replica, the missed events are read from the history.
+				//
+				if last > 0 && event.Version > last+1 {
+					var err error
+					if last, err = s.fillGap(filter.Key, last, event.Version, fn); err != nil {
+						return err
+					}
+				}
+				last = event.Version
+			}
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// fillGap - passes the recorded events of the key between the versions on, it returns the last version passed on.
+// The watch is aborted if the history does not hold them yet, the client resumes it later.
+func (s *service) fillGap(key domain.AnswerKey, last, next int, fn domain.WatchFunc) (int, error) {
+	events, err := s.eventRepository.ListEvents(key)
+	if err != nil {
+		return last, err
+	}
+	for _, event := range events {
+		if event.Version() <= last || event.Version() >= next {
+			continue
+		}
+		if event.Version() > last+1 {
+			break
+		}
+		if err := fn(&domain.WatchedAnswerEvent{Event: event, Version: event.Version()}); err != nil {
+			return last, err
+		}
+		last = event.Version()
+	}
+	if last+1 < next {
+		return last, errors.NewErrAborted(fmt.Sprintf("Watch missed the events after version %v, resume it from the last version", last))
+	}
+	return last, nil
+}
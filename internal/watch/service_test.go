@@ -0,0 +1,329 @@
+package watch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errs "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/servicetest"
+
+	"github.com/go-test/deep"
+)
+
+// watched - the compared part of a watched event.
+type watched struct {
+	EventType domain.AnswerEventType
+	Key       domain.AnswerKey
+	Value     domain.AnswerValue
+	Version   int
+}
+
+// serviceFixture - the service under test together with the store of the history and the hub the events are passed with.
+type serviceFixture struct {
+	service  *service
+	store    *servicetest.Store
+	hub      *Hub
+	sequence int
+	versions map[domain.AnswerKey]int
+}
+
+// newServiceFixture - stores "patient-1/name" with a history of two events.
+func newServiceFixture(t *testing.T, buffer int) *serviceFixture {
+	t.Helper()
+	hub := NewHubWithBuffer(buffer)
+	store := servicetest.NewStore()
+	f := &serviceFixture{
+		service:  newBasicService(store.Events, hub).(*service),
+		store:    store,
+		hub:      hub,
+		versions: make(map[domain.AnswerKey]int),
+	}
+	f.create(t, domain.CreateAnswerEventType, "patient-1/name", "John")
+	f.create(t, domain.UpdateAnswerEventType, "patient-1/name", "Sam")
+	return f
+}
+
+// create - records the event with the next version of its answer and publishes it like the watch worker.
+func (f *serviceFixture) create(t *testing.T, eventType domain.AnswerEventType, key domain.AnswerKey, value domain.AnswerValue) *domain.AnswerEvent {
+	t.Helper()
+	event := f.record(t, eventType, key, value)
+	f.publish(event)
+	return event
+}
+
+// record - records the event with the next version of its answer without publishing it.
+func (f *serviceFixture) record(t *testing.T, eventType domain.AnswerEventType, key domain.AnswerKey, value domain.AnswerValue) *domain.AnswerEvent {
+	t.Helper()
+	f.sequence++
+	f.versions[key]++
+	event := &domain.AnswerEvent{
+		EventType:  eventType,
+		Data:       &domain.Answer{Key: key, Value: value, Version: f.versions[key]},
+		OccurredAt: servicetest.Now.Add(time.Duration(f.sequence) * time.Second),
+	}
+	f.store.CreateEvents(t, event)
+	return event
+}
+
+func (f *serviceFixture) publish(event *domain.AnswerEvent) {
+	f.hub.Publish(&domain.WatchedAnswerEvent{Event: event, Version: event.Version()})
+}
+
+// watch - starts the watch and returns the channel of its events and of its result.
+func (f *serviceFixture) watch(ctx context.Context, filter domain.AnswerWatchFilter, fromVersion int) (<-chan watched, <-chan error) {
+	events := make(chan watched, 100)
+	done := make(chan error, 1)
+	go func() {
+		done <- f.service.WatchAnswers(ctx, filter, fromVersion, func(event *domain.WatchedAnswerEvent) error {
+			events <- watched{
+				EventType: event.Event.EventType,
+				Key:       event.Event.Data.Key,
+				Value:     event.Event.Data.Value,
+				Version:   event.Version,
+			}
+			return nil
+		})
+	}()
+	return events, done
+}
+
+// receive - waits for the number of events.
+func receive(t *testing.T, events <-chan watched, n int) []watched {
+	t.Helper()
+	var received []watched
+	for len(received) < n {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(time.Second):
+			t.Fatalf("received %d of %d events", len(received), n)
+		}
+	}
+	return received
+}
+
+func TestService_WatchAnswers(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      domain.AnswerWatchFilter
+		fromVersion int
+		want        []watched
+	}{
+		{
+			name:        "key from start replays the history",
+			filter:      domain.AnswerWatchFilter{Key: "patient-1/name"},
+			fromVersion: 0,
+			want: []watched{
+				{EventType: domain.CreateAnswerEventType, Key: "patient-1/name", Value: "John", Version: 1},
+				{EventType: domain.UpdateAnswerEventType, Key: "patient-1/name", Value: "Sam", Version: 2},
+				{EventType: domain.UpdateAnswerEventType, Key: "patient-1/name", Value: "Tom", Version: 3},
+				{EventType: domain.DeleteAnswerEventType, Key: "patient-1/name", Value: "Tom", Version: 4},
+			},
+		},
+		{
+			name:        "key resumes after the version",
+			filter:      domain.AnswerWatchFilter{Key: "patient-1/name"},
+			fromVersion: 2,
+			want: []watched{
+				{EventType: domain.UpdateAnswerEventType, Key: "patient-1/name", Value: "Tom", Version: 3},
+				{EventType: domain.DeleteAnswerEventType, Key: "patient-1/name", Value: "Tom", Version: 4},
+			},
+		},
+		{
+			name:   "prefix only gets live events",
+			filter: domain.AnswerWatchFilter{Prefix: "patient-1"},
+			want: []watched{
+				{EventType: domain.UpdateAnswerEventType, Key: "patient-1/name", Value: "Tom", Version: 3},
+				{EventType: domain.CreateAnswerEventType, Key: "patient-10/name", Value: "Ann", Version: 1},
+				{EventType: domain.DeleteAnswerEventType, Key: "patient-1/name", Value: "Tom", Version: 4},
+			},
+		},
+		{
+			name:   "namespace",
+			filter: domain.AnswerWatchFilter{Namespace: "patient-10"},
+			want: []watched{
+				{EventType: domain.CreateAnswerEventType, Key: "patient-10/name", Value: "Ann", Version: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t, DefaultSubscriptionBuffer)
+			ctx, cancel := context.WithCancel(context.Background())
+			events, done := f.watch(ctx, tt.filter, tt.fromVersion)
+
+			// Wait for the subscription, so the live events are not missed.
+			//
+			for !f.subscribed() {
+				time.Sleep(time.Millisecond)
+			}
+			f.create(t, domain.UpdateAnswerEventType, "patient-1/name", "Tom")
+			f.create(t, domain.CreateAnswerEventType, "patient-10/name", "Ann")
+			f.create(t, domain.DeleteAnswerEventType, "patient-1/name", "Tom")
+
+			got := receive(t, events, len(tt.want))
+			cancel()
+			if err := <-done; err != nil {
+				t.Fatalf("WatchAnswers() error = %v", err)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+			if len(events) > 0 {
+				t.Errorf("WatchAnswers() sent %v, want no more events", <-events)
+			}
+		})
+	}
+}
+
+func TestService_WatchAnswers_Redelivered(t *testing.T) {
+	f := newServiceFixture(t, DefaultSubscriptionBuffer)
+	ctx, cancel := context.WithCancel(context.Background())
+	events, done := f.watch(ctx, domain.AnswerWatchFilter{Key: "patient-1/name"}, 1)
+	for !f.subscribed() {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The queue delivers the replayed and the live events again, the key watch sends them once.
+	//
+	history, err := f.store.Events.ListEvents("patient-1/name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.publish(history[1])
+	event := f.create(t, domain.UpdateAnswerEventType, "patient-1/name", "Tom")
+	f.publish(event)
+	f.create(t, domain.DeleteAnswerEventType, "patient-1/name", "Tom")
+
+	got := receive(t, events, 3)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("WatchAnswers() error = %v", err)
+	}
+	want := []watched{
+		{EventType: domain.UpdateAnswerEventType, Key: "patient-1/name", Value: "Sam", Version: 2},
+		{EventType: domain.UpdateAnswerEventType, Key: "patient-1/name", Value: "Tom", Version: 3},
+		{EventType: domain.DeleteAnswerEventType, Key: "patient-1/name", Value: "Tom", Version: 4},
+	}
+	if diff := deep.Equal(got, want); diff != nil {
+		t.Error(diff)
+	}
+	if len(events) > 0 {
+		t.Errorf("WatchAnswers() sent %v, want no more events", <-events)
+	}
+}
+
+func TestService_WatchAnswers_Gap(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T, f *serviceFixture)
+		want    []watched
+		wantErr bool
+	}{
+		{
+			name: "missed event recorded",
+			setup: func(t *testing.T, f *serviceFixture) {
+				f.record(t, domain.UpdateAnswerEventType, "patient-1/name", "Tom")
+				f.create(t, domain.UpdateAnswerEventType, "patient-1/name", "Tim")
+			},
+			want: []watched{
+				{EventType: domain.UpdateAnswerEventType, Key: "patient-1/name", Value: "Tom", Version: 3},
+				{EventType: domain.UpdateAnswerEventType, Key: "patient-1/name", Value: "Tim", Version: 4},
+			},
+		},
+		{
+			name: "missed event not recorded yet",
+			setup: func(t *testing.T, f *serviceFixture) {
+				f.versions["patient-1/name"]++
+				f.create(t, domain.UpdateAnswerEventType, "patient-1/name", "Tim")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t, DefaultSubscriptionBuffer)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events, done := f.watch(ctx, domain.AnswerWatchFilter{Key: "patient-1/name"}, 2)
+			for !f.subscribed() {
+				time.Sleep(time.Millisecond)
+			}
+			tt.setup(t, f)
+
+			// The watch passes the missed event on before the live one, or ends if it cannot.
+			//
+			if tt.wantErr {
+				if _, ok := (<-done).(*errs.ErrAborted); !ok {
+					t.Error("WatchAnswers() error is not ErrAborted")
+				}
+				if len(events) > 0 {
+					t.Errorf("WatchAnswers() sent %v, want no events", <-events)
+				}
+				return
+			}
+			got := receive(t, events, len(tt.want))
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestService_WatchAnswers_Lagging(t *testing.T) {
+	f := newServiceFixture(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	block := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- f.service.WatchAnswers(ctx, domain.AnswerWatchFilter{Key: "patient-1/name"}, 1, func(event *domain.WatchedAnswerEvent) error {
+			<-block
+			return nil
+		})
+	}()
+	for !f.subscribed() {
+		time.Sleep(time.Millisecond)
+	}
+	f.create(t, domain.UpdateAnswerEventType, "patient-1/name", "Tom")
+	f.create(t, domain.UpdateAnswerEventType, "patient-1/name", "Tim")
+	close(block)
+	if _, ok := (<-done).(*errs.ErrAborted); !ok {
+		t.Error("WatchAnswers() error is not ErrAborted")
+	}
+}
+
+func TestService_WatchAnswers_InvalidArgument(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      domain.AnswerWatchFilter
+		fromVersion int
+	}{
+		{name: "no selector", filter: domain.AnswerWatchFilter{}},
+		{name: "two selectors", filter: domain.AnswerWatchFilter{Key: "a", Prefix: "a"}},
+		{name: "negative version", filter: domain.AnswerWatchFilter{Key: "a"}, fromVersion: -1},
+		{name: "version of prefix", filter: domain.AnswerWatchFilter{Prefix: "a"}, fromVersion: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			f := newServiceFixture(t, DefaultSubscriptionBuffer)
+			err := f.service.WatchAnswers(context.Background(), tt.filter, tt.fromVersion, func(*domain.WatchedAnswerEvent) error {
+				return nil
+			})
+			if _, ok := err.(*errs.ErrInvalidArgument); !ok {
+				t.Errorf("WatchAnswers() error = %v, want ErrInvalidArgument", err)
+			}
+		})
+	}
+}
+
+func (f *serviceFixture) subscribed() bool {
+	f.service.hub.mu.Lock()
+	defer f.service.hub.mu.Unlock()
+	return len(f.service.hub.subscriptions) > 0
+}
@@ -0,0 +1,44 @@
+package watch
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+)
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(domain.AnswerWatchService) domain.AnswerWatchService
+
+// LoggingServiceMiddleware takes a logger as a dependency
+// and returns a service Middleware.
+func LoggingServiceMiddleware(logger log.Logger) Middleware {
+	return func(next domain.AnswerWatchService) domain.AnswerWatchService {
+		return loggingMiddleware{logger, next}
+	}
+}
+
+type loggingMiddleware struct {
+	logger log.Logger
+	next   domain.AnswerWatchService
+}
+
+// WatchAnswers - logs the watch once it ends with the number of the events sent.
+func (mw loggingMiddleware) WatchAnswers(ctx context.Context, filter domain.AnswerWatchFilter, fromVersion int, fn domain.WatchFunc) (err error) {
+	var sent int
+	defer func() {
+		_ = mw.logger.Log("method", "WatchAnswers",
+			"key", filter.Key,
+			"prefix", filter.Prefix,
+			"namespace", filter.Namespace,
+			"fromVersion", fromVersion,
+			"sent", sent,
+			"err", err,
+		)
+	}()
+	return mw.next.WatchAnswers(ctx, filter, fromVersion, func(event *domain.WatchedAnswerEvent) error {
+		sent++
+		return fn(event)
+	})
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// nonPublicNetworks - the private, shared and reserved ranges which are not reachable from the internet.
+// The loopback, link-local, multicast and unspecified addresses are checked by their kind.
+var nonPublicNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+	"fc00::/7",
+)
+
+// AddressPolicy - keeps the webhooks from reaching the internal services, only the public addresses
+// and those of the allowed networks may receive them.
+type AddressPolicy struct {
+	allowed []*net.IPNet
+}
+
+// NewAddressPolicy - creates a policy allowing the networks in CIDR notation besides the public addresses.
+func NewAddressPolicy(allowedNetworks []string) (*AddressPolicy, error) {
+	policy := &AddressPolicy{}
+	for _, network := range allowedNetworks {
+		_, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			return nil, fmt.Errorf("network %q is not valid: %v", network, err)
+		}
+		policy.allowed = append(policy.allowed, ipNet)
+	}
+	return policy, nil
+}
+
+// AddressError - the address must not receive the webhooks.
+type AddressError struct {
+	IP net.IP
+}
+
+func (e *AddressError) Error() string {
+	return fmt.Sprintf("address %v is not public", e.IP)
+}
+
+// Check - returns an AddressError if the address is neither public nor in an allowed network.
+func (p *AddressPolicy) Check(ip net.IP) error {
+	if ip == nil {
+		return &AddressError{}
+	}
+	for _, network := range p.allowed {
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() {
+		return &AddressError{IP: ip}
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return &AddressError{IP: ip}
+		}
+	}
+	return nil
+}
+
+// CheckHost - checks the host of a subscription URL without resolving it, an IP address must pass the check
+// and a name must not be a local one. The resolved addresses are checked when a delivery connects.
+func (p *AddressPolicy) CheckHost(host string) error {
+	if ip := net.ParseIP(strings.Trim(host, "[]")); ip != nil {
+		return p.Check(ip)
+	}
+	name := strings.ToLower(strings.TrimSuffix(host, "."))
+	if name == "localhost" || strings.HasSuffix(name, ".localhost") {
+		return p.Check(net.IPv6loopback)
+	}
+	return nil
+}
+
+// newClient - returns a client which checks every address it connects to and does not follow redirects.
+// No proxy is used, so the address of the receiver is the one which is checked.
+func (p *AddressPolicy) newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			return p.Check(net.ParseIP(host))
+		},
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func parseNetworks(networks ...string) []*net.IPNet {
+	var parsed []*net.IPNet
+	for _, network := range networks {
+		_, ipNet, err := net.ParseCIDR(network)
+		if err != nil {
+			panic(err)
+		}
+		parsed = append(parsed, ipNet)
+	}
+	return parsed
+}
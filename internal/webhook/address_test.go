@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"net"
+	"testing"
+)
+
+func TestAddressPolicy(t *testing.T) {
+	policy, err := NewAddressPolicy([]string{"10.1.0.0/16"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		host      string
+		wantError bool
+	}{
+		{host: "93.184.216.34"},
+		{host: "2606:2800:220:1:248:1893:25c8:1946"},
+		{host: "example.com"},
+		{host: "10.1.2.3"},
+		{host: "10.2.0.1", wantError: true},
+		{host: "127.0.0.1", wantError: true},
+		{host: "[::1]", wantError: true},
+		{host: "169.254.169.254", wantError: true},
+		{host: "fe80::1", wantError: true},
+		{host: "172.20.0.1", wantError: true},
+		{host: "192.168.1.1", wantError: true},
+		{host: "100.64.0.1", wantError: true},
+		{host: "fd12::1", wantError: true},
+		{host: "0.0.0.0", wantError: true},
+		{host: "::ffff:127.0.0.1", wantError: true},
+		{host: "localhost", wantError: true},
+		{host: "api.localhost.", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			err := policy.CheckHost(tt.host)
+			if _, ok := err.(*AddressError); ok != tt.wantError || (err != nil && !ok) {
+				t.Errorf("unexpected err: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewAddressPolicyInvalidNetwork(t *testing.T) {
+	if _, err := NewAddressPolicy([]string{"10.0.0.1"}); err == nil {
+		t.Error("expected an error")
+	}
+	if err := (&AddressPolicy{}).Check(net.ParseIP("not an ip")); err == nil {
+		t.Error("expected an error")
+	}
+}
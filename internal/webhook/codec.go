@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	apiv1 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/answer"
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DecodeWebhookSubscription - converts the API subscription to the domain subscription.
+func DecodeWebhookSubscription(subscription *apiv1.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	if subscription == nil {
+		return nil, errors.NewErrInternal("Cannot decode nil value")
+	}
+	decodedSubscription := &domain.WebhookSubscription{
+		ID:     subscription.Id,
+		URL:    subscription.Url,
+		Secret: subscription.Secret,
+	}
+	for _, eventType := range subscription.EventTypes {
+		decodedEventType, err := answer.DecodeAnswerEventType(eventType)
+		if err != nil {
+			return nil, err
+		}
+		decodedSubscription.EventTypes = append(decodedSubscription.EventTypes, decodedEventType)
+	}
+	if subscription.CreatedAt != nil {
+		decodedSubscription.CreatedAt = subscription.CreatedAt.AsTime()
+	}
+	return decodedSubscription, nil
+}
+
+// EncodeWebhookSubscription - converts the domain subscription to the API subscription.
+func EncodeWebhookSubscription(subscription *domain.WebhookSubscription) (*apiv1.WebhookSubscription, error) {
+	if subscription == nil {
+		return nil, errors.NewErrInternal("Cannot encode nil value")
+	}
+	encodedSubscription := &apiv1.WebhookSubscription{
+		Id:     subscription.ID,
+		Url:    subscription.URL,
+		Secret: subscription.Secret,
+	}
+	for _, eventType := range subscription.EventTypes {
+		encodedEventType, err := answer.EncodeAnswerEventType(eventType)
+		if err != nil {
+			return nil, err
+		}
+		encodedSubscription.EventTypes = append(encodedSubscription.EventTypes, encodedEventType)
+	}
+	if !subscription.CreatedAt.IsZero() {
+		encodedSubscription.CreatedAt = timestamppb.New(subscription.CreatedAt)
+	}
+	return encodedSubscription, nil
+}
+
+// EncodeWebhookDelivery - converts the domain delivery to the API delivery.
+func EncodeWebhookDelivery(delivery *domain.WebhookDelivery) (*apiv1.WebhookDelivery, error) {
+	if delivery == nil {
+		return nil, errors.NewErrInternal("Cannot encode nil value")
+	}
+	eventType, err := answer.EncodeAnswerEventType(delivery.EventType)
+	if err != nil {
+		return nil, err
+	}
+	encodedDelivery := &apiv1.WebhookDelivery{
+		Id:             delivery.ID,
+		SubscriptionId: delivery.SubscriptionID,
+		EventType:      eventType,
+		KeyHash:        delivery.KeyHash,
+		Attempt:        int32(delivery.Attempt),
+		StatusCode:     int32(delivery.StatusCode),
+		Error:          delivery.Error,
+		Succeeded:      delivery.Succeeded,
+	}
+	if !delivery.OccurredAt.IsZero() {
+		encodedDelivery.OccurredAt = timestamppb.New(delivery.OccurredAt)
+	}
+	if !delivery.AttemptedAt.IsZero() {
+		encodedDelivery.AttemptedAt = timestamppb.New(delivery.AttemptedAt)
+	}
+	return encodedDelivery, nil
+}
@@ -0,0 +1,242 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-kit/log"
+)
+
+// DispatcherOptions - the retry policy of the deliveries.
+type DispatcherOptions struct {
+	// MaxAttempts - the attempts of a delivery, at least one is made.
+	MaxAttempts int
+	// InitialBackoff - the delay before the second attempt, it is doubled before every further attempt.
+	InitialBackoff time.Duration
+	// Timeout - of a single attempt.
+	Timeout time.Duration
+	// Client - sends the requests, a client which checks the addresses by the policy
+	// and does not follow redirects is used if nil.
+	Client *http.Client
+	// AddressPolicy - the addresses which may receive the webhooks, only the public ones if nil.
+	AddressPolicy *AddressPolicy
+	// KeyHasher - hashes the answer keys of the delivery log, without a secret if nil.
+	KeyHasher *domain.AnswerKeyHasher
+}
+
+// maxClockSkew - the attempts are looked up from the occurrence of the event less this margin,
+// so the clock of the process which sent the event may run ahead.
+const maxClockSkew = time.Minute
+
+// Payload - the body posted to the subscriptions.
+type Payload struct {
+	ID             string              `json:"id"`
+	SubscriptionID string              `json:"subscriptionId"`
+	Event          *domain.AnswerEvent `json:"event"`
+}
+
+// Dispatcher - delivers the answer events to the matching webhook subscriptions.
+//
+// Every attempt is recorded in the delivery log. An attempt is retried with an exponential
+// backoff if no response was received or the status is 408, 429 or 5xx, other failures are
+// permanent. The retries are made when the queue delivers the event again, the delivery log
+// tells which subscriptions are still waiting for it and how many attempts were made.
+type Dispatcher struct {
+	repository domain.WebhookRepository
+	options    DispatcherOptions
+	logger     log.Logger
+	now        func() time.Time
+}
+
+// NewDispatcher creates a dispatcher delivering to the subscriptions of the repository.
+func NewDispatcher(repository domain.WebhookRepository, options DispatcherOptions, logger log.Logger) *Dispatcher {
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = 1
+	}
+	if options.AddressPolicy == nil {
+		options.AddressPolicy = &AddressPolicy{}
+	}
+	if options.KeyHasher == nil {
+		options.KeyHasher = domain.NewAnswerKeyHasher("")
+	}
+	if options.Client == nil {
+		options.Client = options.AddressPolicy.newClient()
+	}
+	return &Dispatcher{
+		repository: repository,
+		options:    options,
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// NotifyAnswerEvent - makes an attempt to deliver the event to every matching subscription which is waiting
+// for it and waits for the attempts to end. An ErrAborted is returned if an attempt is to be retried,
+// so the event is delivered again.
+func (d *Dispatcher) NotifyAnswerEvent(ctx context.Context, event *domain.AnswerEvent) error {
+	subscriptions, err := d.matchingSubscriptions(event.EventType)
+	if err != nil {
+		return err
+	}
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		pending int
+		errs    []error
+	)
+	for _, subscription := range subscriptions {
+		subscription := subscription
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			retry, err := d.deliver(ctx, subscription, event)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+			}
+			if retry {
+				pending++
+			}
+		}()
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	if pending > 0 {
+		return errors.NewErrAborted(fmt.Sprintf("%d webhook deliveries are retried", pending))
+	}
+	return nil
+}
+
+// matchingSubscriptions - returns the subscriptions of the event type across all the pages.
+func (d *Dispatcher) matchingSubscriptions(eventType domain.AnswerEventType) ([]*domain.WebhookSubscription, error) {
+	var matching []*domain.WebhookSubscription
+	page := domain.PageRequest{PageSize: domain.MaxPageSize}
+	for {
+		subscriptions, nextPageToken, err := d.repository.ListSubscriptions(page)
+		if err != nil {
+			return nil, err
+		}
+		for _, subscription := range subscriptions {
+			if subscription.Matches(eventType) {
+				matching = append(matching, subscription)
+			}
+		}
+		if len(nextPageToken) == 0 {
+			return matching, nil
+		}
+		page.PageToken = nextPageToken
+	}
+}
+
+// deliver - makes the next attempt to deliver the event unless it was accepted, the failure was permanent,
+// the attempts ran out or the backoff since the last attempt has not passed yet. It tells if the delivery
+// is to be retried.
+func (d *Dispatcher) deliver(ctx context.Context, subscription *domain.WebhookSubscription, event *domain.AnswerEvent) (bool, error) {
+	id := deliveryID(subscription.ID, event)
+	attempts, err := d.repository.ListDeliveryAttempts(subscription.ID, id, event.OccurredAt.Add(-maxClockSkew))
+	if err != nil {
+		return false, err
+	}
+	if len(attempts) > 0 {
+		last := attempts[len(attempts)-1]
+		if !last.Retryable() || len(attempts) >= d.options.MaxAttempts {
+			return false, nil
+		}
+		if d.now().Before(last.AttemptedAt.Add(d.backoff(len(attempts)))) {
+			return true, nil
+		}
+	}
+
+	body, err := json.Marshal(&Payload{ID: id, SubscriptionID: subscription.ID, Event: event})
+	if err != nil {
+		_ = d.logger.Log("msg", "Webhook payload cannot be encoded", "subscriptionId", subscription.ID, "err", err)
+		return false, nil
+	}
+	delivery := &domain.WebhookDelivery{
+		ID:             id,
+		SubscriptionID: subscription.ID,
+		EventType:      event.EventType,
+		OccurredAt:     event.OccurredAt,
+		Attempt:        len(attempts) + 1,
+		AttemptedAt:    d.now().UTC(),
+	}
+	if event.Data != nil {
+		delivery.KeyHash = d.options.KeyHasher.Hash(event.Data.Key)
+	}
+	d.attempt(ctx, subscription, delivery, body)
+
+	// An attempt which is not recorded would be repeated without a limit.
+	//
+	if err := d.repository.CreateDelivery(delivery); err != nil {
+		return false, err
+	}
+	return delivery.Retryable() && delivery.Attempt < d.options.MaxAttempts, nil
+}
+
+// backoff - returns the delay after the attempts, the initial backoff doubled after every further attempt.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	return d.options.InitialBackoff << (attempts - 1)
+}
+
+// attempt - posts the payload once and records the outcome in the delivery.
+func (d *Dispatcher) attempt(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery, body []byte) {
+	if d.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.options.Timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return
+	}
+	timestamp := strconv.FormatInt(delivery.AttemptedAt.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, delivery.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, delivery.ID, timestamp, body))
+
+	resp, err := d.options.Client.Do(req)
+	if err != nil {
+		delivery.Error = err.Error()
+		return
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	delivery.StatusCode = resp.StatusCode
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		delivery.Succeeded = true
+		return
+	}
+	delivery.Error = fmt.Sprintf("Unexpected status %v", resp.Status)
+}
+
+// deliveryID - identifies the event delivered to the subscription, so it is the same for a redelivered event.
+func deliveryID(subscriptionID string, event *domain.AnswerEvent) string {
+	var key domain.AnswerKey
+	if event.Data != nil {
+		key = event.Data.Key
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v|%v|%v", subscriptionID, key, event.OccurredAt.UnixNano())))
+	return hex.EncodeToString(sum[:16])
+}
+
+var (
+	_ domain.AnswerEventNotifier = &Dispatcher{}
+)
@@ -0,0 +1,343 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errs "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/inmemory"
+
+	"github.com/go-kit/log"
+	"github.com/go-test/deep"
+)
+
+const testBackoff = time.Second
+
+// scriptedReceiver - answers the deliveries with the scripted statuses, the last one is repeated.
+type scriptedReceiver struct {
+	secret   string
+	statuses []int
+
+	mu       sync.Mutex
+	requests int
+	payloads []*Payload
+	invalid  int
+}
+
+func (r *scriptedReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	body, _ := ioutil.ReadAll(req.Body)
+	if !VerifySignature(r.secret, req.Header.Get(HeaderID), req.Header.Get(HeaderTimestamp), body, req.Header.Get(HeaderSignature)) {
+		r.invalid++
+	}
+	payload := &Payload{}
+	_ = json.Unmarshal(body, payload)
+	r.payloads = append(r.payloads, payload)
+	status := r.statuses[len(r.statuses)-1]
+	if r.requests < len(r.statuses) {
+		status = r.statuses[r.requests]
+	}
+	r.requests++
+	w.WriteHeader(status)
+}
+
+type deliveryOutcome struct {
+	Attempt    int
+	StatusCode int
+	Succeeded  bool
+}
+
+func TestDispatcher(t *testing.T) {
+	event := &domain.AnswerEvent{
+		EventType:  domain.UpdateAnswerEventType,
+		Data:       &domain.Answer{Key: "name", Value: "John"},
+		OccurredAt: time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name             string
+		eventTypes       []domain.AnswerEventType
+		statuses         []int
+		wantOutcomes     []deliveryOutcome
+		wantRedeliveries int
+	}{
+		{
+			name:         "delivered",
+			statuses:     []int{http.StatusNoContent},
+			wantOutcomes: []deliveryOutcome{{Attempt: 1, StatusCode: http.StatusNoContent, Succeeded: true}},
+		},
+		{
+			name:       "retried",
+			eventTypes: []domain.AnswerEventType{domain.UpdateAnswerEventType},
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			wantOutcomes: []deliveryOutcome{
+				{Attempt: 1, StatusCode: http.StatusServiceUnavailable},
+				{Attempt: 2, StatusCode: http.StatusTooManyRequests},
+				{Attempt: 3, StatusCode: http.StatusOK, Succeeded: true},
+			},
+			wantRedeliveries: 2,
+		},
+		{
+			name:     "attempts exhausted",
+			statuses: []int{http.StatusInternalServerError},
+			wantOutcomes: []deliveryOutcome{
+				{Attempt: 1, StatusCode: http.StatusInternalServerError},
+				{Attempt: 2, StatusCode: http.StatusInternalServerError},
+				{Attempt: 3, StatusCode: http.StatusInternalServerError},
+			},
+			wantRedeliveries: 2,
+		},
+		{
+			name:         "permanent failure",
+			statuses:     []int{http.StatusGone},
+			wantOutcomes: []deliveryOutcome{{Attempt: 1, StatusCode: http.StatusGone}},
+		},
+		{
+			name:         "redirect not followed",
+			statuses:     []int{http.StatusFound},
+			wantOutcomes: []deliveryOutcome{{Attempt: 1, StatusCode: http.StatusFound}},
+		},
+		{
+			name:       "event type not subscribed",
+			eventTypes: []domain.AnswerEventType{domain.CreateAnswerEventType, domain.DeleteAnswerEventType},
+			statuses:   []int{http.StatusOK},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := &scriptedReceiver{secret: "secret", statuses: tt.statuses}
+			server := httptest.NewServer(receiver)
+			defer server.Close()
+			repository := inmemory.NewWebhookRepository()
+			subscription := &domain.WebhookSubscription{ID: "subscription-1", URL: server.URL, EventTypes: tt.eventTypes, Secret: "secret"}
+			if err := repository.CreateSubscription(subscription); err != nil {
+				t.Fatal(err)
+			}
+			dispatcher, clock := newTestDispatcher(repository, 3)
+
+			// The event is delivered again till the notification succeeds.
+			//
+			redeliveries := notifyTillDone(t, dispatcher, clock, event)
+			if redeliveries != tt.wantRedeliveries {
+				t.Errorf("expected %v redeliveries, got %v", tt.wantRedeliveries, redeliveries)
+			}
+
+			deliveries, _, err := repository.ListDeliveries(subscription.ID, domain.PageRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var outcomes []deliveryOutcome
+			for _, delivery := range deliveries {
+				outcomes = append(outcomes, deliveryOutcome{Attempt: delivery.Attempt, StatusCode: delivery.StatusCode, Succeeded: delivery.Succeeded})
+				if delivery.ID != deliveryID(subscription.ID, event) || delivery.KeyHash != domain.NewAnswerKeyHasher("").Hash("name") || delivery.EventType != event.EventType {
+					t.Errorf("unexpected delivery %+v", delivery)
+				}
+			}
+			if diff := deep.Equal(outcomes, tt.wantOutcomes); diff != nil {
+				t.Error(diff)
+			}
+
+			// Every attempt carries the same signed payload.
+			//
+			if receiver.invalid > 0 {
+				t.Errorf("%v deliveries with an invalid signature", receiver.invalid)
+			}
+			for _, payload := range receiver.payloads {
+				want := &Payload{ID: deliveryID(subscription.ID, event), SubscriptionID: subscription.ID, Event: event}
+				if diff := deep.Equal(payload, want); diff != nil {
+					t.Error(diff)
+				}
+			}
+		})
+	}
+}
+
+func TestDispatcherBackoff(t *testing.T) {
+	receiver := &scriptedReceiver{secret: "secret", statuses: []int{http.StatusServiceUnavailable}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	repository := inmemory.NewWebhookRepository()
+	if err := repository.CreateSubscription(&domain.WebhookSubscription{ID: "subscription-1", URL: server.URL, Secret: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	dispatcher, clock := newTestDispatcher(repository, 4)
+	event := &domain.AnswerEvent{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John"}, OccurredAt: clock.read()}
+
+	// The backoff after an attempt is doubled, an event delivered again before it passed makes no attempt.
+	//
+	steps := []struct {
+		advance      time.Duration
+		wantRequests int
+	}{
+		{wantRequests: 1},
+		{advance: testBackoff / 2, wantRequests: 1},
+		{advance: testBackoff / 2, wantRequests: 2},
+		{advance: testBackoff, wantRequests: 2},
+		{advance: testBackoff, wantRequests: 3},
+	}
+	for i, step := range steps {
+		clock.advance(step.advance)
+		if _, ok := dispatcher.NotifyAnswerEvent(context.Background(), event).(*errs.ErrAborted); !ok {
+			t.Fatalf("step %d: expected ErrAborted", i)
+		}
+		if receiver.requests != step.wantRequests {
+			t.Errorf("step %d: expected %v requests, got %v", i, step.wantRequests, receiver.requests)
+		}
+	}
+}
+
+func TestDispatcherRedeliverySkipsDeliveredSubscriptions(t *testing.T) {
+	accepting := &scriptedReceiver{secret: "secret", statuses: []int{http.StatusOK}}
+	failing := &scriptedReceiver{secret: "secret", statuses: []int{http.StatusBadGateway, http.StatusOK}}
+	repository := inmemory.NewWebhookRepository()
+	for id, receiver := range map[string]*scriptedReceiver{"accepting": accepting, "failing": failing} {
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+		if err := repository.CreateSubscription(&domain.WebhookSubscription{ID: id, URL: server.URL, Secret: "secret"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dispatcher, clock := newTestDispatcher(repository, 3)
+	event := &domain.AnswerEvent{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John"}, OccurredAt: clock.read()}
+
+	if redeliveries := notifyTillDone(t, dispatcher, clock, event); redeliveries != 1 {
+		t.Errorf("expected 1 redelivery, got %v", redeliveries)
+	}
+	if accepting.requests != 1 || failing.requests != 2 {
+		t.Errorf("expected 1 and 2 requests, got %v and %v", accepting.requests, failing.requests)
+	}
+}
+
+func TestDispatcherUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	repository := inmemory.NewWebhookRepository()
+	if err := repository.CreateSubscription(&domain.WebhookSubscription{ID: "subscription-1", URL: server.URL, Secret: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	dispatcher, clock := newTestDispatcher(repository, 2)
+	event := &domain.AnswerEvent{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John"}, OccurredAt: clock.read()}
+
+	if redeliveries := notifyTillDone(t, dispatcher, clock, event); redeliveries != 1 {
+		t.Errorf("expected 1 redelivery, got %v", redeliveries)
+	}
+
+	deliveries, _, err := repository.ListDeliveries("subscription-1", domain.PageRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("expected 2 attempts, got %v", len(deliveries))
+	}
+	for _, delivery := range deliveries {
+		if delivery.StatusCode != 0 || delivery.Succeeded || len(delivery.Error) == 0 {
+			t.Errorf("unexpected delivery %+v", delivery)
+		}
+	}
+}
+
+func TestDispatcherPrivateAddress(t *testing.T) {
+	receiver := &scriptedReceiver{secret: "secret", statuses: []int{http.StatusOK}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	repository := inmemory.NewWebhookRepository()
+	if err := repository.CreateSubscription(&domain.WebhookSubscription{ID: "subscription-1", URL: server.URL, Secret: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The default policy only allows the public addresses, the loopback receiver is never reached.
+	//
+	dispatcher := NewDispatcher(repository, DispatcherOptions{MaxAttempts: 1, Timeout: time.Second}, log.NewNopLogger())
+	event := &domain.AnswerEvent{EventType: domain.CreateAnswerEventType, Data: &domain.Answer{Key: "name", Value: "John"}, OccurredAt: time.Now().UTC()}
+	if err := dispatcher.NotifyAnswerEvent(context.Background(), event); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	deliveries, _, err := repository.ListDeliveries("subscription-1", domain.PageRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || !strings.Contains(deliveries[0].Error, "is not public") || receiver.requests > 0 {
+		t.Errorf("expected a blocked attempt, got %+v and %v requests", deliveries, receiver.requests)
+	}
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":"delivery"}`)
+	signature := Sign("secret", "delivery", "1646128800", body)
+	if !VerifySignature("secret", "delivery", "1646128800", body, signature) {
+		t.Error("expected valid signature")
+	}
+	for name, verified := range map[string]bool{
+		"secret":    VerifySignature("other", "delivery", "1646128800", body, signature),
+		"id":        VerifySignature("secret", "other", "1646128800", body, signature),
+		"timestamp": VerifySignature("secret", "delivery", "1646128801", body, signature),
+		"body":      VerifySignature("secret", "delivery", "1646128800", []byte(`{"id":"other"}`), signature),
+	} {
+		if verified {
+			t.Errorf("signature verified with another %v", name)
+		}
+	}
+}
+
+// testClock - the time of the test dispatcher, it advances by a millisecond per reading,
+// so the attempts have their own times.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) read() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(time.Millisecond)
+	return c.now
+}
+
+func (c *testClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// newTestDispatcher - returns a dispatcher delivering to the loopback addresses with the clock of the test.
+func newTestDispatcher(repository domain.WebhookRepository, maxAttempts int) (*Dispatcher, *testClock) {
+	policy, err := NewAddressPolicy([]string{"127.0.0.0/8", "::1/128"})
+	if err != nil {
+		panic(err)
+	}
+	dispatcher := NewDispatcher(repository, DispatcherOptions{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: testBackoff,
+		Timeout:        time.Second,
+		AddressPolicy:  policy,
+	}, log.NewNopLogger())
+	clock := &testClock{now: time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)}
+	dispatcher.now = clock.read
+	return dispatcher, clock
+}
+
+// notifyTillDone - notifies about the event like a queue delivering it again after every failure and
+// a long enough delay, it returns the number of redeliveries.
+func notifyTillDone(t *testing.T, dispatcher *Dispatcher, clock *testClock, event *domain.AnswerEvent) int {
+	t.Helper()
+	for redeliveries := 0; redeliveries < 10; redeliveries++ {
+		err := dispatcher.NotifyAnswerEvent(context.Background(), event)
+		if err == nil {
+			return redeliveries
+		}
+		if _, ok := err.(*errs.ErrAborted); !ok {
+			t.Fatalf("unexpected err: %v", err)
+		}
+		clock.advance(time.Hour)
+	}
+	t.Fatal("the event is delivered again without an end")
+	return 0
+}
@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/helpers"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/log"
+)
+
+// Endpoints collects all of the endpoints of the webhook service.
+type Endpoints struct {
+	CreateWebhookSubscriptionEndpoint endpoint.Endpoint
+	GetWebhookSubscriptionEndpoint    endpoint.Endpoint
+	ListWebhookSubscriptionsEndpoint  endpoint.Endpoint
+	DeleteWebhookSubscriptionEndpoint endpoint.Endpoint
+	ListWebhookDeliveriesEndpoint     endpoint.Endpoint
+}
+
+// NewEndpoint returns a Set that wraps the provided server, and wires in all of the
+// expected endpoint middlewares via the various parameters.
+// The tokens are verified, unless authentication is nil, and the requests are rate limited, unless rateLimit is nil.
+func NewEndpoint(service domain.WebhookService, authentication *helpers.Authentication, rateLimit *helpers.RateLimit, logger log.Logger) Endpoints {
+	factory := func(creator func(domain.WebhookService) endpoint.Endpoint, logKey string) endpoint.Endpoint {
+		return helpers.SetupEndpoint(creator(service), authentication, rateLimit, logger, "WebhookEndpoints", logKey)
+	}
+	return Endpoints{
+		CreateWebhookSubscriptionEndpoint: factory(MakeCreateWebhookSubscriptionEndpoint, "CreateWebhookSubscription"),
+		GetWebhookSubscriptionEndpoint:    factory(MakeGetWebhookSubscriptionEndpoint, "GetWebhookSubscription"),
+		ListWebhookSubscriptionsEndpoint:  factory(MakeListWebhookSubscriptionsEndpoint, "ListWebhookSubscriptions"),
+		DeleteWebhookSubscriptionEndpoint: factory(MakeDeleteWebhookSubscriptionEndpoint, "DeleteWebhookSubscription"),
+		ListWebhookDeliveriesEndpoint:     factory(MakeListWebhookDeliveriesEndpoint, "ListWebhookDeliveries"),
+	}
+}
+
+// MakeCreateWebhookSubscriptionEndpoint Impl.
+func MakeCreateWebhookSubscriptionEndpoint(service domain.WebhookService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(CreateWebhookSubscriptionRequest)
+
+		// Call the service.
+		subscription, err := service.CreateWebhookSubscription(ctx, req.Subscription)
+		return CreateWebhookSubscriptionResponse{
+			Subscription: subscription,
+			Err:          err,
+		}, nil
+	}
+}
+
+// CreateWebhookSubscriptionRequest - request.
+type CreateWebhookSubscriptionRequest struct {
+	Subscription *domain.WebhookSubscription
+}
+
+// CreateWebhookSubscriptionResponse - response.
+type CreateWebhookSubscriptionResponse struct {
+	Subscription *domain.WebhookSubscription
+	Err          error
+}
+
+// MakeGetWebhookSubscriptionEndpoint Impl.
+func MakeGetWebhookSubscriptionEndpoint(service domain.WebhookService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(GetWebhookSubscriptionRequest)
+
+		// Call the service.
+		subscription, err := service.GetWebhookSubscription(ctx, req.ID)
+		return GetWebhookSubscriptionResponse{
+			Subscription: subscription,
+			Err:          err,
+		}, nil
+	}
+}
+
+// GetWebhookSubscriptionRequest - request.
+type GetWebhookSubscriptionRequest struct {
+	ID string
+}
+
+// GetWebhookSubscriptionResponse - response.
+type GetWebhookSubscriptionResponse struct {
+	Subscription *domain.WebhookSubscription
+	Err          error
+}
+
+// MakeListWebhookSubscriptionsEndpoint Impl.
+func MakeListWebhookSubscriptionsEndpoint(service domain.WebhookService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(ListWebhookSubscriptionsRequest)
+
+		// Call the service.
+		subscriptions, nextPageToken, err := service.ListWebhookSubscriptions(ctx, req.Page)
+		return ListWebhookSubscriptionsResponse{
+			Subscriptions: subscriptions,
+			NextPageToken: nextPageToken,
+			Err:           err,
+		}, nil
+	}
+}
+
+// ListWebhookSubscriptionsRequest - request.
+type ListWebhookSubscriptionsRequest struct {
+	Page domain.PageRequest
+}
+
+// ListWebhookSubscriptionsResponse - response.
+type ListWebhookSubscriptionsResponse struct {
+	Subscriptions []*domain.WebhookSubscription
+	NextPageToken string
+	Err           error
+}
+
+// MakeDeleteWebhookSubscriptionEndpoint Impl.
+func MakeDeleteWebhookSubscriptionEndpoint(service domain.WebhookService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(DeleteWebhookSubscriptionRequest)
+
+		// Call the service.
+		err = service.DeleteWebhookSubscription(ctx, req.ID)
+		return DeleteWebhookSubscriptionResponse{
+			Err: err,
+		}, nil
+	}
+}
+
+// DeleteWebhookSubscriptionRequest - request.
+type DeleteWebhookSubscriptionRequest struct {
+	ID string
+}
+
+// DeleteWebhookSubscriptionResponse - response.
+type DeleteWebhookSubscriptionResponse struct {
+	Err error
+}
+
+// MakeListWebhookDeliveriesEndpoint Impl.
+func MakeListWebhookDeliveriesEndpoint(service domain.WebhookService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(ListWebhookDeliveriesRequest)
+
+		// Call the service.
+		deliveries, nextPageToken, err := service.ListWebhookDeliveries(ctx, req.SubscriptionID, req.Page)
+		return ListWebhookDeliveriesResponse{
+			Deliveries:    deliveries,
+			NextPageToken: nextPageToken,
+			Err:           err,
+		}, nil
+	}
+}
+
+// ListWebhookDeliveriesRequest - request.
+type ListWebhookDeliveriesRequest struct {
+	SubscriptionID string
+	Page           domain.PageRequest
+}
+
+// ListWebhookDeliveriesResponse - response.
+type ListWebhookDeliveriesResponse struct {
+	Deliveries    []*domain.WebhookDelivery
+	NextPageToken string
+	Err           error
+}
+
+// //
+//
+// Error interceptors.
+//
+// //
+
+// compile time assertions for our response types implementing endpoint.Failer.
+var (
+	_ endpoint.Failer = CreateWebhookSubscriptionResponse{}
+	_ endpoint.Failer = GetWebhookSubscriptionResponse{}
+	_ endpoint.Failer = ListWebhookSubscriptionsResponse{}
+	_ endpoint.Failer = DeleteWebhookSubscriptionResponse{}
+	_ endpoint.Failer = ListWebhookDeliveriesResponse{}
+)
+
+// Failed implements endpoint.Failer.
+func (r CreateWebhookSubscriptionResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r GetWebhookSubscriptionResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r ListWebhookSubscriptionsResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r DeleteWebhookSubscriptionResponse) Failed() error { return r.Err }
+
+// Failed implements endpoint.Failer.
+func (r ListWebhookDeliveriesResponse) Failed() error { return r.Err }
@@ -0,0 +1,187 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-kit/log"
+)
+
+const (
+	// idLength and secretLength - in random bytes, they are hex encoded.
+	idLength     = 16
+	secretLength = 32
+)
+
+type service struct {
+	repository    domain.WebhookRepository
+	addressPolicy *AddressPolicy
+	now           func() time.Time
+	random        func(n int) (string, error)
+}
+
+// NewService creates a new service with necessary dependencies.
+// The subscription URLs must pass the address policy, only the public addresses if it is nil.
+func NewService(repository domain.WebhookRepository, addressPolicy *AddressPolicy, logger log.Logger) domain.WebhookService {
+	var service domain.WebhookService
+	{
+		service = newBasicService(repository, addressPolicy)
+		service = LoggingServiceMiddleware(logger)(service)
+	}
+	return service
+}
+
+// Returns a naive, stateless implementation of service.
+func newBasicService(repository domain.WebhookRepository, addressPolicy *AddressPolicy) domain.WebhookService {
+	if addressPolicy == nil {
+		addressPolicy = &AddressPolicy{}
+	}
+	return &service{
+		repository:    repository,
+		addressPolicy: addressPolicy,
+		now:           time.Now,
+		random:        randomHex,
+	}
+}
+
+func (s *service) CreateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+
+	// Check for nil.
+	//
+	if subscription == nil {
+		return nil, errors.NewErrInvalidArgument("WebhookSubscription required")
+	}
+
+	// Validate fields.
+	//
+	if err := subscription.Validate(); err != nil {
+		return nil, errors.NewErrInvalidArgument(err.Error())
+	}
+
+	// The internal services must not be reached, the resolved addresses are checked by the deliveries.
+	//
+	parsed, _ := url.Parse(subscription.URL)
+	if err := s.addressPolicy.CheckHost(parsed.Hostname()); err != nil {
+		return nil, errors.NewErrInvalidArgument("URL must address a public host")
+	}
+
+	// Assign the id, the creation time and the secret if none is given.
+	//
+	createdSubscription := *subscription
+	id, err := s.random(idLength)
+	if err != nil {
+		return nil, err
+	}
+	createdSubscription.ID = id
+	createdSubscription.CreatedAt = s.now().UTC()
+	if len(createdSubscription.Secret) == 0 {
+		secret, err := s.random(secretLength)
+		if err != nil {
+			return nil, err
+		}
+		createdSubscription.Secret = secret
+	}
+
+	// Create subscription.
+	//
+	if err := s.repository.CreateSubscription(&createdSubscription); err != nil {
+		return nil, err
+	}
+	return &createdSubscription, nil
+}
+
+func (s *service) GetWebhookSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+
+	// Check id.
+	//
+	if len(id) == 0 {
+		return nil, errors.NewErrInvalidArgument("WebhookSubscriptionID required")
+	}
+
+	// Return result without the secret.
+	//
+	subscription, err := s.repository.GetSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+func (s *service) ListWebhookSubscriptions(ctx context.Context, page domain.PageRequest) ([]*domain.WebhookSubscription, string, error) {
+
+	// Check page size.
+	//
+	if err := validatePage(page); err != nil {
+		return nil, "", err
+	}
+
+	// Return result without the secrets.
+	//
+	subscriptions, nextPageToken, err := s.repository.ListSubscriptions(page)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, subscription := range subscriptions {
+		subscription.Secret = ""
+	}
+	return subscriptions, nextPageToken, nil
+}
+
+func (s *service) DeleteWebhookSubscription(ctx context.Context, id string) error {
+
+	// Check id.
+	//
+	if len(id) == 0 {
+		return errors.NewErrInvalidArgument("WebhookSubscriptionID required")
+	}
+
+	// Delete subscription.
+	//
+	return s.repository.DeleteSubscription(id)
+}
+
+func (s *service) ListWebhookDeliveries(ctx context.Context, subscriptionID string, page domain.PageRequest) ([]*domain.WebhookDelivery, string, error) {
+
+	// Check arguments.
+	//
+	if len(subscriptionID) == 0 {
+		return nil, "", errors.NewErrInvalidArgument("WebhookSubscriptionID required")
+	}
+	if err := validatePage(page); err != nil {
+		return nil, "", err
+	}
+
+	// An unknown subscription is reported instead of an empty log.
+	//
+	if _, err := s.repository.GetSubscription(subscriptionID); err != nil {
+		return nil, "", err
+	}
+
+	// Return result.
+	//
+	return s.repository.ListDeliveries(subscriptionID, page)
+}
+
+func validatePage(page domain.PageRequest) error {
+	if page.PageSize < 0 || page.PageSize > domain.MaxPageSize {
+		return errors.NewErrInvalidArgument(fmt.Sprintf("PageSize must be between 0 and %d", domain.MaxPageSize))
+	}
+	return nil
+}
+
+// randomHex - returns n random bytes hex encoded.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.NewErrInternal(fmt.Sprintf("Cannot generate random bytes: %v", err))
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+	"dochq.co.uk.answerservice/internal/servicetest"
+
+	"github.com/go-test/deep"
+)
+
+func newTestService() (*service, domain.WebhookRepository) {
+	repository := servicetest.NewStore().Webhooks
+	s := newBasicService(repository, nil).(*service)
+	s.now = servicetest.Clock
+	sequence := 0
+	s.random = func(n int) (string, error) {
+		sequence++
+		return fmt.Sprintf("random-%v-%v", n, sequence), nil
+	}
+	return s, repository
+}
+
+func TestCreateWebhookSubscription(t *testing.T) {
+	tests := []struct {
+		name         string
+		subscription *domain.WebhookSubscription
+		want         *domain.WebhookSubscription
+		wantErr      error
+	}{
+		{
+			name:         "generated secret",
+			subscription: &domain.WebhookSubscription{ID: "ignored", URL: "https://example.com/hooks"},
+			want:         &domain.WebhookSubscription{ID: "random-16-1", URL: "https://example.com/hooks", Secret: "random-32-2", CreatedAt: servicetest.Now},
+		},
+		{
+			name: "given secret",
+			subscription: &domain.WebhookSubscription{
+				URL:        "http://example.com",
+				EventTypes: []domain.AnswerEventType{domain.DeleteAnswerEventType},
+				Secret:     "secret",
+			},
+			want: &domain.WebhookSubscription{
+				ID:         "random-16-1",
+				URL:        "http://example.com",
+				EventTypes: []domain.AnswerEventType{domain.DeleteAnswerEventType},
+				Secret:     "secret",
+				CreatedAt:  servicetest.Now,
+			},
+		},
+		{
+			name:    "nil subscription",
+			wantErr: errors.NewErrInvalidArgument("WebhookSubscription required"),
+		},
+		{
+			name:         "relative url",
+			subscription: &domain.WebhookSubscription{URL: "/hooks"},
+			wantErr:      errors.NewErrInvalidArgument("URL must be an absolute http or https URL"),
+		},
+		{
+			name:         "invalid event type",
+			subscription: &domain.WebhookSubscription{URL: "https://example.com", EventTypes: []domain.AnswerEventType{"Rename"}},
+			wantErr:      errors.NewErrInvalidArgument(`EventType "Rename" is not valid`),
+		},
+		{
+			name:         "loopback address",
+			subscription: &domain.WebhookSubscription{URL: "http://127.0.0.1:8080/hooks"},
+			wantErr:      errors.NewErrInvalidArgument("URL must address a public host"),
+		},
+		{
+			name:         "local name",
+			subscription: &domain.WebhookSubscription{URL: "http://localhost/hooks"},
+			wantErr:      errors.NewErrInvalidArgument("URL must address a public host"),
+		},
+		{
+			name:         "private address",
+			subscription: &domain.WebhookSubscription{URL: "https://[fd00::1]/hooks"},
+			wantErr:      errors.NewErrInvalidArgument("URL must address a public host"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repository := newTestService()
+			got, err := s.CreateWebhookSubscription(context.Background(), tt.subscription)
+			if diff := deep.Equal(err, tt.wantErr); diff != nil {
+				t.Fatal(diff)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+			if tt.want == nil {
+				return
+			}
+			stored, err := repository.GetSubscription(tt.want.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(stored, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestWebhookSubscriptionSecretHidden(t *testing.T) {
+	s, _ := newTestService()
+	ctx := context.Background()
+	created, err := s.CreateWebhookSubscription(ctx, &domain.WebhookSubscription{URL: "https://example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.GetWebhookSubscription(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listed, _, err := s.ListWebhookSubscriptions(ctx, domain.PageRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := *created
+	want.Secret = ""
+	if diff := deep.Equal([]*domain.WebhookSubscription{got, listed[0]}, []*domain.WebhookSubscription{&want, &want}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	s, repository := newTestService()
+	ctx := context.Background()
+	created, err := s.CreateWebhookSubscription(ctx, &domain.WebhookSubscription{URL: "https://example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delivery := &domain.WebhookDelivery{ID: "delivery", SubscriptionID: created.ID, EventType: domain.CreateAnswerEventType, KeyHash: "name-hash", Attempt: 1, AttemptedAt: servicetest.Now}
+	if err := repository.CreateDelivery(delivery); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		subscriptionID string
+		page           domain.PageRequest
+		want           []*domain.WebhookDelivery
+		wantErr        error
+	}{
+		{name: "listed", subscriptionID: created.ID, want: []*domain.WebhookDelivery{delivery}},
+		{name: "missing id", wantErr: errors.NewErrInvalidArgument("WebhookSubscriptionID required")},
+		{
+			name:           "page too large",
+			subscriptionID: created.ID,
+			page:           domain.PageRequest{PageSize: domain.MaxPageSize + 1},
+			wantErr:        errors.NewErrInvalidArgument(fmt.Sprintf("PageSize must be between 0 and %d", domain.MaxPageSize)),
+		},
+		{name: "unknown subscription", subscriptionID: "unknown", wantErr: errors.NewErrNotFound("Webhook subscription not found")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := s.ListWebhookDeliveries(ctx, tt.subscriptionID, tt.page)
+			if diff := deep.Equal(err, tt.wantErr); diff != nil {
+				t.Fatal(diff)
+			}
+			if diff := deep.Equal(got, tt.want); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestDeleteWebhookSubscription(t *testing.T) {
+	s, _ := newTestService()
+	ctx := context.Background()
+	if diff := deep.Equal(s.DeleteWebhookSubscription(ctx, ""), errors.NewErrInvalidArgument("WebhookSubscriptionID required")); diff != nil {
+		t.Error(diff)
+	}
+	created, err := s.CreateWebhookSubscription(ctx, &domain.WebhookSubscription{URL: "https://example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteWebhookSubscription(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetWebhookSubscription(ctx, created.ID); err == nil {
+		t.Error("expected deleted subscription")
+	}
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+)
+
+// Middleware describes a service (as opposed to endpoint) middleware.
+type Middleware func(domain.WebhookService) domain.WebhookService
+
+// LoggingServiceMiddleware takes a logger as a dependency
+// and returns a service Middleware. The secrets are never logged.
+func LoggingServiceMiddleware(logger log.Logger) Middleware {
+	return func(next domain.WebhookService) domain.WebhookService {
+		return loggingMiddleware{logger, next}
+	}
+}
+
+type loggingMiddleware struct {
+	logger log.Logger
+	next   domain.WebhookService
+}
+
+func (mw loggingMiddleware) CreateWebhookSubscription(ctx context.Context, subscription *domain.WebhookSubscription) (created *domain.WebhookSubscription, err error) {
+	defer func() {
+		var id, url string
+		if created != nil {
+			id = created.ID
+		}
+		if subscription != nil {
+			url = subscription.URL
+		}
+		_ = mw.logger.Log("method", "CreateWebhookSubscription",
+			"id", id,
+			"url", url,
+			"err", err,
+		)
+	}()
+	return mw.next.CreateWebhookSubscription(ctx, subscription)
+}
+
+func (mw loggingMiddleware) GetWebhookSubscription(ctx context.Context, id string) (subscription *domain.WebhookSubscription, err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "GetWebhookSubscription",
+			"id", id,
+			"err", err,
+		)
+	}()
+	return mw.next.GetWebhookSubscription(ctx, id)
+}
+
+func (mw loggingMiddleware) ListWebhookSubscriptions(ctx context.Context, page domain.PageRequest) (subscriptions []*domain.WebhookSubscription, nextPageToken string, err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "ListWebhookSubscriptions",
+			"pageSize", page.PageSize,
+			"subscriptions", len(subscriptions),
+			"err", err,
+		)
+	}()
+	return mw.next.ListWebhookSubscriptions(ctx, page)
+}
+
+func (mw loggingMiddleware) DeleteWebhookSubscription(ctx context.Context, id string) (err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "DeleteWebhookSubscription",
+			"id", id,
+			"err", err,
+		)
+	}()
+	return mw.next.DeleteWebhookSubscription(ctx, id)
+}
+
+func (mw loggingMiddleware) ListWebhookDeliveries(ctx context.Context, subscriptionID string, page domain.PageRequest) (deliveries []*domain.WebhookDelivery, nextPageToken string, err error) {
+	defer func() {
+		_ = mw.logger.Log("method", "ListWebhookDeliveries",
+			"subscriptionId", subscriptionID,
+			"pageSize", page.PageSize,
+			"deliveries", len(deliveries),
+			"err", err,
+		)
+	}()
+	return mw.next.ListWebhookDeliveries(ctx, subscriptionID, page)
+}
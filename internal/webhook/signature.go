@@ -0,0 +1,34 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Delivery headers.
+const (
+	// HeaderID - the delivery id, it is the same for all the attempts, so receivers can drop duplicates.
+	HeaderID = "Webhook-Id"
+	// HeaderTimestamp - the unix time of the attempt in seconds.
+	HeaderTimestamp = "Webhook-Timestamp"
+	// HeaderSignature - the signature of the id, the timestamp and the body.
+	HeaderSignature = "Webhook-Signature"
+)
+
+// signatureVersion - prefixes the signatures, it changes with the signing scheme.
+const signatureVersion = "v1="
+
+// Sign - returns the signature of a delivery, the HMAC-SHA256 of "id.timestamp.body" keyed by the secret.
+func Sign(secret, id, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(id + "." + timestamp + "."))
+	_, _ = mac.Write(body)
+	return signatureVersion + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature - checks the signature of a delivery in constant time.
+// Receivers should also reject the timestamps too far from their clock to prevent replays.
+func VerifySignature(secret, id, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, id, timestamp, body)), []byte(signature))
+}
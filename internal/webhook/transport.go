@@ -0,0 +1,228 @@
+package webhook
+
+import (
+	"context"
+
+	apiv1 "dochq.co.uk.answerservice/api/generated/dochq.co.uk/answerserviceapi/v1"
+	"dochq.co.uk.answerservice/internal/domain"
+	"dochq.co.uk.answerservice/internal/helpers"
+
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	"github.com/go-kit/log"
+)
+
+type grpcServer struct {
+	createWebhookSubscription grpctransport.Handler
+	getWebhookSubscription    grpctransport.Handler
+	listWebhookSubscriptions  grpctransport.Handler
+	deleteWebhookSubscription grpctransport.Handler
+	listWebhookDeliveries     grpctransport.Handler
+}
+
+// NewGRPCServer makes a set of endpoints available as a gRPC WebhookServiceServer.
+func NewGRPCServer(endpoints Endpoints, logger log.Logger) apiv1.WebhookServiceServer {
+	options := helpers.SetupServerOptions(logger)
+	return &grpcServer{
+		createWebhookSubscription: grpctransport.NewServer(
+			endpoints.CreateWebhookSubscriptionEndpoint,
+			decodeCreateWebhookSubscriptionRequest,
+			encodeCreateWebhookSubscriptionResponse,
+			options...,
+		),
+		getWebhookSubscription: grpctransport.NewServer(
+			endpoints.GetWebhookSubscriptionEndpoint,
+			decodeGetWebhookSubscriptionRequest,
+			encodeGetWebhookSubscriptionResponse,
+			options...,
+		),
+		listWebhookSubscriptions: grpctransport.NewServer(
+			endpoints.ListWebhookSubscriptionsEndpoint,
+			decodeListWebhookSubscriptionsRequest,
+			encodeListWebhookSubscriptionsResponse,
+			options...,
+		),
+		deleteWebhookSubscription: grpctransport.NewServer(
+			endpoints.DeleteWebhookSubscriptionEndpoint,
+			decodeDeleteWebhookSubscriptionRequest,
+			encodeDeleteWebhookSubscriptionResponse,
+			options...,
+		),
+		listWebhookDeliveries: grpctransport.NewServer(
+			endpoints.ListWebhookDeliveriesEndpoint,
+			decodeListWebhookDeliveriesRequest,
+			encodeListWebhookDeliveriesResponse,
+			options...,
+		),
+	}
+}
+
+// CreateWebhookSubscription Impl.
+func (s *grpcServer) CreateWebhookSubscription(ctx context.Context, req *apiv1.CreateWebhookSubscriptionRequest) (*apiv1.CreateWebhookSubscriptionResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.createWebhookSubscription)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.CreateWebhookSubscriptionResponse), nil
+}
+
+func decodeCreateWebhookSubscriptionRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.CreateWebhookSubscriptionRequest)
+
+	// A missing subscription is rejected by the service.
+	//
+	if req.Subscription == nil {
+		return CreateWebhookSubscriptionRequest{}, nil
+	}
+	decodedSubscription, err := DecodeWebhookSubscription(req.Subscription)
+	if err != nil {
+		return CreateWebhookSubscriptionRequest{}, err
+	}
+	return CreateWebhookSubscriptionRequest{
+		Subscription: decodedSubscription,
+	}, nil
+}
+
+func encodeCreateWebhookSubscriptionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(CreateWebhookSubscriptionResponse)
+	if resp.Err != nil {
+		return &apiv1.CreateWebhookSubscriptionResponse{}, resp.Err
+	}
+	encodedSubscription, err := EncodeWebhookSubscription(resp.Subscription)
+	if err != nil {
+		return &apiv1.CreateWebhookSubscriptionResponse{}, err
+	}
+	return &apiv1.CreateWebhookSubscriptionResponse{
+		Subscription: encodedSubscription,
+	}, nil
+}
+
+// GetWebhookSubscription Impl.
+func (s *grpcServer) GetWebhookSubscription(ctx context.Context, req *apiv1.GetWebhookSubscriptionRequest) (*apiv1.GetWebhookSubscriptionResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.getWebhookSubscription)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.GetWebhookSubscriptionResponse), nil
+}
+
+func decodeGetWebhookSubscriptionRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.GetWebhookSubscriptionRequest)
+	return GetWebhookSubscriptionRequest{
+		ID: req.Id,
+	}, nil
+}
+
+func encodeGetWebhookSubscriptionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(GetWebhookSubscriptionResponse)
+	if resp.Err != nil {
+		return &apiv1.GetWebhookSubscriptionResponse{}, resp.Err
+	}
+	encodedSubscription, err := EncodeWebhookSubscription(resp.Subscription)
+	if err != nil {
+		return &apiv1.GetWebhookSubscriptionResponse{}, err
+	}
+	return &apiv1.GetWebhookSubscriptionResponse{
+		Subscription: encodedSubscription,
+	}, nil
+}
+
+// ListWebhookSubscriptions Impl.
+func (s *grpcServer) ListWebhookSubscriptions(ctx context.Context, req *apiv1.ListWebhookSubscriptionsRequest) (*apiv1.ListWebhookSubscriptionsResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.listWebhookSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.ListWebhookSubscriptionsResponse), nil
+}
+
+func decodeListWebhookSubscriptionsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.ListWebhookSubscriptionsRequest)
+	return ListWebhookSubscriptionsRequest{
+		Page: domain.PageRequest{
+			PageToken: req.PageToken,
+			PageSize:  int(req.PageSize),
+		},
+	}, nil
+}
+
+func encodeListWebhookSubscriptionsResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(ListWebhookSubscriptionsResponse)
+	if resp.Err != nil {
+		return &apiv1.ListWebhookSubscriptionsResponse{}, resp.Err
+	}
+	subscriptions := make([]*apiv1.WebhookSubscription, len(resp.Subscriptions))
+	for i, subscription := range resp.Subscriptions {
+		encodedSubscription, err := EncodeWebhookSubscription(subscription)
+		if err != nil {
+			return &apiv1.ListWebhookSubscriptionsResponse{}, err
+		}
+		subscriptions[i] = encodedSubscription
+	}
+	return &apiv1.ListWebhookSubscriptionsResponse{
+		Subscriptions: subscriptions,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+// DeleteWebhookSubscription Impl.
+func (s *grpcServer) DeleteWebhookSubscription(ctx context.Context, req *apiv1.DeleteWebhookSubscriptionRequest) (*apiv1.DeleteWebhookSubscriptionResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.deleteWebhookSubscription)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.DeleteWebhookSubscriptionResponse), nil
+}
+
+func decodeDeleteWebhookSubscriptionRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.DeleteWebhookSubscriptionRequest)
+	return DeleteWebhookSubscriptionRequest{
+		ID: req.Id,
+	}, nil
+}
+
+func encodeDeleteWebhookSubscriptionResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(DeleteWebhookSubscriptionResponse)
+	if resp.Err != nil {
+		return &apiv1.DeleteWebhookSubscriptionResponse{}, resp.Err
+	}
+	return &apiv1.DeleteWebhookSubscriptionResponse{}, nil
+}
+
+// ListWebhookDeliveries Impl.
+func (s *grpcServer) ListWebhookDeliveries(ctx context.Context, req *apiv1.ListWebhookDeliveriesRequest) (*apiv1.ListWebhookDeliveriesResponse, error) {
+	rep, err := helpers.ServeGrpc(ctx, req, s.listWebhookDeliveries)
+	if err != nil {
+		return nil, err
+	}
+	return rep.(*apiv1.ListWebhookDeliveriesResponse), nil
+}
+
+func decodeListWebhookDeliveriesRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*apiv1.ListWebhookDeliveriesRequest)
+	return ListWebhookDeliveriesRequest{
+		SubscriptionID: req.SubscriptionId,
+		Page: domain.PageRequest{
+			PageToken: req.PageToken,
+			PageSize:  int(req.PageSize),
+		},
+	}, nil
+}
+
+func encodeListWebhookDeliveriesResponse(_ context.Context, response interface{}) (interface{}, error) {
+	resp := response.(ListWebhookDeliveriesResponse)
+	if resp.Err != nil {
+		return &apiv1.ListWebhookDeliveriesResponse{}, resp.Err
+	}
+	deliveries := make([]*apiv1.WebhookDelivery, len(resp.Deliveries))
+	for i, delivery := range resp.Deliveries {
+		encodedDelivery, err := EncodeWebhookDelivery(delivery)
+		if err != nil {
+			return &apiv1.ListWebhookDeliveriesResponse{}, err
+		}
+		deliveries[i] = encodedDelivery
+	}
+	return &apiv1.ListWebhookDeliveriesResponse{
+		Deliveries:    deliveries,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
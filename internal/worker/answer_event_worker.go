@@ -2,6 +2,9 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
 	"dochq.co.uk.answerservice/internal/domain"
 	errors "dochq.co.uk.answerservice/internal/error"
@@ -12,29 +15,107 @@ import (
 // AnswerWorker struct.
 type AnswerWorker struct {
 	*Worker
-	eventRepository domain.AnswerEventRepository
+	eventRepository   domain.AnswerEventRepository
+	erasureRepository domain.ErasureRepository
+	keyHasher         *domain.AnswerKeyHasher
 }
 
 // NewAnswerWorker - sets up a new worker.
 func NewAnswerWorker(
 	props *Props,
-	queueAPI domain.QueueAPI,
+	consumer domain.QueueConsumer,
 	eventRepository domain.AnswerEventRepository,
+	erasureRepository domain.ErasureRepository,
+	keyHasher *domain.AnswerKeyHasher,
 	logger log.Logger) *AnswerWorker {
 	var (
-		worker = new(props, queueAPI, logger)
+		worker = new(props, consumer, logger)
 	)
 	return &AnswerWorker{
-		Worker:          worker,
-		eventRepository: eventRepository,
+		Worker:            worker,
+		eventRepository:   eventRepository,
+		erasureRepository: erasureRepository,
+		keyHasher:         keyHasher,
 	}
 }
 
+// Run - handles the queue messages till the context is done.
+func (w *AnswerWorker) Run(ctx context.Context) error {
+	return w.Start(ctx, w)
+}
+
+// HandleMessage - dispatches the message to the handler of its type.
+func (w *AnswerWorker) HandleMessage(ctx context.Context, msg *domain.ReceivedMessage) error {
+	payload, err := decodeAnswerEventMessage(msg)
+	if err != nil {
+		return err
+	}
+	return w.HandleAnswerEventMessage(ctx, payload)
+}
+
 // HandleAnswerEventMessage - handle message.
 func (w *AnswerWorker) HandleAnswerEventMessage(
 	ctx context.Context,
 	m *domain.AnswerEventMessage,
 ) error {
+	if err := validateAnswerEventMessage(m); err != nil {
+		return err
+	}
+
+	// An event which occurred before the erasure of its answer must not bring the erased value back.
+	//
+	erased, err := erasedBefore(w.erasureRepository, w.keyHasher, m.Event)
+	if err != nil {
+		return err
+	}
+	if erased {
+		_ = w.Logger.Log("msg", "Event of an erased answer dropped", "occurredAt", m.Event.OccurredAt)
+		return nil
+	}
+
+	// The erase event is not recorded, the history must not keep the erased key. The events
+	// recorded since the erasure started are erased instead, a redelivered event erases nothing.
+	//
+	if m.Event.EventType == domain.EraseAnswerEventType {
+		_, err := w.eventRepository.EraseEvents(m.Event.Data.Key, m.Event.OccurredAt)
+		return err
+	}
+
+	// A redelivered event is already recorded.
+	//
+	err = w.eventRepository.Create(m.Event)
+	if _, ok := err.(*errors.ErrAlreadyExist); ok {
+		return nil
+	}
+	return err
+}
+
+var (
+	_ domain.QueueHandler = &AnswerWorker{}
+)
+
+// decodeAnswerEventMessage - unmarshals the payload of an answer event message.
+func decodeAnswerEventMessage(msg *domain.ReceivedMessage) (*domain.AnswerEventMessage, error) {
+	switch msg.MessageType {
+	case domain.AnswerEventMessageType:
+		// Unmarshal payload.
+		//
+		payload := &domain.AnswerEventMessage{}
+		err := json.Unmarshal(msg.Body, payload)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case "":
+		return nil, errors.NewErrNotFound("Message type not found in attributes")
+	default:
+		return nil, errors.NewErrNotFound(fmt.Sprintf("Unsupported message type %v", msg.MessageType))
+	}
+}
+
+// validateAnswerEventMessage - checks the message, events sent by older versions of the application
+// have no occurrence time, they get the current time.
+func validateAnswerEventMessage(m *domain.AnswerEventMessage) error {
 	if m == nil {
 		return errors.NewErrInvalidArgument("AnswerEventMessage required")
 	}
@@ -47,8 +128,30 @@ func (w *AnswerWorker) HandleAnswerEventMessage(
 	if m.Event.Data == nil {
 		return errors.NewErrInvalidArgument("EventData required")
 	}
-	if err := m.Event.Data.Validate(); err != nil {
+
+	// The erase event only holds the key.
+	//
+	if m.Event.EventType == domain.EraseAnswerEventType {
+		if len(m.Event.Data.Key) == 0 {
+			return errors.NewErrInvalidArgument("Key required")
+		}
+	} else if err := m.Event.Data.Validate(); err != nil {
 		return errors.NewErrInvalidArgument(err.Error())
 	}
-	return w.eventRepository.Create(m.Event)
+	if m.Event.OccurredAt.IsZero() {
+		m.Event.OccurredAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// erasedBefore - checks if the answer of the event was erased after the event occurred, so the event must be dropped.
+func erasedBefore(erasureRepository domain.ErasureRepository, keyHasher *domain.AnswerKeyHasher, event *domain.AnswerEvent) (bool, error) {
+	erasure, err := erasureRepository.Get(keyHasher.Hash(event.Data.Key))
+	if _, ok := err.(*errors.ErrNotFound); ok {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return erasure.ErasedBefore(event), nil
 }
@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+)
+
+// NotifierWorker - passes the answer events of its own subscription queue to the notifier.
+type NotifierWorker struct {
+	*Worker
+	notifier          domain.AnswerEventNotifier
+	erasureRepository domain.ErasureRepository
+	keyHasher         *domain.AnswerKeyHasher
+}
+
+// NewNotifierWorker - sets up a new worker.
+func NewNotifierWorker(
+	props *Props,
+	consumer domain.QueueConsumer,
+	notifier domain.AnswerEventNotifier,
+	erasureRepository domain.ErasureRepository,
+	keyHasher *domain.AnswerKeyHasher,
+	logger log.Logger) *NotifierWorker {
+	var (
+		worker = new(props, consumer, logger)
+	)
+	return &NotifierWorker{
+		Worker:            worker,
+		notifier:          notifier,
+		erasureRepository: erasureRepository,
+		keyHasher:         keyHasher,
+	}
+}
+
+// Run - handles the queue messages till the context is done.
+func (w *NotifierWorker) Run(ctx context.Context) error {
+	return w.Start(ctx, w)
+}
+
+// HandleMessage - notifies about the answer event of the message.
+// A failed notification fails the message, so it is delivered again. The events which occurred before
+// the erasure of their answer are dropped, so an erased value is not sent.
+func (w *NotifierWorker) HandleMessage(ctx context.Context, msg *domain.ReceivedMessage) error {
+	payload, err := decodeAnswerEventMessage(msg)
+	if err != nil {
+		return err
+	}
+	if err := validateAnswerEventMessage(payload); err != nil {
+		return err
+	}
+	if erased, err := erasedBefore(w.erasureRepository, w.keyHasher, payload.Event); erased || err != nil {
+		return err
+	}
+	return w.notifier.NotifyAnswerEvent(ctx, payload.Event)
+}
+
+var (
+	_ domain.QueueHandler = &NotifierWorker{}
+)
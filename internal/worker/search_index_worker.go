@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"dochq.co.uk.answerservice/internal/domain"
+	errors "dochq.co.uk.answerservice/internal/error"
+
+	"github.com/go-kit/log"
+)
+
+// SearchIndexWorker - applies the answer events of its own subscription queue to the search index.
+type SearchIndexWorker struct {
+	*Worker
+	index             domain.AnswerSearchIndex
+	erasureRepository domain.ErasureRepository
+	keyHasher         *domain.AnswerKeyHasher
+}
+
+// NewSearchIndexWorker - sets up a new worker.
+func NewSearchIndexWorker(
+	props *Props,
+	consumer domain.QueueConsumer,
+	index domain.AnswerSearchIndex,
+	erasureRepository domain.ErasureRepository,
+	keyHasher *domain.AnswerKeyHasher,
+	logger log.Logger) *SearchIndexWorker {
+	var (
+		worker = new(props, consumer, logger)
+	)
+	return &SearchIndexWorker{
+		Worker:            worker,
+		index:             index,
+		erasureRepository: erasureRepository,
+		keyHasher:         keyHasher,
+	}
+}
+
+// Run - handles the queue messages till the context is done.
+func (w *SearchIndexWorker) Run(ctx context.Context) error {
+	return w.Start(ctx, w)
+}
+
+// HandleMessage - indexes the answer of the event or removes it.
+// The index ignores the events older than the last applied one, so the events may be delivered in any order.
+// The events which occurred before the erasure of their answer are dropped.
+func (w *SearchIndexWorker) HandleMessage(ctx context.Context, msg *domain.ReceivedMessage) error {
+	payload, err := decodeAnswerEventMessage(msg)
+	if err != nil {
+		return err
+	}
+	if err := validateAnswerEventMessage(payload); err != nil {
+		return err
+	}
+	event := payload.Event
+	if erased, err := erasedBefore(w.erasureRepository, w.keyHasher, event); erased || err != nil {
+		return err
+	}
+	switch event.EventType {
+	case domain.CreateAnswerEventType, domain.UpdateAnswerEventType, domain.RestoreAnswerEventType:
+		return w.index.Index(event.Data, event.OccurredAt)
+	case domain.DeleteAnswerEventType, domain.EraseAnswerEventType:
+		return w.index.Remove(event.Data.Key, event.OccurredAt)
+	default:
+		return errors.NewErrInvalidArgument(fmt.Sprintf("Unsupported event type %v", event.EventType))
+	}
+}
+
+var (
+	_ domain.QueueHandler = &SearchIndexWorker{}
+)
@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+
+	"dochq.co.uk.answerservice/internal/domain"
+
+	"github.com/go-kit/log"
+)
+
+// WatchWorker - passes the answer events of its own subscription queue to the watches of the process.
+type WatchWorker struct {
+	*Worker
+	hub               domain.AnswerWatchHub
+	erasureRepository domain.ErasureRepository
+	keyHasher         *domain.AnswerKeyHasher
+}
+
+// NewWatchWorker - sets up a new worker.
+func NewWatchWorker(
+	props *Props,
+	consumer domain.QueueConsumer,
+	hub domain.AnswerWatchHub,
+	erasureRepository domain.ErasureRepository,
+	keyHasher *domain.AnswerKeyHasher,
+	logger log.Logger) *WatchWorker {
+	var (
+		worker = new(props, consumer, logger)
+	)
+	return &WatchWorker{
+		Worker:            worker,
+		hub:               hub,
+		erasureRepository: erasureRepository,
+		keyHasher:         keyHasher,
+	}
+}
+
+// Run - handles the queue messages till the context is done.
+func (w *WatchWorker) Run(ctx context.Context) error {
+	return w.Start(ctx, w)
+}
+
+// HandleMessage - publishes the answer event of the message with the version of its answer.
+// The erase events and the events which occurred before the erasure of their answer are not watched,
+// so an erased value is not sent.
+func (w *WatchWorker) HandleMessage(ctx context.Context, msg *domain.ReceivedMessage) error {
+	payload, err := decodeAnswerEventMessage(msg)
+	if err != nil {
+		return err
+	}
+	if err := validateAnswerEventMessage(payload); err != nil {
+		return err
+	}
+	event := payload.Event
+	if event.EventType == domain.EraseAnswerEventType {
+		return nil
+	}
+	if erased, err := erasedBefore(w.erasureRepository, w.keyHasher, event); erased || err != nil {
+		return err
+	}
+	w.hub.Publish(&domain.WatchedAnswerEvent{Event: event, Version: event.Version()})
+	return nil
+}
+
+var (
+	_ domain.QueueHandler = &WatchWorker{}
+)